@@ -0,0 +1,127 @@
+package common
+
+import (
+	"crypto/elliptic"
+	"crypto/sha256"
+	"crypto/sha512"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/sha3"
+)
+
+const (
+	// MaxEd25519ContextLen is the maximum byte length of the context of Ed25519ph and Ed25519ctx
+	MaxEd25519ContextLen = 255
+
+	// the prefix of dom2 of RFC 8032
+	ed25519DomPrefix = "SigEd25519 no Ed25519 collisions"
+)
+
+// Message is a message to sign in bytes, with the hashing that the signing applies to it.
+// Unlike the *big.Int messages, the leading zero bytes of a message are always signed.
+type Message struct {
+	Mode    HashMode
+	Data    []byte // the digest in the PREHASHED mode, the message otherwise
+	Context []byte // the context of Ed25519ph and Ed25519ctx
+
+	prehashed bool // Data of an Ed25519ph message is already the SHA-512 of the message
+}
+
+// NewPrehashedMessage returns the message of an ECDSA digest, which is signed as it is
+func NewPrehashedMessage(digest []byte) *Message {
+	return &Message{Mode: HashMode_PREHASHED, Data: digest}
+}
+
+// NewSHA256Message returns the message whose SHA-256 is signed with ECDSA
+func NewSHA256Message(msg []byte) *Message {
+	return &Message{Mode: HashMode_SHA256, Data: msg}
+}
+
+// NewKeccak256Message returns the message whose Keccak-256 is signed with ECDSA, as in Ethereum
+func NewKeccak256Message(msg []byte) *Message {
+	return &Message{Mode: HashMode_KECCAK256, Data: msg}
+}
+
+// NewEd25519Message returns the message signed as it is with Ed25519
+func NewEd25519Message(msg []byte) *Message {
+	return &Message{Mode: HashMode_ED25519, Data: msg}
+}
+
+// NewEd25519phMessage returns the message whose SHA-512 is signed with Ed25519ph, with an optional context
+func NewEd25519phMessage(msg, context []byte) *Message {
+	return &Message{Mode: HashMode_ED25519PH, Data: msg, Context: context}
+}
+
+// NewEd25519phDigestMessage returns the message of Ed25519ph given by its SHA-512, as crypto.Signer takes it, with an optional context
+func NewEd25519phDigestMessage(digest, context []byte) *Message {
+	return &Message{Mode: HashMode_ED25519PH, Data: digest, Context: context, prehashed: true}
+}
+
+// NewEd25519ctxMessage returns the message signed with Ed25519ctx, with a non-empty context
+func NewEd25519ctxMessage(msg, context []byte) *Message {
+	return &Message{Mode: HashMode_ED25519CTX, Data: msg, Context: context}
+}
+
+// ECDSADigest returns the digest that ECDSA signs on the curve: the digest of a PREHASHED message, whose length must be
+// the byte length of the order of the curve, or the SHA-256 or Keccak-256 of the message
+func (m *Message) ECDSADigest(ec elliptic.Curve) ([]byte, error) {
+	var digest []byte
+	switch m.Mode {
+	case HashMode_PREHASHED:
+		digest = m.Data
+	case HashMode_SHA256:
+		h := sha256.Sum256(m.Data)
+		digest = h[:]
+	case HashMode_KECCAK256:
+		h := sha3.NewLegacyKeccak256()
+		h.Write(m.Data)
+		digest = h.Sum(nil)
+	default:
+		return nil, fmt.Errorf("the hash mode %s is not for ECDSA", m.Mode)
+	}
+	// a shorter digest would lose its leading zeros, and ECDSA would silently truncate a longer one
+	if byteLen := (ec.Params().N.BitLen() + 7) / 8; len(digest) != byteLen {
+		return nil, fmt.Errorf("the digest has %d bytes, the order of the curve has %d", len(digest), byteLen)
+	}
+	return digest, nil
+}
+
+// EdDSAInput returns the domain separation dom2 of RFC 8032 and the message M that the challenge SHA-512(dom2 || R || A || M) hashes:
+// the message itself for Ed25519 and Ed25519ctx, and its SHA-512 for Ed25519ph. dom2 is empty for Ed25519.
+func (m *Message) EdDSAInput() (dom, msg []byte, err error) {
+	if len(m.Context) > MaxEd25519ContextLen {
+		return nil, nil, fmt.Errorf("the context is longer than %d bytes", MaxEd25519ContextLen)
+	}
+	switch m.Mode {
+	case HashMode_ED25519:
+		if len(m.Context) > 0 {
+			return nil, nil, errors.New("Ed25519 has no context, use Ed25519ctx")
+		}
+		return nil, m.Data, nil
+	case HashMode_ED25519PH:
+		if m.prehashed {
+			if len(m.Data) != sha512.Size {
+				return nil, nil, fmt.Errorf("the digest of Ed25519ph has %d bytes, SHA-512 has %d", len(m.Data), sha512.Size)
+			}
+			return ed25519Dom2(1, m.Context), m.Data, nil
+		}
+		h := sha512.Sum512(m.Data)
+		return ed25519Dom2(1, m.Context), h[:], nil
+	case HashMode_ED25519CTX:
+		if len(m.Context) == 0 {
+			return nil, nil, errors.New("Ed25519ctx needs a non-empty context")
+		}
+		return ed25519Dom2(0, m.Context), m.Data, nil
+	default:
+		return nil, nil, fmt.Errorf("the hash mode %s is not for EdDSA", m.Mode)
+	}
+}
+
+// ed25519Dom2 returns dom2(phflag, context) = "SigEd25519 no Ed25519 collisions" || phflag || len(context) || context
+func ed25519Dom2(phflag byte, context []byte) []byte {
+	dom := make([]byte, 0, len(ed25519DomPrefix)+2+len(context))
+	dom = append(dom, ed25519DomPrefix...)
+	dom = append(dom, phflag, byte(len(context)))
+	return append(dom, context...)
+}
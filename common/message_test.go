@@ -0,0 +1,80 @@
+package common_test
+
+import (
+	"crypto/elliptic"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/felicityin/mpc-tss/common"
+)
+
+func TestECDSADigest(t *testing.T) {
+	ec := elliptic.P256()
+	msg := []byte("hello")
+
+	digest, err := common.NewSHA256Message(msg).ECDSADigest(ec)
+	assert.NoError(t, err)
+	h := sha256.Sum256(msg)
+	assert.Equal(t, h[:], digest)
+
+	// the Keccak-256 of Ethereum, not the SHA3-256 of FIPS 202
+	digest, err = common.NewKeccak256Message(msg).ECDSADigest(ec)
+	assert.NoError(t, err)
+	assert.Equal(t, "1c8aff950685c2ed4bc3174f3472287b56d9517b9c948127319a09a7a36deac8", hex.EncodeToString(digest))
+
+	// a digest with leading zeros is signed as it is
+	prehashed := append([]byte{0, 0}, h[2:]...)
+	digest, err = common.NewPrehashedMessage(prehashed).ECDSADigest(ec)
+	assert.NoError(t, err)
+	assert.Equal(t, prehashed, digest)
+
+	_, err = common.NewPrehashedMessage(h[1:]).ECDSADigest(ec)
+	assert.Error(t, err, "a short digest is rejected")
+	_, err = common.NewPrehashedMessage(append(h[:], 0)).ECDSADigest(ec)
+	assert.Error(t, err, "a long digest is rejected")
+	_, err = common.NewSHA256Message(msg).ECDSADigest(elliptic.P384())
+	assert.Error(t, err, "SHA-256 is shorter than the order of P-384")
+	_, err = common.NewEd25519Message(msg).ECDSADigest(ec)
+	assert.Error(t, err, "not an ECDSA mode")
+}
+
+func TestEdDSAInput(t *testing.T) {
+	msg := []byte{0, 0, 1, 2}
+
+	dom, m, err := common.NewEd25519Message(msg).EdDSAInput()
+	assert.NoError(t, err)
+	assert.Empty(t, dom)
+	assert.Equal(t, msg, m, "the leading zeros are kept")
+
+	dom, m, err = common.NewEd25519ctxMessage(msg, []byte("foo")).EdDSAInput()
+	assert.NoError(t, err)
+	assert.Equal(t, append([]byte("SigEd25519 no Ed25519 collisions\x00\x03"), "foo"...), dom)
+	assert.Equal(t, msg, m)
+
+	dom, m, err = common.NewEd25519phMessage(msg, nil).EdDSAInput()
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("SigEd25519 no Ed25519 collisions\x01\x00"), dom)
+	h := sha512.Sum512(msg)
+	assert.Equal(t, h[:], m)
+
+	// the same message given by its digest
+	prehashed, m, err := common.NewEd25519phDigestMessage(h[:], nil).EdDSAInput()
+	assert.NoError(t, err)
+	assert.Equal(t, dom, prehashed)
+	assert.Equal(t, h[:], m)
+	_, _, err = common.NewEd25519phDigestMessage(h[1:], nil).EdDSAInput()
+	assert.Error(t, err, "the digest is not a SHA-512")
+
+	_, _, err = common.NewEd25519ctxMessage(msg, nil).EdDSAInput()
+	assert.Error(t, err, "Ed25519ctx needs a context")
+	_, _, err = (&common.Message{Mode: common.HashMode_ED25519, Data: msg, Context: []byte("foo")}).EdDSAInput()
+	assert.Error(t, err, "Ed25519 has no context")
+	_, _, err = common.NewEd25519phMessage(msg, make([]byte, common.MaxEd25519ContextLen+1)).EdDSAInput()
+	assert.Error(t, err, "the context is too long")
+	_, _, err = common.NewSHA256Message(msg).EdDSAInput()
+	assert.Error(t, err, "not an EdDSA mode")
+}
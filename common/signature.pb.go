@@ -6,8 +6,8 @@
 
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.26.0
-// 	protoc        v3.12.4
+// 	protoc-gen-go v1.33.0
+// 	protoc        v3.21.4
 // source: protob/signature.proto
 
 package common
@@ -26,6 +26,75 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// The hashing of the message before it is signed
+type HashMode int32
+
+const (
+	// the message of the *big.Int API: the digest for ECDSA, the message for EdDSA
+	HashMode_LEGACY HashMode = 0
+	// the message is the digest to sign, of the byte length of the order of the curve (ECDSA)
+	HashMode_PREHASHED HashMode = 1
+	// the digest to sign is the SHA-256 of the message (ECDSA)
+	HashMode_SHA256 HashMode = 2
+	// the digest to sign is the Keccak-256 of the message, as in Ethereum (ECDSA)
+	HashMode_KECCAK256 HashMode = 3
+	// the message is signed as it is, with the PureEdDSA Ed25519 of RFC 8032
+	HashMode_ED25519 HashMode = 4
+	// the SHA-512 of the message is signed with the HashEdDSA Ed25519ph of RFC 8032
+	HashMode_ED25519PH HashMode = 5
+	// the message is signed with a context with Ed25519ctx of RFC 8032
+	HashMode_ED25519CTX HashMode = 6
+)
+
+// Enum value maps for HashMode.
+var (
+	HashMode_name = map[int32]string{
+		0: "LEGACY",
+		1: "PREHASHED",
+		2: "SHA256",
+		3: "KECCAK256",
+		4: "ED25519",
+		5: "ED25519PH",
+		6: "ED25519CTX",
+	}
+	HashMode_value = map[string]int32{
+		"LEGACY":     0,
+		"PREHASHED":  1,
+		"SHA256":     2,
+		"KECCAK256":  3,
+		"ED25519":    4,
+		"ED25519PH":  5,
+		"ED25519CTX": 6,
+	}
+)
+
+func (x HashMode) Enum() *HashMode {
+	p := new(HashMode)
+	*p = x
+	return p
+}
+
+func (x HashMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (HashMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_protob_signature_proto_enumTypes[0].Descriptor()
+}
+
+func (HashMode) Type() protoreflect.EnumType {
+	return &file_protob_signature_proto_enumTypes[0]
+}
+
+func (x HashMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use HashMode.Descriptor instead.
+func (HashMode) EnumDescriptor() ([]byte, []int) {
+	return file_protob_signature_proto_rawDescGZIP(), []int{0}
+}
+
 // Container for output signatures, mostly used for marshalling this data structure to a mobile app
 type SignatureData struct {
 	state         protoimpl.MessageState
@@ -40,6 +109,10 @@ type SignatureData struct {
 	S []byte `protobuf:"bytes,4,opt,name=s,proto3" json:"s,omitempty"`
 	// M represents the original message digest that was signed M
 	M []byte `protobuf:"bytes,5,opt,name=m,proto3" json:"m,omitempty"`
+	// the hashing of the message that gave M: M is the digest for ECDSA and Ed25519ph, and the message for Ed25519 and Ed25519ctx
+	HashMode HashMode `protobuf:"varint,6,opt,name=hash_mode,json=hashMode,proto3,enum=HashMode" json:"hash_mode,omitempty"`
+	// the context of Ed25519ph and Ed25519ctx
+	Context []byte `protobuf:"bytes,7,opt,name=context,proto3" json:"context,omitempty"`
 }
 
 func (x *SignatureData) Reset() {
@@ -109,11 +182,25 @@ func (x *SignatureData) GetM() []byte {
 	return nil
 }
 
+func (x *SignatureData) GetHashMode() HashMode {
+	if x != nil {
+		return x.HashMode
+	}
+	return HashMode_LEGACY
+}
+
+func (x *SignatureData) GetContext() []byte {
+	if x != nil {
+		return x.Context
+	}
+	return nil
+}
+
 var File_protob_signature_proto protoreflect.FileDescriptor
 
 var file_protob_signature_proto_rawDesc = []byte{
 	0x0a, 0x16, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x2f, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75,
-	0x72, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x86, 0x01, 0x0a, 0x0d, 0x53, 0x69, 0x67,
+	0x72, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xc8, 0x01, 0x0a, 0x0d, 0x53, 0x69, 0x67,
 	0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x44, 0x61, 0x74, 0x61, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69,
 	0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x73,
 	0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x2d, 0x0a, 0x12, 0x73, 0x69, 0x67, 0x6e,
@@ -122,7 +209,18 @@ var file_protob_signature_proto_rawDesc = []byte{
 	0x65, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x12, 0x0c, 0x0a, 0x01, 0x72, 0x18, 0x03, 0x20, 0x01,
 	0x28, 0x0c, 0x52, 0x01, 0x72, 0x12, 0x0c, 0x0a, 0x01, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c,
 	0x52, 0x01, 0x73, 0x12, 0x0c, 0x0a, 0x01, 0x6d, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x01,
-	0x6d, 0x42, 0x0a, 0x5a, 0x08, 0x2e, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x62, 0x06, 0x70,
+	0x6d, 0x12, 0x26, 0x0a, 0x09, 0x68, 0x61, 0x73, 0x68, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x09, 0x2e, 0x48, 0x61, 0x73, 0x68, 0x4d, 0x6f, 0x64, 0x65, 0x52,
+	0x08, 0x68, 0x61, 0x73, 0x68, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e,
+	0x74, 0x65, 0x78, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74,
+	0x65, 0x78, 0x74, 0x2a, 0x6c, 0x0a, 0x08, 0x48, 0x61, 0x73, 0x68, 0x4d, 0x6f, 0x64, 0x65, 0x12,
+	0x0a, 0x0a, 0x06, 0x4c, 0x45, 0x47, 0x41, 0x43, 0x59, 0x10, 0x00, 0x12, 0x0d, 0x0a, 0x09, 0x50,
+	0x52, 0x45, 0x48, 0x41, 0x53, 0x48, 0x45, 0x44, 0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06, 0x53, 0x48,
+	0x41, 0x32, 0x35, 0x36, 0x10, 0x02, 0x12, 0x0d, 0x0a, 0x09, 0x4b, 0x45, 0x43, 0x43, 0x41, 0x4b,
+	0x32, 0x35, 0x36, 0x10, 0x03, 0x12, 0x0b, 0x0a, 0x07, 0x45, 0x44, 0x32, 0x35, 0x35, 0x31, 0x39,
+	0x10, 0x04, 0x12, 0x0d, 0x0a, 0x09, 0x45, 0x44, 0x32, 0x35, 0x35, 0x31, 0x39, 0x50, 0x48, 0x10,
+	0x05, 0x12, 0x0e, 0x0a, 0x0a, 0x45, 0x44, 0x32, 0x35, 0x35, 0x31, 0x39, 0x43, 0x54, 0x58, 0x10,
+	0x06, 0x42, 0x0a, 0x5a, 0x08, 0x2e, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x62, 0x06, 0x70,
 	0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
@@ -138,16 +236,19 @@ func file_protob_signature_proto_rawDescGZIP() []byte {
 	return file_protob_signature_proto_rawDescData
 }
 
+var file_protob_signature_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
 var file_protob_signature_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
 var file_protob_signature_proto_goTypes = []interface{}{
-	(*SignatureData)(nil), // 0: SignatureData
+	(HashMode)(0),         // 0: HashMode
+	(*SignatureData)(nil), // 1: SignatureData
 }
 var file_protob_signature_proto_depIdxs = []int32{
-	0, // [0:0] is the sub-list for method output_type
-	0, // [0:0] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	0, // 0: SignatureData.hash_mode:type_name -> HashMode
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
 }
 
 func init() { file_protob_signature_proto_init() }
@@ -174,13 +275,14 @@ func file_protob_signature_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_protob_signature_proto_rawDesc,
-			NumEnums:      0,
+			NumEnums:      1,
 			NumMessages:   1,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
 		GoTypes:           file_protob_signature_proto_goTypes,
 		DependencyIndexes: file_protob_signature_proto_depIdxs,
+		EnumInfos:         file_protob_signature_proto_enumTypes,
 		MessageInfos:      file_protob_signature_proto_msgTypes,
 	}.Build()
 	File_protob_signature_proto = out.File
@@ -0,0 +1,31 @@
+package common
+
+import (
+	"math/big"
+)
+
+// ZeroBigInts overwrites the words backing each integer with zeros and sets it to 0.
+// Reassigning a secret *big.Int only drops the reference, the value stays in memory until it is collected.
+// The integers must not be shared with code that still needs them.
+func ZeroBigInts(ints ...*big.Int) {
+	for _, x := range ints {
+		if x == nil {
+			continue
+		}
+		words := x.Bits()
+		words = words[:cap(words)]
+		for i := range words {
+			words[i] = 0
+		}
+		x.SetInt64(0)
+	}
+}
+
+// ZeroBytes overwrites each slice with zeros
+func ZeroBytes(bzs ...[]byte) {
+	for _, bz := range bzs {
+		for i := range bz {
+			bz[i] = 0
+		}
+	}
+}
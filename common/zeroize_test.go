@@ -0,0 +1,40 @@
+package common_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/felicityin/mpc-tss/common"
+)
+
+func TestZeroBigInts(t *testing.T) {
+	x, _ := new(big.Int).SetString("123456789012345678901234567890123456789012345678901234567890", 10)
+	words := x.Bits()
+	// a smaller value reuses the backing array, the high words must be wiped as well
+	y := new(big.Int).Set(x)
+	y.Rsh(y, 128)
+	highWords := y.Bits()[:cap(y.Bits())]
+
+	common.ZeroBigInts(x, y, nil)
+	assert.Equal(t, 0, x.Sign())
+	assert.Equal(t, 0, y.Sign())
+	for _, w := range words {
+		assert.Zero(t, w)
+	}
+	for _, w := range highWords {
+		assert.Zero(t, w)
+	}
+
+	// a wiped integer is still usable
+	x.SetInt64(7)
+	assert.Equal(t, int64(7), x.Int64())
+}
+
+func TestZeroBytes(t *testing.T) {
+	a, b := []byte{1, 2, 3}, []byte{4}
+	common.ZeroBytes(a, b, nil)
+	assert.Equal(t, []byte{0, 0, 0}, a)
+	assert.Equal(t, []byte{0}, b)
+}
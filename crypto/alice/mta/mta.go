@@ -15,6 +15,7 @@
 package mta
 
 import (
+	"crypto/elliptic"
 	"fmt"
 	"io"
 	"math/big"
@@ -30,10 +31,8 @@ import (
 )
 
 var (
-	big1         = big.NewInt(1)
-	big2         = big.NewInt(2)
-	parameter    = crypto.NewProofConfig(tss.S256().Params().N)
-	curveNSquare = new(big.Int).Mul(parameter.CurveN, parameter.CurveN)
+	big1 = big.NewInt(1)
+	big2 = big.NewInt(2)
 )
 
 func MtaWithProofAff_g(
@@ -45,22 +44,26 @@ func MtaWithProofAff_g(
 	x *big.Int,
 	ecPoint *crypto.ECPoint,
 ) (*big.Int, *big.Int, *big.Int, *big.Int, []byte, *big.Int, *affproof.PaillierAffAndGroupRangeMessage, error) {
-	beta, s, r, D, F, err := PerformMTA(rand, peerPed, paillierKey, msgCipher, x)
+	// the proof is for the curve of the point
+	config := crypto.CurveProofConfig(ecPoint.Curve())
+	beta, s, r, D, F, err := performMTA(config, rand, peerPed, paillierKey, msgCipher, x)
 	if err != nil {
 		return nil, nil, nil, nil, nil, nil, nil, fmt.Errorf("perform mta err: %s", err.Error())
 	}
 	peerPaillierKey := pailliera.ToPaillierPubKeyWithSpecialG(peerPed)
 	proof, err := affproof.NewPaillierAffAndGroupRangeMessage(
-		parameter, ownssidwithbk, x, beta, s, r, peerPed.GetN(), paillierKey.N,
+		config, ownssidwithbk, x, beta, s, r, peerPed.GetN(), paillierKey.N,
 		msgCipher, D, F, peerPed, ecPoint,
 	)
 	if err != nil {
 		return nil, nil, nil, nil, nil, nil, nil, fmt.Errorf("create range proof err: %s", err.Error())
 	}
-	adjustBeta, count := computeBeta(beta, peerPaillierKey.GetN(), big.NewInt(0))
+	adjustBeta, count := computeBeta(config, beta, peerPaillierKey.GetN(), big.NewInt(0))
 	return adjustBeta, count, r, s, D.Bytes(), F, proof, nil
 }
 
+// MtaWithProofAff_p performs the MtA of gamma with the proof config of secp256k1.
+// Deprecated: use MtaWithProofAff_pOnCurve with the curve of the key.
 func MtaWithProofAff_p(
 	rand io.Reader,
 	ownssidwithbk []byte,
@@ -71,21 +74,39 @@ func MtaWithProofAff_p(
 	mu *big.Int,
 	gammaCiphertext *big.Int,
 ) (*big.Int, *big.Int, *big.Int, *big.Int, *big.Int, *affproof.PaillierOperationAndCommitmentMessage, error) {
-	beta, s, r, D, F, err := PerformMTA(rand, peerPed, paillierKey, msgKCipher, gamma)
+	return MtaWithProofAff_pOnCurve(tss.S256(), rand, ownssidwithbk, peerPed, paillierKey, msgKCipher, gamma, mu, gammaCiphertext)
+}
+
+// MtaWithProofAff_pOnCurve performs the MtA of gamma with the proof config of the curve ec
+func MtaWithProofAff_pOnCurve(
+	ec elliptic.Curve,
+	rand io.Reader,
+	ownssidwithbk []byte,
+	peerPed *zkPaillier.PederssenOpenParameter,
+	paillierKey *paillier.PublicKey,
+	msgKCipher *big.Int,
+	gamma *big.Int,
+	mu *big.Int,
+	gammaCiphertext *big.Int,
+) (*big.Int, *big.Int, *big.Int, *big.Int, *big.Int, *affproof.PaillierOperationAndCommitmentMessage, error) {
+	config := crypto.CurveProofConfig(ec)
+	beta, s, r, D, F, err := performMTA(config, rand, peerPed, paillierKey, msgKCipher, gamma)
 	if err != nil {
 		return nil, nil, nil, nil, nil, nil, err
 	}
 	proof, err := affproof.NewPaillierOperationAndPaillierCommitment(
-		parameter, ownssidwithbk, gamma, beta, s, mu, r, peerPed.GetN(), paillierKey.N,
+		config, ownssidwithbk, gamma, beta, s, mu, r, peerPed.GetN(), paillierKey.N,
 		gammaCiphertext, F, msgKCipher, D, peerPed,
 	)
 	if err != nil {
 		return nil, nil, nil, nil, nil, nil, err
 	}
-	adjustBeta, _ := computeBeta(beta, peerPed.GetN(), big.NewInt(0))
+	adjustBeta, _ := computeBeta(config, beta, peerPed.GetN(), big.NewInt(0))
 	return adjustBeta, r, s, D, F, proof, nil
 }
 
+// PerformMTA performs the MtA of x with the proof config of secp256k1.
+// Deprecated: use PerformMTAOnCurve with the curve of the key.
 func PerformMTA(
 	rand io.Reader,
 	ped *zkPaillier.PederssenOpenParameter,
@@ -93,7 +114,30 @@ func PerformMTA(
 	msgCipher *big.Int,
 	x *big.Int,
 ) (*big.Int, *big.Int, *big.Int, *big.Int, *big.Int, error) {
-	beta, err := utils.RandomPositiveInt(new(big.Int).Lsh(big2, parameter.Lpai))
+	return PerformMTAOnCurve(tss.S256(), rand, ped, paillierKey, msgCipher, x)
+}
+
+// PerformMTAOnCurve performs the MtA of x with the proof config of the curve ec
+func PerformMTAOnCurve(
+	ec elliptic.Curve,
+	rand io.Reader,
+	ped *zkPaillier.PederssenOpenParameter,
+	paillierKey *paillier.PublicKey,
+	msgCipher *big.Int,
+	x *big.Int,
+) (*big.Int, *big.Int, *big.Int, *big.Int, *big.Int, error) {
+	return performMTA(crypto.CurveProofConfig(ec), rand, ped, paillierKey, msgCipher, x)
+}
+
+func performMTA(
+	config *crypto.ProofConfig,
+	rand io.Reader,
+	ped *zkPaillier.PederssenOpenParameter,
+	paillierKey *paillier.PublicKey,
+	msgCipher *big.Int,
+	x *big.Int,
+) (*big.Int, *big.Int, *big.Int, *big.Int, *big.Int, error) {
+	beta, err := utils.RandomPositiveInt(new(big.Int).Lsh(big2, config.Lpai))
 	if err != nil {
 		return nil, nil, nil, nil, nil, err
 	}
@@ -116,9 +160,9 @@ func PerformMTA(
 }
 
 // If k*\gamma + beta < 0, we should change beta value.
-func computeBeta(beta *big.Int, paillierN *big.Int, count *big.Int) (*big.Int, *big.Int) {
+func computeBeta(config *crypto.ProofConfig, beta *big.Int, paillierN *big.Int, count *big.Int) (*big.Int, *big.Int) {
 	result := new(big.Int).Neg(beta)
-	if beta.Cmp(curveNSquare) < 0 {
+	if beta.Cmp(new(big.Int).Mul(config.CurveN, config.CurveN)) < 0 {
 		result.Sub(result, paillierN)
 		count.Add(count, big1)
 	}
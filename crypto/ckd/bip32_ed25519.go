@@ -0,0 +1,119 @@
+package ckd
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"math/big"
+
+	edwards "github.com/decred/dcrd/dcrec/edwards/v2"
+
+	"github.com/felicityin/mpc-tss/common"
+	"github.com/felicityin/mpc-tss/crypto"
+)
+
+// BIP32-Ed25519 (https://input-output-hk.github.io/adrestia/static/Ed25519_BIP.pdf) is the HD derivation of ed25519 keys
+// used by Cardano. As for BIP32, only the public derivation of the non-hardened children is implemented,
+// since the private key of a threshold key is never held by a single party.
+
+const (
+	// the length of ZL, the part of Z that shifts the private key
+	ed25519ZLLen = 28
+
+	ed25519TagZ         byte = 0x02
+	ed25519TagChainCode byte = 0x03
+)
+
+// DeriveEd25519ChildKeyFromHierarchy derives the child key of the path with BIP32-Ed25519.
+// It returns the sum of the shifts of the private key along the path, modulo the order of the curve, and the child key.
+func DeriveEd25519ChildKeyFromHierarchy(indicesHierarchy []uint32, pk *ExtendedKey) (*big.Int, *ExtendedKey, error) {
+	k := pk
+	modN := common.ModInt(edwards.Edwards().N)
+	delta := big.NewInt(0)
+	for _, index := range indicesHierarchy {
+		shift, childKey, err := DeriveEd25519ChildKey(index, k)
+		if err != nil {
+			return nil, nil, err
+		}
+		k = childKey
+		delta = modN.Add(delta, shift)
+	}
+	return delta, k, nil
+}
+
+// DeriveEd25519ChildKeysFromHierarchies derives the child keys of many paths from the same parent key with BIP32-Ed25519,
+// as DeriveEd25519ChildKeyFromHierarchy does. The keys of the common prefixes of the paths are derived once.
+func DeriveEd25519ChildKeysFromHierarchies(indicesHierarchies [][]uint32, pk *ExtendedKey) ([]*big.Int, []*ExtendedKey, error) {
+	return deriveHierarchies(indicesHierarchies, pk, edwards.Edwards().N, DeriveEd25519ChildKey)
+}
+
+// DeriveEd25519ChildKey derives the non-hardened child key of index with BIP32-Ed25519.
+// With Z = HMAC-SHA512(c, 0x02 || A || index) and ZL the first 28 bytes of Z as a little-endian integer,
+// the child public key is A + 8*ZL*G and the child chain code is the last 32 bytes of HMAC-SHA512(c, 0x03 || A || index),
+// where A is the encoding of the parent public key, c its chain code and index is encoded in little-endian.
+// It returns 8*ZL, the shift of the private key, and the child key.
+func DeriveEd25519ChildKey(index uint32, pk *ExtendedKey) (*big.Int, *ExtendedKey, error) {
+	if index >= HardenedKeyStart {
+		return nil, nil, errors.New("the index must be non-hardened")
+	}
+	if pk.Depth == maxDepth {
+		return nil, nil, errors.New("cannot derive key beyond max depth")
+	}
+	ec := edwards.Edwards()
+	if pk.PublicKey == nil || !pk.PublicKey.IsOnCurve() || pk.PublicKey.Curve().Params().N.Cmp(ec.N) != 0 {
+		return nil, nil, errors.New("BIP32-Ed25519 derives ed25519 keys only")
+	}
+
+	pkPublicKeyBytes := SerializeEd25519(pk.PublicKey)
+	data := make([]byte, 1+len(pkPublicKeyBytes)+4)
+	copy(data[1:], pkPublicKeyBytes)
+	binary.LittleEndian.PutUint32(data[1+len(pkPublicKeyBytes):], index)
+
+	data[0] = ed25519TagZ
+	z := hmacSHA512(pk.ChainCode, data)
+	data[0] = ed25519TagChainCode
+	childChainCode := hmacSHA512(pk.ChainCode, data)[32:]
+
+	// 8*ZL < 2^227 is less than the order of the curve
+	zl := new(big.Int).SetBytes(reverseBytes(z[:ed25519ZLLen]))
+	shift := new(big.Int).Lsh(zl, 3)
+
+	childCryptoPk, err := pk.PublicKey.Add(crypto.ScalarBaseMult(ec, shift))
+	if err != nil {
+		common.Logger.Error("error adding delta G to parent key")
+		return nil, nil, err
+	}
+	if childCryptoPk.X().Sign() == 0 {
+		return nil, nil, errors.New("invalid child")
+	}
+
+	childPk := &ExtendedKey{
+		PublicKey:  childCryptoPk,
+		Depth:      pk.Depth + 1,
+		ChildIndex: index,
+		ChainCode:  childChainCode,
+		ParentFP:   hash160(pkPublicKeyBytes)[:4],
+		Version:    pk.Version,
+	}
+	return shift, childPk, nil
+}
+
+// SerializeEd25519 returns the 32-byte encoding of an ed25519 public key (RFC 8032)
+func SerializeEd25519(pk *crypto.ECPoint) []byte {
+	return edwards.NewPublicKey(pk.X(), pk.Y()).Serialize()
+}
+
+func hmacSHA512(key, data []byte) []byte {
+	h := hmac.New(sha512.New, key)
+	h.Write(data)
+	return h.Sum(nil)
+}
+
+func reverseBytes(b []byte) []byte {
+	r := make([]byte, len(b))
+	for i := range b {
+		r[len(b)-1-i] = b[i]
+	}
+	return r
+}
@@ -0,0 +1,174 @@
+package ckd_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/decred/dcrd/dcrec/edwards/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/felicityin/mpc-tss/crypto"
+	"github.com/felicityin/mpc-tss/crypto/ckd"
+)
+
+// The root of the test vectors: the private key is SHA-512("bip32-ed25519 test root") mod N,
+// the chain code is SHA-256("bip32-ed25519 test chain code").
+// The children are checked against the private derivation of the paper in ed25519PrivateChild.
+func ed25519TestRoot(t *testing.T) (*big.Int, *ckd.ExtendedKey) {
+	ec := edwards.Edwards()
+	seed := sha512.Sum512([]byte("bip32-ed25519 test root"))
+	k := new(big.Int).Mod(new(big.Int).SetBytes(seed[:]), ec.N)
+	chainCode := sha256.Sum256([]byte("bip32-ed25519 test chain code"))
+
+	pk := crypto.ScalarBaseMult(ec, k)
+	assert.Equal(t, "0ba08e71016dd12ec88b88f98955bdbbaea355757f74daf6915eb67d7809209b", hex.EncodeToString(ckd.SerializeEd25519(pk)))
+	return k, &ckd.ExtendedKey{
+		PublicKey: pk,
+		ChainCode: chainCode[:],
+		ParentFP:  []byte{0x00, 0x00, 0x00, 0x00},
+	}
+}
+
+// ed25519PrivateChild is the private child derivation of section V.B of the paper, as a reference for the public one:
+// with Z = HMAC-SHA512(c, 0x02 || A || i), the left half of the child key is kL + 8*ZL, and the child chain code is
+// the last 32 bytes of HMAC-SHA512(c, 0x03 || A || i), where A = kL * B and the integers are little-endian.
+// The right half kR of the key is left out, since it changes neither the public key nor the chain code.
+func ed25519PrivateChild(kL *big.Int, chainCode []byte, index uint32) (*big.Int, []byte) {
+	A := ckd.SerializeEd25519(crypto.ScalarBaseMult(edwards.Edwards(), kL))
+	data := make([]byte, 1+len(A)+4)
+	copy(data[1:], A)
+	binary.LittleEndian.PutUint32(data[1+len(A):], index)
+
+	data[0] = 0x02
+	mac := hmac.New(sha512.New, chainCode)
+	mac.Write(data)
+	z := mac.Sum(nil)
+	data[0] = 0x03
+	mac = hmac.New(sha512.New, chainCode)
+	mac.Write(data)
+	childChainCode := mac.Sum(nil)[32:]
+
+	zl := make([]byte, 28)
+	for i := range zl {
+		zl[i] = z[27-i]
+	}
+	childKL := new(big.Int).Add(kL, new(big.Int).Lsh(new(big.Int).SetBytes(zl), 3))
+	return childKL, childChainCode
+}
+
+// ed25519PrivateDerivation derives the child of the path with ed25519PrivateChild,
+// and returns its public key and its chain code
+func ed25519PrivateDerivation(kL *big.Int, chainCode []byte, path []uint32) (*crypto.ECPoint, []byte) {
+	for _, index := range path {
+		kL, chainCode = ed25519PrivateChild(kL, chainCode, index)
+	}
+	return crypto.ScalarBaseMult(edwards.Edwards(), kL), chainCode
+}
+
+func TestEd25519PublicDerivation(t *testing.T) {
+	tests := []struct {
+		name          string
+		path          []uint32
+		wantDelta     string
+		wantPub       string
+		wantChainCode string
+	}{
+		{
+			name:          "m/0",
+			path:          []uint32{0},
+			wantDelta:     "3abf9d89ec4c57fe794314e9a59f31f60a22622d071b91b328001d50",
+			wantPub:       "dce6af9cc58cb2f6c9fd37c0b0e14b02e659e8076d1d6bbfc8a851c8833db685",
+			wantChainCode: "e5cc04f5885d2f745be455f4460ccf65963b7eca82badeca35ba79a0b4774e16",
+		},
+		{
+			name:          "m/0/1/2/2/10",
+			path:          []uint32{0, 1, 2, 2, 10},
+			wantDelta:     "d1d274237e372a5c56251832c99c89bd5b7233174ee3fc40153f45e90",
+			wantPub:       "acb4ea71a19a812714d1b3b2d5f625f4ce97c9c05f7069237715c0eeab6a72ad",
+			wantChainCode: "2d680ec686c5fa518ac5deb51c827f391e0dd86ef434ae2f4644b7df14ad1f58",
+		},
+		{
+			name:          "m/2147483647/7",
+			path:          []uint32{2147483647, 7},
+			wantDelta:     "deb33f2820487cfbcd910f5d568a23ec262246899311fc375bab666d0",
+			wantPub:       "c6907973bdf3e422b64dda466d39bcbff5710a739616d42d81b8d1a436270d77",
+			wantChainCode: "bd1b1fad60e46fa772cb34de3e0e710eeb848271d6eee585feb68a26b09c2680",
+		},
+	}
+
+	ec := edwards.Edwards()
+	k, root := ed25519TestRoot(t)
+	for _, test := range tests {
+		delta, child, err := ckd.DeriveEd25519ChildKeyFromHierarchy(test.path, root)
+		if !assert.NoError(t, err, test.name) {
+			continue
+		}
+		assert.Equal(t, test.wantDelta, delta.Text(16), test.name)
+		assert.Equal(t, test.wantPub, hex.EncodeToString(ckd.SerializeEd25519(child.PublicKey)), test.name)
+		assert.Equal(t, test.wantChainCode, hex.EncodeToString(child.ChainCode), test.name)
+		assert.Equal(t, uint8(len(test.path)), child.Depth, test.name)
+
+		// the private key of the child is the private key of the root shifted by delta
+		childK := new(big.Int).Mod(new(big.Int).Add(k, delta), ec.N)
+		assert.True(t, crypto.ScalarBaseMult(ec, childK).Equals(child.PublicKey), test.name)
+
+		// the public derivation gives the public key and the chain code of the private derivation
+		wantPk, wantChainCode := ed25519PrivateDerivation(k, root.ChainCode, test.path)
+		assert.True(t, wantPk.Equals(child.PublicKey), test.name)
+		assert.Equal(t, wantChainCode, child.ChainCode, test.name)
+	}
+}
+
+// The chain code is the HMAC key of 32 bytes, whose leading zero bytes are part of it
+func TestEd25519PublicDerivationLeadingZeroChainCode(t *testing.T) {
+	k, root := ed25519TestRoot(t)
+	root.ChainCode = append([]byte{0x00, 0x00}, root.ChainCode[2:]...)
+
+	path := []uint32{0, 1, 2}
+	_, child, err := ckd.DeriveEd25519ChildKeyFromHierarchy(path, root)
+	assert.NoError(t, err)
+	wantPk, wantChainCode := ed25519PrivateDerivation(k, root.ChainCode, path)
+	assert.True(t, wantPk.Equals(child.PublicKey))
+	assert.Equal(t, wantChainCode, child.ChainCode)
+
+	trimmed := *root
+	trimmed.ChainCode = root.ChainCode[2:]
+	_, other, err := ckd.DeriveEd25519ChildKeyFromHierarchy(path, &trimmed)
+	assert.NoError(t, err)
+	assert.False(t, other.PublicKey.Equals(child.PublicKey), "the leading zeros of the chain code change the children")
+}
+
+func TestEd25519DeriveChildKeysFromHierarchies(t *testing.T) {
+	_, root := ed25519TestRoot(t)
+
+	paths := [][]uint32{{0, 1, 2, 2, 10}, {0}, {0, 1, 2}, {2147483647, 7}}
+	deltas, keys, err := ckd.DeriveEd25519ChildKeysFromHierarchies(paths, root)
+	assert.NoError(t, err)
+	for i, path := range paths {
+		wantDelta, wantKey, err := ckd.DeriveEd25519ChildKeyFromHierarchy(path, root)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, wantDelta.Cmp(deltas[i]))
+		assert.True(t, wantKey.PublicKey.Equals(keys[i].PublicKey))
+		assert.Equal(t, wantKey.ChainCode, keys[i].ChainCode)
+	}
+}
+
+func TestEd25519DerivationErrors(t *testing.T) {
+	_, root := ed25519TestRoot(t)
+
+	_, _, err := ckd.DeriveEd25519ChildKey(ckd.HardenedKeyStart, root)
+	assert.Error(t, err, "hardened children cannot be derived from the public key")
+
+	secp := &ckd.ExtendedKey{
+		PublicKey: crypto.ScalarBaseMult(btcec.S256(), big.NewInt(7)),
+		ChainCode: root.ChainCode,
+	}
+	_, _, err = ckd.DeriveEd25519ChildKey(0, secp)
+	assert.Error(t, err, "only ed25519 keys can be derived")
+}
@@ -13,7 +13,6 @@ import (
 	"fmt"
 	"hash"
 	"math/big"
-	"regexp"
 	"strconv"
 	"strings"
 
@@ -119,7 +118,10 @@ func NewExtendedKeyFromString(key string, curve elliptic.Curve) (*ExtendedKey, e
 			return nil, err
 		}
 	} else {
-		px, py := elliptic.Unmarshal(curve, keyData)
+		px, py := elliptic.UnmarshalCompressed(curve, keyData)
+		if px == nil {
+			return nil, errors.New("invalid extended key")
+		}
 		pubKey, err = crypto.NewECPoint(curve, px, py)
 		if err != nil {
 			return nil, err
@@ -182,27 +184,28 @@ func serializeCompressed(publicKeyX *big.Int, publicKeyY *big.Int) []byte {
 	return paddedAppend(b, 32, publicKeyX.Bytes())
 }
 
+// ConvertPath parses a BIP32 path of non-hardened indices, "m/0/1" or "0/1", into its indices. The path of the master key is "m" or "".
+// The hardened indices, such as 44' or 44h, cannot be derived from a public key and are rejected.
 func ConvertPath(path string) ([]uint32, error) {
 	path = strings.TrimSpace(path)
 	if path == "" {
 		return []uint32{}, nil
 	}
-	var paths []uint32
 	parts := strings.Split(path, "/")
-	for i, part := range parts {
-		// do we have an apostrophe?
-		harden := part[len(part)-1:] == "'"
-		if harden {
-			part = part[:len(part)-1]
-		}
-		if i == 0 && regexp.MustCompile(`^[a-zA-Z]+$`).MatchString(part) {
-			fmt.Println(part)
-			continue
+	if parts[0] == "m" || parts[0] == "M" {
+		parts = parts[1:]
+	}
+	paths := make([]uint32, 0, len(parts))
+	for _, part := range parts {
+		if strings.HasSuffix(part, "'") || strings.HasSuffix(part, "h") || strings.HasSuffix(part, "H") {
+			return nil, fmt.Errorf("invalid BIP 32 path %q: the hardened index %s cannot be derived from a public key", path, part)
 		}
-		// harden == private derivation, else public derivation:
 		idx, err := strconv.ParseUint(part, 10, 32)
 		if err != nil {
-			return nil, fmt.Errorf("invalid BIP 32 path: %s", err)
+			return nil, fmt.Errorf("invalid BIP 32 path %q: %s", path, err)
+		}
+		if idx >= HardenedKeyStart {
+			return nil, fmt.Errorf("invalid BIP 32 path %q: the index %d is hardened", path, idx)
 		}
 		paths = append(paths, uint32(idx))
 	}
@@ -227,6 +230,50 @@ func DeriveChildKeyFromHierarchy(indicesHierarchy []uint32, pk *ExtendedKey, mod
 	return ilNum, k, nil
 }
 
+// DeriveChildKeysFromHierarchies derives the child keys of many paths from the same parent key, as DeriveChildKeyFromHierarchy does.
+// The keys of the common prefixes of the paths are derived once.
+func DeriveChildKeysFromHierarchies(indicesHierarchies [][]uint32, pk *ExtendedKey, mod *big.Int, curve elliptic.Curve) ([]*big.Int, []*ExtendedKey, error) {
+	return deriveHierarchies(indicesHierarchies, pk, mod, func(index uint32, k *ExtendedKey) (*big.Int, *ExtendedKey, error) {
+		return DeriveChildKey(index, k, curve)
+	})
+}
+
+// deriveHierarchies derives the child keys of the paths with deriveChild, caching the keys of the prefixes of the paths,
+// and returns the sums of the shifts of the private key along the paths
+func deriveHierarchies(
+	indicesHierarchies [][]uint32,
+	pk *ExtendedKey,
+	mod *big.Int,
+	deriveChild func(index uint32, pk *ExtendedKey) (*big.Int, *ExtendedKey, error),
+) ([]*big.Int, []*ExtendedKey, error) {
+	type derived struct {
+		delta *big.Int
+		key   *ExtendedKey
+	}
+	modN := common.ModInt(mod)
+	cache := map[string]derived{"": {big.NewInt(0), pk}}
+	deltas := make([]*big.Int, len(indicesHierarchies))
+	keys := make([]*ExtendedKey, len(indicesHierarchies))
+	for i, indices := range indicesHierarchies {
+		parent, prefix := cache[""], ""
+		for _, index := range indices {
+			prefix += "/" + strconv.FormatUint(uint64(index), 10)
+			child, ok := cache[prefix]
+			if !ok {
+				shift, childKey, err := deriveChild(index, parent.key)
+				if err != nil {
+					return nil, nil, err
+				}
+				child = derived{modN.Add(parent.delta, shift), childKey}
+				cache[prefix] = child
+			}
+			parent = child
+		}
+		deltas[i], keys[i] = parent.delta, parent.key
+	}
+	return deltas, keys, nil
+}
+
 // DeriveChildKey Derive a child key from the given parent key. The function returns "IL" ("I left"), per BIP-32 spec. It also
 // returns the derived child key.
 func DeriveChildKey(index uint32, pk *ExtendedKey, curve elliptic.Curve) (*big.Int, *ExtendedKey, error) {
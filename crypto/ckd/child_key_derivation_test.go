@@ -186,8 +186,42 @@ func TestConvertPath(t *testing.T) {
 		}
 	}
 
-	_, err := ConvertPath("11/a/23")
-	assert.Error(t, err)
+	for _, path := range []string{
+		"11/a/23",
+		"m/44'/0",
+		"m/0/1h",
+		"m/0/1H",
+		"m//1",
+		"m/0/",
+		"x/0",
+		"0/m/1",
+		"m/-1",
+		"m/2147483648",
+		"m/4294967296",
+	} {
+		_, err := ConvertPath(path)
+		assert.Error(t, err, path)
+	}
+}
+
+func TestDeriveChildKeysFromHierarchies(t *testing.T) {
+	// the master public key of the test vector 2 of BIP32
+	extKey, err := NewExtendedKeyFromString("xpub661MyMwAqRbcFW31YEwpkMuc5THy2PSt5bDMsktWQcFF8syAmRUapSCGu8ED9W6oDMSgv6Zz8idoc4a6mr8BDzTJY47LJhkJ8UB7WEGuduB", btcec.S256())
+	assert.NoError(t, err)
+
+	paths := [][]uint32{{0, 1}, {0}, {}, {0, 1, 2}, {2147483647, 1}, {0, 1}}
+	deltas, keys, err := DeriveChildKeysFromHierarchies(paths, extKey, btcec.S256().N, btcec.S256())
+	assert.NoError(t, err)
+	assert.Len(t, keys, len(paths))
+	for i, path := range paths {
+		wantDelta, wantKey, err := DeriveChildKeyFromHierarchy(path, extKey, btcec.S256().N, btcec.S256())
+		assert.NoError(t, err)
+		assert.Equal(t, 0, wantDelta.Cmp(deltas[i]))
+		assert.Equal(t, wantKey.String(), keys[i].String())
+	}
+
+	_, _, err = DeriveChildKeysFromHierarchies([][]uint32{{0}, {HardenedKeyStart}}, extKey, btcec.S256().N, btcec.S256())
+	assert.Error(t, err, "hardened children cannot be derived from the public key")
 }
 
 func TestEddsaPublicDerivation(t *testing.T) {
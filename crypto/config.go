@@ -2,10 +2,17 @@
 
 package crypto
 
-import "math/big"
+import (
+	"crypto/elliptic"
+	"math/big"
+	"sync"
+)
 
 var (
 	big2 = big.NewInt(2)
+
+	// the proof configs of the curves, by curve order
+	curveProofConfigs sync.Map
 )
 
 const (
@@ -38,3 +45,24 @@ func NewProofConfig(curveN *big.Int) *ProofConfig {
 		Lpai:                 Lpai,
 	}
 }
+
+// CurveProofConfig returns the proof config for the order of the curve ec.
+// The config of each curve is built once and must not be modified.
+func CurveProofConfig(ec elliptic.Curve) *ProofConfig {
+	key := ec.Params().N.String()
+	if config, ok := curveProofConfigs.Load(key); ok {
+		return config.(*ProofConfig)
+	}
+	config, _ := curveProofConfigs.LoadOrStore(key, NewProofConfig(ec.Params().N))
+	return config.(*ProofConfig)
+}
+
+// MaskProofConfig returns the proof config for the order of the curve ec, with the range 2^Lpai of the masks of the MtA
+// in place of the range 2^L of the secrets, for the proofs about the masks.
+func MaskProofConfig(ec elliptic.Curve) *ProofConfig {
+	config := *CurveProofConfig(ec)
+	config.TwoExpL = new(big.Int).Lsh(big2, config.Lpai)
+	config.TwoExpLAddepsilon = config.TwoExpLpaiAddepsilon
+	config.LAddEpsilon = config.LpaiAddEpsilon
+	return &config
+}
@@ -0,0 +1,65 @@
+// Package dleq implements the Chaum-Pedersen proof that two points have the same discrete logarithm:
+// X = x * G for the generator G, and Y = x * P for another base P.
+package dleq
+
+import (
+	"errors"
+	"io"
+	"math/big"
+
+	"github.com/felicityin/mpc-tss/common"
+	"github.com/felicityin/mpc-tss/crypto"
+)
+
+// Proof is a proof of knowledge of x such that X = x * G and Y = x * P
+type Proof struct {
+	A, B *crypto.ECPoint // a * G and a * P
+	Z    *big.Int        // a + c * x
+}
+
+// NewProof constructs the proof that X = x * G and Y = x * P
+func NewProof(Session []byte, x *big.Int, X, P, Y *crypto.ECPoint, rand io.Reader) (*Proof, error) {
+	if x == nil || X == nil || P == nil || Y == nil || !X.ValidateBasic() || !P.ValidateBasic() || !Y.ValidateBasic() {
+		return nil, errors.New("dleq.NewProof received nil or invalid value(s)")
+	}
+	ec := X.Curve()
+	q := ec.Params().N
+
+	a := common.GetRandomPositiveInt(rand, q)
+	A, B := crypto.ScalarBaseMult(ec, a), P.ScalarMult(a)
+	c := challenge(Session, X, P, Y, A, B)
+	z := common.ModInt(q).Add(a, new(big.Int).Mul(c, x))
+	common.ZeroBigInts(a)
+
+	return &Proof{A: A, B: B, Z: z}, nil
+}
+
+// Verify checks that z * G = A + c * X and z * P = B + c * Y
+func (pf *Proof) Verify(Session []byte, X, P, Y *crypto.ECPoint) bool {
+	if pf == nil || !pf.ValidateBasic() || X == nil || P == nil || Y == nil ||
+		!X.ValidateBasic() || !P.ValidateBasic() || !Y.ValidateBasic() {
+		return false
+	}
+	ec := X.Curve()
+	c := challenge(Session, X, P, Y, pf.A, pf.B)
+
+	AcX, err := pf.A.Add(X.ScalarMult(c))
+	if err != nil {
+		return false
+	}
+	BcY, err := pf.B.Add(Y.ScalarMult(c))
+	if err != nil {
+		return false
+	}
+	return crypto.ScalarBaseMult(ec, pf.Z).Equals(AcX) && P.ScalarMult(pf.Z).Equals(BcY)
+}
+
+func (pf *Proof) ValidateBasic() bool {
+	return pf.Z != nil && pf.A != nil && pf.B != nil && pf.A.ValidateBasic() && pf.B.ValidateBasic()
+}
+
+func challenge(Session []byte, X, P, Y, A, B *crypto.ECPoint) *big.Int {
+	ecParams := X.Curve().Params()
+	cHash := common.SHA512_256i_TAGGED(Session, ecParams.Gx, ecParams.Gy, X.X(), X.Y(), P.X(), P.Y(), Y.X(), Y.Y(), A.X(), A.Y(), B.X(), B.Y())
+	return common.RejectionSample(ecParams.N, cHash)
+}
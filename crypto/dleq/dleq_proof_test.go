@@ -0,0 +1,67 @@
+package dleq_test
+
+import (
+	"crypto/elliptic"
+	"crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/felicityin/mpc-tss/common"
+	"github.com/felicityin/mpc-tss/crypto"
+	. "github.com/felicityin/mpc-tss/crypto/dleq"
+	"github.com/felicityin/mpc-tss/tss"
+)
+
+var Session = []byte("session")
+
+func randomPoint(ec elliptic.Curve) *crypto.ECPoint {
+	return crypto.ScalarBaseMult(ec, common.GetRandomPositiveInt(rand.Reader, ec.Params().N))
+}
+
+func TestDLEQProofVerify(t *testing.T) {
+	for _, ec := range []elliptic.Curve{tss.S256(), tss.Edwards()} {
+		x := common.GetRandomPositiveInt(rand.Reader, ec.Params().N)
+		X := crypto.ScalarBaseMult(ec, x)
+		P := randomPoint(ec)
+		Y := P.ScalarMult(x)
+
+		proof, err := NewProof(Session, x, X, P, Y, rand.Reader)
+		assert.NoError(t, err)
+		assert.True(t, proof.Verify(Session, X, P, Y), "verify result must be true")
+		assert.False(t, proof.Verify([]byte("other session"), X, P, Y), "the proof is bound to the session")
+	}
+}
+
+func TestDLEQProofVerifyBadY(t *testing.T) {
+	ec := tss.S256()
+	x := common.GetRandomPositiveInt(rand.Reader, ec.Params().N)
+	X := crypto.ScalarBaseMult(ec, x)
+	P := randomPoint(ec)
+	Y := randomPoint(ec)
+
+	proof, err := NewProof(Session, x, X, P, Y, rand.Reader)
+	assert.NoError(t, err)
+	assert.False(t, proof.Verify(Session, X, P, Y), "verify result must be false")
+
+	proof, err = NewProof(Session, x, X, P, P.ScalarMult(x), rand.Reader)
+	assert.NoError(t, err)
+	assert.False(t, proof.Verify(Session, X, randomPoint(ec), P.ScalarMult(x)), "verify result must be false")
+}
+
+func TestVerifyShare(t *testing.T) {
+	for _, ec := range []elliptic.Curve{tss.S256(), tss.Edwards()} {
+		x := common.GetRandomPositiveInt(rand.Reader, ec.Params().N)
+		X := crypto.ScalarBaseMult(ec, x)
+		P := randomPoint(ec)
+
+		Y, proof, err := NewShare(Session, x, X, P, rand.Reader)
+		assert.NoError(t, err)
+		assert.True(t, P.ScalarMult(x).Equals(Y))
+		assert.NoError(t, VerifyShare(ec, Session, X, P, Y, proof))
+		assert.Error(t, VerifyShare(ec, Session, X, P, randomPoint(ec), proof), "the proof is bound to the share")
+
+		other := tss.P256()
+		assert.Error(t, VerifyShare(other, Session, X, P, Y, proof), "the share is not on the curve")
+	}
+}
@@ -0,0 +1,43 @@
+package dleq
+
+import (
+	"crypto/elliptic"
+	"errors"
+	"io"
+	"math/big"
+
+	"github.com/felicityin/mpc-tss/crypto"
+	"github.com/felicityin/mpc-tss/tss"
+)
+
+// NewShare returns the share Y = x * P of the base P for the key share X = x * G, with the proof that Y and X have the
+// same discrete logarithm
+func NewShare(Session []byte, x *big.Int, X, P *crypto.ECPoint, rand io.Reader) (*crypto.ECPoint, *Proof, error) {
+	if x == nil || P == nil || !P.ValidateBasic() {
+		return nil, nil, errors.New("dleq.NewShare received nil or invalid value(s)")
+	}
+	Y := P.ScalarMult(x)
+	proof, err := NewProof(Session, x, X, P, Y, rand)
+	if err != nil {
+		return nil, nil, err
+	}
+	return Y, proof, nil
+}
+
+// VerifyShare checks the proof that the share Y of the base P has the discrete logarithm of the key share X,
+// that Y and the proof are on the curve ec, and that Y is not of small order on ed25519
+func VerifyShare(ec elliptic.Curve, Session []byte, X, P, Y *crypto.ECPoint, proof *Proof) error {
+	if Y == nil || proof == nil || !proof.ValidateBasic() {
+		return errors.New("the share or the DLEQ proof is missing")
+	}
+	if !tss.SameCurve(Y.Curve(), ec) || !tss.SameCurve(proof.A.Curve(), ec) || !tss.SameCurve(proof.B.Curve(), ec) {
+		return errors.New("the share or the DLEQ proof is not on the curve")
+	}
+	if tss.SameCurve(ec, tss.Edwards()) && !Y.EightInvEight().Equals(Y) {
+		return errors.New("the share is not in the prime-order subgroup")
+	}
+	if !proof.Verify(Session, X, P, Y) {
+		return errors.New("the DLEQ proof of the share failed")
+	}
+	return nil
+}
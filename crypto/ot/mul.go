@@ -0,0 +1,233 @@
+// Package ot implements the two-party multiplication of DKLs19 over oblivious transfers:
+// Alice with the inputs a_1, ..., a_m and Bob with the input b end up with additive shares of a_1 * b, ..., a_m * b mod q.
+//
+// Bob encodes b with the random encoding of DKLs19 into L = |q| + 2s choice bits, so that the bits that a malicious Alice
+// learns by a selective failure reveal nothing about b. Each bit is the choice of a base OT of Chou and Orlandi ("The Simplest
+// Oblivious Transfer"), whose pads Alice correlates with her inputs: Bob receives t_k = alpha_k + beta_k * a for the choice beta_k,
+// and the shares are -sum(g_k * alpha_k) for Alice and sum(g_k * t_k) for Bob, with the gadget vector g of the encoding.
+//
+// The multiplication takes three messages: A from Alice, the B_k of Bob, and the corrections of Alice.
+// It does not check that Alice used the same inputs in all the OTs: the protocols check the shares in the exponent.
+package ot
+
+import (
+	"crypto/elliptic"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/felicityin/mpc-tss/common"
+	"github.com/felicityin/mpc-tss/crypto"
+	"github.com/felicityin/mpc-tss/tss"
+)
+
+// StatisticalSecurity is the statistical security parameter s of the encoding of Bob's input
+const StatisticalSecurity = 80
+
+type (
+	// Alice is the OT sender of the multiplication, with the inputs a_1, ..., a_m
+	Alice struct {
+		ec     elliptic.Curve
+		ssid   []byte
+		inputs []*big.Int
+		secret *big.Int // the key y of A = y * G
+		A      *crypto.ECPoint
+	}
+
+	// Bob is the OT receiver of the multiplication, with the input b
+	Bob struct {
+		ec      elliptic.Curve
+		ssid    []byte
+		choices []uint
+		keys    [][]byte // the keys of the chosen messages
+	}
+)
+
+// EncodingLen returns the number L = |q| + 2s of the OTs of a multiplication on the curve
+func EncodingLen(ec elliptic.Curve) int {
+	return ec.Params().N.BitLen() + 2*StatisticalSecurity
+}
+
+// NewAlice returns Alice with the inputs, and the point A that she sends to Bob.
+// ssid must be unique to the multiplication and known to both.
+func NewAlice(ec elliptic.Curve, ssid []byte, inputs []*big.Int, rand io.Reader) (*Alice, *crypto.ECPoint) {
+	y := common.GetRandomPositiveInt(rand, ec.Params().N)
+	A := crypto.ScalarBaseMult(ec, y)
+	return &Alice{ec: ec, ssid: ssid, inputs: inputs, secret: y, A: A}, A
+}
+
+// NewBob returns Bob with the input b, and the points B_k that he sends to Alice, one for each bit of the encoding of b:
+// B_k = x_k * G if the bit is 0, and A + x_k * G if it is 1
+func NewBob(ec elliptic.Curve, ssid []byte, b *big.Int, A *crypto.ECPoint, rand io.Reader) (*Bob, []*crypto.ECPoint, error) {
+	if A == nil || !A.ValidateBasic() || !tss.SameCurve(A.Curve(), ec) {
+		return nil, nil, errors.New("the point A of Alice is missing or not on the curve")
+	}
+	choices := encode(ec, ssid, b, rand)
+	bob := &Bob{ec: ec, ssid: ssid, choices: choices, keys: make([][]byte, len(choices))}
+	Bs := make([]*crypto.ECPoint, len(choices))
+	for k, choice := range choices {
+		x := common.GetRandomPositiveInt(rand, ec.Params().N)
+		B := crypto.ScalarBaseMult(ec, x)
+		if choice == 1 {
+			var err error
+			if B, err = B.Add(A); err != nil {
+				return nil, nil, fmt.Errorf("A + x * G err: %s", err.Error())
+			}
+		}
+		Bs[k] = B
+		bob.keys[k] = deriveKey(ssid, k, A, B, A.ScalarMult(x))
+		common.ZeroBigInts(x)
+	}
+	return bob, Bs, nil
+}
+
+// Transfer returns the corrections that Alice sends to Bob for his points B_k, and her shares of a_1 * b, ..., a_m * b.
+// The correction tau_k = alpha_k + a - rho_k turns the pad rho_k of the choice 1 into alpha_k + a, where alpha_k is the pad of the choice 0.
+func (alice *Alice) Transfer(Bs []*crypto.ECPoint) ([][]*big.Int, []*big.Int, error) {
+	ec := alice.ec
+	if len(Bs) != EncodingLen(ec) {
+		return nil, nil, fmt.Errorf("expected %d points of Bob, got %d", EncodingLen(ec), len(Bs))
+	}
+	modN := common.ModInt(ec.Params().N)
+	g := gadget(ec, alice.ssid)
+	m := len(alice.inputs)
+
+	corrections := make([][]*big.Int, len(Bs))
+	shares := make([]*big.Int, m)
+	for l := range shares {
+		shares[l] = big.NewInt(0)
+	}
+	minusA := alice.A.ScalarMult(new(big.Int).Sub(ec.Params().N, big.NewInt(1)))
+	for k, B := range Bs {
+		if B == nil || !B.ValidateBasic() || !tss.SameCurve(B.Curve(), ec) {
+			return nil, nil, fmt.Errorf("the point B_%d of Bob is missing or not on the curve", k)
+		}
+		B1, err := B.Add(minusA)
+		if err != nil {
+			return nil, nil, fmt.Errorf("B_%d - A err: %s", k, err.Error())
+		}
+		alpha := pads(ec, deriveKey(alice.ssid, k, alice.A, B, B.ScalarMult(alice.secret)), m)
+		rho := pads(ec, deriveKey(alice.ssid, k, alice.A, B, B1.ScalarMult(alice.secret)), m)
+
+		corrections[k] = make([]*big.Int, m)
+		for l, a := range alice.inputs {
+			corrections[k][l] = modN.Sub(modN.Add(alpha[l], a), rho[l])
+			shares[l] = modN.Sub(shares[l], modN.Mul(g[k], alpha[l]))
+		}
+		common.ZeroBigInts(append(alpha, rho...)...)
+	}
+	common.ZeroBigInts(alice.secret)
+	return corrections, shares, nil
+}
+
+// Receive returns the shares of Bob of a_1 * b, ..., a_m * b from the corrections of Alice
+func (bob *Bob) Receive(corrections [][]*big.Int) ([]*big.Int, error) {
+	ec := bob.ec
+	if len(corrections) != len(bob.choices) || len(corrections[0]) == 0 {
+		return nil, fmt.Errorf("expected %d corrections of Alice, got %d", len(bob.choices), len(corrections))
+	}
+	modN := common.ModInt(ec.Params().N)
+	g := gadget(ec, bob.ssid)
+	m := len(corrections[0])
+
+	shares := make([]*big.Int, m)
+	for l := range shares {
+		shares[l] = big.NewInt(0)
+	}
+	for k, choice := range bob.choices {
+		if len(corrections[k]) != m {
+			return nil, fmt.Errorf("the correction %d of Alice has %d values, expected %d", k, len(corrections[k]), m)
+		}
+		// all the corrections are checked, so that the abort does not depend on the choice
+		for _, tau := range corrections[k] {
+			if tau == nil || tau.Cmp(ec.Params().N) >= 0 {
+				return nil, fmt.Errorf("the correction %d of Alice is out of range", k)
+			}
+		}
+		t := pads(ec, bob.keys[k], m)
+		for l := range t {
+			if choice == 1 {
+				t[l] = modN.Add(t[l], corrections[k][l])
+			}
+			shares[l] = modN.Add(shares[l], modN.Mul(g[k], t[l]))
+		}
+		common.ZeroBigInts(t...)
+	}
+	common.ZeroBytes(bob.keys...)
+	return shares, nil
+}
+
+// encode returns the random encoding of b: the bits beta with sum(g_k * beta_k) = b mod q, where the last 2s bits are random
+// and the first |q| bits are those of b - sum(g_k * beta_k) over the last 2s bits
+func encode(ec elliptic.Curve, ssid []byte, b *big.Int, rand io.Reader) []uint {
+	q := ec.Params().N
+	modN := common.ModInt(q)
+	g := gadget(ec, ssid)
+	choices := make([]uint, EncodingLen(ec))
+
+	random := common.MustGetRandomInt(rand, 2*StatisticalSecurity)
+	rest := new(big.Int).Mod(b, q)
+	for k := q.BitLen(); k < len(choices); k++ {
+		choices[k] = random.Bit(k - q.BitLen())
+		if choices[k] == 1 {
+			rest = modN.Sub(rest, g[k])
+		}
+	}
+	for k := 0; k < q.BitLen(); k++ {
+		choices[k] = rest.Bit(k)
+	}
+	common.ZeroBigInts(random, rest)
+	return choices
+}
+
+// gadget returns the gadget vector g of the encoding: g_k = 2^k for the first |q| entries, and public random values for the last 2s
+func gadget(ec elliptic.Curve, ssid []byte) []*big.Int {
+	q := ec.Params().N
+	g := make([]*big.Int, EncodingLen(ec))
+	for k := range g {
+		if k < q.BitLen() {
+			g[k] = new(big.Int).Lsh(big.NewInt(1), uint(k))
+			continue
+		}
+		g[k] = hashToScalar(q, []byte("gadget"), ssid, uint32(k), 0)
+	}
+	return g
+}
+
+// deriveKey returns the key H(ssid, k, A, B, P) of the k-th OT, where P is the Diffie-Hellman point of one of the messages
+func deriveKey(ssid []byte, k int, A, B, P *crypto.ECPoint) []byte {
+	h := sha512.New()
+	h.Write(ssid)
+	var idx [4]byte
+	binary.BigEndian.PutUint32(idx[:], uint32(k))
+	h.Write(idx[:])
+	for _, point := range []*crypto.ECPoint{A, B, P} {
+		h.Write(point.X().Bytes())
+		h.Write(point.Y().Bytes())
+	}
+	return h.Sum(nil)
+}
+
+// pads expands the key of an OT into m scalars
+func pads(ec elliptic.Curve, key []byte, m int) []*big.Int {
+	out := make([]*big.Int, m)
+	for l := range out {
+		out[l] = hashToScalar(ec.Params().N, []byte("pad"), key, 0, uint32(l))
+	}
+	return out
+}
+
+// hashToScalar reduces SHA-512 of its inputs mod q, whose bias is negligible for the 256-bit orders
+func hashToScalar(q *big.Int, tag, data []byte, k, l uint32) *big.Int {
+	h := sha512.New()
+	h.Write(tag)
+	h.Write(data)
+	var idx [8]byte
+	binary.BigEndian.PutUint32(idx[:4], k)
+	binary.BigEndian.PutUint32(idx[4:], l)
+	h.Write(idx[:])
+	return new(big.Int).Mod(new(big.Int).SetBytes(h.Sum(nil)), q)
+}
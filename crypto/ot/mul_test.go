@@ -0,0 +1,77 @@
+package ot
+
+import (
+	"crypto/elliptic"
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/felicityin/mpc-tss/common"
+	"github.com/felicityin/mpc-tss/tss"
+)
+
+func TestMultiplication(t *testing.T) {
+	for _, ec := range []elliptic.Curve{tss.S256(), tss.P256()} {
+		q := ec.Params().N
+		modN := common.ModInt(q)
+		ssid := []byte("session")
+		inputs := []*big.Int{common.GetRandomPositiveInt(rand.Reader, q), common.GetRandomPositiveInt(rand.Reader, q)}
+		b := common.GetRandomPositiveInt(rand.Reader, q)
+
+		alice, A := NewAlice(ec, ssid, inputs, rand.Reader)
+		bob, Bs, err := NewBob(ec, ssid, b, A, rand.Reader)
+		assert.NoError(t, err)
+		assert.Len(t, Bs, EncodingLen(ec))
+		corrections, aliceShares, err := alice.Transfer(Bs)
+		assert.NoError(t, err)
+		bobShares, err := bob.Receive(corrections)
+		assert.NoError(t, err)
+		for l, a := range inputs {
+			assert.Equal(t, modN.Mul(a, b), modN.Add(aliceShares[l], bobShares[l]), "the shares add up to a * b")
+		}
+	}
+}
+
+func TestEncoding(t *testing.T) {
+	ec := tss.S256()
+	modN := common.ModInt(ec.Params().N)
+	g := gadget(ec, []byte("session"))
+	b := common.GetRandomPositiveInt(rand.Reader, ec.Params().N)
+
+	// the encodings of b are random, and all decode to b
+	first := encode(ec, []byte("session"), b, rand.Reader)
+	second := encode(ec, []byte("session"), b, rand.Reader)
+	assert.NotEqual(t, first, second)
+	for _, choices := range [][]uint{first, second} {
+		sum := big.NewInt(0)
+		for k, choice := range choices {
+			if choice == 1 {
+				sum = modN.Add(sum, g[k])
+			}
+		}
+		assert.Equal(t, b, sum)
+	}
+}
+
+func TestBadCorrections(t *testing.T) {
+	ec := tss.S256()
+	q := ec.Params().N
+	alice, A := NewAlice(ec, []byte("session"), []*big.Int{big.NewInt(3)}, rand.Reader)
+	bob, Bs, err := NewBob(ec, []byte("session"), big.NewInt(5), A, rand.Reader)
+	assert.NoError(t, err)
+	_, _, err = alice.Transfer(Bs[1:])
+	assert.Error(t, err, "a point of Bob is missing")
+	corrections, _, err := alice.Transfer(Bs)
+	assert.NoError(t, err)
+
+	_, err = bob.Receive(corrections[1:])
+	assert.Error(t, err, "a correction is missing")
+	corrections[0][0] = new(big.Int).Set(q)
+	_, err = bob.Receive(corrections)
+	assert.Error(t, err, "a correction is out of range")
+
+	_, _, err = NewBob(ec, []byte("session"), big.NewInt(5), nil, rand.Reader)
+	assert.Error(t, err)
+}
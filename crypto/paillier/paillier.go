@@ -167,6 +167,11 @@ func (privateKey *PrivateKey) Decrypt(c *big.Int) (m *big.Int, err error) {
 	return
 }
 
+// Destroy wipes the factorization of N from memory; the private key cannot be used afterwards
+func (privateKey *PrivateKey) Destroy() {
+	common.ZeroBigInts(privateKey.LambdaN, privateKey.PhiN, privateKey.P, privateKey.Q)
+}
+
 func (privateKey *PrivateKey) NewPedersenParameterByPaillier() (*pailliera.PederssenParameter, error) {
 	eulern, err := utils.EulerFunction([]*big.Int{privateKey.P, privateKey.Q})
 	n := privateKey.PublicKey.N
@@ -0,0 +1,111 @@
+package sigfmt
+
+import (
+	"crypto/elliptic"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/felicityin/mpc-tss/common"
+	"github.com/felicityin/mpc-tss/crypto"
+	"github.com/felicityin/mpc-tss/tss"
+)
+
+// RecoverPubkey recovers the public key of the ECDSA signature of the curve from its recovery id (SEC 1, 4.1.6).
+// M of the signature data is the digest that was signed, which is truncated to the bit length of the order as in crypto/ecdsa.
+func RecoverPubkey(ec elliptic.Curve, data *common.SignatureData) (*crypto.ECPoint, error) {
+	if tss.SameCurve(ec, tss.Edwards()) {
+		return nil, errors.New("the public key of an EdDSA signature cannot be recovered")
+	}
+	r, s, err := rs(data)
+	if err != nil {
+		return nil, err
+	}
+	recid, err := RecoveryID(data)
+	if err != nil {
+		return nil, err
+	}
+	N := ec.Params().N
+	if r.Cmp(N) >= 0 || s.Cmp(N) >= 0 {
+		return nil, errors.New("the signature r or s is not less than the order of the curve")
+	}
+
+	// R.X = r, or r + N if the bit 1 of the recovery id is set, and the bit 0 is the parity of R.Y
+	x := new(big.Int).Set(r)
+	if recid&2 != 0 {
+		x.Add(x, N)
+	}
+	R, err := liftX(ec, x, recid&1 == 1)
+	if err != nil {
+		return nil, err
+	}
+
+	// Q = r^-1 * (s*R - e*G)
+	modN := common.ModInt(N)
+	e := hashToInt(data.M, N)
+	Q := R.ScalarMult(s)
+	if minusE := modN.Sub(big.NewInt(0), e); minusE.Sign() != 0 {
+		if Q, err = Q.Add(crypto.ScalarBaseMult(ec, minusE)); err != nil {
+			return nil, fmt.Errorf("invalid recovered key: %s", err.Error())
+		}
+	}
+	Q = Q.ScalarMult(modN.ModInverse(r))
+	if !Q.ValidateBasic() {
+		return nil, errors.New("invalid recovered key")
+	}
+	return Q, nil
+}
+
+// CheckRecovery checks that the public key recovered from the ECDSA signature is the group key
+func CheckRecovery(data *common.SignatureData, pubkey *crypto.ECPoint) error {
+	if pubkey == nil {
+		return errors.New("nil public key")
+	}
+	Q, err := RecoverPubkey(pubkey.Curve(), data)
+	if err != nil {
+		return err
+	}
+	if !Q.Equals(pubkey) {
+		return errors.New("the recovered public key is not the group key")
+	}
+	return nil
+}
+
+// liftX returns the point of the curve with the coordinate x and the parity of y, on the curves y^2 = x^3 + a*x + b
+// with a = 0 (secp256k1) or a = -3 (the NIST curves)
+func liftX(ec elliptic.Curve, x *big.Int, odd bool) (*crypto.ECPoint, error) {
+	params := ec.Params()
+	P := params.P
+	if x.Cmp(P) >= 0 {
+		return nil, errors.New("the x coordinate of R is not in the field")
+	}
+	y2 := new(big.Int).Exp(x, big.NewInt(3), P)
+	if !tss.SameCurve(ec, tss.S256()) {
+		y2.Sub(y2, new(big.Int).Mul(big.NewInt(3), x))
+	}
+	y2.Add(y2, params.B)
+	y2.Mod(y2, P)
+
+	y := new(big.Int).ModSqrt(y2, P)
+	if y == nil {
+		return nil, errors.New("R is not on the curve")
+	}
+	if (y.Bit(0) == 1) != odd {
+		y.Sub(P, y)
+	}
+	return crypto.NewECPoint(ec, x, y)
+}
+
+// hashToInt converts the digest to an integer as crypto/ecdsa does: the bits beyond the bit length of the order are dropped
+func hashToInt(hash []byte, N *big.Int) *big.Int {
+	orderBits := N.BitLen()
+	orderBytes := (orderBits + 7) / 8
+	if len(hash) > orderBytes {
+		hash = hash[:orderBytes]
+	}
+	e := new(big.Int).SetBytes(hash)
+	if excess := len(hash)*8 - orderBits; excess > 0 {
+		e.Rsh(e, uint(excess))
+	}
+	return e
+}
@@ -0,0 +1,221 @@
+// Package sigfmt encodes the signatures of common.SignatureData in the standard formats:
+// ASN.1 DER, the 65-byte compact recoverable form of Ethereum, the low S DER form of Bitcoin and the 64-byte form of RFC 8032.
+package sigfmt
+
+import (
+	stdcrypto "crypto"
+	"crypto/ed25519"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"math/big"
+
+	edwards "github.com/decred/dcrd/dcrec/edwards/v2"
+
+	"github.com/felicityin/mpc-tss/common"
+	"github.com/felicityin/mpc-tss/crypto"
+	"github.com/felicityin/mpc-tss/tss"
+)
+
+const (
+	// CompactLen is the byte length of the compact recoverable encoding r || s || v
+	CompactLen = 65
+
+	// Ed25519Len is the byte length of the encoding R || S of RFC 8032
+	Ed25519Len = 64
+
+	scalarLen = 32
+)
+
+// The offsets of the recovery byte v of the compact encoding
+const (
+	// VOffsetNone is for v = the recovery id, as in crypto.Sign of go-ethereum
+	VOffsetNone byte = 0
+	// VOffsetLegacy is for v = 27 + the recovery id, as in the Ethereum transactions before EIP-155 and personal_sign
+	VOffsetLegacy byte = 27
+)
+
+type ecdsaSignature struct {
+	R, S *big.Int
+}
+
+// DER returns the ASN.1 DER encoding of the ECDSA signature, the SEQUENCE of the INTEGERs r and s
+func DER(data *common.SignatureData) ([]byte, error) {
+	r, s, err := rs(data)
+	if err != nil {
+		return nil, err
+	}
+	return asn1.Marshal(ecdsaSignature{r, s})
+}
+
+// ParseDER parses the ASN.1 DER encoding of an ECDSA signature. The encodings that are not the canonical DER are rejected.
+func ParseDER(der []byte) (r, s *big.Int, err error) {
+	var sig ecdsaSignature
+	rest, err := asn1.Unmarshal(der, &sig)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(rest) != 0 {
+		return nil, nil, errors.New("trailing bytes after the DER signature")
+	}
+	if sig.R.Sign() <= 0 || sig.S.Sign() <= 0 {
+		return nil, nil, errors.New("the DER signature has a non-positive r or s")
+	}
+	// asn1 accepts some BER encodings, which differ from the DER encoding of the same signature
+	if canonical, err := asn1.Marshal(sig); err != nil || string(canonical) != string(der) {
+		return nil, nil, errors.New("the signature is not DER encoded")
+	}
+	return sig.R, sig.S, nil
+}
+
+// BitcoinDER returns the DER encoding of a secp256k1 signature, checked to be in the low S form that Bitcoin requires (BIP-146).
+// The sighash type byte of a transaction signature is appended by the caller.
+func BitcoinDER(data *common.SignatureData) ([]byte, error) {
+	_, s, err := rs(data)
+	if err != nil {
+		return nil, err
+	}
+	if !IsLowS(tss.S256().Params().N, s) {
+		return nil, errors.New("the signature is not in the low S form")
+	}
+	return DER(data)
+}
+
+// IsLowS tells whether s is at most half the order of the curve
+func IsLowS(N, s *big.Int) bool {
+	return s.Cmp(new(big.Int).Rsh(N, 1)) <= 0
+}
+
+// Compact returns the 65-byte recoverable encoding r || s || v of the ECDSA signature, with v = vOffset + the recovery id.
+// vOffset is VOffsetNone or VOffsetLegacy, see CompactEIP155 for the transactions of EIP-155.
+func Compact(data *common.SignatureData, vOffset byte) ([]byte, error) {
+	recid, err := RecoveryID(data)
+	if err != nil {
+		return nil, err
+	}
+	if vOffset != VOffsetNone && vOffset != VOffsetLegacy {
+		return nil, fmt.Errorf("unknown v offset %d", vOffset)
+	}
+	return compact(data, vOffset+recid)
+}
+
+// CompactEIP155 returns the 65-byte recoverable encoding r || s || v of the ECDSA signature of a transaction of EIP-155,
+// with v = 35 + 2*chainID + the recovery id. The chain IDs whose v does not fit in a byte need EIP155V.
+func CompactEIP155(data *common.SignatureData, chainID *big.Int) ([]byte, error) {
+	v, err := EIP155V(data, chainID)
+	if err != nil {
+		return nil, err
+	}
+	if !v.IsUint64() || v.Uint64() > 0xff {
+		return nil, fmt.Errorf("v of the chain ID %s does not fit in a byte", chainID)
+	}
+	return compact(data, byte(v.Uint64()))
+}
+
+// EIP155V returns v = 35 + 2*chainID + the recovery id of the ECDSA signature of a transaction of EIP-155
+func EIP155V(data *common.SignatureData, chainID *big.Int) (*big.Int, error) {
+	recid, err := RecoveryID(data)
+	if err != nil {
+		return nil, err
+	}
+	// R.X >= N cannot be recovered by the Ethereum clients
+	if recid > 1 {
+		return nil, fmt.Errorf("the recovery id %d is not supported by EIP-155", recid)
+	}
+	if chainID == nil || chainID.Sign() <= 0 {
+		return nil, errors.New("the chain ID must be positive")
+	}
+	v := new(big.Int).Lsh(chainID, 1)
+	return v.Add(v, big.NewInt(35+int64(recid))), nil
+}
+
+// Ed25519 returns the 64-byte encoding R || S of RFC 8032 of the EdDSA signature.
+// R and S of the signature data are the big-endian integers of the little-endian encodings, as edwards.Verify takes them.
+func Ed25519(data *common.SignatureData) ([]byte, error) {
+	if len(data.R) == 0 || len(data.R) > scalarLen || len(data.S) == 0 || len(data.S) > scalarLen {
+		return nil, errors.New("the signature has no R and S of 32 bytes")
+	}
+	sig := make([]byte, 0, Ed25519Len)
+	sig = append(sig, reverse(common.PadToLengthBytesInPlace(data.R, scalarLen))...)
+	sig = append(sig, reverse(common.PadToLengthBytesInPlace(data.S, scalarLen))...)
+
+	// the S of the canonical signatures is reduced modulo the order of the curve
+	if new(big.Int).SetBytes(data.S).Cmp(tss.Edwards().Params().N) >= 0 {
+		return nil, errors.New("the signature S is not reduced")
+	}
+	if len(data.Signature) > 0 && string(data.Signature) != string(sig) {
+		return nil, errors.New("the signature does not match its R and S")
+	}
+	return sig, nil
+}
+
+// VerifyEd25519 verifies the EdDSA signature of the public key with crypto/ed25519, in the variant of RFC 8032 of its HashMode:
+// Ed25519 for the legacy and ED25519 modes, Ed25519ph and Ed25519ctx with the context of the signature.
+// M of an Ed25519ph signature is the SHA-512 of the message.
+func VerifyEd25519(pubkey *crypto.ECPoint, data *common.SignatureData) error {
+	if pubkey == nil || !tss.SameCurve(pubkey.Curve(), tss.Edwards()) {
+		return errors.New("the public key is not an ed25519 key")
+	}
+	sig, err := Ed25519(data)
+	if err != nil {
+		return err
+	}
+	// crypto/ed25519 tells Ed25519ctx from Ed25519 by the context
+	opts := &ed25519.Options{}
+	switch data.HashMode {
+	case common.HashMode_LEGACY, common.HashMode_ED25519:
+	case common.HashMode_ED25519PH:
+		opts.Hash, opts.Context = stdcrypto.SHA512, string(data.Context)
+	case common.HashMode_ED25519CTX:
+		opts.Context = string(data.Context)
+	default:
+		return fmt.Errorf("the hash mode %s is not for EdDSA", data.HashMode)
+	}
+	pk := edwards.NewPublicKey(pubkey.X(), pubkey.Y()).Serialize()
+	return ed25519.VerifyWithOptions(pk, data.M, sig, opts)
+}
+
+// RecoveryID returns the recovery id of the ECDSA signature, 0 to 3:
+// the parity of R.Y in the bit 0, and whether R.X is at least the order of the curve in the bit 1
+func RecoveryID(data *common.SignatureData) (byte, error) {
+	if len(data.SignatureRecovery) == 0 {
+		return 0, errors.New("the signature has no recovery id")
+	}
+	recid := data.SignatureRecovery[0]
+	if recid > 3 {
+		return 0, fmt.Errorf("invalid recovery id %d", recid)
+	}
+	return recid, nil
+}
+
+func compact(data *common.SignatureData, v byte) ([]byte, error) {
+	if len(data.R) > scalarLen || len(data.S) > scalarLen {
+		return nil, errors.New("the compact encoding has r and s of 32 bytes")
+	}
+	if _, _, err := rs(data); err != nil {
+		return nil, err
+	}
+	sig := make([]byte, 0, CompactLen)
+	sig = append(sig, common.PadToLengthBytesInPlace(data.R, scalarLen)...)
+	sig = append(sig, common.PadToLengthBytesInPlace(data.S, scalarLen)...)
+	return append(sig, v), nil
+}
+
+func rs(data *common.SignatureData) (r, s *big.Int, err error) {
+	if data == nil {
+		return nil, nil, errors.New("nil signature")
+	}
+	r, s = new(big.Int).SetBytes(data.R), new(big.Int).SetBytes(data.S)
+	if r.Sign() == 0 || s.Sign() == 0 {
+		return nil, nil, errors.New("the signature has a zero r or s")
+	}
+	return r, s, nil
+}
+
+func reverse(b []byte) []byte {
+	r := make([]byte, len(b))
+	for i := range b {
+		r[len(b)-1-i] = b[i]
+	}
+	return r
+}
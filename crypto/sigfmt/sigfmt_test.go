@@ -0,0 +1,234 @@
+package sigfmt_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"math/big"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	btcecdsa "github.com/btcsuite/btcd/btcec/v2/ecdsa"
+	"github.com/decred/dcrd/dcrec/edwards/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/felicityin/mpc-tss/common"
+	"github.com/felicityin/mpc-tss/crypto"
+	"github.com/felicityin/mpc-tss/crypto/sigfmt"
+	"github.com/felicityin/mpc-tss/tss"
+)
+
+var testHash = sha256.Sum256([]byte("sigfmt test message"))
+
+// secp256k1Signature returns a signature of btcec as the ECDSA signing outputs it, and the compact signature of btcec
+func secp256k1Signature(t *testing.T) (*btcec.PrivateKey, *common.SignatureData, []byte) {
+	priv, err := btcec.NewPrivateKey()
+	assert.NoError(t, err)
+	// v || r || s, with v = 27 + the recovery id for the uncompressed public keys
+	compact, err := btcecdsa.SignCompact(priv, testHash[:], false)
+	assert.NoError(t, err)
+	return priv, &common.SignatureData{
+		Signature:         compact[1:],
+		SignatureRecovery: []byte{compact[0] - 27},
+		R:                 compact[1:33],
+		S:                 compact[33:],
+		M:                 testHash[:],
+	}, compact
+}
+
+func TestSecp256k1Encodings(t *testing.T) {
+	priv, data, btcCompact := secp256k1Signature(t)
+
+	der, err := sigfmt.DER(data)
+	assert.NoError(t, err)
+	assert.Equal(t, btcecdsa.Sign(priv, testHash[:]).Serialize(), der)
+	r, s, err := sigfmt.ParseDER(der)
+	assert.NoError(t, err)
+	assert.Equal(t, data.R, r.Bytes())
+	assert.Equal(t, data.S, common.PadToLengthBytesInPlace(s.Bytes(), 32))
+
+	bitcoin, err := sigfmt.BitcoinDER(data)
+	assert.NoError(t, err)
+	assert.Equal(t, der, bitcoin)
+	parsed, err := btcecdsa.ParseDERSignature(bitcoin)
+	assert.NoError(t, err)
+	assert.True(t, parsed.Verify(testHash[:], priv.PubKey()))
+
+	compact, err := sigfmt.Compact(data, sigfmt.VOffsetLegacy)
+	assert.NoError(t, err)
+	assert.Len(t, compact, sigfmt.CompactLen)
+	assert.Equal(t, append(append([]byte{}, btcCompact[1:]...), btcCompact[0]), compact)
+	compact, err = sigfmt.Compact(data, sigfmt.VOffsetNone)
+	assert.NoError(t, err)
+	assert.Equal(t, data.SignatureRecovery[0], compact[64])
+
+	eip155, err := sigfmt.CompactEIP155(data, big.NewInt(1))
+	assert.NoError(t, err)
+	assert.Equal(t, 37+data.SignatureRecovery[0], eip155[64])
+	_, err = sigfmt.CompactEIP155(data, big.NewInt(1000))
+	assert.Error(t, err, "v does not fit in a byte")
+	v, err := sigfmt.EIP155V(data, big.NewInt(1000))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2035+int(data.SignatureRecovery[0])), v.Int64())
+
+	// the high S form of the same signature is valid but not for Bitcoin
+	highS := &common.SignatureData{
+		SignatureRecovery: []byte{data.SignatureRecovery[0] ^ 1},
+		R:                 data.R,
+		S:                 new(big.Int).Sub(tss.S256().Params().N, new(big.Int).SetBytes(data.S)).Bytes(),
+		M:                 data.M,
+	}
+	_, err = sigfmt.BitcoinDER(highS)
+	assert.Error(t, err, "the high S form is rejected")
+	_, err = sigfmt.DER(highS)
+	assert.NoError(t, err)
+	pub, err := crypto.NewECPoint(tss.S256(), priv.PubKey().X(), priv.PubKey().Y())
+	assert.NoError(t, err)
+	assert.NoError(t, sigfmt.CheckRecovery(highS, pub), "negating s flips the parity of R.Y")
+}
+
+func TestParseDERRejectsNonCanonical(t *testing.T) {
+	_, data, _ := secp256k1Signature(t)
+	der, err := sigfmt.DER(data)
+	assert.NoError(t, err)
+
+	_, _, err = sigfmt.ParseDER(append(der, 0))
+	assert.Error(t, err, "trailing bytes")
+
+	// r with a superfluous leading zero
+	r := data.R
+	padded := []byte{0x30, byte(4 + len(r) + 1 + 2 + len(data.S)), 0x02, byte(len(r) + 1), 0x00}
+	padded = append(padded, r...)
+	padded = append(padded, 0x02, byte(len(data.S)))
+	padded = append(padded, data.S...)
+	_, _, err = sigfmt.ParseDER(padded)
+	assert.Error(t, err, "non-minimal integer")
+}
+
+func TestRecoverPubkey(t *testing.T) {
+	priv, data, _ := secp256k1Signature(t)
+	pub, err := crypto.NewECPoint(tss.S256(), priv.PubKey().X(), priv.PubKey().Y())
+	assert.NoError(t, err)
+
+	Q, err := sigfmt.RecoverPubkey(tss.S256(), data)
+	assert.NoError(t, err)
+	assert.True(t, Q.Equals(pub))
+	assert.NoError(t, sigfmt.CheckRecovery(data, pub))
+
+	wrong := &common.SignatureData{
+		SignatureRecovery: []byte{data.SignatureRecovery[0] ^ 1},
+		R:                 data.R,
+		S:                 data.S,
+		M:                 data.M,
+	}
+	assert.Error(t, sigfmt.CheckRecovery(wrong, pub), "the other recovery id recovers another key")
+
+	other := crypto.ScalarBaseMult(tss.S256(), big.NewInt(7))
+	assert.Error(t, sigfmt.CheckRecovery(data, other), "the signature is not of the key")
+
+	// P-256, with the recovery id computed as the signing does
+	ec := tss.P256()
+	key, err := ecdsa.GenerateKey(ec, rand.Reader)
+	assert.NoError(t, err)
+	data = signWithRecovery(ec, key.D, testHash[:])
+	pub, err = crypto.NewECPoint(ec, key.X, key.Y)
+	assert.NoError(t, err)
+	assert.NoError(t, sigfmt.CheckRecovery(data, pub))
+	der, err := sigfmt.DER(data)
+	assert.NoError(t, err)
+	assert.True(t, ecdsa.VerifyASN1(&key.PublicKey, testHash[:], der))
+
+	_, err = sigfmt.RecoverPubkey(tss.Edwards(), data)
+	assert.Error(t, err, "EdDSA has no recovery")
+}
+
+func TestEd25519(t *testing.T) {
+	priv, err := edwards.GeneratePrivateKey()
+	assert.NoError(t, err)
+	msg := []byte("sigfmt test message")
+	r, s, err := edwards.Sign(priv, msg)
+	assert.NoError(t, err)
+	sig := edwards.NewSignature(r, s)
+
+	// as the EdDSA signing outputs it
+	data := &common.SignatureData{
+		Signature: sig.Serialize(),
+		R:         sig.R.Bytes(),
+		S:         sig.S.Bytes(),
+		M:         msg,
+	}
+	encoded, err := sigfmt.Ed25519(data)
+	assert.NoError(t, err)
+	assert.Len(t, encoded, sigfmt.Ed25519Len)
+	assert.Equal(t, sig.Serialize(), encoded)
+	assert.True(t, ed25519.Verify(ed25519.PublicKey(priv.PubKey().Serialize()), msg, encoded))
+
+	data.Signature = nil
+	encoded, err = sigfmt.Ed25519(data)
+	assert.NoError(t, err)
+	assert.Equal(t, sig.Serialize(), encoded)
+
+	data.S = new(big.Int).Add(sig.S, tss.Edwards().Params().N).Bytes()
+	_, err = sigfmt.Ed25519(data)
+	assert.Error(t, err, "S must be reduced")
+}
+
+// signWithRecovery signs the hash with the private key d, and computes the recovery id as the ECDSA signing does
+func signWithRecovery(ec elliptic.Curve, d *big.Int, hash []byte) *common.SignatureData {
+	N := ec.Params().N
+	modN := common.ModInt(N)
+	for {
+		k := common.GetRandomPositiveInt(rand.Reader, N)
+		R := crypto.ScalarBaseMult(ec, k)
+		r := new(big.Int).Mod(R.X(), N)
+		e := new(big.Int).SetBytes(hash)
+		s := modN.Mul(modN.ModInverse(k), modN.Add(e, modN.Mul(r, d)))
+		if r.Sign() == 0 || s.Sign() == 0 {
+			continue
+		}
+		recid := byte(R.Y().Bit(0))
+		if R.X().Cmp(N) >= 0 {
+			recid |= 2
+		}
+		return &common.SignatureData{
+			SignatureRecovery: []byte{recid},
+			R:                 r.Bytes(),
+			S:                 s.Bytes(),
+			M:                 hash,
+		}
+	}
+}
+
+func TestVerifyEd25519(t *testing.T) {
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	pk, err := edwards.ParsePubKey(priv.Public().(ed25519.PublicKey))
+	assert.NoError(t, err)
+	pubkey, err := crypto.NewECPoint(tss.Edwards(), pk.X, pk.Y)
+	assert.NoError(t, err)
+
+	msg := []byte("sigfmt test message")
+	sig, err := priv.Sign(nil, msg, &ed25519.Options{Context: "foo"})
+	assert.NoError(t, err)
+	// R and S as the EdDSA signing outputs them
+	parsed, err := edwards.ParseSignature(sig)
+	assert.NoError(t, err)
+	data := &common.SignatureData{
+		Signature: sig,
+		R:         parsed.R.Bytes(),
+		S:         parsed.S.Bytes(),
+		M:         msg,
+		HashMode:  common.HashMode_ED25519CTX,
+		Context:   []byte("foo"),
+	}
+	assert.NoError(t, sigfmt.VerifyEd25519(pubkey, data))
+
+	data.Context = []byte("bar")
+	assert.Error(t, sigfmt.VerifyEd25519(pubkey, data), "another context")
+	data.HashMode, data.Context = common.HashMode_ED25519, nil
+	assert.Error(t, sigfmt.VerifyEd25519(pubkey, data), "not an Ed25519 signature")
+	data.HashMode = common.HashMode_SHA256
+	assert.Error(t, sigfmt.VerifyEd25519(pubkey, data), "not an EdDSA mode")
+}
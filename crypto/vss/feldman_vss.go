@@ -89,6 +89,8 @@ func Create(ec elliptic.Curve, threshold int, secret *big.Int, indexes []*big.In
 		share := evaluatePolynomial(ec, threshold, poly, ids[i])
 		shares[i] = &Share{Threshold: threshold, ID: ids[i], Share: share}
 	}
+	// the secret belongs to the caller, the other coefficients are wiped
+	common.ZeroBigInts(poly[1:]...)
 	return v, shares, nil
 }
 
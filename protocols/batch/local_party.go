@@ -0,0 +1,322 @@
+package batch
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/felicityin/mpc-tss/common"
+	"github.com/felicityin/mpc-tss/tss"
+)
+
+// Implements Party
+// Implements Stringer
+var _ tss.Party = (*LocalParty)(nil)
+var _ fmt.Stringer = (*LocalParty)(nil)
+
+type (
+	// LocalParty runs a batch of independent instances of the same protocol in one execution.
+	//
+	// Each instance is a complete party of its own. The messages that the instances send to
+	// the same recipients are carried together in one BatchMessage, and the instances process
+	// every BatchMessage in parallel, so a batch takes as many round trips as a single instance.
+	LocalParty struct {
+		*tss.BaseParty
+		params *tss.Parameters
+		task   string
+
+		parties []tss.Party
+		outs    []chan tss.Message
+
+		mtx      sync.Mutex
+		started  bool
+		finished bool
+		finish   func()
+
+		// outbound messaging
+		out chan<- tss.Message
+	}
+
+	// NewPartyFunc creates the instance `index` of the batch, which must send its messages to `out`
+	NewPartyFunc func(index int, out chan<- tss.Message) (tss.Party, error)
+)
+
+// NewLocalParty creates a batch of `count` instances. finish is called once, when every instance is done,
+// and is expected to collect the results of the instances and pass them on to the caller.
+func NewLocalParty(
+	params *tss.Parameters,
+	task string,
+	count int,
+	newParty NewPartyFunc,
+	finish func(),
+	out chan<- tss.Message,
+) (*LocalParty, error) {
+	if count < 1 {
+		return nil, errors.New("batch size must be positive")
+	}
+	p := &LocalParty{
+		BaseParty: new(tss.BaseParty),
+		params:    params,
+		task:      task,
+		parties:   make([]tss.Party, count),
+		outs:      make([]chan tss.Message, count),
+		finish:    finish,
+		out:       out,
+	}
+	// an instance sends at most a broadcast and a p2p message to every other party per round,
+	// which must not block since the messages are only forwarded once the instance has returned
+	bufferLen := 4 * len(params.Parties().IDs())
+	for index := range p.parties {
+		p.outs[index] = make(chan tss.Message, bufferLen)
+		party, err := newParty(index, p.outs[index])
+		if err != nil {
+			return nil, err
+		}
+		p.parties[index] = party
+	}
+	return p, nil
+}
+
+// NewCollectingLocalParty creates a batch of `count` instances that output one result each, such as the presignatures of a presign.
+// newParty creates the instance `index` with the channels it must send its messages and its result to,
+// and the results are sent to `end` in the order of the instances once every instance is done.
+func NewCollectingLocalParty[T any](
+	params *tss.Parameters,
+	task string,
+	count int,
+	newParty func(index int, out chan<- tss.Message, end chan<- T) (tss.Party, error),
+	out chan<- tss.Message,
+	end chan<- []T,
+) (*LocalParty, error) {
+	if count < 1 {
+		return nil, errors.New("batch size must be positive")
+	}
+	ends := make([]chan T, count)
+	newInstance := func(index int, out chan<- tss.Message) (tss.Party, error) {
+		ends[index] = make(chan T, 1)
+		return newParty(index, out, ends[index])
+	}
+	finish := func() {
+		results := make([]T, count)
+		for index := range ends {
+			results[index] = <-ends[index]
+		}
+		end <- results
+	}
+	return NewLocalParty(params, task, count, newInstance, finish, out)
+}
+
+func (p *LocalParty) FirstRound() tss.Round {
+	// the rounds are run by the instances
+	return nil
+}
+
+func (p *LocalParty) Start() *tss.Error {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	if p.started {
+		return p.WrapError(errors.New("could not start. this batch has already been started"))
+	}
+	p.started = true
+
+	common.Logger.Infof("party %s: %s batch of %d starting", p.PartyID(), p.task, len(p.parties))
+	err := p.forEach(func(index int, party tss.Party) *tss.Error {
+		return party.Start()
+	})
+	if err != nil {
+		return err
+	}
+	return p.flush()
+}
+
+func (p *LocalParty) Update(msg tss.ParsedMessage) (ok bool, err *tss.Error) {
+	if _, err := p.ValidateMessage(msg); err != nil {
+		return false, err
+	}
+	content, isBatch := msg.Content().(*BatchMessage)
+	if !isBatch {
+		common.Logger.Warningf("unrecognised message ignored: %v", msg)
+		return false, nil
+	}
+
+	entries := make([][][]byte, len(p.parties))
+	for _, entry := range content.GetEntries() {
+		index := int(entry.GetIndex())
+		if len(p.parties) <= index {
+			return false, p.WrapError(fmt.Errorf("received a batch entry with an index too great (%d <= %d)",
+				len(p.parties)-1, index), msg.GetFrom())
+		}
+		entries[index] = append(entries[index], entry.GetMessage())
+	}
+
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	err = p.forEach(func(index int, party tss.Party) *tss.Error {
+		for _, bz := range entries[index] {
+			if _, err := party.UpdateFromBytes(bz, msg.GetFrom(), msg.IsBroadcast()); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	if err = p.flush(); err != nil {
+		return false, err
+	}
+	if p.started && !p.finished && p.done() {
+		p.finished = true
+		common.Logger.Infof("party %s: %s batch finished!", p.PartyID(), p.task)
+		p.finish()
+	}
+	return true, nil
+}
+
+func (p *LocalParty) UpdateFromBytes(wireBytes []byte, from *tss.PartyID, isBroadcast bool) (bool, *tss.Error) {
+	msg, err := tss.ParseWireMessage(wireBytes, from, isBroadcast)
+	if err != nil {
+		return false, p.WrapError(err)
+	}
+	return p.Update(msg)
+}
+
+func (p *LocalParty) ValidateMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	if msg == nil || msg.GetFrom() == nil || !msg.GetFrom().ValidateBasic() {
+		return false, p.WrapError(fmt.Errorf("received msg with an invalid sender: %s", msg))
+	}
+	// check that the message's "from index" will fit into the array
+	if maxFromIdx := len(p.params.Parties().IDs()) - 1; maxFromIdx < msg.GetFrom().Index {
+		return false, p.WrapError(fmt.Errorf("received msg with a sender index too great (%d <= %d)",
+			maxFromIdx, msg.GetFrom().Index), msg.GetFrom())
+	}
+	return p.BaseParty.ValidateMessage(msg)
+}
+
+func (p *LocalParty) StoreMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	// messages are stored by the instances when they are updated
+	return false, p.WrapError(errors.New("a batch does not store messages, use Update"))
+}
+
+func (p *LocalParty) Running() bool {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	return p.started && !p.finished
+}
+
+func (p *LocalParty) WaitingFor() []*tss.PartyID {
+	waiting := make(map[int]*tss.PartyID)
+	for _, party := range p.parties {
+		for _, Pj := range party.WaitingFor() {
+			waiting[Pj.Index] = Pj
+		}
+	}
+	ids := make([]*tss.PartyID, 0, len(waiting))
+	for _, Pj := range p.params.Parties().IDs() {
+		if _, ok := waiting[Pj.Index]; ok {
+			ids = append(ids, Pj)
+		}
+	}
+	return ids
+}
+
+func (p *LocalParty) WrapError(err error, culprits ...*tss.PartyID) *tss.Error {
+	return tss.NewError(err, p.task, -1, p.PartyID(), culprits...)
+}
+
+func (p *LocalParty) PartyID() *tss.PartyID {
+	return p.params.PartyID()
+}
+
+func (p *LocalParty) String() string {
+	return fmt.Sprintf("id: %s, batch of %d %s", p.PartyID(), len(p.parties), p.task)
+}
+
+// ----- //
+
+// forEach runs fn for every instance in parallel. The first error is returned, blaming every culprit found by the instances
+// with all their evidence.
+func (p *LocalParty) forEach(fn func(index int, party tss.Party) *tss.Error) *tss.Error {
+	errs := make([]*tss.Error, len(p.parties))
+	wg := sync.WaitGroup{}
+	wg.Add(len(p.parties))
+	for index, party := range p.parties {
+		go func(index int, party tss.Party) {
+			defer wg.Done()
+			errs[index] = fn(index, party)
+		}(index, party)
+	}
+	wg.Wait()
+
+	var (
+		first    *tss.Error
+		culprits []*tss.PartyID
+		evidence []*tss.Evidence
+	)
+	for index, err := range errs {
+		if err == nil {
+			continue
+		}
+		common.Logger.Errorf("party %s: %s batch instance %d failed: %s", p.PartyID(), p.task, index, err)
+		if first == nil {
+			first = err
+		}
+		culprits = append(culprits, err.Culprits()...)
+		evidence = append(evidence, err.Evidence()...)
+	}
+	if first == nil {
+		return nil
+	}
+	return tss.NewEvidenceError(first.Cause(), p.task, first.Round(), p.PartyID(), evidence, culprits...)
+}
+
+// flush forwards the pending messages of the instances, grouped by recipients
+func (p *LocalParty) flush() *tss.Error {
+	var broadcast []*BatchMessage_Entry
+	p2p := make([][]*BatchMessage_Entry, len(p.params.Parties().IDs()))
+	for index, out := range p.outs {
+	drain:
+		for {
+			select {
+			case msg := <-out:
+				bz, _, err := msg.WireBytes()
+				if err != nil {
+					return p.WrapError(err)
+				}
+				entry := &BatchMessage_Entry{Index: uint32(index), Message: bz}
+				if msg.IsBroadcast() {
+					broadcast = append(broadcast, entry)
+					continue
+				}
+				for _, Pj := range msg.GetTo() {
+					p2p[Pj.Index] = append(p2p[Pj.Index], entry)
+				}
+			default:
+				break drain
+			}
+		}
+	}
+
+	if len(broadcast) > 0 {
+		p.out <- NewBatchMessage(nil, p.PartyID(), broadcast)
+	}
+	for j, Pj := range p.params.Parties().IDs() {
+		if len(p2p[j]) > 0 {
+			p.out <- NewBatchMessage(Pj, p.PartyID(), p2p[j])
+		}
+	}
+	return nil
+}
+
+// done reports whether every instance has finished
+func (p *LocalParty) done() bool {
+	for _, party := range p.parties {
+		if party.Running() {
+			return false
+		}
+	}
+	return true
+}
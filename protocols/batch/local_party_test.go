@@ -0,0 +1,223 @@
+package batch
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/felicityin/mpc-tss/tss"
+)
+
+const (
+	testParticipants = 3
+	testBatchSize    = 2
+	testTask         = "test-batch"
+)
+
+// testParty is an instance of a batch that sends a broadcast and a p2p message to every other party in Start,
+// and outputs the messages it received once it has received them all. It blames the sender of every message
+// with the culprits of blame, if any.
+type testParty struct {
+	*tss.BaseParty
+	params *tss.Parameters
+	index  int
+	blame  func(from *tss.PartyID) []*tss.PartyID
+
+	received []tss.ParsedMessage
+	running  bool
+
+	out chan<- tss.Message
+	end chan<- []tss.ParsedMessage
+}
+
+func (p *testParty) FirstRound() tss.Round {
+	return nil
+}
+
+func (p *testParty) Start() *tss.Error {
+	p.running = true
+	p.out <- newTestMessage(nil, p.PartyID(), p.index, "broadcast")
+	for _, Pj := range p.params.Parties().IDs() {
+		if Pj.Index != p.PartyID().Index {
+			p.out <- newTestMessage(Pj, p.PartyID(), p.index, fmt.Sprintf("p2p to %d", Pj.Index))
+		}
+	}
+	return nil
+}
+
+func (p *testParty) Update(msg tss.ParsedMessage) (bool, *tss.Error) {
+	if p.blame != nil {
+		return false, p.WrapError(fmt.Errorf("instance %d rejects the message", p.index), p.blame(msg.GetFrom())...)
+	}
+	p.received = append(p.received, msg)
+	if len(p.received) == 2*(len(p.params.Parties().IDs())-1) {
+		p.running = false
+		p.end <- p.received
+	}
+	return true, nil
+}
+
+func (p *testParty) UpdateFromBytes(wireBytes []byte, from *tss.PartyID, isBroadcast bool) (bool, *tss.Error) {
+	msg, err := tss.ParseWireMessage(wireBytes, from, isBroadcast)
+	if err != nil {
+		return false, p.WrapError(err)
+	}
+	return p.Update(msg)
+}
+
+func (p *testParty) Running() bool {
+	return p.running
+}
+
+func (p *testParty) StoreMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	return true, nil
+}
+
+func (p *testParty) WrapError(err error, culprits ...*tss.PartyID) *tss.Error {
+	return tss.NewError(err, testTask, 1, p.PartyID(), culprits...)
+}
+
+func (p *testParty) PartyID() *tss.PartyID {
+	return p.params.PartyID()
+}
+
+func (p *testParty) String() string {
+	return fmt.Sprintf("id: %s, instance %d", p.PartyID(), p.index)
+}
+
+// newTestMessage returns a message of the instance `index`, whose content is a batch message with the single entry text
+func newTestMessage(to, from *tss.PartyID, index int, text string) tss.ParsedMessage {
+	return NewBatchMessage(to, from, []*BatchMessage_Entry{{Index: uint32(index), Message: []byte(text)}})
+}
+
+// newTestBatches returns a batch of testBatchSize instances for every party, where blame, if not nil,
+// gives the culprits that the instance `index` of the last party blames for a message
+func newTestBatches(
+	t *testing.T,
+	blame func(index int, from *tss.PartyID) []*tss.PartyID,
+	out chan<- tss.Message,
+	end chan<- [][]tss.ParsedMessage,
+) []*LocalParty {
+	pIDs := tss.GenerateTestPartyIDs(testParticipants)
+	p2pCtx := tss.NewPeerContext(pIDs)
+	batches := make([]*LocalParty, 0, len(pIDs))
+	for i := 0; i < len(pIDs); i++ {
+		params := tss.NewParameters(tss.S256(), p2pCtx, pIDs[i], len(pIDs), len(pIDs)-1)
+		newParty := func(index int, out chan<- tss.Message, end chan<- []tss.ParsedMessage) (tss.Party, error) {
+			party := &testParty{BaseParty: new(tss.BaseParty), params: params, index: index, out: out, end: end}
+			if blame != nil && params.PartyID().Index == len(pIDs)-1 {
+				party.blame = func(from *tss.PartyID) []*tss.PartyID {
+					return blame(index, from)
+				}
+			}
+			return party, nil
+		}
+		p, err := NewCollectingLocalParty(params, testTask, testBatchSize, newParty, out, end)
+		assert.NoError(t, err)
+		batches = append(batches, p)
+	}
+	return batches
+}
+
+// route delivers msg to its recipients, and returns the errors of the updates
+func route(batches []*LocalParty, msg tss.Message) []*tss.Error {
+	var errs []*tss.Error
+	for _, P := range batches {
+		if P.PartyID().Index == msg.GetFrom().Index {
+			continue
+		}
+		if dest := msg.GetTo(); dest != nil && dest[0].Index != P.PartyID().Index {
+			continue
+		}
+		if _, err := P.Update(msg.(tss.ParsedMessage)); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+func TestRouting(t *testing.T) {
+	outCh := make(chan tss.Message, testParticipants*testParticipants)
+	endCh := make(chan [][]tss.ParsedMessage, testParticipants)
+	batches := newTestBatches(t, nil, outCh, endCh)
+
+	for _, P := range batches {
+		assert.Nil(t, P.Start())
+	}
+	// every party sends one broadcast and one p2p message to every other party for the whole batch
+	assert.Len(t, outCh, testParticipants*testParticipants)
+	for len(outCh) > 0 {
+		msg := <-outCh
+		entries := msg.(tss.ParsedMessage).Content().(*BatchMessage).GetEntries()
+		assert.Len(t, entries, testBatchSize, "the instances share the round messages")
+		assert.Empty(t, route(batches, msg))
+	}
+
+	assert.Len(t, endCh, testParticipants, "every batch finishes once")
+	for len(endCh) > 0 {
+		results := <-endCh
+		if !assert.Len(t, results, testBatchSize) {
+			continue
+		}
+		for index, received := range results {
+			for _, msg := range received {
+				// the entries reach the instance of the same index, with the routing of the batch message
+				entry := msg.Content().(*BatchMessage).GetEntries()[0]
+				assert.Equal(t, uint32(index), entry.GetIndex())
+				if msg.IsBroadcast() {
+					assert.Equal(t, "broadcast", string(entry.GetMessage()))
+				} else {
+					assert.Contains(t, string(entry.GetMessage()), "p2p to ")
+				}
+			}
+		}
+	}
+	for _, P := range batches {
+		assert.False(t, P.Running())
+	}
+}
+
+func TestIndexTooGreat(t *testing.T) {
+	outCh := make(chan tss.Message, testParticipants*testParticipants)
+	endCh := make(chan [][]tss.ParsedMessage, testParticipants)
+	batches := newTestBatches(t, nil, outCh, endCh)
+	assert.Nil(t, batches[1].Start())
+
+	msg := newTestMessage(nil, batches[0].PartyID(), testBatchSize, "broadcast")
+	_, err := batches[1].Update(msg)
+	if assert.NotNil(t, err) {
+		if assert.Len(t, err.Culprits(), 1) {
+			assert.Equal(t, 0, err.Culprits()[0].Index)
+		}
+	}
+}
+
+func TestErrorAggregation(t *testing.T) {
+	outCh := make(chan tss.Message, testParticipants*testParticipants)
+	endCh := make(chan [][]tss.ParsedMessage, testParticipants)
+
+	// the instance 0 of the last party blames the sender, and the instance 1 blames the party 1
+	var batches []*LocalParty
+	batches = newTestBatches(t, func(index int, from *tss.PartyID) []*tss.PartyID {
+		if index == 0 {
+			return []*tss.PartyID{from}
+		}
+		return []*tss.PartyID{batches[1].PartyID()}
+	}, outCh, endCh)
+	assert.Nil(t, batches[0].Start())
+
+	errs := route(batches, <-outCh)
+	if !assert.Len(t, errs, 1) {
+		return
+	}
+	err := errs[0]
+	assert.Equal(t, testTask, err.Task())
+	assert.Equal(t, testParticipants-1, err.Victim().Index)
+	assert.Contains(t, err.Cause().Error(), "instance 0", "the cause is that of the first instance that failed")
+	if assert.Len(t, err.Culprits(), 2, "the culprits of every instance are blamed") {
+		assert.Equal(t, 0, err.Culprits()[0].Index)
+		assert.Equal(t, 1, err.Culprits()[1].Index)
+	}
+	assert.Empty(t, endCh)
+}
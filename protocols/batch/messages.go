@@ -0,0 +1,47 @@
+package batch
+
+import (
+	"github.com/felicityin/mpc-tss/common"
+	"github.com/felicityin/mpc-tss/tss"
+)
+
+// These messages were generated from Protocol Buffers definitions into batch.pb.go
+// The following messages are registered on the Protocol Buffers "wire"
+
+var (
+	// Ensure that batch messages implement ValidateBasic
+	_ = []tss.MessageContent{
+		(*BatchMessage)(nil),
+	}
+)
+
+// NewBatchMessage creates a message carrying entries to `to`, or to all parties if `to` is nil
+func NewBatchMessage(
+	to, from *tss.PartyID,
+	entries []*BatchMessage_Entry,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: to == nil,
+	}
+	if to != nil {
+		meta.To = []*tss.PartyID{to}
+	}
+	content := &BatchMessage{
+		Entries: entries,
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *BatchMessage) ValidateBasic() bool {
+	if m == nil || len(m.GetEntries()) == 0 {
+		return false
+	}
+	for _, entry := range m.GetEntries() {
+		if entry == nil || !common.NonEmptyBytes(entry.GetMessage()) {
+			return false
+		}
+	}
+	return true
+}
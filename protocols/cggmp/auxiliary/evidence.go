@@ -0,0 +1,228 @@
+package auxiliary
+
+import (
+	"bytes"
+	"crypto/elliptic"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/felicityin/mpc-tss/common"
+	"github.com/felicityin/mpc-tss/crypto"
+	"github.com/felicityin/mpc-tss/crypto/alice/utils"
+	zkPaillier "github.com/felicityin/mpc-tss/crypto/alice/zkproof/paillier"
+	"github.com/felicityin/mpc-tss/crypto/prmproof"
+	"github.com/felicityin/mpc-tss/tss"
+)
+
+// Kinds of the evidence against a party of the auxiliary protocol
+const (
+	EvidenceParameters = "cggmp/auxiliary/parameters"
+	EvidenceProofs     = "cggmp/auxiliary/proofs"
+)
+
+func init() {
+	tss.RegisterEvidenceCheck(EvidenceParameters, checkParametersEvidence)
+	tss.RegisterEvidenceCheck(EvidenceProofs, checkProofsEvidence)
+}
+
+type (
+	// ParametersInputs are the public inputs of the check of the round 2 broadcast of Pj.
+	// Ks are the keys of the parties, which the ssid is computed from.
+	ParametersInputs struct {
+		Index int        `json:"index"`
+		Ks    []*big.Int `json:"ks"`
+	}
+
+	// ProofsInputs are the public inputs of the check of the mod and fac proofs that Pj sent to the victim,
+	// where the fac proof is for the curve. The ssid is computed from the keys Ks of the parties, and rho and the pedersen
+	// parameters of the victim from the round 1 and round 2 broadcasts of all the parties.
+	ProofsInputs struct {
+		Curve tss.CurveName `json:"curve"`
+		Index int           `json:"index"`
+		Ks    []*big.Int    `json:"ks"`
+	}
+)
+
+// computeSSID returns the ssid of the auxiliary protocol of the parties of the keys ks
+func computeSSID(ks []*big.Int, number int, nonce *big.Int) []byte {
+	ssidList := []*big.Int{}
+	ssidList = append(ssidList, ks...)
+	ssidList = append(ssidList, big.NewInt(int64(number))) // round number
+	ssidList = append(ssidList, nonce)
+	return common.SHA512_256i(ssidList...).Bytes()
+}
+
+// checkParameters checks the ssid, the prm proof and the commited V_j of the round 2 broadcast of Pj
+func checkParameters(in *ParametersInputs, ssid []byte, r1msg *AuxRound1Message, r2msg *AuxRound2Message) error {
+	if !bytes.Equal(r2msg.GetSsid(), ssid) {
+		return errors.New("payload.ssid != round.temp.ssid")
+	}
+
+	prmProof, err := r2msg.UnmarshalPrmProof()
+	if err != nil {
+		return errors.New("unmarshal prm proof failed")
+	}
+	pedersen := r2msg.UnmarshalPedersenPK()
+	if err := verifyPrmPubkeys(pedersen, prmProof); err != nil {
+		return err
+	}
+	contextJ := common.AppendBigIntToBytesSlice(ssid, big.NewInt(int64(in.Index)))
+	if err := prmProof.Verify(contextJ); err != nil {
+		return fmt.Errorf("verify prm proof failed: %s", err.Error())
+	}
+
+	hash := common.SHA512_256(
+		ssid,
+		[]byte(strconv.Itoa(in.Index)),
+		r2msg.GetSrid(),
+		r2msg.UnmarshalPaillierPK().N.Bytes(),
+		pedersen.S.Bytes(),
+		pedersen.T.Bytes(),
+		prmProof.Salt,
+		r2msg.GetRho(),
+		r2msg.GetU(),
+	)
+	if !bytes.Equal(hash, r1msg.GetHash()) {
+		return errors.New("commited v_i verify failed")
+	}
+	return nil
+}
+
+// checkProofs checks the mod and fac proofs of Pj for the paillier key it broadcast in round 2,
+// where the fac proof is for the pedersen parameters of the recipient
+func checkProofs(
+	ec elliptic.Curve, ssid, rho []byte, pedersen *zkPaillier.PederssenOpenParameter, r2msg *AuxRound2Message, r3msg *AuxRound3Message,
+) error {
+	N := r2msg.UnmarshalPaillierPK().N
+
+	modProof, err := r3msg.UnmarshalModProof()
+	if err != nil {
+		return fmt.Errorf("unmarshal mod proof failed: %s", err.Error())
+	}
+	if err := modProof.Verify(rho, N); err != nil {
+		return fmt.Errorf("mod proof verify failed: %s", err.Error())
+	}
+
+	facProof, err := r3msg.UnmarshalFacProof()
+	if err != nil {
+		return errors.New("unmarshal fac proof failed")
+	}
+	if err := facProof.Verify(crypto.CurveProofConfig(ec), ssid, rho, N, pedersen); err != nil {
+		return fmt.Errorf("fac proof verify failed: %s", err.Error())
+	}
+	return nil
+}
+
+func verifyPrmPubkeys(pedersen *zkPaillier.PederssenOpenParameter, msg *prmproof.RingPederssenParameterMessage) error {
+	if new(big.Int).SetBytes(msg.N).Cmp(pedersen.N) != 0 {
+		return errors.New("msg.N != save.N")
+	}
+	if new(big.Int).SetBytes(msg.S).Cmp(pedersen.S) != 0 {
+		return errors.New("msg.S != save.S")
+	}
+	if new(big.Int).SetBytes(msg.T).Cmp(pedersen.T) != 0 {
+		return errors.New("msg.T != save.T")
+	}
+	return nil
+}
+
+func checkParametersEvidence(ev *tss.Evidence, msgs []tss.ParsedMessage) (failure error, err error) {
+	in := new(ParametersInputs)
+	if err := ev.UnmarshalInputs(in); err != nil {
+		return nil, err
+	}
+	if err := checkKeys(ev, in.Ks, in.Index); err != nil {
+		return nil, err
+	}
+	if len(msgs) != 2 {
+		return nil, errors.New("expected the round 1 and round 2 broadcasts")
+	}
+	r1msg, ok1 := msgs[0].Content().(*AuxRound1Message)
+	r2msg, ok2 := msgs[1].Content().(*AuxRound2Message)
+	if !ok1 || !ok2 {
+		return nil, errors.New("expected the round 1 and round 2 broadcasts")
+	}
+	return checkParameters(in, computeSSID(in.Ks, 1, big.NewInt(0)), r1msg, r2msg), nil
+}
+
+// checkProofsEvidence checks the round 3 message that the culprit sent to the victim, which the evidence holds first,
+// against the round 1 and round 2 broadcasts of all the parties
+func checkProofsEvidence(ev *tss.Evidence, msgs []tss.ParsedMessage) (failure error, err error) {
+	in := new(ProofsInputs)
+	if err := ev.UnmarshalInputs(in); err != nil {
+		return nil, err
+	}
+	if err := checkKeys(ev, in.Ks, in.Index); err != nil {
+		return nil, err
+	}
+	ec, ok := tss.GetCurveByName(in.Curve)
+	if !ok {
+		return nil, fmt.Errorf("unknown curve %s", in.Curve)
+	}
+	victim := ev.Victim
+	if victim == nil || victim.Index < 0 || victim.Index >= len(in.Ks) || victim.KeyInt().Cmp(in.Ks[victim.Index]) != 0 {
+		return nil, errors.New("the victim is not a party of the keys")
+	}
+	if len(msgs) == 0 {
+		return nil, errors.New("expected the round 3 message")
+	}
+	r3msg, ok := msgs[0].Content().(*AuxRound3Message)
+	if !ok {
+		return nil, errors.New("expected the round 3 message")
+	}
+	r1msgs, err := messagesOfKeys(msgs[1:], in.Ks, (*AuxRound1Message)(nil))
+	if err != nil {
+		return nil, err
+	}
+	r2msgs, err := messagesOfKeys(msgs[1:], in.Ks, (*AuxRound2Message)(nil))
+	if err != nil {
+		return nil, err
+	}
+
+	// rho is the xor of the rho of all the parties
+	ssid := computeSSID(in.Ks, 1, big.NewInt(0))
+	var rho []byte
+	for k := range in.Ks {
+		r2msg := r2msgs[k].Content().(*AuxRound2Message)
+		inputs := &ParametersInputs{Index: k, Ks: in.Ks}
+		if err := checkParameters(inputs, ssid, r1msgs[k].Content().(*AuxRound1Message), r2msg); err != nil {
+			// the party should be blamed for its parameters instead
+			return nil, fmt.Errorf("the broadcasts of party %d are invalid: %s", k, err.Error())
+		}
+		rho = utils.Xor(rho, r2msg.GetRho())
+	}
+	pedersen := r2msgs[victim.Index].Content().(*AuxRound2Message).UnmarshalPedersenPK()
+	return checkProofs(ec, ssid, rho, pedersen, r2msgs[in.Index].Content().(*AuxRound2Message), r3msg), nil
+}
+
+// messagesOfKeys returns the messages of the content type of sample of the parties of the keys ks, in the order of their index
+func messagesOfKeys(msgs []tss.ParsedMessage, ks []*big.Int, sample tss.MessageContent) ([]tss.ParsedMessage, error) {
+	byIndex, err := tss.MessagesBySender(msgs, len(ks), sample)
+	if err != nil {
+		return nil, err
+	}
+	for j, msg := range byIndex {
+		if msg.GetFrom().KeyInt().Cmp(ks[j]) != 0 {
+			return nil, fmt.Errorf("the message of party %d is not from the party of its key", j)
+		}
+	}
+	return byIndex, nil
+}
+
+// checkKeys checks that the keys of the parties hold the key of the culprit at its index
+func checkKeys(ev *tss.Evidence, ks []*big.Int, index int) error {
+	if index != ev.Culprit.Index {
+		return fmt.Errorf("the inputs are about party %d, not the culprit", index)
+	}
+	if index < 0 || index >= len(ks) || ks[index] == nil || ks[index].Cmp(ev.Culprit.KeyInt()) != 0 {
+		return errors.New("the keys of the parties do not hold the key of the culprit")
+	}
+	for j, k := range ks {
+		if k == nil {
+			return fmt.Errorf("the key of party %d is missing", j)
+		}
+	}
+	return nil
+}
@@ -1,6 +1,7 @@
 package auxiliary
 
 import (
+	"crypto/elliptic"
 	"encoding/json"
 	"math/big"
 	"os"
@@ -30,6 +31,15 @@ func setUp(level string) {
 }
 
 func TestE2EPreConcurrentWithPre(t *testing.T) {
+	testEcdsaE2EConcurrentAndSaveFixtures(t, keygen.Ecdsa, nil, testPaillierKey())
+}
+
+// the proofs of the parties are for the curve of the parameters
+func TestE2EP256ConcurrentWithPre(t *testing.T) {
+	testEcdsaE2EConcurrentAndSaveFixtures(t, keygen.Ecdsa, tss.P256(), testPaillierKey())
+}
+
+func testPaillierKey() *paillier.PrivateKey {
 	big1 := big.NewInt(1)
 	P, _ := new(big.Int).SetString("104975615121222854384410219330480259027041155688835759631647658735069527864919393410352284436544267374160206678331198777612866309766581999589789442827625308608614590850591998897357449886061863686453412019330757447743487422636807387508460941025550338019105820406950462187693188000168607236389735877001362796259", 10)
 	Q, _ := new(big.Int).SetString("102755306389915984635356782597494195047102560555160692696207839728487252530690043689166546890155633162017964085393843240989395317546293846694693801865924045225783240995686020308553449158438908412088178393717793204697268707791329981413862246773904710409946848630083569401668855899757371993960961231481357354607", 10)
@@ -44,20 +54,18 @@ func TestE2EPreConcurrentWithPre(t *testing.T) {
 	lambdaN := new(big.Int).Div(phiN, gcd)
 
 	publicKey := &paillier.PublicKey{N: N}
-	privateKey := &paillier.PrivateKey{PublicKey: *publicKey, LambdaN: lambdaN, PhiN: phiN, P: P, Q: Q}
-
-	testEcdsaE2EConcurrentAndSaveFixtures(t, keygen.Ecdsa, privateKey)
+	return &paillier.PrivateKey{PublicKey: *publicKey, LambdaN: lambdaN, PhiN: phiN, P: P, Q: Q}
 }
 
 func TestEcdsaE2EConcurrentAndSaveFixtures(t *testing.T) {
-	testEcdsaE2EConcurrentAndSaveFixtures(t, keygen.Ecdsa, nil)
+	testEcdsaE2EConcurrentAndSaveFixtures(t, keygen.Ecdsa, nil, nil)
 }
 
 func TestEddsaE2EConcurrentAndSaveFixtures(t *testing.T) {
-	testEcdsaE2EConcurrentAndSaveFixtures(t, keygen.Eddsa, nil)
+	testEcdsaE2EConcurrentAndSaveFixtures(t, keygen.Eddsa, nil, nil)
 }
 
-func testEcdsaE2EConcurrentAndSaveFixtures(t *testing.T, kind int, sk *paillier.PrivateKey) {
+func testEcdsaE2EConcurrentAndSaveFixtures(t *testing.T, kind int, ec elliptic.Curve, sk *paillier.PrivateKey) {
 	setUp("debug")
 
 	threshold := testThreshold
@@ -80,7 +88,7 @@ func testEcdsaE2EConcurrentAndSaveFixtures(t *testing.T, kind int, sk *paillier.
 
 	// init the parties
 	for i := 0; i < len(pIDs); i++ {
-		params := tss.NewParameters(nil, p2pCtx, pIDs[i], len(pIDs), threshold)
+		params := tss.NewParameters(ec, p2pCtx, pIDs[i], len(pIDs), threshold)
 		P := NewLocalParty(params, outCh, endCh).(*LocalParty)
 		P.SetPaillierSK(sk)
 		parties = append(parties, P)
@@ -155,6 +163,99 @@ AUX:
 	}
 }
 
+func TestEvidenceOfBadFacProof(t *testing.T) {
+	setUp("info")
+
+	_, pIDs, err := keygen.LoadKeygenTestFixturesRandomSet(keygen.Ecdsa, testThreshold, testParticipants)
+	if err != nil {
+		common.Logger.Error("No keygen test fixtures were found")
+	}
+
+	p2pCtx := tss.NewPeerContext(pIDs)
+	parties := make([]*LocalParty, 0, len(pIDs))
+
+	errCh := make(chan *tss.Error, len(pIDs))
+	outCh := make(chan tss.Message, len(pIDs))
+	endCh := make(chan *LocalPartySaveData, len(pIDs))
+
+	updater := test.SharedPartyUpdater
+
+	for i := 0; i < len(pIDs); i++ {
+		params := tss.NewParameters(nil, p2pCtx, pIDs[i], len(pIDs), testThreshold)
+		P := NewLocalParty(params, outCh, endCh).(*LocalParty)
+		P.SetPaillierSK(testPaillierKey())
+		parties = append(parties, P)
+		go func(P *LocalParty) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	// P0 sends to P1 the fac proof for the pedersen parameters of P2, so P1 must blame it
+	r3msgs := make(map[int]tss.Message)
+	var errs []*tss.Error
+	var ended int
+	for len(errs)+ended < len(pIDs) {
+		select {
+		case err := <-errCh:
+			errs = append(errs, err)
+
+		case msg := <-outCh:
+			if _, ok := msg.(tss.ParsedMessage).Content().(*AuxRound3Message); ok && msg.GetFrom().Index == 0 {
+				r3msgs[msg.GetTo()[0].Index] = msg
+				if len(r3msgs) < 2 {
+					continue
+				}
+				toP1 := r3msgs[1].(tss.ParsedMessage).Content().(*AuxRound3Message)
+				toP2 := r3msgs[2].(tss.ParsedMessage).Content().(*AuxRound3Message)
+				meta := tss.MessageRouting{From: pIDs[0], To: []*tss.PartyID{pIDs[1]}}
+				content := &AuxRound3Message{FacProof: toP2.FacProof, ModProof: toP1.ModProof}
+				go updater(parties[1], tss.NewMessage(meta, content, tss.NewMessageWrapper(meta, content)), errCh)
+				go updater(parties[2], r3msgs[2], errCh)
+				continue
+			}
+			if msg.GetTo() == nil {
+				for _, P := range parties {
+					if P.PartyID().Index != msg.GetFrom().Index {
+						go updater(P, msg, errCh)
+					}
+				}
+			} else {
+				go updater(parties[msg.GetTo()[0].Index], msg, errCh)
+			}
+
+		case <-endCh:
+			ended++
+		}
+	}
+
+	if !assert.Len(t, errs, 1) {
+		return
+	}
+	assert.Equal(t, 1, errs[0].Victim().Index)
+	if assert.Len(t, errs[0].Culprits(), 1) {
+		assert.Equal(t, 0, errs[0].Culprits()[0].Index)
+	}
+	if !assert.Len(t, errs[0].Evidence(), 1) {
+		return
+	}
+	ev := errs[0].Evidence()[0]
+	assert.Equal(t, EvidenceProofs, ev.Kind)
+	assert.NoError(t, ev.Verify())
+
+	// anyone can verify the evidence from its JSON encoding
+	bz, jsonErr := json.Marshal(ev)
+	assert.NoError(t, jsonErr)
+	decoded := new(tss.Evidence)
+	assert.NoError(t, json.Unmarshal(bz, decoded))
+	assert.NoError(t, decoded.Verify())
+
+	// the pedersen parameters come from the broadcast of the victim: the proof is valid for P2
+	decoded.Victim = pIDs[2]
+	assert.ErrorIs(t, decoded.Verify(), tss.ErrNoMisbehaviour)
+}
+
 func tryWriteTestFixtureFile(t *testing.T, kind, index int, data LocalPartySaveData) {
 	fixtureFileName := makeTestFixtureFilePath(kind, index)
 
@@ -12,6 +12,8 @@ import (
 	"github.com/felicityin/mpc-tss/tss"
 )
 
+// ProofParameter is the proof config of secp256k1.
+// Deprecated: the proofs use the proof config of the curve of the key, see crypto.CurveProofConfig.
 var ProofParameter = crypto.NewProofConfig(tss.S256().Params().N)
 
 // round 1 represents round 1 of the keygen part of the EDDSA TSS spec
@@ -82,6 +84,10 @@ func (round *round1) Start() *tss.Error {
 	}
 	round.temp.prmProof = prmProof
 
+	// Security: the trapdoor of the ring-pedersen parameters is only needed for the prm proof.
+	// P and Q are shared with the Paillier key and are kept
+	common.ZeroBigInts(pedersen.GetEulerValue(), pedersen.Getlambda())
+
 	round.temp.u, _ = common.GetRandomBytes(round.Rand(), 32)
 	round.temp.rho, _ = common.GetRandomBytes(round.Rand(), 32)
 	round.temp.srid, _ = common.GetRandomBytes(round.Rand(), 32)
@@ -24,32 +24,14 @@ func (round *round4) Start() *tss.Error {
 			continue
 		}
 
-		common.Logger.Debugf("round_4 get proof")
-
-		r3msg := msg.Content().(*AuxRound3Message)
-
-		// Verify mod proof
-		modProof, err := r3msg.UnmarshalModProof()
-		if err != nil {
-			common.Logger.Errorf("[j: %d] unmarshal mod proof failed: %s", j, err.Error())
-			return round.WrapError(fmt.Errorf("[j: %d] unmarshal mod proof failed: %s", j, err.Error()))
-		}
-		if err := modProof.Verify(round.temp.rho, round.save.PaillierPKs[j].N); err != nil {
-			common.Logger.Errorf("[j: %d] mod proof verify failed: %s", j, err.Error())
-			return round.WrapError(fmt.Errorf("[j: %d] mod proof verify failed: %s", j, err.Error()))
-		}
-
-		// Verify fac proof
-		facProof, err := r3msg.UnmarshalFacProof()
-		if err != nil {
-			common.Logger.Errorf("[j: %d] unmarshal fac proof failed", j)
-			return round.WrapError(fmt.Errorf("[j: %d] unmarshal fac proof failed", j))
-		}
-
-		if err := facProof.Verify(ProofParameter, round.temp.ssid, round.temp.rho,
-			round.save.PaillierPKs[j].N, round.save.PedersenPKs[i]); err != nil {
-			common.Logger.Errorf("verify prm proof failed, party: %d", j)
-			return round.WrapError(err)
+		// Verify mod proof and fac proof
+		r2msg := round.temp.auxRound2Messages[j].Content().(*AuxRound2Message)
+		if err := checkProofs(round.curve(), round.temp.ssid, round.temp.rho, round.save.PedersenPKs[i], r2msg, msg.Content().(*AuxRound3Message)); err != nil {
+			common.Logger.Errorf("[j: %d] %s", j, err.Error())
+			inputs := &ProofsInputs{Curve: round.curveName(), Index: j, Ks: round.Parties().IDs().Keys()}
+			msgs := append([]tss.ParsedMessage{msg}, round.temp.auxRound1Messages...)
+			msgs = append(msgs, round.temp.auxRound2Messages...)
+			return round.WrapEvidence(fmt.Errorf("[j: %d] %s", j, err.Error()), EvidenceProofs, inputs, msgs...)
 		}
 	}
 	common.Logger.Infof("party: %d, round_4 save", i)
@@ -1,9 +1,10 @@
 package auxiliary
 
 import (
-	"math/big"
+	"crypto/elliptic"
 
 	"github.com/felicityin/mpc-tss/common"
+	"github.com/felicityin/mpc-tss/crypto"
 	"github.com/felicityin/mpc-tss/tss"
 )
 
@@ -74,6 +75,17 @@ func (round *base) WrapError(err error, culprits ...*tss.PartyID) *tss.Error {
 	return tss.NewError(err, TaskName, round.number, round.PartyID(), culprits...)
 }
 
+// WrapEvidence returns an error blaming the sender of msgs, with the evidence that the check `kind` failed on them
+func (round *base) WrapEvidence(err error, kind string, inputs interface{}, msgs ...tss.ParsedMessage) *tss.Error {
+	culprit := msgs[0].GetFrom()
+	ev, evErr := tss.NewEvidence(kind, TaskName, round.number, culprit, inputs, msgs...)
+	if evErr != nil {
+		common.Logger.Errorf("failed to create the evidence %s against %s: %s", kind, culprit, evErr)
+		return round.WrapError(err, culprit)
+	}
+	return tss.NewEvidenceError(err, TaskName, round.number, round.PartyID(), []*tss.Evidence{ev})
+}
+
 // ----- //
 
 // `ok` tracks parties which have been verified by Update()
@@ -83,12 +95,25 @@ func (round *base) resetOK() {
 	}
 }
 
+// curve returns the curve of the parameters, or the default curve if they have none,
+// since the paillier keys are not bound to a curve but their proofs are
+func (round *base) curve() elliptic.Curve {
+	if ec := round.EC(); ec != nil {
+		return ec
+	}
+	return tss.EC()
+}
+
+func (round *base) curveName() tss.CurveName {
+	name, _ := tss.GetCurveName(round.curve())
+	return name
+}
+
+func (round *base) proofConfig() *crypto.ProofConfig {
+	return crypto.CurveProofConfig(round.curve())
+}
+
 // get ssid from local params
 func (round *base) getSSID() ([]byte, error) {
-	ssidList := []*big.Int{}
-	ssidList = append(ssidList, round.Parties().IDs().Keys()...)
-	ssidList = append(ssidList, big.NewInt(int64(round.number))) // round number
-	ssidList = append(ssidList, round.temp.ssidNonce)
-	ssid := common.SHA512_256i(ssidList...).Bytes()
-	return ssid, nil
+	return computeSSID(round.Parties().IDs().Keys(), round.number, round.temp.ssidNonce), nil
 }
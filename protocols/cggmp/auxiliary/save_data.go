@@ -2,9 +2,12 @@ package auxiliary
 
 import (
 	"encoding/hex"
+	"errors"
+	"fmt"
 	"math/big"
 
 	"github.com/felicityin/mpc-tss/common"
+	"github.com/felicityin/mpc-tss/crypto/alice/utils"
 	zkPaillier "github.com/felicityin/mpc-tss/crypto/alice/zkproof/paillier"
 	"github.com/felicityin/mpc-tss/crypto/paillier"
 	"github.com/felicityin/mpc-tss/tss"
@@ -36,6 +39,15 @@ func NewLocalPartySaveData(partyCount int) (saveData LocalPartySaveData) {
 	return
 }
 
+// Destroy wipes the Paillier private key from memory once the save data is no longer needed.
+// Parties created from the save data must not be used afterwards.
+func (save *LocalPartySaveData) Destroy() {
+	if save.PaillierSK != nil {
+		save.PaillierSK.Destroy()
+		save.PaillierSK = nil
+	}
+}
+
 // BuildLocalSaveDataSubset re-creates the LocalPartySaveData to contain data for only the list of signing parties.
 func BuildLocalSaveDataSubset(sourceData LocalPartySaveData, sortedIDs tss.SortedPartyIDs) LocalPartySaveData {
 	keysToIndices := make(map[string]int, len(sourceData.Ks))
@@ -55,3 +67,77 @@ func BuildLocalSaveDataSubset(sourceData LocalPartySaveData, sortedIDs tss.Sorte
 	}
 	return newData
 }
+
+// Validate checks that the save data is well formed before it is used for pre-signing:
+// every Paillier modulus has the expected bit length, PaillierSK matches this party's
+// public key and every ring-Pedersen parameter is a valid (N, s, t) triple over that modulus.
+func (save LocalPartySaveData) Validate() error {
+	if save.PaillierSK == nil || save.PaillierSK.N == nil || save.PaillierSK.P == nil || save.PaillierSK.Q == nil {
+		return errors.New("save data is missing PaillierSK")
+	}
+	if save.ShareID == nil {
+		return errors.New("save data is missing ShareID")
+	}
+	if len(save.Ks) == 0 || len(save.Ks) != len(save.PaillierPKs) || len(save.Ks) != len(save.PedersenPKs) {
+		return fmt.Errorf("save data has mismatched Ks, PaillierPKs and PedersenPKs (%d, %d, %d)",
+			len(save.Ks), len(save.PaillierPKs), len(save.PedersenPKs))
+	}
+	visited := make(map[string]struct{}, len(save.Ks))
+	for j, kj := range save.Ks {
+		if kj == nil || kj.Sign() == 0 {
+			return fmt.Errorf("save data Ks[%d] is nil or zero", j)
+		}
+		if _, ok := visited[kj.String()]; ok {
+			return fmt.Errorf("save data Ks[%d] is a duplicate", j)
+		}
+		visited[kj.String()] = struct{}{}
+	}
+	i, err := save.OriginalIndex()
+	if err != nil {
+		return err
+	}
+
+	sk := save.PaillierSK
+	if sk.P.BitLen() != paillierModulusLen/2 || sk.Q.BitLen() != paillierModulusLen/2 {
+		return fmt.Errorf("save data PaillierSK factors are not %d bits", paillierModulusLen/2)
+	}
+	if new(big.Int).Mul(sk.P, sk.Q).Cmp(sk.N) != 0 {
+		return errors.New("save data PaillierSK N != P*Q")
+	}
+	if save.PaillierPKs[i] == nil || save.PaillierPKs[i].N == nil || save.PaillierPKs[i].N.Cmp(sk.N) != 0 {
+		return fmt.Errorf("save data PaillierPKs[%d] does not match PaillierSK", i)
+	}
+
+	for j := range save.Ks {
+		pk := save.PaillierPKs[j]
+		if pk == nil || pk.N == nil {
+			return fmt.Errorf("save data PaillierPKs[%d] is missing", j)
+		}
+		// the product of two paillierModulusLen/2-bit primes has one of two bit lengths
+		if bits := pk.N.BitLen(); bits != paillierModulusLen && bits != paillierModulusLen-1 {
+			return fmt.Errorf("save data PaillierPKs[%d] has a %d-bit modulus", j, bits)
+		}
+		if pk.N.Bit(0) == 0 {
+			return fmt.Errorf("save data PaillierPKs[%d] has an even modulus", j)
+		}
+		ped := save.PedersenPKs[j]
+		if ped == nil || ped.GetN() == nil || ped.GetS() == nil || ped.GetT() == nil {
+			return fmt.Errorf("save data PedersenPKs[%d] is missing", j)
+		}
+		if ped.GetN().Cmp(pk.N) != 0 {
+			return fmt.Errorf("save data PedersenPKs[%d] modulus does not match PaillierPKs[%d]", j, j)
+		}
+		if !isPedersenElement(ped.GetS(), ped.GetN()) || !isPedersenElement(ped.GetT(), ped.GetN()) {
+			return fmt.Errorf("save data PedersenPKs[%d] s or t is not a unit of Z_N other than 1", j)
+		}
+		if ped.GetS().Cmp(ped.GetT()) == 0 {
+			return fmt.Errorf("save data PedersenPKs[%d] s == t", j)
+		}
+	}
+	return nil
+}
+
+// isPedersenElement reports whether v is in Z_N^* and is not the trivial element 1
+func isPedersenElement(v, n *big.Int) bool {
+	return v.Cmp(big1) > 0 && v.Cmp(n) < 0 && utils.IsRelativePrime(v, n)
+}
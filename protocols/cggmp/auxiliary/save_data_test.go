@@ -0,0 +1,39 @@
+package auxiliary
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/felicityin/mpc-tss/protocols/cggmp/keygen"
+)
+
+func TestValidateAuxFixtures(t *testing.T) {
+	for _, kind := range []int{keygen.Ecdsa, keygen.Eddsa} {
+		auxs, _, err := LoadAuxTestFixtures(kind, testParticipants)
+		assert.NoError(t, err, "should load auxiliary fixtures")
+		for _, aux := range auxs {
+			assert.NoError(t, aux.Validate())
+		}
+	}
+}
+
+func TestValidateAuxCorrupted(t *testing.T) {
+	auxs, _, err := LoadAuxTestFixtures(keygen.Eddsa, testParticipants)
+	assert.NoError(t, err, "should load auxiliary fixtures")
+
+	aux := auxs[0]
+	aux.Ks = aux.Ks[:len(aux.Ks)-1]
+	assert.Error(t, aux.Validate(), "Ks and public keys must have the same length")
+
+	aux = auxs[0]
+	aux.Ks = []*big.Int{aux.Ks[1], aux.Ks[1], aux.Ks[2]}
+	assert.Error(t, aux.Validate(), "Ks must be unique")
+
+	aux = auxs[0]
+	pk := *aux.PedersenPKs[1]
+	pk.S = pk.T
+	aux.PedersenPKs = append(aux.PedersenPKs[:1:1], &pk, aux.PedersenPKs[2])
+	assert.Error(t, aux.Validate(), "Pedersen s and t must differ")
+}
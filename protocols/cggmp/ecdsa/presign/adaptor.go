@@ -0,0 +1,83 @@
+package presign
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/felicityin/mpc-tss/crypto"
+	"github.com/felicityin/mpc-tss/crypto/dleq"
+	"github.com/felicityin/mpc-tss/tss"
+)
+
+type (
+	// Adaptor is the adaptor point T of a presignature and the nonce point RT = k^-1 * T of its pre-signatures,
+	// with the proof that RT has the discrete logarithm of R = k^-1 * G in base T
+	Adaptor struct {
+		T     *crypto.ECPoint
+		RT    *crypto.ECPoint
+		Proof *AdaptorProof
+	}
+
+	// AdaptorProof proves that R = δ^-1 * Σ Γj and RT = δ^-1 * Σ Γj^T, with the DLEQ proof of each Pj that Γj^T = γj * T
+	// for Γj = γj * G, in the context (ssid, j). It is the transcript of the presigning, which every party has.
+	AdaptorProof struct {
+		Ssid    []byte
+		Delta   *big.Int
+		Gammas  []*crypto.ECPoint
+		GammaTs []*crypto.ECPoint
+		Proofs  []*dleq.Proof
+	}
+)
+
+// Verify checks that RT = k^-1 * T for the nonce point R = k^-1 * G of the presignature
+func (a *Adaptor) Verify(R *crypto.ECPoint) error {
+	if a == nil || a.T == nil || a.RT == nil || a.Proof == nil || R == nil {
+		return errors.New("the adaptor is missing T, RT or its proof")
+	}
+	ec := R.Curve()
+	if !tss.SameCurve(a.T.Curve(), ec) || !tss.SameCurve(a.RT.Curve(), ec) {
+		return errors.New("the adaptor is not on the curve of R")
+	}
+	pf := a.Proof
+	if pf.Delta == nil || pf.Delta.Sign() == 0 || len(pf.Gammas) == 0 || len(pf.GammaTs) != len(pf.Gammas) || len(pf.Proofs) != len(pf.Gammas) {
+		return errors.New("the adaptor proof is incomplete")
+	}
+	var sumGamma, sumGammaT *crypto.ECPoint
+	for j := range pf.Gammas {
+		if pf.Gammas[j] == nil || pf.GammaTs[j] == nil ||
+			!tss.SameCurve(pf.Gammas[j].Curve(), ec) || !tss.SameCurve(pf.GammaTs[j].Curve(), ec) {
+			return fmt.Errorf("the adaptor proof of party %d is not on the curve of R", j)
+		}
+		if !pf.Proofs[j].Verify(proofContext(pf.Ssid, j), pf.Gammas[j], a.T, pf.GammaTs[j]) {
+			return fmt.Errorf("the adaptor proof of party %d failed", j)
+		}
+		if j == 0 {
+			sumGamma, sumGammaT = pf.Gammas[0], pf.GammaTs[0]
+			continue
+		}
+		var err error
+		if sumGamma, err = sumGamma.Add(pf.Gammas[j]); err != nil {
+			return err
+		}
+		if sumGammaT, err = sumGammaT.Add(pf.GammaTs[j]); err != nil {
+			return err
+		}
+	}
+	deltaInv := new(big.Int).ModInverse(pf.Delta, ec.Params().N)
+	if deltaInv == nil {
+		return errors.New("the adaptor proof has no invertible δ")
+	}
+	if !sumGamma.ScalarMult(deltaInv).Equals(R) {
+		return errors.New("R is not δ^-1 * Γ")
+	}
+	if !sumGammaT.ScalarMult(deltaInv).Equals(a.RT) {
+		return errors.New("RT is not δ^-1 * Γ^T")
+	}
+	return nil
+}
+
+// proofContext returns the context (ssid, j) of the proofs of Pj
+func proofContext(ssid []byte, j int) []byte {
+	return append(append([]byte{}, ssid...), big.NewInt(int64(j)).Bytes()...)
+}
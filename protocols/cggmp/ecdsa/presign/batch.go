@@ -0,0 +1,37 @@
+package presign
+
+import (
+	"math/big"
+
+	"github.com/felicityin/mpc-tss/protocols/batch"
+	"github.com/felicityin/mpc-tss/protocols/cggmp/auxiliary"
+	"github.com/felicityin/mpc-tss/protocols/cggmp/keygen"
+	"github.com/felicityin/mpc-tss/tss"
+)
+
+// NewBatchLocalParty creates a party generating `count` independent presignatures in one execution of the protocol.
+// Every party sends the presignatures to `end` in the same order.
+func NewBatchLocalParty(
+	isThreshold bool,
+	params *tss.Parameters,
+	key keygen.LocalPartySaveData,
+	aux auxiliary.LocalPartySaveData,
+	count int,
+	out chan<- tss.Message,
+	end chan<- []*LocalPartySaveData,
+) (tss.Party, error) {
+	newParty := func(index int, out chan<- tss.Message, end chan<- *LocalPartySaveData) (tss.Party, error) {
+		party, err := NewLocalParty(isThreshold, params, key, aux, out, end)
+		if err != nil {
+			return nil, err
+		}
+		// the instances of a batch have distinct ssids, so that their proofs cannot be swapped
+		party.(*LocalParty).temp.ssidNonce = big.NewInt(int64(index))
+		return party, nil
+	}
+	p, err := batch.NewCollectingLocalParty(params, TaskName, count, newParty, out, end)
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
@@ -0,0 +1,112 @@
+package presign
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/felicityin/mpc-tss/common"
+	"github.com/felicityin/mpc-tss/crypto"
+	"github.com/felicityin/mpc-tss/protocols/cggmp/auxiliary"
+	"github.com/felicityin/mpc-tss/protocols/cggmp/keygen"
+	nonKeygen "github.com/felicityin/mpc-tss/protocols/cggmp/keygen/non_threshold"
+	"github.com/felicityin/mpc-tss/protocols/cggmp/test"
+	"github.com/felicityin/mpc-tss/tss"
+)
+
+const testBatchSize = 3
+
+func TestE2EBatchConcurrent(t *testing.T) {
+	setUp("info")
+
+	threshold := testParticipants
+
+	// PHASE: load keygen fixtures
+	keys, signPIDs, err := nonKeygen.LoadKeygenTestFixturesRandomSet(keygen.Ecdsa, threshold, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	auxs, _, err := auxiliary.LoadAuxTestFixtures(keygen.Ecdsa, threshold)
+	assert.NoError(t, err, "should load aux fixtures")
+
+	// PHASE: batch presigning
+
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	parties := make([]tss.Party, 0, len(signPIDs))
+
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+	endCh := make(chan []*LocalPartySaveData, len(signPIDs))
+
+	updater := test.SharedPartyUpdater
+
+	// init the parties
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(tss.S256(), p2pCtx, signPIDs[i], len(signPIDs), threshold)
+		P, err := NewBatchLocalParty(false, params, keys[i], auxs[i], testBatchSize, outCh, endCh)
+		assert.NoError(t, err)
+		parties = append(parties, P)
+
+		go func(P tss.Party) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	msgs := 0
+	results := make([][]*LocalPartySaveData, 0, len(signPIDs))
+PRESIGN:
+	for {
+		select {
+		case err := <-errCh:
+			common.Logger.Errorf("Error: %s", err)
+			assert.FailNow(t, err.Error())
+			break PRESIGN
+
+		case msg := <-outCh:
+			msgs++
+			dest := msg.GetTo()
+			if dest == nil {
+				for _, P := range parties {
+					if P.PartyID().Index == msg.GetFrom().Index {
+						continue
+					}
+					go updater(P, msg, errCh)
+				}
+			} else {
+				go updater(parties[dest[0].Index], msg, errCh)
+			}
+
+		case pres := <-endCh:
+			assert.Equal(t, testBatchSize, len(pres))
+			results = append(results, pres)
+			if len(results) == len(signPIDs) {
+				break PRESIGN
+			}
+		}
+	}
+
+	// one broadcast and one p2p message to every other party in rounds 1 and 3, p2p messages in round 2
+	n := len(signPIDs)
+	assert.Equal(t, n*(2+3*(n-1)), msgs, "the instances of a batch must share round messages")
+
+	// every instance is a valid presignature: R = k^-1 * G with k = sum of ki
+	ids := make(map[string]struct{}, testBatchSize)
+	N := tss.S256().Params().N
+	for index := 0; index < testBatchSize; index++ {
+		k := big.NewInt(0)
+		id, err := results[0][index].ID()
+		assert.NoError(t, err)
+		for _, pres := range results {
+			idj, err := pres[index].ID()
+			assert.NoError(t, err)
+			assert.Equal(t, id, idj, "all parties must output the presignatures in the same order")
+			k.Add(k, pres[index].K)
+		}
+		ids[id] = struct{}{}
+		kR := results[0][index].R.ScalarMult(new(big.Int).Mod(k, N))
+		assert.True(t, kR.Equals(crypto.ScalarBaseMult(tss.S256(), big.NewInt(1))))
+	}
+	assert.Equal(t, testBatchSize, len(ids), "the presignatures of a batch must be independent")
+}
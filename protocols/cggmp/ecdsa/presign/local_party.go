@@ -1,6 +1,7 @@
 package presign
 
 import (
+	"errors"
 	"fmt"
 	"math/big"
 
@@ -38,13 +39,15 @@ type (
 		signRound1Message1s,
 		signRound1Message2s,
 		signRound2Messages,
-		signRound3Messages []tss.ParsedMessage
+		signRound3Messages,
+		signRound3Message2s []tss.ParsedMessage
 	}
 
 	localTempData struct {
 		localMessageStore
 
 		isThreshold bool
+		adaptor     *crypto.ECPoint // T, nil for the presignatures of the signatures
 
 		// round 1
 		gamma            *big.Int
@@ -58,6 +61,12 @@ type (
 		betaHat []*big.Int
 		Gamma   *crypto.ECPoint
 
+		// the MtA masks yi,j, which βi,j hides, their ciphertexts Fi,j and their randomness, for the identification
+		masks, maskCiphertexts, maskRands []*big.Int
+
+		// the shares of the adaptor nonce Γj^T = γj * T and their proofs
+		adaptorShares []*sign.AdaptorShare
+
 		// round 3
 		sumGamma *crypto.ECPoint
 		delta    *big.Int
@@ -102,12 +111,42 @@ func NewLocalParty(
 	p.temp.signRound1Message2s = make([]tss.ParsedMessage, partyCount)
 	p.temp.signRound2Messages = make([]tss.ParsedMessage, partyCount)
 	p.temp.signRound3Messages = make([]tss.ParsedMessage, partyCount)
+	p.temp.signRound3Message2s = make([]tss.ParsedMessage, partyCount)
 
 	p.temp.isThreshold = isThreshold
+	p.temp.ssidNonce = new(big.Int)
 	p.temp.kCiphertexts = make([]*big.Int, partyCount)
 	p.temp.gammaCiphertexts = make([]*big.Int, partyCount)
 	p.temp.beta = make([]*big.Int, partyCount)
 	p.temp.betaHat = make([]*big.Int, partyCount)
+	p.temp.masks = make([]*big.Int, partyCount)
+	p.temp.maskCiphertexts = make([]*big.Int, partyCount)
+	p.temp.maskRands = make([]*big.Int, partyCount)
+	return p, nil
+}
+
+// NewLocalPartyWithAdaptor returns a party whose presignature makes pre-signatures for the adaptor point T:
+// their nonce point is RT = k^-1 * T instead of R, so that they become signatures with the discrete logarithm of T.
+// See signing.NewLocalPartyWithAdaptor.
+func NewLocalPartyWithAdaptor(
+	T *crypto.ECPoint,
+	isThreshold bool,
+	params *tss.Parameters,
+	key keygen.LocalPartySaveData,
+	aux auxiliary.LocalPartySaveData,
+	out chan<- tss.Message,
+	end chan<- *LocalPartySaveData,
+) (tss.Party, error) {
+	if T == nil || !T.ValidateBasic() || !tss.SameCurve(T.Curve(), params.EC()) {
+		return nil, errors.New("the adaptor point is not on the curve of the parameters")
+	}
+	party, err := NewLocalParty(isThreshold, params, key, aux, out, end)
+	if err != nil {
+		return nil, err
+	}
+	p := party.(*LocalParty)
+	p.temp.adaptor = T
+	p.temp.adaptorShares = make([]*sign.AdaptorShare, len(params.Parties().IDs()))
 	return p, nil
 }
 
@@ -165,6 +204,9 @@ func (p *LocalParty) StoreMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
 	case *sign.SignRound3Message:
 		p.temp.signRound3Messages[fromPIdx] = msg
 
+	case *sign.SignRound3Message2:
+		p.temp.signRound3Message2s[fromPIdx] = msg
+
 	default: // unrecognised message, just ignore!
 		common.Logger.Warningf("unrecognised message ignored: %v", msg)
 		return false, nil
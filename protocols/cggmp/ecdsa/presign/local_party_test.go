@@ -3,16 +3,21 @@
 package presign
 
 import (
+	"crypto/rand"
 	"encoding/json"
+	"math/big"
 	"os"
 	"sync/atomic"
 	"testing"
 
 	"github.com/ipfs/go-log"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/felicityin/mpc-tss/common"
+	"github.com/felicityin/mpc-tss/crypto"
 	"github.com/felicityin/mpc-tss/protocols/cggmp/auxiliary"
+	"github.com/felicityin/mpc-tss/protocols/cggmp/ecdsa/sign"
 	"github.com/felicityin/mpc-tss/protocols/cggmp/keygen"
 	nonKeygen "github.com/felicityin/mpc-tss/protocols/cggmp/keygen/non_threshold"
 	tKeygen "github.com/felicityin/mpc-tss/protocols/cggmp/keygen/threshold"
@@ -105,7 +110,7 @@ SIGN:
 		case save := <-endCh:
 			index, err := save.OriginalIndex()
 			assert.NoErrorf(t, err, "should not be an error getting a party's index from save data")
-			tryWriteTestFixtureFile(t, false, index, *save)
+			tryWriteTestFixtureFile(t, keygen.Ecdsa, false, index, *save)
 
 			atomic.AddInt32(&ended, 1)
 			if atomic.LoadInt32(&ended) == int32(len(signPIDs)) {
@@ -119,12 +124,20 @@ SIGN:
 }
 
 func TestE2EThresholdConcurrent(t *testing.T) {
+	testE2EThresholdConcurrent(t, keygen.Ecdsa)
+}
+
+func TestE2EP256Concurrent(t *testing.T) {
+	testE2EThresholdConcurrent(t, keygen.EcdsaP256)
+}
+
+func testE2EThresholdConcurrent(t *testing.T, kind int) {
 	setUp("debug")
 
 	threshold := testThreshold
 
 	// PHASE: load keygen fixtures
-	keys, signPIDs, err := tKeygen.LoadKeygenTestFixturesRandomSet(keygen.Ecdsa, testThreshold+1, testParticipants)
+	keys, signPIDs, err := tKeygen.LoadKeygenTestFixturesRandomSet(kind, testThreshold+1, testParticipants)
 	assert.NoError(t, err, "should load keygen fixtures")
 
 	auxs, _, err := auxiliary.LoadAuxTestFixtures(keygen.Ecdsa, testThreshold+1)
@@ -143,7 +156,7 @@ func TestE2EThresholdConcurrent(t *testing.T) {
 
 	// init the parties
 	for i := 0; i < len(signPIDs); i++ {
-		params := tss.NewParameters(tss.S256(), p2pCtx, signPIDs[i], len(signPIDs), threshold)
+		params := tss.NewParameters(tKeygen.TestCurve(kind), p2pCtx, signPIDs[i], len(signPIDs), threshold)
 		party, err := NewLocalParty(true, params, keys[i], auxs[i], outCh, endCh)
 		assert.NoError(t, err)
 		P := party.(*LocalParty)
@@ -186,7 +199,7 @@ SIGN:
 		case save := <-endCh:
 			index, err := save.OriginalIndex()
 			assert.NoErrorf(t, err, "should not be an error getting a party's index from save data")
-			tryWriteTestFixtureFile(t, true, index, *save)
+			tryWriteTestFixtureFile(t, kind, true, index, *save)
 
 			atomic.AddInt32(&ended, 1)
 			if atomic.LoadInt32(&ended) == int32(len(signPIDs)) {
@@ -198,8 +211,8 @@ SIGN:
 	}
 }
 
-func tryWriteTestFixtureFile(t *testing.T, isThreshold bool, index int, data LocalPartySaveData) {
-	fixtureFileName := makeTestFixtureFilePath(isThreshold, index)
+func tryWriteTestFixtureFile(t *testing.T, kind int, isThreshold bool, index int, data LocalPartySaveData) {
+	fixtureFileName := makeTestFixtureFilePath(kind, isThreshold, index)
 
 	// fixture file does not already exist?
 	// if it does, we won't re-create it here
@@ -223,3 +236,159 @@ func tryWriteTestFixtureFile(t *testing.T, isThreshold bool, index int, data Loc
 	}
 	//
 }
+
+// presignWithAdaptor runs the threshold presigning of the fixtures of the kind for the adaptor point T.
+// tamper, if not nil, changes the messages to the last party.
+func presignWithAdaptor(
+	t *testing.T,
+	kind int,
+	T *crypto.ECPoint,
+	tamper func(msg tss.ParsedMessage) tss.ParsedMessage,
+) ([]*LocalPartySaveData, *tss.Error) {
+	keys, signPIDs, err := tKeygen.LoadKeygenTestFixturesRandomSet(kind, testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	auxs, _, err := auxiliary.LoadAuxTestFixtures(keygen.Ecdsa, testThreshold+1)
+	assert.NoError(t, err, "should load aux fixtures")
+
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	parties := make([]*LocalParty, 0, len(signPIDs))
+
+	errCh := make(chan *tss.Error, len(signPIDs))
+	// round 1 sends a message to every other party
+	outCh := make(chan tss.Message, len(signPIDs)*len(signPIDs))
+	endCh := make(chan *LocalPartySaveData, len(signPIDs))
+
+	updater := test.SharedPartyUpdater
+
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(tKeygen.TestCurve(kind), p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+		party, err := NewLocalPartyWithAdaptor(T, true, params, keys[i], auxs[i], outCh, endCh)
+		assert.NoError(t, err)
+		parties = append(parties, party.(*LocalParty))
+	}
+	// start every party before routing the messages, so that no party receives all the messages of round 1 before it starts
+	for _, P := range parties {
+		if err := P.Start(); err != nil {
+			return nil, err
+		}
+	}
+
+	victim := len(signPIDs) - 1
+	pres := make([]*LocalPartySaveData, len(signPIDs))
+	for ended := 0; ended < len(signPIDs); {
+		select {
+		case err := <-errCh:
+			return nil, err
+
+		case msg := <-outCh:
+			dest := msg.GetTo()
+			if dest == nil {
+				for _, P := range parties {
+					if P.PartyID().Index == msg.GetFrom().Index {
+						continue
+					}
+					go updater(P, msg, errCh)
+				}
+				continue
+			}
+			if tamper != nil && dest[0].Index == victim {
+				go updater(parties[victim], tamper(msg.(tss.ParsedMessage)), errCh)
+				continue
+			}
+			go updater(parties[dest[0].Index], msg, errCh)
+
+		case save := <-endCh:
+			index, err := save.OriginalIndex()
+			assert.NoError(t, err)
+			pres[index] = save
+			ended++
+		}
+	}
+	return pres, nil
+}
+
+func TestE2EAdaptorConcurrent(t *testing.T) {
+	setUp("info")
+
+	ec := tss.S256()
+	secret := common.GetRandomPositiveInt(rand.Reader, ec.Params().N)
+	T := crypto.ScalarBaseMult(ec, secret)
+	pres, err := presignWithAdaptor(t, keygen.Ecdsa, T, nil)
+	if !assert.Nil(t, err) {
+		return
+	}
+	for _, pre := range pres {
+		if assert.NotNil(t, pre.Adaptor) {
+			assert.NoError(t, pre.Adaptor.Verify(pre.R))
+			assert.True(t, pre.R.ScalarMult(secret).Equals(pre.Adaptor.RT), "RT = k^-1 * T = t * R")
+			assert.True(t, pres[0].Adaptor.RT.Equals(pre.Adaptor.RT), "the parties agree on RT")
+		}
+	}
+
+	// the adaptor survives the storage of the presignature
+	bz, jsonErr := json.Marshal(pres[0])
+	assert.NoError(t, jsonErr)
+	var pre LocalPartySaveData
+	assert.NoError(t, json.Unmarshal(bz, &pre))
+	assert.NoError(t, pre.Adaptor.Verify(pre.R))
+
+	// the proof of the adaptor is bound to T and R
+	other := *pre.Adaptor
+	other.T = crypto.ScalarBaseMult(ec, big.NewInt(2))
+	assert.Error(t, other.Verify(pre.R))
+	assert.Error(t, pre.Adaptor.Verify(crypto.ScalarBaseMult(ec, big.NewInt(2))))
+}
+
+func TestCulpritsOfBadAdaptorProofs(t *testing.T) {
+	setUp("info")
+
+	// P0 and P1 both send a share of the adaptor nonce of another γ to P2, which must blame both of them
+	T := crypto.ScalarBaseMult(tss.S256(), big.NewInt(42))
+	_, tssErr := presignWithAdaptor(t, keygen.Ecdsa, T, func(msg tss.ParsedMessage) tss.ParsedMessage {
+		r2msg, ok := msg.Content().(*sign.SignRound2Message)
+		if !ok {
+			return msg
+		}
+		content := proto.Clone(r2msg).(*sign.SignRound2Message)
+		content.AdaptorGamma, _ = T.MarshalJSON()
+		meta := tss.MessageRouting{From: msg.GetFrom(), To: msg.GetTo(), IsBroadcast: false}
+		return tss.NewMessage(meta, content, tss.NewMessageWrapper(meta, content))
+	})
+	if !assert.NotNil(t, tssErr) {
+		return
+	}
+	if assert.Len(t, tssErr.Culprits(), 2) {
+		assert.ElementsMatch(t, []int{0, 1}, []int{tssErr.Culprits()[0].Index, tssErr.Culprits()[1].Index})
+	}
+	for _, ev := range tssErr.Evidence() {
+		assert.Equal(t, sign.EvidenceAdaptorProof, ev.Kind)
+		assert.NoError(t, ev.Verify())
+	}
+}
+
+func TestEvidenceOfBadDelta(t *testing.T) {
+	setUp("info")
+
+	// P0 sends P2 a δ0 which does not match ∆0, with a valid log proof of ∆0, so P2 must identify it
+	T := crypto.ScalarBaseMult(tss.S256(), big.NewInt(42))
+	_, tssErr := presignWithAdaptor(t, keygen.Ecdsa, T, func(msg tss.ParsedMessage) tss.ParsedMessage {
+		r3msg, ok := msg.Content().(*sign.SignRound3Message)
+		if !ok || msg.GetFrom().Index != 0 {
+			return msg
+		}
+		content := proto.Clone(r3msg).(*sign.SignRound3Message)
+		content.Delta = new(big.Int).Add(new(big.Int).SetBytes(content.Delta), big.NewInt(1)).Bytes()
+		meta := tss.MessageRouting{From: msg.GetFrom(), To: msg.GetTo(), IsBroadcast: false}
+		return tss.NewMessage(meta, content, tss.NewMessageWrapper(meta, content))
+	})
+	if !assert.NotNil(t, tssErr) {
+		return
+	}
+	if assert.Len(t, tssErr.Culprits(), 1) {
+		assert.Equal(t, 0, tssErr.Culprits()[0].Index)
+	}
+	if assert.Len(t, tssErr.Evidence(), 1) {
+		assert.Equal(t, sign.EvidenceDeltaShare, tssErr.Evidence()[0].Kind)
+		assert.NoError(t, tssErr.Evidence()[0].Verify())
+	}
+}
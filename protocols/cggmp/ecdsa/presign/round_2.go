@@ -10,6 +10,7 @@ import (
 	"github.com/felicityin/mpc-tss/crypto"
 	"github.com/felicityin/mpc-tss/crypto/affproof"
 	"github.com/felicityin/mpc-tss/crypto/alice/mta"
+	"github.com/felicityin/mpc-tss/crypto/dleq"
 	"github.com/felicityin/mpc-tss/crypto/logproof"
 	"github.com/felicityin/mpc-tss/protocols/cggmp/ecdsa/sign"
 	"github.com/felicityin/mpc-tss/tss"
@@ -30,6 +31,7 @@ func (round *round2) Start() *tss.Error {
 	contextI := append(round.temp.ssid, big.NewInt(int64(i)).Bytes()...)
 
 	// Verify received enc proof
+	session := round.session()
 	for j := range Ps {
 		if j == i {
 			continue
@@ -41,19 +43,13 @@ func (round *round2) Start() *tss.Error {
 		common.Logger.Debugf("P[%d]: receive P[%d]'s kCiphertext and gammaCiphertext", i, j)
 
 		r1msg2 := round.temp.signRound1Message2s[j].Content().(*sign.SignRound1Message2)
-		encProof, err := r1msg2.UnmarshalEncProof()
-		if err != nil {
-			common.Logger.Errorf("unmarshal enc proof failed, party: %d", j)
-			return round.WrapError(err)
-		}
-		common.Logger.Debugf("P[%d]: receive P[%d]'s enc proof", i, j)
-
-		if err := encProof.Verify(
-			ProofParameter, contextI, round.temp.kCiphertexts[j],
-			round.aux.PaillierPKs[j].N, round.aux.PedersenPKs[i],
-		); err != nil {
-			common.Logger.Errorf("verify enc proof failed, party: %d", j)
-			return round.WrapError(err)
+		if err := sign.CheckEncProof(round.EC(), session, round.temp.ssid, j, i, r1msg1, r1msg2); err != nil {
+			inputs := &sign.Inputs{Session: *session, Index: j}
+			common.Logger.Errorf("[j: %d] %s", j, err.Error())
+			return round.WrapEvidence(
+				fmt.Errorf("[j: %d] %s", j, err.Error()), sign.EvidenceEncProof, inputs,
+				round.temp.signRound1Message1s[j], round.temp.signRound1Message2s[j],
+			)
 		}
 		common.Logger.Debugf("P[%d]: verify P[%d]'s enc proof ok", i, j)
 	}
@@ -62,6 +58,18 @@ func (round *round2) Start() *tss.Error {
 	common.Logger.Debugf("P[%d]: calc Gammai", i)
 	round.temp.Gamma = crypto.ScalarBaseMult(round.EC(), round.temp.gamma)
 
+	// the share of the adaptor nonce γi * T, proven against Γi
+	var adaptor *sign.AdaptorShare
+	if round.temp.adaptor != nil {
+		GammaT := round.temp.adaptor.ScalarMult(round.temp.gamma)
+		proof, err := dleq.NewProof(contextI, round.temp.gamma, round.temp.Gamma, round.temp.adaptor, GammaT, round.Rand())
+		if err != nil {
+			return round.WrapError(fmt.Errorf("create adaptor proof failed: %s", err.Error()))
+		}
+		adaptor = &sign.AdaptorShare{GammaT: GammaT, Proof: proof}
+		round.temp.adaptorShares[i] = adaptor
+	}
+
 	var Ds = make([][]byte, len(round.Parties().IDs()))
 	var Fs = make([]*big.Int, len(round.Parties().IDs()))
 	var psiProofs = make([]*affproof.PaillierAffAndGroupRangeMessage, len(round.Parties().IDs()))
@@ -87,11 +95,14 @@ func (round *round2) Start() *tss.Error {
 				round.Rand(), contextI, round.aux.PedersenPKs[j], round.aux.PaillierPKs[i],
 				round.temp.kCiphertexts[j], round.temp.gamma, round.temp.Gamma,
 			)
-			Ds[j], Fs[j], psiProofs[j], round.temp.beta[j], _, _, _ = D, F, psiProof, negBeta, countDelta, r, s
 			if err != nil {
 				common.Logger.Errorf("create aff-g proof 1 failed: %s", err.Error())
 				errChs <- round.WrapError(fmt.Errorf("create aff-g proof 1 failed: %s", err.Error()))
+				return
 			}
+			Ds[j], Fs[j], psiProofs[j], round.temp.beta[j], _ = D, F, psiProof, negBeta, s
+			round.temp.masks[j] = sign.MtAMask(negBeta, countDelta, round.aux.PaillierPKs[j].N)
+			round.temp.maskCiphertexts[j], round.temp.maskRands[j] = F, r
 		}(j, Pj)
 
 		go func(j int, Pj *tss.PartyID) {
@@ -101,23 +112,24 @@ func (round *round2) Start() *tss.Error {
 				round.Rand(), contextI, round.aux.PedersenPKs[j], round.aux.PaillierPKs[i],
 				round.temp.kCiphertexts[j], round.key.PrivXi, round.key.PubXj[i],
 			)
-			Dhats[j], Fhats[j], psiHatProofs[j], round.temp.betaHat[j], _, _, _ = Dhat, Fhat, psiHatProof, negBetaHat, countSigma, rhat, shat
 			if err != nil {
 				common.Logger.Errorf("create aff-g proof 2 failed: %s", err.Error())
 				errChs <- round.WrapError(fmt.Errorf("create aff-g proof 2 failed: %s", err.Error()))
+				return
 			}
+			Dhats[j], Fhats[j], psiHatProofs[j], round.temp.betaHat[j], _, _, _ = Dhat, Fhat, psiHatProof, negBetaHat, countSigma, rhat, shat
 		}(j, Pj)
 	}
 
 	// Consume error channels; wait for goroutines
 	wg.Wait()
 	close(errChs)
-	culprits := make([]*tss.PartyID, 0, len(round.Parties().IDs()))
+	var errs []*tss.Error
 	for err := range errChs {
-		culprits = append(culprits, err.Culprits()...)
+		errs = append(errs, err)
 	}
-	if len(culprits) > 0 {
-		return round.WrapError(errors.New("failed to calculate affg proof"), culprits...)
+	if err := tss.JoinErrors(errs...); err != nil {
+		return err
 	}
 
 	for j, Pj := range round.Parties().IDs() {
@@ -127,7 +139,7 @@ func (round *round2) Start() *tss.Error {
 
 		// log proof for the secret gamma, mu: M(prove, Πlog, (sid, i), (Iε, Gi, Γi, g); (γi, νi))
 		logProof, err := logproof.NewKnowExponentAndPaillierEncryption(
-			ProofParameter, contextI, round.temp.gamma, round.temp.mu, round.temp.gammaCiphertexts[i],
+			crypto.CurveProofConfig(round.EC()), contextI, round.temp.gamma, round.temp.mu, round.temp.gammaCiphertexts[i],
 			round.aux.PaillierPKs[i].N, round.aux.PedersenPKs[j], round.temp.Gamma, nil,
 		)
 		if err != nil {
@@ -137,11 +149,13 @@ func (round *round2) Start() *tss.Error {
 
 		common.Logger.Debugf("P[%d]: send proofs to P[%d]", i, j)
 		r2msg, err := sign.NewSignRound2Message(
-			Pj, round.PartyID(), round.temp.Gamma, Ds[j], Fs[j], Dhats[j], Fhats[j], psiProofs[j], psiHatProofs[j], logProof,
+			Pj, round.PartyID(), round.temp.Gamma, Ds[j], Fs[j], Dhats[j], Fhats[j], psiProofs[j], psiHatProofs[j], logProof, adaptor,
 		)
 		if err != nil {
 			return round.WrapError(err, Pj)
 		}
+		// keep one of its messages, whose Γi the evidence of the later rounds needs
+		round.temp.signRound2Messages[i] = r2msg
 		round.out <- r2msg
 	}
 	return nil
@@ -8,6 +8,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/felicityin/mpc-tss/common"
+	"github.com/felicityin/mpc-tss/crypto"
 	"github.com/felicityin/mpc-tss/crypto/logproof"
 	"github.com/felicityin/mpc-tss/protocols/cggmp/ecdsa/sign"
 	"github.com/felicityin/mpc-tss/tss"
@@ -28,93 +29,86 @@ func (round *round3) Start() *tss.Error {
 	// Γ = sum_j Γj
 	sumGamma := round.temp.Gamma
 
-	errChs := make(chan *tss.Error, (len(round.Parties().IDs())-1)*3)
+	// each Pj can fail the affg, affg_hat and log proofs, and the adaptor proof
+	errChs := make(chan *tss.Error, (len(round.Parties().IDs())-1)*4)
 	wg := sync.WaitGroup{}
 	wg.Add((len(round.Parties().IDs()) - 1) * 2)
 
 	// verify received proofs
+	session := round.session()
 	for j, Pj := range round.Parties().IDs() {
 		if j == i {
 			continue
 		}
 
-		contextJ := append(round.temp.ssid, big.NewInt(int64(j)).Bytes()...)
-		r2msg := round.temp.signRound2Messages[j].Content().(*sign.SignRound2Message)
+		r2msg := round.temp.signRound2Messages[j]
 
-		Gamma, err := r2msg.UnmarshalGamma()
+		Gamma, err := r2msg.Content().(*sign.SignRound2Message).UnmarshalGamma()
 		if err != nil {
 			return round.WrapError(err, Pj)
 		}
-
 		sumGamma, err = sumGamma.Add(Gamma)
 		if err != nil {
 			return round.WrapError(err, Pj)
 		}
-
-		psiProof, err := r2msg.UnmarshalAffgProof()
-		if err != nil {
-			common.Logger.Errorf("[j: %d] failed to unmarshal affg proof: %s", j, err.Error())
-			return round.WrapError(fmt.Errorf("[j: %d] failed to unmarshal affg proof: %s", j, err.Error()))
-		}
-		common.Logger.Debugf("P[%d]: receive P[%d]'s affg proof", i, j)
-
-		psiHatProof, err := r2msg.UnmarshalAffgHatProof()
-		if err != nil {
-			common.Logger.Errorf("[j: %d] failed to unmarshal affg_hat proof: %s", j, err.Error())
-			return round.WrapError(fmt.Errorf("[j: %d] failed to unmarshal affg_hat proof: %s", j, err.Error()))
-		}
-		common.Logger.Debugf("P[%d]: receive P[%d]'s affg_hat proof", i, j)
-
-		logProof, err := r2msg.UnmarshalLogProof()
-		if err != nil {
-			common.Logger.Errorf("[j: %d] failed to unmarshal log proof: %s", j, err.Error())
-			return round.WrapError(fmt.Errorf("failed to unmarshal log proof: %s", err.Error()), Pj)
-		}
-		common.Logger.Debugf("P[%d]: receive P[%d]'s log proof", i, j)
+		common.Logger.Debugf("P[%d]: receive P[%d]'s proofs", i, j)
 
 		go func(j int) {
 			defer wg.Done()
 
-			if err = psiProof.Verify(
-				ProofParameter, contextJ, round.aux.PaillierPKs[i].N, round.aux.PedersenPKs[j].N, round.temp.kCiphertexts[i],
-				new(big.Int).SetBytes(r2msg.GetD()), new(big.Int).SetBytes(r2msg.GetF()), round.aux.PedersenPKs[i], Gamma,
-			); err != nil {
-				common.Logger.Errorf("[j: %d] failed to verify affg proof: %s", j, err)
-				errChs <- round.WrapError(fmt.Errorf("[j: %d] failed to verify affg proof: %s", j, err.Error()))
+			K := round.temp.kCiphertexts[i]
+			if err := sign.CheckAffgProof(round.EC(), session, round.temp.ssid, j, i, K, r2msg.Content().(*sign.SignRound2Message), false); err != nil {
+				common.Logger.Errorf("[j: %d] %s", j, err)
+				inputs := &sign.Inputs{Session: *session, Index: j}
+				errChs <- round.WrapEvidence(
+					fmt.Errorf("[j: %d] %s", j, err.Error()), sign.EvidenceAffgProof, inputs, r2msg, round.temp.signRound1Message1s[i],
+				)
 			}
 		}(j)
 
 		go func(j int) {
 			defer wg.Done()
 
-			if err = psiHatProof.Verify(
-				ProofParameter, contextJ, round.aux.PaillierPKs[i].N, round.aux.PedersenPKs[j].N, round.temp.kCiphertexts[i],
-				new(big.Int).SetBytes(r2msg.GetDHat()), new(big.Int).SetBytes(r2msg.GetFHat()), round.aux.PedersenPKs[i], round.key.PubXj[j],
-			); err != nil {
-				common.Logger.Errorf("[j: %d] failed to verify affg_hat proof: %s", j, err)
-				errChs <- round.WrapError(fmt.Errorf("failed to verify affg_hat proof: %s", err.Error()), Pj)
+			inputs := &sign.Inputs{Session: *session, Index: j}
+			K := round.temp.kCiphertexts[i]
+			if err := sign.CheckAffgProof(round.EC(), session, round.temp.ssid, j, i, K, r2msg.Content().(*sign.SignRound2Message), true); err != nil {
+				common.Logger.Errorf("[j: %d] %s", j, err)
+				errChs <- round.WrapEvidence(
+					fmt.Errorf("[j: %d] %s", j, err.Error()), sign.EvidenceAffgHatProof, inputs, r2msg, round.temp.signRound1Message1s[i],
+				)
 			}
 
-			if err := logProof.Verify(
-				ProofParameter, contextJ, round.temp.gammaCiphertexts[j], round.aux.PaillierPKs[j].N,
-				round.aux.PedersenPKs[i], Gamma, nil,
+			r1msg1 := round.temp.signRound1Message1s[j]
+			if err := sign.CheckLogProof(
+				round.EC(), session, round.temp.ssid, j, i, r1msg1.Content().(*sign.SignRound1Message1), r2msg.Content().(*sign.SignRound2Message),
 			); err != nil {
-				common.Logger.Errorf("verify log proof failed: %s, party: %d", err, j)
-				errChs <- round.WrapError(fmt.Errorf("verify log proof failed: %s", err), Pj)
+				common.Logger.Errorf("[j: %d] %s", j, err)
+				errChs <- round.WrapEvidence(fmt.Errorf("[j: %d] %s", j, err.Error()), sign.EvidenceLogProof, inputs, r1msg1, r2msg)
+				return
 			}
 			common.Logger.Debugf("P[%d]: verify P[%d]'s log proof ok", i, j)
 		}(j)
+
+		if round.temp.adaptor != nil {
+			if err := sign.CheckAdaptorProof(round.EC(), round.temp.ssid, j, round.temp.adaptor, r2msg.Content().(*sign.SignRound2Message)); err != nil {
+				common.Logger.Errorf("[j: %d] %s", j, err)
+				inputs := &sign.Inputs{Session: *session, Index: j}
+				errChs <- round.WrapEvidence(fmt.Errorf("[j: %d] %s", j, err.Error()), sign.EvidenceAdaptorProof, inputs, r2msg)
+				continue
+			}
+			round.temp.adaptorShares[j], _ = r2msg.Content().(*sign.SignRound2Message).UnmarshalAdaptor()
+		}
 	}
 
 	// Consume error channels; wait for goroutines
 	wg.Wait()
 	close(errChs)
-	culprits := make([]*tss.PartyID, 0, len(round.Parties().IDs()))
+	var errs []*tss.Error
 	for err := range errChs {
-		culprits = append(culprits, err.Culprits()...)
+		errs = append(errs, err)
 	}
-	if len(culprits) > 0 {
-		return round.WrapError(errors.New("failed to calculate affg proof"), culprits...)
+	if err := tss.JoinErrors(errs...); err != nil {
+		return err
 	}
 
 	// ∆i = Γ^ki
@@ -125,7 +119,7 @@ func (round *round3) Start() *tss.Error {
 	chi := new(big.Int).Mul(round.key.PrivXi, round.save.K)
 
 	// calculate δi, χi
-	for j := range round.Parties().IDs() {
+	for j, Pj := range round.Parties().IDs() {
 		if j == i {
 			continue
 		}
@@ -135,13 +129,13 @@ func (round *round3) Start() *tss.Error {
 		alpha, err := round.aux.PaillierSK.Decrypt(new(big.Int).SetBytes(r2msg.GetD()))
 		if err != nil {
 			common.Logger.Errorf("[j: %d] failed to decrypt alpha: %s", j, err)
-			return round.WrapError(fmt.Errorf("[j: %d] failed to decrypt alpha: %s", j, err))
+			return round.WrapError(fmt.Errorf("[j: %d] failed to decrypt alpha: %s", j, err), Pj)
 		}
 
 		alphaHat, err := round.aux.PaillierSK.Decrypt(new(big.Int).SetBytes(r2msg.GetDHat()))
 		if err != nil {
 			common.Logger.Errorf("[j: %d] failed to decrypt alpha_hat: %s", j, err)
-			return round.WrapError(fmt.Errorf("[j: %d] failed to decrypt alpha: %s", j, err))
+			return round.WrapError(fmt.Errorf("[j: %d] failed to decrypt alpha: %s", j, err), Pj)
 		}
 
 		delta.Add(delta, alpha)
@@ -151,14 +145,39 @@ func (round *round3) Start() *tss.Error {
 		chi.Add(chi, alphaHat)
 		chi.Add(chi, round.temp.betaHat[j])
 		chi.Mod(chi, round.EC().Params().N)
+		common.ZeroBigInts(alpha, alphaHat)
 	}
 
+	// Security: γi, the MtA masks and the key share are no longer needed
+	common.ZeroBigInts(round.temp.gamma, round.temp.mu, round.key.PrivXi)
+	common.ZeroBigInts(round.temp.beta...)
+	common.ZeroBigInts(round.temp.betaHat...)
+
 	round.temp.sumGamma = sumGamma
 	round.temp.delta = delta
 	round.save.Chi = chi
 
-	// P2P send log proof to Pj
+	// broadcast the MtA masks, which identify the culprit if δ does not verify
 	contextI := append(round.temp.ssid, big.NewInt(int64(i)).Bytes()...)
+	masks, err := sign.NewMtAMasks(
+		round.EC(), contextI, round.temp.masks, round.temp.maskCiphertexts, round.temp.maskRands,
+		round.aux.PaillierPKs[i].N, round.aux.PedersenPKs,
+	)
+	if err != nil {
+		return round.WrapError(err)
+	}
+	// Security: the masks were only needed for their proofs
+	common.ZeroBigInts(round.temp.masks...)
+	common.ZeroBigInts(round.temp.maskRands...)
+
+	r3msg2, err := sign.NewSignRound3Message2(round.PartyID(), masks, nil)
+	if err != nil {
+		return round.WrapError(err)
+	}
+	round.temp.signRound3Message2s[i] = r3msg2
+	round.out <- r3msg2
+
+	// P2P send log proof to Pj
 	for j, Pj := range round.Parties().IDs() {
 		if j == i {
 			round.ok[j] = true
@@ -166,7 +185,7 @@ func (round *round3) Start() *tss.Error {
 		}
 		// log proof: M(prove, Πlog, (ssid, i), (Iε, Ki, ∆i, Γ); (ki, ρi))
 		logProof, err := logproof.NewKnowExponentAndPaillierEncryption(
-			ProofParameter, contextI, round.save.K, round.temp.rho, round.temp.kCiphertexts[i],
+			crypto.CurveProofConfig(round.EC()), contextI, round.save.K, round.temp.rho, round.temp.kCiphertexts[i],
 			round.aux.PaillierPKs[i].N, round.aux.PedersenPKs[j], round.temp.Delta, sumGamma,
 		)
 		if err != nil {
@@ -175,13 +194,17 @@ func (round *round3) Start() *tss.Error {
 		}
 
 		common.Logger.Debugf("P[%d]: send log proof to P[%d]", i, j)
-		r3msg, err := sign.NewSignRound3Message(Pj, round.PartyID(), delta, round.temp.Delta, logProof)
+		r3msg, err := sign.NewSignRound3Message(Pj, round.PartyID(), delta, round.temp.Delta, logProof, nil)
 		if err != nil {
 			return round.WrapError(err, Pj)
 		}
+		// keep one of its messages, whose δi the evidence of the later rounds needs
+		round.temp.signRound3Messages[i] = r3msg
 		round.out <- r3msg
 	}
 
+	// Security: ρi was only needed for the log proofs
+	common.ZeroBigInts(round.temp.rho)
 	return nil
 }
 
@@ -195,6 +218,11 @@ func (round *round3) Update() (bool, *tss.Error) {
 			ret = false
 			continue
 		}
+		msg2 := round.temp.signRound3Message2s[j]
+		if msg2 == nil || !round.CanAccept(msg2) {
+			ret = false
+			continue
+		}
 		round.ok[j] = true
 	}
 	return ret, nil
@@ -204,6 +232,9 @@ func (round *round3) CanAccept(msg tss.ParsedMessage) bool {
 	if _, ok := msg.Content().(*sign.SignRound3Message); ok {
 		return !msg.IsBroadcast()
 	}
+	if _, ok := msg.Content().(*sign.SignRound3Message2); ok {
+		return msg.IsBroadcast()
+	}
 	return false
 }
 
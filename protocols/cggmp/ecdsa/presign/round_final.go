@@ -8,6 +8,7 @@ import (
 
 	"github.com/felicityin/mpc-tss/common"
 	"github.com/felicityin/mpc-tss/crypto"
+	"github.com/felicityin/mpc-tss/crypto/dleq"
 	"github.com/felicityin/mpc-tss/protocols/cggmp/ecdsa/sign"
 	"github.com/felicityin/mpc-tss/tss"
 )
@@ -25,6 +26,7 @@ func (round *round4) Start() *tss.Error {
 
 	common.Logger.Infof("[sign] party: %d, round4 start", i)
 
+	session := round.session()
 	sumDelta := new(big.Int).Set(round.temp.delta)
 	sumBigDelta := round.temp.Delta
 
@@ -33,24 +35,21 @@ func (round *round4) Start() *tss.Error {
 		if j == i {
 			continue
 		}
-		contextJ := append(round.temp.ssid, big.NewInt(int64(j)).Bytes()...)
+		r1msg1 := round.temp.signRound1Message1s[j]
 		r3msg := round.temp.signRound3Messages[j].Content().(*sign.SignRound3Message)
 
+		if err := sign.CheckDeltaLogProof(
+			round.EC(), session, round.temp.ssid, j, i, round.temp.sumGamma, r1msg1.Content().(*sign.SignRound1Message1), r3msg,
+		); err != nil {
+			common.Logger.Errorf("[j: %d] %s", j, err.Error())
+			// Γ is computed again from the round 2 messages
+			inputs := &sign.Inputs{Session: *session, Index: j}
+			msgs := append([]tss.ParsedMessage{round.temp.signRound3Messages[j], r1msg1}, round.temp.signRound2Messages...)
+			return round.WrapEvidence(fmt.Errorf("[j: %d] %s", j, err.Error()), sign.EvidenceDeltaLogProof, inputs, msgs...)
+		}
 		Delta, err := r3msg.UnmarshalBigDelta()
 		if err != nil {
-			return round.WrapError(fmt.Errorf("[j: %d] unmarshal big delta err: %s", j, err.Error()))
-		}
-
-		logProof, err := r3msg.UnmarshalLogProof()
-		if err != nil {
-			return round.WrapError(fmt.Errorf("[j: %d] unmarshal log proof err: %s", j, err.Error()))
-		}
-		if err = logProof.Verify(
-			ProofParameter, contextJ, round.temp.kCiphertexts[j], round.aux.PaillierPKs[j].N,
-			round.aux.PedersenPKs[i], Delta, round.temp.sumGamma,
-		); err != nil {
-			common.Logger.Errorf("[j: %d] verify log proof failed: %s, party: %d", j, err)
-			return round.WrapError(fmt.Errorf("[j: %d] verify log proof failed: %s", j, err))
+			return round.WrapError(fmt.Errorf("[j: %d] unmarshal big delta err: %s", j, err.Error()), Pj)
 		}
 
 		sumDelta.Add(sumDelta, r3msg.UnmarshalDelta())
@@ -65,15 +64,69 @@ func (round *round4) Start() *tss.Error {
 
 	if hex.EncodeToString(gDelta.X().Bytes()) != hex.EncodeToString(sumBigDelta.X().Bytes()) ||
 		hex.EncodeToString(gDelta.Y().Bytes()) != hex.EncodeToString(sumBigDelta.Y().Bytes()) {
+		// identify the parties whose δj does not match ∆j
+		id := round.identification()
+		if err := id.CheckBroadcasts(round.EC(), false); err != nil {
+			return err
+		}
+		if err := tss.JoinErrors(id.IdentifyDelta(round.EC())...); err != nil {
+			return err
+		}
 		return round.WrapError(fmt.Errorf("verify delta failed"))
 	}
 
-	round.save.R = round.temp.sumGamma.ScalarMult(new(big.Int).ModInverse(sumDelta, round.EC().Params().N))
+	sumDelta.Mod(sumDelta, round.EC().Params().N)
+	deltaInv := new(big.Int).ModInverse(sumDelta, round.EC().Params().N)
+	round.save.R = round.temp.sumGamma.ScalarMult(deltaInv)
+
+	if round.temp.adaptor != nil {
+		adaptor, err := round.newAdaptor(sumDelta, deltaInv)
+		if err != nil {
+			return round.WrapError(err)
+		}
+		round.save.Adaptor = adaptor
+	}
 
 	round.end <- round.save
 	return nil
 }
 
+// newAdaptor returns RT = δ^-1 * Σ Γj^T, with the transcript that proves it to the holders of the pre-signatures
+func (round *round4) newAdaptor(delta, deltaInv *big.Int) (*Adaptor, error) {
+	proof := &AdaptorProof{
+		Ssid:    round.temp.ssid,
+		Delta:   delta,
+		Gammas:  make([]*crypto.ECPoint, len(round.temp.adaptorShares)),
+		GammaTs: make([]*crypto.ECPoint, len(round.temp.adaptorShares)),
+		Proofs:  make([]*dleq.Proof, len(round.temp.adaptorShares)),
+	}
+	var sumGammaT *crypto.ECPoint
+	for j, share := range round.temp.adaptorShares {
+		if j == round.PartyID().Index {
+			proof.Gammas[j] = round.temp.Gamma
+		} else {
+			var err error
+			if proof.Gammas[j], err = round.temp.signRound2Messages[j].Content().(*sign.SignRound2Message).UnmarshalGamma(); err != nil {
+				return nil, err
+			}
+		}
+		proof.GammaTs[j], proof.Proofs[j] = share.GammaT, share.Proof
+		if sumGammaT == nil {
+			sumGammaT = share.GammaT
+			continue
+		}
+		var err error
+		if sumGammaT, err = sumGammaT.Add(share.GammaT); err != nil {
+			return nil, err
+		}
+	}
+	return &Adaptor{
+		T:     round.temp.adaptor,
+		RT:    sumGammaT.ScalarMult(deltaInv),
+		Proof: proof,
+	}, nil
+}
+
 func (round *round4) CanAccept(msg tss.ParsedMessage) bool {
 	// not expecting any incoming messages in this round
 	return false
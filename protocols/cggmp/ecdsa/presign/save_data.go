@@ -25,6 +25,9 @@ type (
 
 		// original indexes (ki in signing preparation phase)
 		Ks []*big.Int
+
+		// the adaptor point of the pre-signatures of the presignature, nil for the presignatures of the signatures
+		Adaptor *Adaptor `json:",omitempty"`
 	}
 )
 
@@ -50,6 +53,29 @@ func (save LocalPartySaveData) OriginalIndex() (int, error) {
 	return index, nil
 }
 
+// ID returns an identifier of the presignature derived from its public part (Ks and R),
+// which is the same for every party holding a share of it.
+// Parties use it to agree on the presignature consumed by a signing session.
+func (save LocalPartySaveData) ID() (string, error) {
+	for j, kj := range save.Ks {
+		if kj == nil {
+			return "", fmt.Errorf("presign save data Ks[%d] is missing", j)
+		}
+	}
+	if save.R == nil || !save.R.ValidateBasic() {
+		return "", errors.New("presign save data is missing R")
+	}
+	ids := append([]*big.Int{}, save.Ks...)
+	ids = append(ids, save.R.X(), save.R.Y())
+	return hex.EncodeToString(common.SHA512_256i_TAGGED([]byte("ecdsa-presign"), ids...).Bytes()), nil
+}
+
+// Destroy wipes the nonce share k and chi from memory. Signing calls it once the presignature is consumed.
+func (save *LocalPartySaveData) Destroy() {
+	common.ZeroBigInts(save.K, save.Chi)
+	save.K, save.Chi = nil, nil
+}
+
 // BuildLocalSaveDataSubset re-creates the LocalPartySaveData to contain data for only the list of signing parties.
 func BuildLocalSaveDataSubset(sourceData LocalPartySaveData, sortedIDs tss.SortedPartyIDs) (newData LocalPartySaveData, err error) {
 	keysToIndices := make(map[string]int, len(sourceData.Ks))
@@ -58,6 +84,7 @@ func BuildLocalSaveDataSubset(sourceData LocalPartySaveData, sortedIDs tss.Sorte
 	}
 	newData = NewLocalPartySaveData(sortedIDs.Len())
 	newData.LocalSecrets = sourceData.LocalSecrets
+	newData.Adaptor = sourceData.Adaptor
 	for j, id := range sortedIDs {
 		savedIdx, ok := keysToIndices[hex.EncodeToString(id.Key)]
 		if !ok {
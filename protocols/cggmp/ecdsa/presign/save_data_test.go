@@ -0,0 +1,45 @@
+package presign
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIDAgrees(t *testing.T) {
+	for _, isThreshold := range []bool{true, false} {
+		pres, _, err := LoadPreTestFixtures(isThreshold, testParticipants)
+		assert.NoError(t, err, "should load presign fixtures")
+
+		id, err := pres[0].ID()
+		assert.NoError(t, err)
+		for i := 1; i < testParticipants; i++ {
+			idi, err := pres[i].ID()
+			assert.NoError(t, err)
+			assert.Equal(t, id, idi, "all parties must derive the same presign id")
+		}
+
+		pre := pres[0]
+		pre.Ks = []*big.Int{pre.Ks[1], pre.Ks[0], pre.Ks[2]}
+		other, err := pre.ID()
+		assert.NoError(t, err)
+		assert.NotEqual(t, id, other)
+	}
+}
+
+func TestDestroy(t *testing.T) {
+	pres, _, err := LoadPreTestFixtures(false, testParticipants)
+	assert.NoError(t, err, "should load presign fixtures")
+
+	pre := pres[0]
+	k, chi := pre.K, pre.Chi
+	pre.Destroy()
+	assert.Nil(t, pre.K)
+	assert.Nil(t, pre.Chi)
+	assert.Zero(t, k.Sign())
+	assert.Zero(t, chi.Sign())
+	for _, w := range k.Bits()[:cap(k.Bits())] {
+		assert.Zero(t, w)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/felicityin/mpc-tss/common"
+	"github.com/felicityin/mpc-tss/protocols/cggmp/keygen"
 	"github.com/felicityin/mpc-tss/tss"
 )
 
@@ -17,16 +18,22 @@ const (
 	testNonThresholdFixtureDirFormat = "%s/../../test/_presign_fixtures/non_threshold"
 	testThresholdFixtureDirFormat    = "%s/../../test/_presign_fixtures/threshold"
 	testFixtureFileFormat            = "ecdsa_presign_data_%d.json"
+	testP256FixtureFileFormat        = "ecdsa_p256_presign_data_%d.json"
 )
 
 func LoadPreTestFixtures(isThreshold bool, qty int, optionalStart ...int) ([]LocalPartySaveData, tss.SortedPartyIDs, error) {
+	return LoadPreTestFixturesOfKind(keygen.Ecdsa, isThreshold, qty, optionalStart...)
+}
+
+// LoadPreTestFixturesOfKind loads the presign fixtures of the keygen fixtures of the kind, keygen.Ecdsa or keygen.EcdsaP256
+func LoadPreTestFixturesOfKind(kind int, isThreshold bool, qty int, optionalStart ...int) ([]LocalPartySaveData, tss.SortedPartyIDs, error) {
 	pres := make([]LocalPartySaveData, 0, qty)
 	start := 0
 	if 0 < len(optionalStart) {
 		start = optionalStart[0]
 	}
 	for i := start; i < qty; i++ {
-		fixtureFilePath := makeTestFixtureFilePath(isThreshold, i)
+		fixtureFilePath := makeTestFixtureFilePath(kind, isThreshold, i)
 		common.Logger.Infof("path: %s", fixtureFilePath)
 		bz, err := ioutil.ReadFile(fixtureFilePath)
 		if err != nil {
@@ -51,7 +58,7 @@ func LoadPreTestFixtures(isThreshold bool, qty int, optionalStart ...int) ([]Loc
 	return pres, sortedPIDs, nil
 }
 
-func makeTestFixtureFilePath(isThreshold bool, partyIndex int) string {
+func makeTestFixtureFilePath(kind int, isThreshold bool, partyIndex int) string {
 	_, callerFileName, _, _ := runtime.Caller(0)
 	srcDirName := filepath.Dir(callerFileName)
 
@@ -62,5 +69,8 @@ func makeTestFixtureFilePath(isThreshold bool, partyIndex int) string {
 		fixtureDirName = fmt.Sprintf(testNonThresholdFixtureDirFormat, srcDirName)
 	}
 
+	if kind == keygen.EcdsaP256 {
+		return fmt.Sprintf("%s/"+testP256FixtureFileFormat, fixtureDirName, partyIndex)
+	}
 	return fmt.Sprintf("%s/"+testFixtureFileFormat, fixtureDirName, partyIndex)
 }
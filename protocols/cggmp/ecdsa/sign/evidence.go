@@ -0,0 +1,784 @@
+package sign
+
+import (
+	"crypto/elliptic"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/felicityin/mpc-tss/common"
+	"github.com/felicityin/mpc-tss/crypto"
+	"github.com/felicityin/mpc-tss/crypto/affproof"
+	zkPaillier "github.com/felicityin/mpc-tss/crypto/alice/zkproof/paillier"
+	"github.com/felicityin/mpc-tss/tss"
+)
+
+// Kinds of the evidence against a party of the signing.
+// The presigning sends the same messages, so its evidence has the same kinds.
+const (
+	EvidenceEncProof      = "cggmp/ecdsa/sign/enc-proof"
+	EvidenceAffgProof     = "cggmp/ecdsa/sign/affg-proof"
+	EvidenceAffgHatProof  = "cggmp/ecdsa/sign/affg-hat-proof"
+	EvidenceLogProof      = "cggmp/ecdsa/sign/log-proof"
+	EvidenceDeltaLogProof = "cggmp/ecdsa/sign/delta-log-proof"
+	EvidenceAdaptorProof  = "cggmp/ecdsa/sign/adaptor-proof"
+
+	// the evidence of the identification of the parties whose δj or σj does not verify
+	EvidenceMaskProof        = "cggmp/ecdsa/sign/mask-proof"
+	EvidenceMaskHatProof     = "cggmp/ecdsa/sign/mask-hat-proof"
+	EvidenceMaskEquivocation = "cggmp/ecdsa/sign/mask-equivocation"
+	EvidenceNonceProof       = "cggmp/ecdsa/sign/nonce-proof"
+	EvidenceDeltaShare       = "cggmp/ecdsa/sign/delta-share"
+	EvidenceSigmaShare       = "cggmp/ecdsa/sign/sigma-share"
+)
+
+func init() {
+	tss.RegisterEvidenceCheck(EvidenceEncProof, checkEncProofEvidence)
+	tss.RegisterEvidenceCheck(EvidenceAffgProof, checkAffgProofEvidence)
+	tss.RegisterEvidenceCheck(EvidenceAffgHatProof, checkAffgProofEvidence)
+	tss.RegisterEvidenceCheck(EvidenceLogProof, checkLogProofEvidence)
+	tss.RegisterEvidenceCheck(EvidenceDeltaLogProof, checkDeltaLogProofEvidence)
+	tss.RegisterEvidenceCheck(EvidenceAdaptorProof, checkAdaptorProofEvidence)
+	tss.RegisterEvidenceCheck(EvidenceMaskProof, checkMaskProofEvidence)
+	tss.RegisterEvidenceCheck(EvidenceMaskHatProof, checkMaskProofEvidence)
+	tss.RegisterEvidenceCheck(EvidenceMaskEquivocation, checkMaskEquivocationEvidence)
+	tss.RegisterEvidenceCheck(EvidenceNonceProof, checkNonceProofEvidence)
+	tss.RegisterEvidenceCheck(EvidenceDeltaShare, checkDeltaShareEvidence)
+	tss.RegisterEvidenceCheck(EvidenceSigmaShare, checkSigmaShareEvidence)
+}
+
+type (
+	// Session are the public values of a signing or a presigning that are fixed before it, which a verifier should check
+	// against what it knows of the key and the auxiliary data of the parties: the keys Ks of the signers, their key shares
+	// PubXj, which sum to the public key, the adaptor point of an adaptor presigning, the nonce of the ssid, which is
+	// the index of a presigning in its batch, and the paillier keys and pedersen parameters of the signers.
+	// The ssid is computed from them.
+	Session struct {
+		Curve      tss.CurveName                        `json:"curve"`
+		Ks         []*big.Int                           `json:"ks"`
+		PubXj      []*crypto.ECPoint                    `json:"pub_xj"`
+		Adaptor    *crypto.ECPoint                      `json:"adaptor,omitempty"`
+		Nonce      *big.Int                             `json:"nonce"`
+		PaillierNs []*big.Int                           `json:"paillier_ns"`
+		Pedersens  []*zkPaillier.PederssenOpenParameter `json:"pedersens"`
+	}
+
+	// Inputs are the public inputs of the checks of the messages of Pj. The verifier of the proofs of Pj is the victim
+	// of the evidence, and the values that the parties compute in the session are computed again from the messages.
+	Inputs struct {
+		Session
+		Index int `json:"index"`
+	}
+
+	// MaskProofInputs are the public inputs of the check of the log proof of Pj that it encrypted its MtA mask with
+	// the verifier in Fj,verifier, where the pedersen parameters are those of the verifier
+	MaskProofInputs struct {
+		Inputs
+		Verifier int `json:"verifier"`
+	}
+
+	// MaskEquivocationInputs are the public inputs of the check that Pj broadcast the ciphertexts of the MtA masks
+	// that it sent to the verifier in round 2
+	MaskEquivocationInputs struct {
+		Index    int `json:"index"`
+		Verifier int `json:"verifier"`
+	}
+
+	// SigmaShareInputs are the public inputs of the check of σj of the message m against the nonce share and the MtA
+	// masks of χ
+	SigmaShareInputs struct {
+		Inputs
+		M *big.Int `json:"m"`
+	}
+)
+
+// SSID returns the ssid of the session, as the parties compute it in round 1
+func (s *Session) SSID(ec elliptic.Curve) ([]byte, error) {
+	ssidList := []*big.Int{ec.Params().P, ec.Params().N, ec.Params().Gx, ec.Params().Gy} // ec curve
+	ssidList = append(ssidList, s.Ks...)                                                 // parties
+	BigXjList, err := crypto.FlattenECPoints(s.PubXj)
+	if err != nil {
+		return nil, errors.New("read BigXj failed")
+	}
+	ssidList = append(ssidList, BigXjList...) // BigXj
+	if s.Adaptor != nil {
+		ssidList = append(ssidList, s.Adaptor.X(), s.Adaptor.Y()) // T
+	}
+	ssidList = append(ssidList, big.NewInt(1)) // round number
+	ssidList = append(ssidList, s.Nonce)
+	return common.SHA512_256i(ssidList...).Bytes(), nil
+}
+
+// PublicKey returns the public key X = sum_j Xj of the key shares of the signers
+func (s *Session) PublicKey() (*crypto.ECPoint, error) {
+	return sumPoints(s.PubXj...)
+}
+
+// validate checks that the session is on its curve with the data of every signer, and that the inputs are about the culprit
+func (s *Session) validate(ev *tss.Evidence, index int) (elliptic.Curve, error) {
+	ec, err := evidenceCurve(ev, s.Curve, index)
+	if err != nil {
+		return nil, err
+	}
+	n := len(s.Ks)
+	if len(s.PubXj) != n || len(s.PaillierNs) != n || len(s.Pedersens) != n || s.Nonce == nil {
+		return nil, errors.New("expected the key share, the paillier key and the pedersen parameters of every signer")
+	}
+	for j := range s.Ks {
+		if s.Ks[j] == nil || s.PubXj[j] == nil || !tss.SameCurve(s.PubXj[j].Curve(), ec) || s.PaillierNs[j] == nil || !validPedersen(s.Pedersens[j]) {
+			return nil, fmt.Errorf("the data of party %d are missing", j)
+		}
+	}
+	if index < 0 || index >= n || s.Ks[index].Cmp(ev.Culprit.KeyInt()) != 0 {
+		return nil, errors.New("the keys of the signers do not hold the key of the culprit")
+	}
+	if s.Adaptor != nil && !tss.SameCurve(s.Adaptor.Curve(), ec) {
+		return nil, errors.New("the adaptor point is not on the curve")
+	}
+	return ec, nil
+}
+
+// victim returns the index of the victim of the evidence, who verified the proofs of the culprit
+func (s *Session) victim(ev *tss.Evidence) (int, error) {
+	v := ev.Victim
+	if v == nil || v.Index < 0 || v.Index >= len(s.Ks) || v.KeyInt().Cmp(s.Ks[v.Index]) != 0 || v.Index == ev.Culprit.Index {
+		return 0, errors.New("the victim is not another signer of the session")
+	}
+	return v.Index, nil
+}
+
+// messagesOf returns the messages of the content type of sample of all the signers, in the order of their index
+func (s *Session) messagesOf(msgs []tss.ParsedMessage, sample tss.MessageContent) ([]tss.ParsedMessage, error) {
+	byIndex, err := tss.MessagesBySender(msgs, len(s.Ks), sample)
+	if err != nil {
+		return nil, err
+	}
+	for j, msg := range byIndex {
+		if msg.GetFrom().KeyInt().Cmp(s.Ks[j]) != 0 {
+			return nil, fmt.Errorf("the message of party %d is not from the signer of its key", j)
+		}
+	}
+	return byIndex, nil
+}
+
+// CheckEncProof checks the enc proof of Pj to the verifier v for its ciphertext K_j
+func CheckEncProof(ec elliptic.Curve, s *Session, ssid []byte, j, v int, r1msg1 *SignRound1Message1, r1msg2 *SignRound1Message2) error {
+	encProof, err := r1msg2.UnmarshalEncProof()
+	if err != nil {
+		return fmt.Errorf("unmarshal enc proof failed: %s", err.Error())
+	}
+	if err := encProof.Verify(
+		crypto.CurveProofConfig(ec), proofContext(ssid, v), r1msg1.UnmarshalK(), s.PaillierNs[j], s.Pedersens[v],
+	); err != nil {
+		return fmt.Errorf("verify enc proof failed: %s", err.Error())
+	}
+	return nil
+}
+
+// CheckAffgProof checks the affg proof of Pj for the MtA with the verifier v, whose ciphertext of its nonce is K,
+// or its affg_hat proof for its key share X_j if hat is true
+func CheckAffgProof(ec elliptic.Curve, s *Session, ssid []byte, j, v int, K *big.Int, r2msg *SignRound2Message, hat bool) error {
+	var (
+		proof *affproof.PaillierAffAndGroupRangeMessage
+		D, F  []byte
+		X     = s.PubXj[j]
+		err   error
+	)
+	if hat {
+		proof, err = r2msg.UnmarshalAffgHatProof()
+		D, F = r2msg.GetDHat(), r2msg.GetFHat()
+	} else {
+		proof, err = r2msg.UnmarshalAffgProof()
+		D, F = r2msg.GetD(), r2msg.GetF()
+		if err == nil {
+			X, err = unmarshalPoint(ec, r2msg.GetBigGamma())
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal affg proof: %s", err.Error())
+	}
+	if err := proof.Verify(
+		crypto.CurveProofConfig(ec), proofContext(ssid, j), s.PaillierNs[v], s.Pedersens[j].N, K,
+		new(big.Int).SetBytes(D), new(big.Int).SetBytes(F), s.Pedersens[v], X,
+	); err != nil {
+		return fmt.Errorf("failed to verify affg proof: %s", err.Error())
+	}
+	return nil
+}
+
+// CheckLogProof checks the log proof of Pj to the verifier v that Γj is the exponent encrypted in G_j
+func CheckLogProof(ec elliptic.Curve, s *Session, ssid []byte, j, v int, r1msg1 *SignRound1Message1, r2msg *SignRound2Message) error {
+	Gamma, err := unmarshalPoint(ec, r2msg.GetBigGamma())
+	if err != nil {
+		return err
+	}
+	logProof, err := r2msg.UnmarshalLogProof()
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal log proof: %s", err.Error())
+	}
+	if err := logProof.Verify(
+		crypto.CurveProofConfig(ec), proofContext(ssid, j), r1msg1.UnmarshalGamma(), s.PaillierNs[j], s.Pedersens[v], Gamma, nil,
+	); err != nil {
+		return fmt.Errorf("verify log proof failed: %s", err.Error())
+	}
+	return nil
+}
+
+// CheckDeltaLogProof checks the log proof of Pj to the verifier v that ∆j = Γ^kj, where kj is encrypted in K_j
+func CheckDeltaLogProof(
+	ec elliptic.Curve, s *Session, ssid []byte, j, v int, sumGamma *crypto.ECPoint, r1msg1 *SignRound1Message1, r3msg *SignRound3Message,
+) error {
+	Delta, err := unmarshalPoint(ec, r3msg.GetBigDelta())
+	if err != nil {
+		return fmt.Errorf("unmarshal big delta err: %s", err.Error())
+	}
+	logProof, err := r3msg.UnmarshalLogProof()
+	if err != nil {
+		return fmt.Errorf("unmarshal log proof err: %s", err.Error())
+	}
+	if err = logProof.Verify(
+		crypto.CurveProofConfig(ec), proofContext(ssid, j), r1msg1.UnmarshalK(), s.PaillierNs[j], s.Pedersens[v], Delta, sumGamma,
+	); err != nil {
+		return fmt.Errorf("verify log proof failed: %s", err.Error())
+	}
+	return nil
+}
+
+// CheckAdaptorProof checks the DLEQ proof of Pj that its share of the adaptor nonce is γj * T, where Γj = γj * G
+func CheckAdaptorProof(ec elliptic.Curve, ssid []byte, j int, T *crypto.ECPoint, r2msg *SignRound2Message) error {
+	Gamma, err := unmarshalPoint(ec, r2msg.GetBigGamma())
+	if err != nil {
+		return err
+	}
+	adaptor, err := r2msg.UnmarshalAdaptor()
+	if err != nil {
+		return fmt.Errorf("unmarshal adaptor share err: %s", err.Error())
+	}
+	if adaptor == nil {
+		return errors.New("the share of the adaptor nonce is missing")
+	}
+	if !tss.SameCurve(adaptor.GammaT.Curve(), ec) || !tss.SameCurve(adaptor.Proof.A.Curve(), ec) || !tss.SameCurve(adaptor.Proof.B.Curve(), ec) {
+		return errors.New("the share of the adaptor nonce is not on the curve of the signing")
+	}
+	if !adaptor.Proof.Verify(proofContext(ssid, j), Gamma, T, adaptor.GammaT) {
+		return errors.New("verify adaptor proof failed")
+	}
+	return nil
+}
+
+// CheckMaskProof checks the log proof of Pj that Fj,v encrypts its mask with the verifier v,
+// or the mask of the MtA of χ if hat is true
+func CheckMaskProof(ec elliptic.Curve, s *Session, ssid []byte, j, v int, r3msg2 *SignRound3Message2, hat bool) error {
+	masks, err := r3msg2.UnmarshalMasks(ec, len(r3msg2.GetF()), j, hat)
+	if err != nil {
+		return err
+	}
+	if v < 0 || v >= len(masks.F) || v >= len(s.Pedersens) || v == j {
+		return fmt.Errorf("invalid verifier %d", v)
+	}
+	if err := masks.Proofs[v].Verify(
+		crypto.MaskProofConfig(ec), proofContext(ssid, j), masks.F[v], s.PaillierNs[j], s.Pedersens[v], masks.Y[v], nil,
+	); err != nil {
+		return fmt.Errorf("verify mask proof failed: %s", err.Error())
+	}
+	return nil
+}
+
+// CheckMaskEquivocation checks that Pj broadcast the ciphertexts of the MtA masks that it sent to the verifier
+func CheckMaskEquivocation(in *MaskEquivocationInputs, r2msg *SignRound2Message, r3msg2 *SignRound3Message2) error {
+	v := in.Verifier
+	if v < 0 || v >= len(r3msg2.GetF()) || v == in.Index {
+		return fmt.Errorf("invalid verifier %d", v)
+	}
+	if new(big.Int).SetBytes(r3msg2.GetF()[v]).Cmp(new(big.Int).SetBytes(r2msg.GetF())) != 0 {
+		return errors.New("the broadcast ciphertext of the mask is not the one sent in round 2")
+	}
+	if len(r3msg2.GetFHat()) > 0 && new(big.Int).SetBytes(r3msg2.GetFHat()[v]).Cmp(new(big.Int).SetBytes(r2msg.GetFHat())) != 0 {
+		return errors.New("the broadcast ciphertext of the mask of χ is not the one sent in round 2")
+	}
+	return nil
+}
+
+// CheckNonceProof checks the log proofs of Pj to the verifier v that kj * G and kj * X have the nonce kj encrypted in K_j,
+// where X is the public key
+func CheckNonceProof(
+	ec elliptic.Curve, s *Session, ssid []byte, j, v int, X *crypto.ECPoint, r1msg1 *SignRound1Message1, r3msg *SignRound3Message,
+) error {
+	nonce, err := r3msg.UnmarshalNonce(ec)
+	if err != nil {
+		return fmt.Errorf("unmarshal nonce share err: %s", err.Error())
+	}
+	config, ctx := crypto.CurveProofConfig(ec), proofContext(ssid, j)
+	if err := nonce.KProof.Verify(config, ctx, r1msg1.UnmarshalK(), s.PaillierNs[j], s.Pedersens[v], nonce.K, nil); err != nil {
+		return fmt.Errorf("verify nonce proof failed: %s", err.Error())
+	}
+	if err := nonce.KXProof.Verify(config, ctx, r1msg1.UnmarshalK(), s.PaillierNs[j], s.Pedersens[v], nonce.KX, X); err != nil {
+		return fmt.Errorf("verify nonce proof failed: %s", err.Error())
+	}
+	return nil
+}
+
+// CheckDeltaShare checks δj of Pj against ∆j and the MtA masks: δj * G + sum_l Yj,l = ∆j + sum_l Yl,j,
+// where masks are the masks Yl,j of the other parties, nil for Pj
+func CheckDeltaShare(ec elliptic.Curve, j int, masks []*crypto.ECPoint, r3msg *SignRound3Message, r3msg2 *SignRound3Message2) error {
+	own, err := r3msg2.UnmarshalMasks(ec, len(masks), j, false)
+	if err != nil {
+		return err
+	}
+	Delta, err := unmarshalPoint(ec, r3msg.GetBigDelta())
+	if err != nil {
+		return fmt.Errorf("unmarshal big delta err: %s", err.Error())
+	}
+	sumOwn, err := sumPoints(own.Y...)
+	if err != nil {
+		return err
+	}
+	sumOthers, err := sumPoints(masks...)
+	if err != nil {
+		return err
+	}
+	lhs, err := sumPoints(crypto.ScalarBaseMult(ec, r3msg.UnmarshalDelta()), sumOwn)
+	if err != nil {
+		return err
+	}
+	rhs, err := sumPoints(Delta, sumOthers)
+	if err != nil {
+		return err
+	}
+	if !lhs.Equals(rhs) {
+		return errors.New("δ does not match the MtA masks")
+	}
+	return nil
+}
+
+// CheckSigmaShare checks σj of Pj of the message m against its nonce share and the MtA masks of χ, where R is the nonce
+// point of the signature: σj * G + r * sum_l Ŷj,l = m * kj * G + r * (kj * X + sum_l Ŷl,j), where masks are the masks
+// Ŷl,j of the other parties, nil for Pj
+func CheckSigmaShare(
+	ec elliptic.Curve, j int, m *big.Int, R *crypto.ECPoint, masks []*crypto.ECPoint,
+	r3msg *SignRound3Message, r3msg2 *SignRound3Message2, r4msg *SignRound4Message,
+) error {
+	nonce, err := r3msg.UnmarshalNonce(ec)
+	if err != nil {
+		return fmt.Errorf("unmarshal nonce share err: %s", err.Error())
+	}
+	own, err := r3msg2.UnmarshalMasks(ec, len(masks), j, true)
+	if err != nil {
+		return err
+	}
+	r := new(big.Int).Mod(R.X(), ec.Params().N)
+	sumOwn, err := sumPoints(own.Y...)
+	if err != nil {
+		return err
+	}
+	lhs, err := sumPoints(crypto.ScalarBaseMult(ec, r4msg.UnmarshalS()), sumOwn.ScalarMult(r))
+	if err != nil {
+		return err
+	}
+	sumOthers, err := sumPoints(masks...)
+	if err != nil {
+		return err
+	}
+	if sumOthers, err = sumOthers.Add(nonce.KX); err != nil {
+		return err
+	}
+	rhs, err := sumPoints(nonce.K.ScalarMult(m), sumOthers.ScalarMult(r))
+	if err != nil {
+		return err
+	}
+	if !lhs.Equals(rhs) {
+		return errors.New("σ does not match the nonce share and the MtA masks")
+	}
+	return nil
+}
+
+// SumGamma returns Γ = sum_j Γj of the round 2 messages of all the signers
+func SumGamma(ec elliptic.Curve, r2msgs []tss.ParsedMessage) (*crypto.ECPoint, error) {
+	Gammas := make([]*crypto.ECPoint, len(r2msgs))
+	for j, msg := range r2msgs {
+		var err error
+		if Gammas[j], err = unmarshalPoint(ec, msg.Content().(*SignRound2Message).GetBigGamma()); err != nil {
+			return nil, fmt.Errorf("Γ of party %d: %s", j, err.Error())
+		}
+	}
+	return sumPoints(Gammas...)
+}
+
+// MasksOf returns the MtA masks Yl,j of the other parties with Pj from their round 3 broadcasts, or those of χ
+// if hat is true, nil for Pj
+func MasksOf(ec elliptic.Curve, j int, r3msg2s []tss.ParsedMessage, hat bool) ([]*crypto.ECPoint, error) {
+	masks := make([]*crypto.ECPoint, len(r3msg2s))
+	for l, msg := range r3msg2s {
+		if l == j {
+			continue
+		}
+		lMasks, err := msg.Content().(*SignRound3Message2).UnmarshalMasks(ec, len(r3msg2s), l, hat)
+		if err != nil {
+			return nil, fmt.Errorf("the masks of party %d are invalid: %s", l, err.Error())
+		}
+		masks[l] = lMasks.Y[j]
+	}
+	return masks, nil
+}
+
+// sumPoints returns the sum of the points that are not nil
+func sumPoints(points ...*crypto.ECPoint) (*crypto.ECPoint, error) {
+	var sum *crypto.ECPoint
+	for _, pt := range points {
+		if pt == nil {
+			continue
+		}
+		if sum == nil {
+			sum = pt
+			continue
+		}
+		var err error
+		if sum, err = sum.Add(pt); err != nil {
+			return nil, err
+		}
+	}
+	if sum == nil {
+		return nil, errors.New("no point to sum")
+	}
+	return sum, nil
+}
+
+// proofContext returns the context (ssid, j) of the proofs of Pj
+func proofContext(ssid []byte, j int) []byte {
+	return append(append([]byte{}, ssid...), big.NewInt(int64(j)).Bytes()...)
+}
+
+// unmarshalPoint decodes a point sent by Pj, which must be on the curve of the signing
+func unmarshalPoint(ec elliptic.Curve, bz []byte) (*crypto.ECPoint, error) {
+	pt, err := crypto.UnmarshalJSONPoint(bz)
+	if err != nil {
+		return nil, err
+	}
+	if !tss.SameCurve(pt.Curve(), ec) {
+		return nil, errors.New("the point is not on the curve of the signing")
+	}
+	return pt, nil
+}
+
+// parseInputs decodes the inputs of the evidence into v, which holds in, and returns the curve and the ssid of their session
+func parseInputs(ev *tss.Evidence, v interface{}, in *Inputs) (elliptic.Curve, []byte, error) {
+	if err := ev.UnmarshalInputs(v); err != nil {
+		return nil, nil, err
+	}
+	ec, err := in.validate(ev, in.Index)
+	if err != nil {
+		return nil, nil, err
+	}
+	ssid, err := in.SSID(ec)
+	if err != nil {
+		return nil, nil, err
+	}
+	return ec, ssid, nil
+}
+
+func checkEncProofEvidence(ev *tss.Evidence, msgs []tss.ParsedMessage) (failure error, err error) {
+	in := new(Inputs)
+	ec, ssid, err := parseInputs(ev, in, in)
+	if err != nil {
+		return nil, err
+	}
+	v, err := in.victim(ev)
+	if err != nil {
+		return nil, err
+	}
+	if len(msgs) != 2 {
+		return nil, errors.New("expected the round 1 messages")
+	}
+	r1msg1, ok1 := msgs[0].Content().(*SignRound1Message1)
+	r1msg2, ok2 := msgs[1].Content().(*SignRound1Message2)
+	if !ok1 || !ok2 {
+		return nil, errors.New("expected the round 1 messages")
+	}
+	return CheckEncProof(ec, &in.Session, ssid, in.Index, v, r1msg1, r1msg2), nil
+}
+
+// checkAffgProofEvidence checks the round 2 message of the culprit to the victim, which the evidence holds first,
+// against the ciphertext K of the nonce of the victim, from its round 1 broadcast
+func checkAffgProofEvidence(ev *tss.Evidence, msgs []tss.ParsedMessage) (failure error, err error) {
+	in := new(Inputs)
+	ec, ssid, err := parseInputs(ev, in, in)
+	if err != nil {
+		return nil, err
+	}
+	v, err := in.victim(ev)
+	if err != nil {
+		return nil, err
+	}
+	if len(msgs) != 2 {
+		return nil, errors.New("expected the round 2 message and the round 1 broadcast of the victim")
+	}
+	r2msg, ok1 := msgs[0].Content().(*SignRound2Message)
+	r1msg1, ok2 := msgs[1].Content().(*SignRound1Message1)
+	if !ok1 || !ok2 || msgs[1].GetFrom().Index != v {
+		return nil, errors.New("expected the round 2 message and the round 1 broadcast of the victim")
+	}
+	return CheckAffgProof(ec, &in.Session, ssid, in.Index, v, r1msg1.UnmarshalK(), r2msg, ev.Kind == EvidenceAffgHatProof), nil
+}
+
+func checkLogProofEvidence(ev *tss.Evidence, msgs []tss.ParsedMessage) (failure error, err error) {
+	in := new(Inputs)
+	ec, ssid, err := parseInputs(ev, in, in)
+	if err != nil {
+		return nil, err
+	}
+	v, err := in.victim(ev)
+	if err != nil {
+		return nil, err
+	}
+	if len(msgs) != 2 {
+		return nil, errors.New("expected the round 1 broadcast and the round 2 message")
+	}
+	r1msg1, ok1 := msgs[0].Content().(*SignRound1Message1)
+	r2msg, ok2 := msgs[1].Content().(*SignRound2Message)
+	if !ok1 || !ok2 {
+		return nil, errors.New("expected the round 1 broadcast and the round 2 message")
+	}
+	return CheckLogProof(ec, &in.Session, ssid, in.Index, v, r1msg1, r2msg), nil
+}
+
+// checkDeltaLogProofEvidence checks the round 3 message of the culprit to the victim, which the evidence holds first,
+// followed by the round 1 broadcast of the culprit, against Γ of the round 2 messages of all the signers
+func checkDeltaLogProofEvidence(ev *tss.Evidence, msgs []tss.ParsedMessage) (failure error, err error) {
+	in := new(Inputs)
+	ec, ssid, err := parseInputs(ev, in, in)
+	if err != nil {
+		return nil, err
+	}
+	v, err := in.victim(ev)
+	if err != nil {
+		return nil, err
+	}
+	if len(msgs) < 2 {
+		return nil, errors.New("expected the round 3 message and the round 1 broadcast")
+	}
+	r3msg, ok1 := msgs[0].Content().(*SignRound3Message)
+	r1msg1, ok2 := msgs[1].Content().(*SignRound1Message1)
+	if !ok1 || !ok2 || msgs[1].GetFrom().Index != in.Index {
+		return nil, errors.New("expected the round 3 message and the round 1 broadcast")
+	}
+	r2msgs, err := in.messagesOf(msgs[2:], (*SignRound2Message)(nil))
+	if err != nil {
+		return nil, err
+	}
+	sumGamma, err := SumGamma(ec, r2msgs)
+	if err != nil {
+		// the party should be blamed for its Γ instead
+		return nil, err
+	}
+	return CheckDeltaLogProof(ec, &in.Session, ssid, in.Index, v, sumGamma, r1msg1, r3msg), nil
+}
+
+func checkAdaptorProofEvidence(ev *tss.Evidence, msgs []tss.ParsedMessage) (failure error, err error) {
+	in := new(Inputs)
+	ec, ssid, err := parseInputs(ev, in, in)
+	if err != nil {
+		return nil, err
+	}
+	if in.Adaptor == nil {
+		return nil, errors.New("the adaptor point is missing")
+	}
+	if len(msgs) != 1 {
+		return nil, errors.New("expected the round 2 message")
+	}
+	r2msg, ok := msgs[0].Content().(*SignRound2Message)
+	if !ok {
+		return nil, errors.New("expected the round 2 message")
+	}
+	return CheckAdaptorProof(ec, ssid, in.Index, in.Adaptor, r2msg), nil
+}
+
+func checkMaskProofEvidence(ev *tss.Evidence, msgs []tss.ParsedMessage) (failure error, err error) {
+	in := new(MaskProofInputs)
+	ec, ssid, err := parseInputs(ev, in, &in.Inputs)
+	if err != nil {
+		return nil, err
+	}
+	if in.Verifier < 0 || in.Verifier >= len(in.Ks) || in.Verifier == in.Index {
+		return nil, fmt.Errorf("invalid verifier %d", in.Verifier)
+	}
+	if len(msgs) != 1 {
+		return nil, errors.New("expected the round 3 broadcast")
+	}
+	r3msg2, ok := msgs[0].Content().(*SignRound3Message2)
+	if !ok {
+		return nil, errors.New("expected the round 3 broadcast")
+	}
+	return CheckMaskProof(ec, &in.Session, ssid, in.Index, in.Verifier, r3msg2, ev.Kind == EvidenceMaskHatProof), nil
+}
+
+func checkMaskEquivocationEvidence(ev *tss.Evidence, msgs []tss.ParsedMessage) (failure error, err error) {
+	in := new(MaskEquivocationInputs)
+	if err := ev.UnmarshalInputs(in); err != nil {
+		return nil, err
+	}
+	if err := checkIndex(ev, in.Index); err != nil {
+		return nil, err
+	}
+	if len(msgs) != 2 {
+		return nil, errors.New("expected the round 2 message and the round 3 broadcast")
+	}
+	r2msg, ok2 := msgs[0].Content().(*SignRound2Message)
+	r3msg2, ok3 := msgs[1].Content().(*SignRound3Message2)
+	if !ok2 || !ok3 {
+		return nil, errors.New("expected the round 2 message and the round 3 broadcast")
+	}
+	if to := msgs[0].GetTo(); len(to) != 1 || to[0].Index != in.Verifier {
+		return nil, errors.New("the round 2 message was not sent to the verifier")
+	}
+	return CheckMaskEquivocation(in, r2msg, r3msg2), nil
+}
+
+func checkNonceProofEvidence(ev *tss.Evidence, msgs []tss.ParsedMessage) (failure error, err error) {
+	in := new(Inputs)
+	ec, ssid, err := parseInputs(ev, in, in)
+	if err != nil {
+		return nil, err
+	}
+	v, err := in.victim(ev)
+	if err != nil {
+		return nil, err
+	}
+	X, err := in.PublicKey()
+	if err != nil {
+		return nil, err
+	}
+	if len(msgs) != 2 {
+		return nil, errors.New("expected the round 1 broadcast and the round 3 message")
+	}
+	r1msg1, ok1 := msgs[0].Content().(*SignRound1Message1)
+	r3msg, ok3 := msgs[1].Content().(*SignRound3Message)
+	if !ok1 || !ok3 {
+		return nil, errors.New("expected the round 1 broadcast and the round 3 message")
+	}
+	return CheckNonceProof(ec, &in.Session, ssid, in.Index, v, X, r1msg1, r3msg), nil
+}
+
+// checkDeltaShareEvidence checks the round 3 message of the culprit to the victim, which the evidence holds first,
+// against the MtA masks of the round 3 broadcasts of all the signers
+func checkDeltaShareEvidence(ev *tss.Evidence, msgs []tss.ParsedMessage) (failure error, err error) {
+	in := new(Inputs)
+	ec, ssid, err := parseInputs(ev, in, in)
+	if err != nil {
+		return nil, err
+	}
+	if len(msgs) == 0 {
+		return nil, errors.New("expected the round 3 message")
+	}
+	r3msg, ok := msgs[0].Content().(*SignRound3Message)
+	if !ok {
+		return nil, errors.New("expected the round 3 message")
+	}
+	r3msg2s, err := in.messagesOf(msgs[1:], (*SignRound3Message2)(nil))
+	if err != nil {
+		return nil, err
+	}
+	masks, err := in.checkMasks(ec, ssid, r3msg2s, false)
+	if err != nil {
+		return nil, err
+	}
+	return CheckDeltaShare(ec, in.Index, masks, r3msg, r3msg2s[in.Index].Content().(*SignRound3Message2)), nil
+}
+
+// checkSigmaShareEvidence checks the round 4 broadcast of the culprit, which the evidence holds first, against its nonce
+// share and the nonce point R = Γ^(1/δ), from the round 2 messages, the round 3 messages and the round 3 broadcasts
+// of all the signers
+func checkSigmaShareEvidence(ev *tss.Evidence, msgs []tss.ParsedMessage) (failure error, err error) {
+	in := new(SigmaShareInputs)
+	ec, ssid, err := parseInputs(ev, in, &in.Inputs)
+	if err != nil {
+		return nil, err
+	}
+	if in.M == nil {
+		return nil, errors.New("the message is missing")
+	}
+	if len(msgs) == 0 {
+		return nil, errors.New("expected the round 4 broadcast")
+	}
+	r4msg, ok := msgs[0].Content().(*SignRound4Message)
+	if !ok {
+		return nil, errors.New("expected the round 4 broadcast")
+	}
+	r2msgs, err := in.messagesOf(msgs[1:], (*SignRound2Message)(nil))
+	if err != nil {
+		return nil, err
+	}
+	r3msgs, err := in.messagesOf(msgs[1:], (*SignRound3Message)(nil))
+	if err != nil {
+		return nil, err
+	}
+	r3msg2s, err := in.messagesOf(msgs[1:], (*SignRound3Message2)(nil))
+	if err != nil {
+		return nil, err
+	}
+	sumGamma, err := SumGamma(ec, r2msgs)
+	if err != nil {
+		return nil, err
+	}
+	sumDelta := new(big.Int)
+	for _, msg := range r3msgs {
+		sumDelta.Add(sumDelta, msg.Content().(*SignRound3Message).UnmarshalDelta())
+	}
+	deltaInv := new(big.Int).ModInverse(sumDelta, ec.Params().N)
+	if deltaInv == nil {
+		return nil, errors.New("δ is not invertible")
+	}
+	R := sumGamma.ScalarMult(deltaInv)
+
+	r3msg := r3msgs[in.Index].Content().(*SignRound3Message)
+	if _, err := r3msg.UnmarshalNonce(ec); err != nil {
+		// the culprit should be blamed for its nonce share instead
+		return nil, err
+	}
+	masks, err := in.checkMasks(ec, ssid, r3msg2s, true)
+	if err != nil {
+		return nil, err
+	}
+	return CheckSigmaShare(ec, in.Index, in.M, R, masks, r3msg, r3msg2s[in.Index].Content().(*SignRound3Message2), r4msg), nil
+}
+
+// checkMasks returns the MtA masks Yl,j of the other parties with Pj, or those of χ if hat is true, after checking
+// the masks of Pj and the proofs of the masks of the other parties to Pj, which they should be blamed for instead
+func (in *Inputs) checkMasks(ec elliptic.Curve, ssid []byte, r3msg2s []tss.ParsedMessage, hat bool) ([]*crypto.ECPoint, error) {
+	j := in.Index
+	if _, err := r3msg2s[j].Content().(*SignRound3Message2).UnmarshalMasks(ec, len(r3msg2s), j, hat); err != nil {
+		// the culprit should be blamed for its masks instead
+		return nil, err
+	}
+	for l, msg := range r3msg2s {
+		if l == j {
+			continue
+		}
+		if err := CheckMaskProof(ec, &in.Session, ssid, l, j, msg.Content().(*SignRound3Message2), hat); err != nil {
+			return nil, fmt.Errorf("the masks of party %d are invalid: %s", l, err.Error())
+		}
+	}
+	return MasksOf(ec, j, r3msg2s, hat)
+}
+
+func checkIndex(ev *tss.Evidence, index int) error {
+	if index != ev.Culprit.Index {
+		return fmt.Errorf("the inputs are about party %d, not the culprit", index)
+	}
+	return nil
+}
+
+// evidenceCurve returns the curve of the inputs of the evidence, which must be about the culprit
+func evidenceCurve(ev *tss.Evidence, curve tss.CurveName, index int) (elliptic.Curve, error) {
+	if err := checkIndex(ev, index); err != nil {
+		return nil, err
+	}
+	ec, ok := tss.GetCurveByName(curve)
+	if !ok {
+		return nil, fmt.Errorf("unknown curve %s", curve)
+	}
+	return ec, nil
+}
+
+func validPedersen(ped *zkPaillier.PederssenOpenParameter) bool {
+	return ped != nil && ped.N != nil && ped.S != nil && ped.T != nil
+}
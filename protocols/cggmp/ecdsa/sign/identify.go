@@ -0,0 +1,191 @@
+package sign
+
+import (
+	"crypto/elliptic"
+	"fmt"
+	"math/big"
+
+	"github.com/felicityin/mpc-tss/crypto"
+	zkPaillier "github.com/felicityin/mpc-tss/crypto/alice/zkproof/paillier"
+	"github.com/felicityin/mpc-tss/crypto/logproof"
+	"github.com/felicityin/mpc-tss/tss"
+)
+
+// Blamer is a round of a signing or a presigning, which blames the culprits of its errors
+type Blamer interface {
+	WrapError(err error, culprits ...*tss.PartyID) *tss.Error
+	WrapEvidence(err error, kind string, inputs interface{}, msgs ...tss.ParsedMessage) *tss.Error
+}
+
+// Identification is what a party received in a signing or a presigning, from which it identifies the parties whose δj
+// or σj does not verify. The messages are indexed by their sender: the round 2 and round 3 messages of the party are
+// one of those it sent, and its round 3 broadcast is the one it sent.
+type Identification struct {
+	Round   Blamer
+	Session *Session
+	Ssid    []byte
+	Self    int
+	Parties []*tss.PartyID
+	R1msg1s []tss.ParsedMessage
+	R2msgs  []tss.ParsedMessage
+	R3msgs  []tss.ParsedMessage
+	R3msg2s []tss.ParsedMessage
+}
+
+// NewMtAMasks returns the MtA masks ys of Pi with every Pj in the exponent, with the log proofs to Pj that the
+// ciphertexts Fs, whose randomness is rands, encrypt them with the paillier key N of Pi. The entries of Pi are nil.
+func NewMtAMasks(
+	ec elliptic.Curve, ctx []byte, ys, Fs, rands []*big.Int, N *big.Int, pedersens []*zkPaillier.PederssenOpenParameter,
+) (*MtAMasks, error) {
+	masks := &MtAMasks{
+		F:      Fs,
+		Y:      make([]*crypto.ECPoint, len(ys)),
+		Proofs: make([]*logproof.LogStarMessage, len(ys)),
+	}
+	for j, y := range ys {
+		if y == nil {
+			continue
+		}
+		masks.Y[j] = crypto.ScalarBaseMult(ec, y)
+		var err error
+		if masks.Proofs[j], err = logproof.NewKnowExponentAndPaillierEncryption(
+			crypto.MaskProofConfig(ec), ctx, y, rands[j], Fs[j], N, pedersens[j], masks.Y[j], nil,
+		); err != nil {
+			return nil, fmt.Errorf("create mask proof failed: %s", err.Error())
+		}
+	}
+	return masks, nil
+}
+
+// NewNonceShare returns k * G and k * X for the public key X, with the log proofs to the verifier with the pedersen
+// parameters ped that k is encrypted in K with the randomness rho and the paillier key N
+func NewNonceShare(
+	ec elliptic.Curve, ctx []byte, k, rho, K, N *big.Int, ped *zkPaillier.PederssenOpenParameter, X *crypto.ECPoint,
+) (*NonceShare, error) {
+	config := crypto.CurveProofConfig(ec)
+	nonce := &NonceShare{K: crypto.ScalarBaseMult(ec, k), KX: X.ScalarMult(k)}
+	var err error
+	if nonce.KProof, err = logproof.NewKnowExponentAndPaillierEncryption(config, ctx, k, rho, K, N, ped, nonce.K, nil); err != nil {
+		return nil, fmt.Errorf("create nonce proof failed: %s", err.Error())
+	}
+	if nonce.KXProof, err = logproof.NewKnowExponentAndPaillierEncryption(config, ctx, k, rho, K, N, ped, nonce.KX, X); err != nil {
+		return nil, fmt.Errorf("create nonce proof failed: %s", err.Error())
+	}
+	return nonce, nil
+}
+
+// CheckBroadcasts checks that every party broadcast its MtA masks in round 3, those of χ too if hat is true,
+// with the ciphertexts that it sent to the party in round 2
+func (id *Identification) CheckBroadcasts(ec elliptic.Curve, hat bool) *tss.Error {
+	for j, Pj := range id.Parties {
+		if j == id.Self {
+			continue
+		}
+		r3msg2 := id.R3msg2s[j].Content().(*SignRound3Message2)
+		if _, err := r3msg2.UnmarshalMasks(ec, len(id.Parties), j, false); err != nil {
+			return id.Round.WrapError(fmt.Errorf("[j: %d] %s", j, err.Error()), Pj)
+		}
+		if hat {
+			if _, err := r3msg2.UnmarshalMasks(ec, len(id.Parties), j, true); err != nil {
+				return id.Round.WrapError(fmt.Errorf("[j: %d] %s", j, err.Error()), Pj)
+			}
+		}
+		inputs := &MaskEquivocationInputs{Index: j, Verifier: id.Self}
+		if err := CheckMaskEquivocation(inputs, id.R2msgs[j].Content().(*SignRound2Message), r3msg2); err != nil {
+			return id.Round.WrapEvidence(fmt.Errorf("[j: %d] %s", j, err.Error()), EvidenceMaskEquivocation, inputs, id.R2msgs[j], id.R3msg2s[j])
+		}
+	}
+	return nil
+}
+
+// IdentifyDelta returns the errors against the parties whose δj does not match ∆j and the MtA masks,
+// or against the parties whose masks do not verify
+func (id *Identification) IdentifyDelta(ec elliptic.Curve) []*tss.Error {
+	if errs := id.checkMaskProofs(ec, false); len(errs) > 0 {
+		return errs
+	}
+	var errs []*tss.Error
+	for j := range id.Parties {
+		if j == id.Self {
+			continue
+		}
+		// the broadcasts have been checked by CheckBroadcasts
+		masks, _ := MasksOf(ec, j, id.R3msg2s, false)
+		r3msg := id.R3msgs[j].Content().(*SignRound3Message)
+		if err := CheckDeltaShare(ec, j, masks, r3msg, id.R3msg2s[j].Content().(*SignRound3Message2)); err != nil {
+			inputs := &Inputs{Session: *id.Session, Index: j}
+			msgs := append([]tss.ParsedMessage{id.R3msgs[j]}, id.R3msg2s...)
+			errs = append(errs, id.Round.WrapEvidence(fmt.Errorf("[j: %d] %s", j, err.Error()), EvidenceDeltaShare, inputs, msgs...))
+		}
+	}
+	return errs
+}
+
+// IdentifySigma returns the errors against the parties whose σj of the message m does not match their nonce share
+// and the MtA masks of χ, or against the parties whose nonce shares or masks do not verify.
+// R is the nonce point of the signature.
+func (id *Identification) IdentifySigma(ec elliptic.Curve, R *crypto.ECPoint, m *big.Int, r4msgs []tss.ParsedMessage) []*tss.Error {
+	X, err := id.Session.PublicKey()
+	if err != nil {
+		return []*tss.Error{id.Round.WrapError(err)}
+	}
+	var errs []*tss.Error
+	for j := range id.Parties {
+		if j == id.Self {
+			continue
+		}
+		r1msg1 := id.R1msg1s[j].Content().(*SignRound1Message1)
+		if err := CheckNonceProof(ec, id.Session, id.Ssid, j, id.Self, X, r1msg1, id.R3msgs[j].Content().(*SignRound3Message)); err != nil {
+			inputs := &Inputs{Session: *id.Session, Index: j}
+			errs = append(errs, id.Round.WrapEvidence(
+				fmt.Errorf("[j: %d] %s", j, err.Error()), EvidenceNonceProof, inputs, id.R1msg1s[j], id.R3msgs[j],
+			))
+		}
+	}
+	if errs = append(errs, id.checkMaskProofs(ec, true)...); len(errs) > 0 {
+		return errs
+	}
+	for j := range id.Parties {
+		if j == id.Self {
+			continue
+		}
+		masks, _ := MasksOf(ec, j, id.R3msg2s, true)
+		if err := CheckSigmaShare(
+			ec, j, m, R, masks, id.R3msgs[j].Content().(*SignRound3Message), id.R3msg2s[j].Content().(*SignRound3Message2),
+			r4msgs[j].Content().(*SignRound4Message),
+		); err != nil {
+			// the nonce point R is computed again from the round 2 and round 3 messages
+			inputs := &SigmaShareInputs{Inputs: Inputs{Session: *id.Session, Index: j}, M: m}
+			msgs := append([]tss.ParsedMessage{r4msgs[j]}, id.R2msgs...)
+			msgs = append(append(msgs, id.R3msgs...), id.R3msg2s...)
+			errs = append(errs, id.Round.WrapEvidence(fmt.Errorf("[j: %d] %s", j, err.Error()), EvidenceSigmaShare, inputs, msgs...))
+		}
+	}
+	return errs
+}
+
+// checkMaskProofs returns the errors against the parties whose proofs of their MtA masks, or of those of χ if hat is
+// true, do not verify
+func (id *Identification) checkMaskProofs(ec elliptic.Curve, hat bool) []*tss.Error {
+	kind := EvidenceMaskProof
+	if hat {
+		kind = EvidenceMaskHatProof
+	}
+	var errs []*tss.Error
+	for j := range id.Parties {
+		if j == id.Self {
+			continue
+		}
+		for v := range id.Parties {
+			if v == j {
+				continue
+			}
+			if err := CheckMaskProof(ec, id.Session, id.Ssid, j, v, id.R3msg2s[j].Content().(*SignRound3Message2), hat); err != nil {
+				inputs := &MaskProofInputs{Inputs: Inputs{Session: *id.Session, Index: j}, Verifier: v}
+				errs = append(errs, id.Round.WrapEvidence(fmt.Errorf("[j: %d] %s", j, err.Error()), kind, inputs, id.R3msg2s[j]))
+				break
+			}
+		}
+	}
+	return errs
+}
@@ -37,6 +37,7 @@ type (
 		signRound1Message2s,
 		signRound2Messages,
 		signRound3Messages,
+		signRound3Message2s,
 		signRound4Messages []tss.ParsedMessage
 	}
 
@@ -60,6 +61,10 @@ type (
 		betaHat []*big.Int
 		Gamma   *crypto.ECPoint
 
+		// the MtA masks yi,j, which βi,j hides, their ciphertexts Fi,j and their randomness, for the identification
+		masks, maskCiphertexts, maskRands          []*big.Int
+		maskHats, maskHatCiphertexts, maskHatRands []*big.Int
+
 		// round 3
 		sumGamma *crypto.ECPoint
 		chi      *big.Int
@@ -90,7 +95,7 @@ func NewLocalParty(
 	if err != nil {
 		return nil, err
 	}
-	err = utils.UpdateKeyForSigning(&key, path, isThreshold, params.Threshold())
+	err = utils.UpdateKeyForSigning(&key, path, isThreshold, params.Threshold(), params.KeyTweaks()...)
 	if err != nil {
 		return nil, err
 	}
@@ -110,6 +115,7 @@ func NewLocalParty(
 	p.temp.signRound1Message2s = make([]tss.ParsedMessage, partyCount)
 	p.temp.signRound2Messages = make([]tss.ParsedMessage, partyCount)
 	p.temp.signRound3Messages = make([]tss.ParsedMessage, partyCount)
+	p.temp.signRound3Message2s = make([]tss.ParsedMessage, partyCount)
 	p.temp.signRound4Messages = make([]tss.ParsedMessage, partyCount)
 
 	// temp data init
@@ -124,9 +130,39 @@ func NewLocalParty(
 	p.temp.gammaCiphertexts = make([]*big.Int, partyCount)
 	p.temp.beta = make([]*big.Int, partyCount)
 	p.temp.betaHat = make([]*big.Int, partyCount)
+	p.temp.masks = make([]*big.Int, partyCount)
+	p.temp.maskCiphertexts = make([]*big.Int, partyCount)
+	p.temp.maskRands = make([]*big.Int, partyCount)
+	p.temp.maskHats = make([]*big.Int, partyCount)
+	p.temp.maskHatCiphertexts = make([]*big.Int, partyCount)
+	p.temp.maskHatRands = make([]*big.Int, partyCount)
 	return p, nil
 }
 
+// NewLocalPartyWithMessage returns a party that signs the digest of the message, in the hash mode of the message:
+// PREHASHED, SHA256 or KECCAK256. The digest keeps its leading zero bytes, and its length is checked against the curve.
+func NewLocalPartyWithMessage(
+	msg *common.Message,
+	isThreshold bool,
+	params *tss.Parameters,
+	path string,
+	key keygen.LocalPartySaveData,
+	aux auxiliary.LocalPartySaveData,
+	out chan<- tss.Message,
+	end chan<- *common.SignatureData,
+) (tss.Party, error) {
+	digest, err := msg.ECDSADigest(params.EC())
+	if err != nil {
+		return nil, err
+	}
+	party, err := NewLocalParty(new(big.Int).SetBytes(digest), isThreshold, params, path, key, aux, out, end, len(digest))
+	if err != nil {
+		return nil, err
+	}
+	party.(*LocalParty).data.HashMode = msg.Mode
+	return party, nil
+}
+
 func (p *LocalParty) FirstRound() tss.Round {
 	return newRound1(p.temp.isThreshold, p.params, &p.keys, &p.auxs, p.data, &p.temp, p.out, p.end)
 }
@@ -181,6 +217,9 @@ func (p *LocalParty) StoreMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
 	case *SignRound3Message:
 		p.temp.signRound3Messages[fromPIdx] = msg
 
+	case *SignRound3Message2:
+		p.temp.signRound3Message2s[fromPIdx] = msg
+
 	case *SignRound4Message:
 		p.temp.signRound4Messages[fromPIdx] = msg
 
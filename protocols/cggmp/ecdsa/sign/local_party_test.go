@@ -5,6 +5,7 @@ package sign
 import (
 	"crypto/ecdsa"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"math/big"
 	"sync/atomic"
@@ -12,6 +13,7 @@ import (
 
 	"github.com/ipfs/go-log"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/felicityin/mpc-tss/common"
 	"github.com/felicityin/mpc-tss/protocols/cggmp/auxiliary"
@@ -141,12 +143,21 @@ SIGN:
 }
 
 func TestE2EThresholdConcurrent(t *testing.T) {
+	testE2EThresholdConcurrent(t, keygen.Ecdsa)
+}
+
+func TestE2EP256Concurrent(t *testing.T) {
+	testE2EThresholdConcurrent(t, keygen.EcdsaP256)
+}
+
+func testE2EThresholdConcurrent(t *testing.T, kind int) {
 	setUp("debug")
 
 	threshold := testThreshold
+	ec := tKeygen.TestCurve(kind)
 
 	// PHASE: load keygen fixtures
-	keys, signPIDs, err := tKeygen.LoadKeygenTestFixturesRandomSet(keygen.Ecdsa, testThreshold+1, testParticipants)
+	keys, signPIDs, err := tKeygen.LoadKeygenTestFixturesRandomSet(kind, testThreshold+1, testParticipants)
 	assert.NoError(t, err, "should load keygen fixtures")
 
 	auxs, _, err := auxiliary.LoadAuxTestFixtures(keygen.Ecdsa, testThreshold+1)
@@ -168,7 +179,7 @@ func TestE2EThresholdConcurrent(t *testing.T) {
 
 	// init the parties
 	for i := 0; i < len(signPIDs); i++ {
-		params := tss.NewParameters(tss.S256(), p2pCtx, signPIDs[i], len(signPIDs), threshold)
+		params := tss.NewParameters(ec, p2pCtx, signPIDs[i], len(signPIDs), threshold)
 		party, err := NewLocalParty(new(big.Int).SetBytes(msg), true, params, path, keys[i], auxs[i], outCh, endCh, len(msg))
 		assert.NoError(t, err)
 		P := party.(*LocalParty)
@@ -215,7 +226,7 @@ SIGN:
 				r := parties[0].temp.R.X()
 				fmt.Printf("sign result: R(%s, %s), r=%s\n", R.X().String(), R.Y().String(), r.String())
 
-				modN := common.ModInt(tss.S256().Params().N)
+				modN := common.ModInt(ec.Params().N)
 
 				// BEGIN check s correctness
 				sumS := big.NewInt(0)
@@ -227,7 +238,7 @@ SIGN:
 
 				// BEGIN ECDSA verify
 				pk := ecdsa.PublicKey{
-					Curve: tss.S256(),
+					Curve: ec,
 					X:     parties[0].keys.Pubkey.X(),
 					Y:     parties[0].keys.Pubkey.Y(),
 				}
@@ -241,3 +252,275 @@ SIGN:
 		}
 	}
 }
+
+func TestEvidenceOfBadAffgHatProof(t *testing.T) {
+	setUp("info")
+
+	threshold := testParticipants
+
+	keys, signPIDs, err := nonKeygen.LoadKeygenTestFixturesRandomSet(keygen.Ecdsa, threshold, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	auxs, _, err := auxiliary.LoadAuxTestFixtures(keygen.Ecdsa, threshold)
+	assert.NoError(t, err, "should load aux fixtures")
+
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	parties := make([]*LocalParty, 0, len(signPIDs))
+
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+	endCh := make(chan *common.SignatureData, len(signPIDs))
+
+	updater := test.SharedPartyUpdater
+
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(tss.S256(), p2pCtx, signPIDs[i], len(signPIDs), threshold)
+		party, err := NewLocalParty(big.NewInt(42), false, params, "0/1/2/2/10", keys[i], auxs[i], outCh, endCh)
+		assert.NoError(t, err)
+		P := party.(*LocalParty)
+		parties = append(parties, P)
+
+		go func(P *LocalParty) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	// P0 cheats with the MtA of its key share with P1, so P1 must blame it
+	var tssErr *tss.Error
+	for tssErr == nil {
+		select {
+		case tssErr = <-errCh:
+
+		case msg := <-outCh:
+			dest := msg.GetTo()
+			if r2msg, ok := msg.(tss.ParsedMessage).Content().(*SignRound2Message); ok &&
+				msg.GetFrom().Index == 0 && dest[0].Index == 1 {
+				content := proto.Clone(r2msg).(*SignRound2Message)
+				content.FHat = new(big.Int).Add(new(big.Int).SetBytes(content.FHat), big.NewInt(1)).Bytes()
+				meta := tss.MessageRouting{From: msg.GetFrom(), To: dest}
+				msg = tss.NewMessage(meta, content, tss.NewMessageWrapper(meta, content))
+			}
+			if dest == nil {
+				for _, P := range parties {
+					go updater(P, msg, errCh)
+				}
+			} else {
+				go updater(parties[dest[0].Index], msg, errCh)
+			}
+
+		case <-endCh:
+			assert.FailNow(t, "the signing should not end")
+		}
+	}
+
+	assert.Equal(t, 1, tssErr.Victim().Index)
+	if assert.Len(t, tssErr.Culprits(), 1) {
+		assert.Equal(t, 0, tssErr.Culprits()[0].Index)
+	}
+	if assert.Len(t, tssErr.Evidence(), 1) {
+		ev := tssErr.Evidence()[0]
+		assert.Equal(t, EvidenceAffgHatProof, ev.Kind)
+
+		bz, err := json.Marshal(ev)
+		assert.NoError(t, err)
+		decoded := new(tss.Evidence)
+		assert.NoError(t, json.Unmarshal(bz, decoded))
+		assert.NoError(t, decoded.Verify())
+
+		// the affg proof of the same message is valid
+		decoded.Kind = EvidenceAffgProof
+		assert.ErrorIs(t, decoded.Verify(), tss.ErrNoMisbehaviour)
+	}
+}
+
+func TestE2EKeccak256Concurrent(t *testing.T) {
+	setUp("info")
+
+	keys, signPIDs, err := tKeygen.LoadKeygenTestFixturesRandomSet(keygen.Ecdsa, testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	auxs, _, err := auxiliary.LoadAuxTestFixtures(keygen.Ecdsa, testThreshold+1)
+	assert.NoError(t, err, "should load aux fixtures")
+
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	parties := make([]*LocalParty, 0, len(signPIDs))
+
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+	endCh := make(chan *common.SignatureData, len(signPIDs))
+
+	msg := common.NewKeccak256Message([]byte("hello"))
+	digest, err := msg.ECDSADigest(tss.S256())
+	assert.NoError(t, err)
+
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(tss.S256(), p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+		_, err := NewLocalPartyWithMessage(common.NewPrehashedMessage(digest[1:]), true, params, "0/1", keys[i], auxs[i], outCh, endCh)
+		assert.Error(t, err, "the digest is shorter than the order of the curve")
+
+		party, err := NewLocalPartyWithMessage(msg, true, params, "0/1", keys[i], auxs[i], outCh, endCh)
+		assert.NoError(t, err)
+		P := party.(*LocalParty)
+		parties = append(parties, P)
+
+		go func(P *LocalParty) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	for ended := 0; ended < len(parties); {
+		select {
+		case err := <-errCh:
+			assert.FailNow(t, err.Error())
+
+		case msg := <-outCh:
+			if dest := msg.GetTo(); dest != nil {
+				go test.SharedPartyUpdater(parties[dest[0].Index], msg, errCh)
+				continue
+			}
+			for _, P := range parties {
+				if P.PartyID().Index == msg.GetFrom().Index {
+					continue
+				}
+				go test.SharedPartyUpdater(P, msg, errCh)
+			}
+
+		case data := <-endCh:
+			ended++
+			assert.Equal(t, common.HashMode_KECCAK256, data.HashMode)
+			assert.Equal(t, digest, data.M)
+			pk := ecdsa.PublicKey{
+				Curve: tss.S256(),
+				X:     parties[0].keys.Pubkey.X(),
+				Y:     parties[0].keys.Pubkey.Y(),
+			}
+			r, s := new(big.Int).SetBytes(data.R), new(big.Int).SetBytes(data.S)
+			assert.True(t, ecdsa.Verify(&pk, digest, r, s), "ecdsa verify must pass")
+		}
+	}
+}
+
+// signTampered runs the non threshold signing of the fixtures, with the messages changed by tamper before they are
+// routed, and returns the first error of the parties
+func signTampered(t *testing.T, tamper func(msg tss.ParsedMessage) tss.ParsedMessage) *tss.Error {
+	threshold := testParticipants
+
+	keys, signPIDs, err := nonKeygen.LoadKeygenTestFixturesRandomSet(keygen.Ecdsa, threshold, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	auxs, _, err := auxiliary.LoadAuxTestFixtures(keygen.Ecdsa, threshold)
+	assert.NoError(t, err, "should load aux fixtures")
+
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	parties := make([]*LocalParty, 0, len(signPIDs))
+
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+	endCh := make(chan *common.SignatureData, len(signPIDs))
+
+	updater := test.SharedPartyUpdater
+
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(tss.S256(), p2pCtx, signPIDs[i], len(signPIDs), threshold)
+		party, err := NewLocalParty(big.NewInt(42), false, params, "0/1/2/2/10", keys[i], auxs[i], outCh, endCh)
+		assert.NoError(t, err)
+		P := party.(*LocalParty)
+		parties = append(parties, P)
+
+		go func(P *LocalParty) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	for ended := 0; ; {
+		select {
+		case tssErr := <-errCh:
+			return tssErr
+
+		case msg := <-outCh:
+			msg = tamper(msg.(tss.ParsedMessage))
+			dest := msg.GetTo()
+			if dest == nil {
+				for _, P := range parties {
+					if P.PartyID().Index == msg.GetFrom().Index {
+						continue
+					}
+					go updater(P, msg, errCh)
+				}
+			} else {
+				go updater(parties[dest[0].Index], msg, errCh)
+			}
+
+		case <-endCh:
+			// the cheater may not see what it changed
+			if ended++; ended == len(signPIDs) {
+				assert.FailNow(t, "the signing should not end")
+			}
+		}
+	}
+}
+
+func TestEvidenceOfBadDelta(t *testing.T) {
+	setUp("info")
+
+	// P0 sends P1 a δ0 which does not match ∆0, with a valid log proof of ∆0, so P1 must identify it
+	tssErr := signTampered(t, func(msg tss.ParsedMessage) tss.ParsedMessage {
+		r3msg, ok := msg.Content().(*SignRound3Message)
+		if !ok || msg.GetFrom().Index != 0 || msg.GetTo()[0].Index != 1 {
+			return msg
+		}
+		content := proto.Clone(r3msg).(*SignRound3Message)
+		content.Delta = new(big.Int).Add(new(big.Int).SetBytes(content.Delta), big.NewInt(1)).Bytes()
+		meta := tss.MessageRouting{From: msg.GetFrom(), To: msg.GetTo()}
+		return tss.NewMessage(meta, content, tss.NewMessageWrapper(meta, content))
+	})
+
+	assert.Equal(t, 1, tssErr.Victim().Index)
+	if assert.Len(t, tssErr.Culprits(), 1) {
+		assert.Equal(t, 0, tssErr.Culprits()[0].Index)
+	}
+	if assert.Len(t, tssErr.Evidence(), 1) {
+		ev := tssErr.Evidence()[0]
+		assert.Equal(t, EvidenceDeltaShare, ev.Kind)
+
+		bz, err := json.Marshal(ev)
+		assert.NoError(t, err)
+		decoded := new(tss.Evidence)
+		assert.NoError(t, json.Unmarshal(bz, decoded))
+		assert.NoError(t, decoded.Verify())
+	}
+}
+
+func TestEvidenceOfBadSigma(t *testing.T) {
+	setUp("info")
+
+	// P0 broadcasts a σ0 which does not match its nonce share, so that the signature does not verify
+	tssErr := signTampered(t, func(msg tss.ParsedMessage) tss.ParsedMessage {
+		r4msg, ok := msg.Content().(*SignRound4Message)
+		if !ok || msg.GetFrom().Index != 0 {
+			return msg
+		}
+		content := proto.Clone(r4msg).(*SignRound4Message)
+		content.Sigma = new(big.Int).Add(new(big.Int).SetBytes(content.Sigma), big.NewInt(1)).Bytes()
+		meta := tss.MessageRouting{From: msg.GetFrom(), IsBroadcast: true}
+		return tss.NewMessage(meta, content, tss.NewMessageWrapper(meta, content))
+	})
+
+	assert.NotEqual(t, 0, tssErr.Victim().Index)
+	if assert.Len(t, tssErr.Culprits(), 1) {
+		assert.Equal(t, 0, tssErr.Culprits()[0].Index)
+	}
+	if assert.Len(t, tssErr.Evidence(), 1) {
+		ev := tssErr.Evidence()[0]
+		assert.Equal(t, EvidenceSigmaShare, ev.Kind)
+
+		bz, err := json.Marshal(ev)
+		assert.NoError(t, err)
+		decoded := new(tss.Evidence)
+		assert.NoError(t, json.Unmarshal(bz, decoded))
+		assert.NoError(t, decoded.Verify())
+	}
+}
@@ -1,6 +1,7 @@
 package sign
 
 import (
+	"crypto/elliptic"
 	"fmt"
 	"math/big"
 
@@ -9,6 +10,7 @@ import (
 	"github.com/felicityin/mpc-tss/common"
 	"github.com/felicityin/mpc-tss/crypto"
 	"github.com/felicityin/mpc-tss/crypto/affproof"
+	"github.com/felicityin/mpc-tss/crypto/dleq"
 	"github.com/felicityin/mpc-tss/crypto/encproof"
 	"github.com/felicityin/mpc-tss/crypto/logproof"
 	"github.com/felicityin/mpc-tss/tss"
@@ -24,10 +26,36 @@ var (
 		(*SignRound1Message2)(nil),
 		(*SignRound2Message)(nil),
 		(*SignRound3Message)(nil),
+		(*SignRound3Message2)(nil),
 		(*SignRound4Message)(nil),
 	}
 )
 
+// AdaptorShare is the share γi * T of the adaptor nonce of a presignature with the adaptor point T,
+// with the DLEQ proof that it has the discrete logarithm γi of Γi
+type AdaptorShare struct {
+	GammaT *crypto.ECPoint
+	Proof  *dleq.Proof
+}
+
+// NonceShare is the nonce share ki * G and ki * X for the public key X, with the log proofs that ki is encrypted in Ki.
+// It identifies the party whose σi does not verify.
+type NonceShare struct {
+	K       *crypto.ECPoint
+	KX      *crypto.ECPoint
+	KProof  *logproof.LogStarMessage
+	KXProof *logproof.LogStarMessage
+}
+
+// MtAMasks are the ciphertexts Fi,j of the masks yi,j of the MtA of Pi with every Pj, the masks in the exponent
+// Yi,j = yi,j * G and the log proofs that Fi,j encrypts them, whose verifier is Pj. The entries of Pi are nil.
+// They identify the party whose δi or σi does not verify.
+type MtAMasks struct {
+	F      []*big.Int
+	Y      []*crypto.ECPoint
+	Proofs []*logproof.LogStarMessage
+}
+
 func NewSignRound1Message1(
 	from *tss.PartyID,
 	kCiphertext *big.Int,
@@ -105,6 +133,7 @@ func NewSignRound2Message(
 	psiProof *affproof.PaillierAffAndGroupRangeMessage,
 	psiHatProof *affproof.PaillierAffAndGroupRangeMessage,
 	logProof *logproof.LogStarMessage,
+	adaptor *AdaptorShare,
 ) (tss.ParsedMessage, error) {
 	GammaBytes, err := Gamma.MarshalJSON()
 	if err != nil {
@@ -142,6 +171,18 @@ func NewSignRound2Message(
 		AffgHatProof: psiHahtProofBytes,
 		LogProof:     logProofBytes,
 	}
+	if adaptor != nil {
+		if content.AdaptorGamma, err = adaptor.GammaT.MarshalJSON(); err != nil {
+			return nil, fmt.Errorf("marshal adaptor gamma err: %s", err.Error())
+		}
+		if content.AdaptorProofA, err = adaptor.Proof.A.MarshalJSON(); err != nil {
+			return nil, fmt.Errorf("marshal adaptor proof err: %s", err.Error())
+		}
+		if content.AdaptorProofB, err = adaptor.Proof.B.MarshalJSON(); err != nil {
+			return nil, fmt.Errorf("marshal adaptor proof err: %s", err.Error())
+		}
+		content.AdaptorProofZ = adaptor.Proof.Z.Bytes()
+	}
 	msg := tss.NewMessageWrapper(meta, content)
 	return tss.NewMessage(meta, content, msg), nil
 }
@@ -190,6 +231,26 @@ func (m *SignRound2Message) UnmarshalLogProof() (*logproof.LogStarMessage, error
 	return logProof, nil
 }
 
+// UnmarshalAdaptor returns the share of the adaptor nonce, or nil if the message has none
+func (m *SignRound2Message) UnmarshalAdaptor() (*AdaptorShare, error) {
+	if len(m.GetAdaptorGamma()) == 0 {
+		return nil, nil
+	}
+	GammaT, err := crypto.UnmarshalJSONPoint(m.GetAdaptorGamma())
+	if err != nil {
+		return nil, err
+	}
+	A, err := crypto.UnmarshalJSONPoint(m.GetAdaptorProofA())
+	if err != nil {
+		return nil, err
+	}
+	B, err := crypto.UnmarshalJSONPoint(m.GetAdaptorProofB())
+	if err != nil {
+		return nil, err
+	}
+	return &AdaptorShare{GammaT: GammaT, Proof: &dleq.Proof{A: A, B: B, Z: new(big.Int).SetBytes(m.GetAdaptorProofZ())}}, nil
+}
+
 // ----- //
 
 func NewSignRound3Message(
@@ -197,6 +258,7 @@ func NewSignRound3Message(
 	delta *big.Int,
 	Delta *crypto.ECPoint,
 	logProof *logproof.LogStarMessage,
+	nonce *NonceShare,
 ) (tss.ParsedMessage, error) {
 	logProofBytes, err := proto.Marshal(logProof)
 	if err != nil {
@@ -219,6 +281,20 @@ func NewSignRound3Message(
 		BigDelta: deltaBytes,
 		LogProof: logProofBytes,
 	}
+	if nonce != nil {
+		if content.BigK, err = nonce.K.MarshalJSON(); err != nil {
+			return nil, fmt.Errorf("marshal nonce share err: %s", err.Error())
+		}
+		if content.BigKX, err = nonce.KX.MarshalJSON(); err != nil {
+			return nil, fmt.Errorf("marshal nonce share err: %s", err.Error())
+		}
+		if content.KProof, err = proto.Marshal(nonce.KProof); err != nil {
+			return nil, fmt.Errorf("marshal nonce proof err: %s", err.Error())
+		}
+		if content.KXProof, err = proto.Marshal(nonce.KXProof); err != nil {
+			return nil, fmt.Errorf("marshal nonce proof err: %s", err.Error())
+		}
+	}
 	msg := tss.NewMessageWrapper(meta, content)
 	return tss.NewMessage(meta, content, msg), nil
 }
@@ -250,6 +326,107 @@ func (m *SignRound3Message) UnmarshalLogProof() (*logproof.LogStarMessage, error
 	return logProof, nil
 }
 
+// UnmarshalNonce returns the nonce share of the sender, which must be on the curve ec
+func (m *SignRound3Message) UnmarshalNonce(ec elliptic.Curve) (*NonceShare, error) {
+	K, err := unmarshalPoint(ec, m.GetBigK())
+	if err != nil {
+		return nil, err
+	}
+	KX, err := unmarshalPoint(ec, m.GetBigKX())
+	if err != nil {
+		return nil, err
+	}
+	nonce := &NonceShare{K: K, KX: KX, KProof: &logproof.LogStarMessage{}, KXProof: &logproof.LogStarMessage{}}
+	if err := proto.Unmarshal(m.GetKProof(), nonce.KProof); err != nil {
+		return nil, err
+	}
+	if err := proto.Unmarshal(m.GetKXProof(), nonce.KXProof); err != nil {
+		return nil, err
+	}
+	return nonce, nil
+}
+
+// ----- //
+
+// NewSignRound3Message2 returns the broadcast of the MtA masks of the party, where hatMasks are those of the MtA
+// of χ, which a presigning does not send as it has no σ to check
+func NewSignRound3Message2(from *tss.PartyID, masks, hatMasks *MtAMasks) (tss.ParsedMessage, error) {
+	content := &SignRound3Message2{}
+	var err error
+	if content.F, content.Mask, content.MaskProof, err = marshalMasks(masks); err != nil {
+		return nil, err
+	}
+	if hatMasks != nil {
+		if content.FHat, content.MaskHat, content.MaskHatProof, err = marshalMasks(hatMasks); err != nil {
+			return nil, err
+		}
+	}
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg), nil
+}
+
+func (m *SignRound3Message2) ValidateBasic() bool {
+	return m != nil &&
+		len(m.F) > 0 &&
+		len(m.Mask) == len(m.F) &&
+		len(m.MaskProof) == len(m.F) &&
+		len(m.MaskHat) == len(m.FHat) &&
+		len(m.MaskHatProof) == len(m.FHat)
+}
+
+// UnmarshalMasks returns the MtA masks of the sender for the parties, or those of the MtA of χ if hat is true.
+// The masks must be on the curve ec, and there must be one for every party but the sender.
+func (m *SignRound3Message2) UnmarshalMasks(ec elliptic.Curve, partyCount, from int, hat bool) (*MtAMasks, error) {
+	F, Y, proofs := m.GetF(), m.GetMask(), m.GetMaskProof()
+	if hat {
+		F, Y, proofs = m.GetFHat(), m.GetMaskHat(), m.GetMaskHatProof()
+	}
+	if len(F) != partyCount || len(Y) != partyCount || len(proofs) != partyCount {
+		return nil, fmt.Errorf("expected the masks of %d parties, got %d", partyCount, len(F))
+	}
+	masks := &MtAMasks{
+		F:      make([]*big.Int, partyCount),
+		Y:      make([]*crypto.ECPoint, partyCount),
+		Proofs: make([]*logproof.LogStarMessage, partyCount),
+	}
+	for j := range F {
+		if j == from {
+			continue
+		}
+		var err error
+		masks.F[j] = new(big.Int).SetBytes(F[j])
+		if masks.Y[j], err = unmarshalPoint(ec, Y[j]); err != nil {
+			return nil, fmt.Errorf("unmarshal mask %d err: %s", j, err.Error())
+		}
+		masks.Proofs[j] = &logproof.LogStarMessage{}
+		if err := proto.Unmarshal(proofs[j], masks.Proofs[j]); err != nil {
+			return nil, fmt.Errorf("unmarshal mask proof %d err: %s", j, err.Error())
+		}
+	}
+	return masks, nil
+}
+
+func marshalMasks(masks *MtAMasks) (F, Y, proofs [][]byte, err error) {
+	F, Y, proofs = make([][]byte, len(masks.F)), make([][]byte, len(masks.F)), make([][]byte, len(masks.F))
+	for j := range masks.F {
+		if masks.F[j] == nil {
+			continue
+		}
+		F[j] = masks.F[j].Bytes()
+		if Y[j], err = masks.Y[j].MarshalJSON(); err != nil {
+			return nil, nil, nil, fmt.Errorf("marshal mask err: %s", err.Error())
+		}
+		if proofs[j], err = proto.Marshal(masks.Proofs[j]); err != nil {
+			return nil, nil, nil, fmt.Errorf("marshal mask proof err: %s", err.Error())
+		}
+	}
+	return F, Y, proofs, nil
+}
+
 // ----- //
 
 func NewSignRound4Message(
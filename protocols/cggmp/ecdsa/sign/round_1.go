@@ -12,7 +12,9 @@ import (
 	"github.com/felicityin/mpc-tss/tss"
 )
 
-var ProofParameter = crypto.NewProofConfig(tss.S256().Params().N)
+// ProofParameter is the proof config of secp256k1.
+// The rounds use the config of the curve of the parameters, see crypto.CurveProofConfig.
+var ProofParameter = crypto.CurveProofConfig(tss.S256())
 
 // round 1 represents round 1 of the signing part of the EDDSA TSS spec
 func newRound1(
@@ -90,7 +92,7 @@ func (round *round1) Start() *tss.Error {
 
 		// M(prove, Πenc, (sid,i), (Iε,Ki); (ki,rhoi))
 		encProof, err := encproof.NewEncryptRangeMessage(
-			ProofParameter, contextJ, round.temp.kCiphertexts[i],
+			crypto.CurveProofConfig(round.EC()), contextJ, round.temp.kCiphertexts[i],
 			round.aux.PaillierPKs[i].N, round.temp.k, round.temp.rho, round.aux.PedersenPKs[j],
 		)
 		if err != nil {
@@ -29,6 +29,7 @@ func (round *round2) Start() *tss.Error {
 	contextI := append(round.temp.ssid, big.NewInt(int64(i)).Bytes()...)
 
 	// Verify received enc proof
+	session := round.session()
 	for j := range Ps {
 		if j == i {
 			continue
@@ -40,19 +41,13 @@ func (round *round2) Start() *tss.Error {
 		common.Logger.Debugf("P[%d]: receive P[%d]'s kCiphertext and gammaCiphertext", i, j)
 
 		r1msg2 := round.temp.signRound1Message2s[j].Content().(*SignRound1Message2)
-		encProof, err := r1msg2.UnmarshalEncProof()
-		if err != nil {
-			common.Logger.Errorf("unmarshal enc proof failed, party: %d", j)
-			return round.WrapError(err)
-		}
-		common.Logger.Debugf("P[%d]: receive P[%d]'s enc proof", i, j)
-
-		if err := encProof.Verify(
-			ProofParameter, contextI, round.temp.kCiphertexts[j],
-			round.aux.PaillierPKs[j].N, round.aux.PedersenPKs[i],
-		); err != nil {
-			common.Logger.Errorf("verify enc proof failed, party: %d", j)
-			return round.WrapError(err)
+		if err := CheckEncProof(round.EC(), session, round.temp.ssid, j, i, r1msg1, r1msg2); err != nil {
+			inputs := &Inputs{Session: *session, Index: j}
+			common.Logger.Errorf("[j: %d] %s", j, err.Error())
+			return round.WrapEvidence(
+				fmt.Errorf("[j: %d] %s", j, err.Error()), EvidenceEncProof, inputs,
+				round.temp.signRound1Message1s[j], round.temp.signRound1Message2s[j],
+			)
 		}
 		common.Logger.Debugf("P[%d]: verify P[%d]'s enc proof ok", i, j)
 	}
@@ -86,11 +81,14 @@ func (round *round2) Start() *tss.Error {
 				round.Rand(), contextI, round.aux.PedersenPKs[j], round.aux.PaillierPKs[i],
 				round.temp.kCiphertexts[j], round.temp.gamma, round.temp.Gamma,
 			)
-			Ds[j], Fs[j], psiProofs[j], round.temp.beta[j], _, _, _ = D, F, psiProof, negBeta, countDelta, r, s
 			if err != nil {
 				common.Logger.Errorf("create aff-g proof 1 failed: %s", err.Error())
 				errChs <- round.WrapError(fmt.Errorf("create aff-g proof 1 failed: %s", err.Error()))
+				return
 			}
+			Ds[j], Fs[j], psiProofs[j], round.temp.beta[j], _ = D, F, psiProof, negBeta, s
+			round.temp.masks[j] = MtAMask(negBeta, countDelta, round.aux.PaillierPKs[j].N)
+			round.temp.maskCiphertexts[j], round.temp.maskRands[j] = F, r
 		}(j, Pj)
 
 		go func(j int, Pj *tss.PartyID) {
@@ -100,23 +98,26 @@ func (round *round2) Start() *tss.Error {
 				round.Rand(), contextI, round.aux.PedersenPKs[j], round.aux.PaillierPKs[i],
 				round.temp.kCiphertexts[j], round.key.PrivXi, round.key.PubXj[i],
 			)
-			Dhats[j], Fhats[j], psiHatProofs[j], round.temp.betaHat[j], _, _, _ = Dhat, Fhat, psiHatProof, negBetaHat, countSigma, rhat, shat
 			if err != nil {
 				common.Logger.Errorf("create aff-g proof 2 failed: %s", err.Error())
 				errChs <- round.WrapError(fmt.Errorf("create aff-g proof 2 failed: %s", err.Error()))
+				return
 			}
+			Dhats[j], Fhats[j], psiHatProofs[j], round.temp.betaHat[j], _ = Dhat, Fhat, psiHatProof, negBetaHat, shat
+			round.temp.maskHats[j] = MtAMask(negBetaHat, countSigma, round.aux.PaillierPKs[j].N)
+			round.temp.maskHatCiphertexts[j], round.temp.maskHatRands[j] = Fhat, rhat
 		}(j, Pj)
 	}
 
 	// Consume error channels; wait for goroutines
 	wg.Wait()
 	close(errChs)
-	culprits := make([]*tss.PartyID, 0, len(round.Parties().IDs()))
+	var errs []*tss.Error
 	for err := range errChs {
-		culprits = append(culprits, err.Culprits()...)
+		errs = append(errs, err)
 	}
-	if len(culprits) > 0 {
-		return round.WrapError(errors.New("failed to calculate affg proof"), culprits...)
+	if err := tss.JoinErrors(errs...); err != nil {
+		return err
 	}
 
 	for j, Pj := range round.Parties().IDs() {
@@ -126,7 +127,7 @@ func (round *round2) Start() *tss.Error {
 
 		// log proof for the secret gamma, mu: M(prove, Πlog, (sid, i), (Iε, Gi, Γi, g); (γi, νi))
 		logProof, err := logproof.NewKnowExponentAndPaillierEncryption(
-			ProofParameter, contextI, round.temp.gamma, round.temp.mu, round.temp.gammaCiphertexts[i],
+			crypto.CurveProofConfig(round.EC()), contextI, round.temp.gamma, round.temp.mu, round.temp.gammaCiphertexts[i],
 			round.aux.PaillierPKs[i].N, round.aux.PedersenPKs[j], round.temp.Gamma, nil,
 		)
 		if err != nil {
@@ -136,16 +137,24 @@ func (round *round2) Start() *tss.Error {
 
 		common.Logger.Debugf("P[%d]: send proofs to P[%d]", i, j)
 		r2msg, err := NewSignRound2Message(
-			Pj, round.PartyID(), round.temp.Gamma, Ds[j], Fs[j], Dhats[j], Fhats[j], psiProofs[j], psiHatProofs[j], logProof,
+			Pj, round.PartyID(), round.temp.Gamma, Ds[j], Fs[j], Dhats[j], Fhats[j], psiProofs[j], psiHatProofs[j], logProof, nil,
 		)
 		if err != nil {
 			return round.WrapError(err, Pj)
 		}
+		// keep one of its messages, whose Γi the evidence of the later rounds needs
+		round.temp.signRound2Messages[i] = r2msg
 		round.out <- r2msg
 	}
 	return nil
 }
 
+// MtAMask returns the mask y of the MtA, which is encrypted in F, from β = -y - count * N for the paillier key N of the peer
+func MtAMask(negBeta, count, N *big.Int) *big.Int {
+	y := new(big.Int).Neg(negBeta)
+	return y.Sub(y, new(big.Int).Mul(count, N))
+}
+
 func (round *round2) CanAccept(msg tss.ParsedMessage) bool {
 	if _, ok := msg.Content().(*SignRound2Message); ok {
 		return !msg.IsBroadcast()
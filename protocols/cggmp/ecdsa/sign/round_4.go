@@ -24,6 +24,7 @@ func (round *round4) Start() *tss.Error {
 
 	common.Logger.Infof("[sign] party: %d, round4 start", i)
 
+	session := round.session()
 	sumDelta := new(big.Int).Set(round.temp.delta)
 	sumBigDelta := round.temp.Delta
 
@@ -31,24 +32,21 @@ func (round *round4) Start() *tss.Error {
 		if j == i {
 			continue
 		}
-		contextJ := append(round.temp.ssid, big.NewInt(int64(j)).Bytes()...)
+		r1msg1 := round.temp.signRound1Message1s[j]
 		r3msg := round.temp.signRound3Messages[j].Content().(*SignRound3Message)
 
-		Delta, err := r3msg.UnmarshalBigDelta()
-		if err != nil {
-			return round.WrapError(fmt.Errorf("[j: %d] unmarshal big delta err: %s", j, err.Error()))
+		if err := CheckDeltaLogProof(
+			round.EC(), session, round.temp.ssid, j, i, round.temp.sumGamma, r1msg1.Content().(*SignRound1Message1), r3msg,
+		); err != nil {
+			common.Logger.Errorf("[j: %d] %s", j, err.Error())
+			// Γ is computed again from the round 2 messages
+			inputs := &Inputs{Session: *session, Index: j}
+			msgs := append([]tss.ParsedMessage{round.temp.signRound3Messages[j], r1msg1}, round.temp.signRound2Messages...)
+			return round.WrapEvidence(fmt.Errorf("[j: %d] %s", j, err.Error()), EvidenceDeltaLogProof, inputs, msgs...)
 		}
-
-		logProof, err := r3msg.UnmarshalLogProof()
+		Delta, err := r3msg.UnmarshalBigDelta()
 		if err != nil {
-			return round.WrapError(fmt.Errorf("[j: %d] unmarshal log proof err: %s", j, err.Error()))
-		}
-		if err = logProof.Verify(
-			ProofParameter, contextJ, round.temp.kCiphertexts[j], round.aux.PaillierPKs[j].N,
-			round.aux.PedersenPKs[i], Delta, round.temp.sumGamma,
-		); err != nil {
-			common.Logger.Errorf("[j: %d] verify log proof failed: %s, party: %d", j, err)
-			return round.WrapError(fmt.Errorf("[j: %d] verify log proof failed: %s", j, err))
+			return round.WrapError(fmt.Errorf("[j: %d] unmarshal big delta err: %s", j, err.Error()), Pj)
 		}
 
 		sumDelta.Add(sumDelta, r3msg.UnmarshalDelta())
@@ -63,6 +61,14 @@ func (round *round4) Start() *tss.Error {
 
 	if hex.EncodeToString(gDelta.X().Bytes()) != hex.EncodeToString(sumBigDelta.X().Bytes()) ||
 		hex.EncodeToString(gDelta.Y().Bytes()) != hex.EncodeToString(sumBigDelta.Y().Bytes()) {
+		// identify the parties whose δj does not match ∆j
+		id := round.identification()
+		if err := id.CheckBroadcasts(round.EC(), true); err != nil {
+			return err
+		}
+		if err := tss.JoinErrors(id.IdentifyDelta(round.EC())...); err != nil {
+			return err
+		}
 		return round.WrapError(fmt.Errorf("verify delta failed"))
 	}
 
@@ -71,6 +77,11 @@ func (round *round4) Start() *tss.Error {
 	modN := common.ModInt(round.EC().Params().N)
 	round.temp.si = modN.Add(modN.Mul(round.temp.k, round.temp.msg), modN.Mul(round.temp.R.X(), round.temp.chi))
 
+	// Security: the nonces, the MtA masks and the key share are no longer needed
+	common.ZeroBigInts(round.temp.k, round.temp.gamma, round.temp.rho, round.temp.mu, round.temp.chi, round.key.PrivXi)
+	common.ZeroBigInts(round.temp.beta...)
+	common.ZeroBigInts(round.temp.betaHat...)
+
 	// broadcast sigma
 	common.Logger.Debugf("P[%d]: broadcast sigma", i)
 	r4msg := NewSignRound4Message(round.PartyID(), round.temp.si)
@@ -37,28 +37,34 @@ func (round *finalization) Start() *tss.Error {
 		sumS.Mod(sumS, round.EC().Params().N)
 	}
 
+	N := round.Params().EC().Params().N
+	r := new(big.Int).Mod(round.temp.R.X(), N)
+
 	recid := 0
-	// byte v = if(R.X > curve.N) then 2 else 0) | (if R.Y.IsEven then 0 else 1);
-	if round.temp.R.X().Cmp(round.Params().EC().Params().N) > 0 {
+	// byte v = if(R.X >= curve.N) then 2 else 0) | (if R.Y.IsEven then 0 else 1);
+	if round.temp.R.X().Cmp(N) >= 0 {
 		recid = 2
 	}
 	if round.temp.R.Y().Bit(0) != 0 {
 		recid |= 1
 	}
 
+	// The low S form is a convention of secp256k1, the signatures on other curves such as P-256 are left as they are.
 	// This is copied from:
 	// https://github.com/btcsuite/btcd/blob/c26ffa870fd817666a857af1bf6498fabba1ffe3/btcec/signature.go#L442-L444
 	// This is needed because of tendermint checks here:
 	// https://github.com/tendermint/tendermint/blob/d9481e3648450cb99e15c6a070c1fb69aa0c255b/crypto/secp256k1/secp256k1_nocgo.go#L43-L47
-	secp256k1halfN := new(big.Int).Rsh(round.Params().EC().Params().N, 1)
-	if sumS.Cmp(secp256k1halfN) > 0 {
-		sumS.Sub(round.Params().EC().Params().N, sumS)
-		recid ^= 1
+	if tss.SameCurve(round.Params().EC(), tss.S256()) {
+		secp256k1halfN := new(big.Int).Rsh(N, 1)
+		if sumS.Cmp(secp256k1halfN) > 0 {
+			sumS.Sub(N, sumS)
+			recid ^= 1
+		}
 	}
 
 	// save the signature for final output
-	bitSizeInBytes := round.Params().EC().Params().BitSize / 8
-	round.data.R = padToLengthBytesInPlace(round.temp.R.X().Bytes(), bitSizeInBytes)
+	bitSizeInBytes := (round.Params().EC().Params().BitSize + 7) / 8
+	round.data.R = padToLengthBytesInPlace(r.Bytes(), bitSizeInBytes)
 	round.data.S = padToLengthBytesInPlace(sumS.Bytes(), bitSizeInBytes)
 	round.data.Signature = append(round.data.R, round.data.S...)
 	round.data.SignatureRecovery = []byte{byte(recid)}
@@ -76,8 +82,15 @@ func (round *finalization) Start() *tss.Error {
 		Y:     round.key.Pubkey.Y(),
 	}
 
-	ok := ecdsa.Verify(&pk, round.data.M, round.temp.R.X(), sumS)
+	ok := ecdsa.Verify(&pk, round.data.M, r, sumS)
 	if !ok {
+		// identify the parties whose σj does not match their nonce share
+		id := round.identification()
+		if err := tss.JoinErrors(
+			id.IdentifySigma(round.EC(), round.temp.R, round.temp.msg, round.temp.signRound4Messages)...,
+		); err != nil {
+			return err
+		}
 		return round.WrapError(fmt.Errorf("signature verification failed"))
 	}
 
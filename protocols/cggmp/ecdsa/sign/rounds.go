@@ -1,7 +1,6 @@
 package sign
 
 import (
-	"errors"
 	"math/big"
 
 	"github.com/felicityin/mpc-tss/common"
@@ -94,6 +93,60 @@ func (round *base) WrapError(err error, culprits ...*tss.PartyID) *tss.Error {
 	return tss.NewError(err, TaskName, round.number, round.PartyID(), culprits...)
 }
 
+// WrapEvidence returns an error blaming the sender of msgs, with the evidence that the check `kind` failed on them
+func (round *base) WrapEvidence(err error, kind string, inputs interface{}, msgs ...tss.ParsedMessage) *tss.Error {
+	culprit := msgs[0].GetFrom()
+	ev, evErr := tss.NewEvidence(kind, TaskName, round.number, culprit, inputs, msgs...)
+	if evErr != nil {
+		common.Logger.Errorf("failed to create the evidence %s against %s: %s", kind, culprit, evErr)
+		return round.WrapError(err, culprit)
+	}
+	return tss.NewEvidenceError(err, TaskName, round.number, round.PartyID(), []*tss.Evidence{ev})
+}
+
+// publicKey returns the public key X = sum_j Xj of the key shares of the signers
+func (round *base) publicKey() (*crypto.ECPoint, error) {
+	X := round.key.PubXj[0]
+	for _, Xj := range round.key.PubXj[1:] {
+		var err error
+		if X, err = X.Add(Xj); err != nil {
+			return nil, err
+		}
+	}
+	return X, nil
+}
+
+// identification returns what the party received, to identify the parties whose δj or σj does not verify
+func (round *base) identification() *Identification {
+	return &Identification{
+		Round:   round,
+		Session: round.session(),
+		Ssid:    round.temp.ssid,
+		Self:    round.PartyID().Index,
+		Parties: round.Parties().IDs(),
+		R1msg1s: round.temp.signRound1Message1s,
+		R2msgs:  round.temp.signRound2Messages,
+		R3msgs:  round.temp.signRound3Messages,
+		R3msg2s: round.temp.signRound3Message2s,
+	}
+}
+
+// session returns the public values of the signing that are fixed before it
+func (round *base) session() *Session {
+	paillierNs := make([]*big.Int, len(round.aux.PaillierPKs))
+	for j, pk := range round.aux.PaillierPKs {
+		paillierNs[j] = pk.N
+	}
+	return &Session{
+		Curve:      round.curveName(),
+		Ks:         round.Parties().IDs().Keys(),
+		PubXj:      round.key.PubXj,
+		Nonce:      round.temp.ssidNonce,
+		PaillierNs: paillierNs,
+		Pedersens:  round.aux.PedersenPKs,
+	}
+}
+
 // ----- //
 
 // `ok` tracks parties which have been verified by Update()
@@ -103,17 +156,16 @@ func (round *base) resetOK() {
 	}
 }
 
+func (round *base) curveName() tss.CurveName {
+	name, _ := tss.GetCurveName(round.EC())
+	return name
+}
+
 // get ssid from local params
 func (round *base) getSSID() ([]byte, error) {
-	ssidList := []*big.Int{round.EC().Params().P, round.EC().Params().N, round.EC().Params().Gx, round.EC().Params().Gy} // ec curve
-	ssidList = append(ssidList, round.Parties().IDs().Keys()...)                                                         // parties
-	BigXjList, err := crypto.FlattenECPoints(round.key.PubXj)
+	ssid, err := round.session().SSID(round.EC())
 	if err != nil {
-		return nil, round.WrapError(errors.New("read BigXj failed"), round.PartyID())
+		return nil, round.WrapError(err, round.PartyID())
 	}
-	ssidList = append(ssidList, BigXjList...)                    // BigXj
-	ssidList = append(ssidList, big.NewInt(int64(round.number))) // round number
-	ssidList = append(ssidList, round.temp.ssidNonce)
-	ssid := common.SHA512_256i(ssidList...).Bytes()
 	return ssid, nil
 }
@@ -2,7 +2,7 @@
 // versions:
 // 	protoc-gen-go v1.33.0
 // 	protoc        v3.21.4
-// source: sign.proto
+// source: protocols/cggmp/ecdsa/sign/sign.proto
 
 package sign
 
@@ -33,7 +33,7 @@ type SignRound1Message1 struct {
 func (x *SignRound1Message1) Reset() {
 	*x = SignRound1Message1{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_sign_proto_msgTypes[0]
+		mi := &file_protocols_cggmp_ecdsa_sign_sign_proto_msgTypes[0]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -46,7 +46,7 @@ func (x *SignRound1Message1) String() string {
 func (*SignRound1Message1) ProtoMessage() {}
 
 func (x *SignRound1Message1) ProtoReflect() protoreflect.Message {
-	mi := &file_sign_proto_msgTypes[0]
+	mi := &file_protocols_cggmp_ecdsa_sign_sign_proto_msgTypes[0]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -59,7 +59,7 @@ func (x *SignRound1Message1) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SignRound1Message1.ProtoReflect.Descriptor instead.
 func (*SignRound1Message1) Descriptor() ([]byte, []int) {
-	return file_sign_proto_rawDescGZIP(), []int{0}
+	return file_protocols_cggmp_ecdsa_sign_sign_proto_rawDescGZIP(), []int{0}
 }
 
 func (x *SignRound1Message1) GetKCiphertext() []byte {
@@ -88,7 +88,7 @@ type SignRound1Message2 struct {
 func (x *SignRound1Message2) Reset() {
 	*x = SignRound1Message2{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_sign_proto_msgTypes[1]
+		mi := &file_protocols_cggmp_ecdsa_sign_sign_proto_msgTypes[1]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -101,7 +101,7 @@ func (x *SignRound1Message2) String() string {
 func (*SignRound1Message2) ProtoMessage() {}
 
 func (x *SignRound1Message2) ProtoReflect() protoreflect.Message {
-	mi := &file_sign_proto_msgTypes[1]
+	mi := &file_protocols_cggmp_ecdsa_sign_sign_proto_msgTypes[1]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -114,7 +114,7 @@ func (x *SignRound1Message2) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SignRound1Message2.ProtoReflect.Descriptor instead.
 func (*SignRound1Message2) Descriptor() ([]byte, []int) {
-	return file_sign_proto_rawDescGZIP(), []int{1}
+	return file_protocols_cggmp_ecdsa_sign_sign_proto_rawDescGZIP(), []int{1}
 }
 
 func (x *SignRound1Message2) GetEncProof() []byte {
@@ -139,12 +139,17 @@ type SignRound2Message struct {
 	AffgProof    []byte `protobuf:"bytes,7,opt,name=affg_proof,json=affgProof,proto3" json:"affg_proof,omitempty"`
 	AffgHatProof []byte `protobuf:"bytes,8,opt,name=affg_hat_proof,json=affgHatProof,proto3" json:"affg_hat_proof,omitempty"`
 	LogProof     []byte `protobuf:"bytes,9,opt,name=log_proof,json=logProof,proto3" json:"log_proof,omitempty"`
+	// the share γi * T of the adaptor nonce and its DLEQ proof against Γi, for a presignature with an adaptor point T
+	AdaptorGamma  []byte `protobuf:"bytes,10,opt,name=adaptor_gamma,json=adaptorGamma,proto3" json:"adaptor_gamma,omitempty"`
+	AdaptorProofA []byte `protobuf:"bytes,11,opt,name=adaptor_proof_a,json=adaptorProofA,proto3" json:"adaptor_proof_a,omitempty"`
+	AdaptorProofB []byte `protobuf:"bytes,12,opt,name=adaptor_proof_b,json=adaptorProofB,proto3" json:"adaptor_proof_b,omitempty"`
+	AdaptorProofZ []byte `protobuf:"bytes,13,opt,name=adaptor_proof_z,json=adaptorProofZ,proto3" json:"adaptor_proof_z,omitempty"`
 }
 
 func (x *SignRound2Message) Reset() {
 	*x = SignRound2Message{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_sign_proto_msgTypes[2]
+		mi := &file_protocols_cggmp_ecdsa_sign_sign_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -157,7 +162,7 @@ func (x *SignRound2Message) String() string {
 func (*SignRound2Message) ProtoMessage() {}
 
 func (x *SignRound2Message) ProtoReflect() protoreflect.Message {
-	mi := &file_sign_proto_msgTypes[2]
+	mi := &file_protocols_cggmp_ecdsa_sign_sign_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -170,7 +175,7 @@ func (x *SignRound2Message) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SignRound2Message.ProtoReflect.Descriptor instead.
 func (*SignRound2Message) Descriptor() ([]byte, []int) {
-	return file_sign_proto_rawDescGZIP(), []int{2}
+	return file_protocols_cggmp_ecdsa_sign_sign_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *SignRound2Message) GetSsid() []byte {
@@ -236,6 +241,34 @@ func (x *SignRound2Message) GetLogProof() []byte {
 	return nil
 }
 
+func (x *SignRound2Message) GetAdaptorGamma() []byte {
+	if x != nil {
+		return x.AdaptorGamma
+	}
+	return nil
+}
+
+func (x *SignRound2Message) GetAdaptorProofA() []byte {
+	if x != nil {
+		return x.AdaptorProofA
+	}
+	return nil
+}
+
+func (x *SignRound2Message) GetAdaptorProofB() []byte {
+	if x != nil {
+		return x.AdaptorProofB
+	}
+	return nil
+}
+
+func (x *SignRound2Message) GetAdaptorProofZ() []byte {
+	if x != nil {
+		return x.AdaptorProofZ
+	}
+	return nil
+}
+
 // Represents a P2P message sent to all parties during Round 3 of the TSS signing protocol.
 type SignRound3Message struct {
 	state         protoimpl.MessageState
@@ -245,12 +278,18 @@ type SignRound3Message struct {
 	Delta    []byte `protobuf:"bytes,1,opt,name=delta,proto3" json:"delta,omitempty"`
 	BigDelta []byte `protobuf:"bytes,2,opt,name=big_delta,json=bigDelta,proto3" json:"big_delta,omitempty"`
 	LogProof []byte `protobuf:"bytes,3,opt,name=log_proof,json=logProof,proto3" json:"log_proof,omitempty"`
+	// the nonce share ki in the exponent of G and of the public key X, with their log proofs against Ki,
+	// which identify the party whose σi does not verify
+	BigK    []byte `protobuf:"bytes,4,opt,name=big_k,json=bigK,proto3" json:"big_k,omitempty"`
+	BigKX   []byte `protobuf:"bytes,5,opt,name=big_k_x,json=bigKX,proto3" json:"big_k_x,omitempty"`
+	KProof  []byte `protobuf:"bytes,6,opt,name=k_proof,json=kProof,proto3" json:"k_proof,omitempty"`
+	KXProof []byte `protobuf:"bytes,7,opt,name=k_x_proof,json=kXProof,proto3" json:"k_x_proof,omitempty"`
 }
 
 func (x *SignRound3Message) Reset() {
 	*x = SignRound3Message{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_sign_proto_msgTypes[3]
+		mi := &file_protocols_cggmp_ecdsa_sign_sign_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -263,7 +302,7 @@ func (x *SignRound3Message) String() string {
 func (*SignRound3Message) ProtoMessage() {}
 
 func (x *SignRound3Message) ProtoReflect() protoreflect.Message {
-	mi := &file_sign_proto_msgTypes[3]
+	mi := &file_protocols_cggmp_ecdsa_sign_sign_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -276,7 +315,7 @@ func (x *SignRound3Message) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SignRound3Message.ProtoReflect.Descriptor instead.
 func (*SignRound3Message) Descriptor() ([]byte, []int) {
-	return file_sign_proto_rawDescGZIP(), []int{3}
+	return file_protocols_cggmp_ecdsa_sign_sign_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *SignRound3Message) GetDelta() []byte {
@@ -300,6 +339,124 @@ func (x *SignRound3Message) GetLogProof() []byte {
 	return nil
 }
 
+func (x *SignRound3Message) GetBigK() []byte {
+	if x != nil {
+		return x.BigK
+	}
+	return nil
+}
+
+func (x *SignRound3Message) GetBigKX() []byte {
+	if x != nil {
+		return x.BigKX
+	}
+	return nil
+}
+
+func (x *SignRound3Message) GetKProof() []byte {
+	if x != nil {
+		return x.KProof
+	}
+	return nil
+}
+
+func (x *SignRound3Message) GetKXProof() []byte {
+	if x != nil {
+		return x.KXProof
+	}
+	return nil
+}
+
+// Represents a BROADCAST message sent to all parties during Round 3 of the TSS signing protocol.
+// It has the ciphertexts Fi,j of the MtA masks of the party for every Pj, empty for Pi, the masks in the exponent
+// and their log proofs, which identify the party whose δi or σi does not verify.
+type SignRound3Message2 struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	F            [][]byte `protobuf:"bytes,1,rep,name=f,proto3" json:"f,omitempty"`
+	Mask         [][]byte `protobuf:"bytes,2,rep,name=mask,proto3" json:"mask,omitempty"`
+	MaskProof    [][]byte `protobuf:"bytes,3,rep,name=mask_proof,json=maskProof,proto3" json:"mask_proof,omitempty"`
+	FHat         [][]byte `protobuf:"bytes,4,rep,name=f_hat,json=fHat,proto3" json:"f_hat,omitempty"`
+	MaskHat      [][]byte `protobuf:"bytes,5,rep,name=mask_hat,json=maskHat,proto3" json:"mask_hat,omitempty"`
+	MaskHatProof [][]byte `protobuf:"bytes,6,rep,name=mask_hat_proof,json=maskHatProof,proto3" json:"mask_hat_proof,omitempty"`
+}
+
+func (x *SignRound3Message2) Reset() {
+	*x = SignRound3Message2{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocols_cggmp_ecdsa_sign_sign_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SignRound3Message2) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignRound3Message2) ProtoMessage() {}
+
+func (x *SignRound3Message2) ProtoReflect() protoreflect.Message {
+	mi := &file_protocols_cggmp_ecdsa_sign_sign_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignRound3Message2.ProtoReflect.Descriptor instead.
+func (*SignRound3Message2) Descriptor() ([]byte, []int) {
+	return file_protocols_cggmp_ecdsa_sign_sign_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *SignRound3Message2) GetF() [][]byte {
+	if x != nil {
+		return x.F
+	}
+	return nil
+}
+
+func (x *SignRound3Message2) GetMask() [][]byte {
+	if x != nil {
+		return x.Mask
+	}
+	return nil
+}
+
+func (x *SignRound3Message2) GetMaskProof() [][]byte {
+	if x != nil {
+		return x.MaskProof
+	}
+	return nil
+}
+
+func (x *SignRound3Message2) GetFHat() [][]byte {
+	if x != nil {
+		return x.FHat
+	}
+	return nil
+}
+
+func (x *SignRound3Message2) GetMaskHat() [][]byte {
+	if x != nil {
+		return x.MaskHat
+	}
+	return nil
+}
+
+func (x *SignRound3Message2) GetMaskHatProof() [][]byte {
+	if x != nil {
+		return x.MaskHatProof
+	}
+	return nil
+}
+
 // Represents a BROADCAST message sent to all parties during Round 4 of the TSS signing protocol.
 type SignRound4Message struct {
 	state         protoimpl.MessageState
@@ -312,7 +469,7 @@ type SignRound4Message struct {
 func (x *SignRound4Message) Reset() {
 	*x = SignRound4Message{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_sign_proto_msgTypes[4]
+		mi := &file_protocols_cggmp_ecdsa_sign_sign_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -325,7 +482,7 @@ func (x *SignRound4Message) String() string {
 func (*SignRound4Message) ProtoMessage() {}
 
 func (x *SignRound4Message) ProtoReflect() protoreflect.Message {
-	mi := &file_sign_proto_msgTypes[4]
+	mi := &file_protocols_cggmp_ecdsa_sign_sign_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -338,7 +495,7 @@ func (x *SignRound4Message) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SignRound4Message.ProtoReflect.Descriptor instead.
 func (*SignRound4Message) Descriptor() ([]byte, []int) {
-	return file_sign_proto_rawDescGZIP(), []int{4}
+	return file_protocols_cggmp_ecdsa_sign_sign_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *SignRound4Message) GetSigma() []byte {
@@ -348,70 +505,99 @@ func (x *SignRound4Message) GetSigma() []byte {
 	return nil
 }
 
-var File_sign_proto protoreflect.FileDescriptor
-
-var file_sign_proto_rawDesc = []byte{
-	0x0a, 0x0a, 0x73, 0x69, 0x67, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x17, 0x74, 0x73,
-	0x73, 0x6c, 0x69, 0x62, 0x2e, 0x63, 0x67, 0x67, 0x6d, 0x70, 0x2e, 0x73, 0x69, 0x67, 0x6e, 0x2e,
-	0x65, 0x63, 0x64, 0x73, 0x61, 0x22, 0x62, 0x0a, 0x12, 0x73, 0x69, 0x67, 0x6e, 0x52, 0x6f, 0x75,
-	0x6e, 0x64, 0x31, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x31, 0x12, 0x21, 0x0a, 0x0c, 0x6b,
-	0x5f, 0x63, 0x69, 0x70, 0x68, 0x65, 0x72, 0x74, 0x65, 0x78, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x0c, 0x52, 0x0b, 0x6b, 0x43, 0x69, 0x70, 0x68, 0x65, 0x72, 0x74, 0x65, 0x78, 0x74, 0x12, 0x29,
-	0x0a, 0x10, 0x67, 0x61, 0x6d, 0x6d, 0x61, 0x5f, 0x63, 0x69, 0x70, 0x68, 0x65, 0x72, 0x74, 0x65,
-	0x78, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0f, 0x67, 0x61, 0x6d, 0x6d, 0x61, 0x43,
-	0x69, 0x70, 0x68, 0x65, 0x72, 0x74, 0x65, 0x78, 0x74, 0x22, 0x31, 0x0a, 0x12, 0x73, 0x69, 0x67,
-	0x6e, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x31, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x32, 0x12,
-	0x1b, 0x0a, 0x09, 0x65, 0x6e, 0x63, 0x5f, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0c, 0x52, 0x08, 0x65, 0x6e, 0x63, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x22, 0xec, 0x01, 0x0a,
-	0x11, 0x73, 0x69, 0x67, 0x6e, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x32, 0x4d, 0x65, 0x73, 0x73, 0x61,
-	0x67, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x73, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c,
-	0x52, 0x04, 0x73, 0x73, 0x69, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x62, 0x69, 0x67, 0x5f, 0x67, 0x61,
-	0x6d, 0x6d, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x62, 0x69, 0x67, 0x47, 0x61,
-	0x6d, 0x6d, 0x61, 0x12, 0x0c, 0x0a, 0x01, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x01,
-	0x64, 0x12, 0x0c, 0x0a, 0x01, 0x66, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x01, 0x66, 0x12,
-	0x13, 0x0a, 0x05, 0x64, 0x5f, 0x68, 0x61, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04,
-	0x64, 0x48, 0x61, 0x74, 0x12, 0x13, 0x0a, 0x05, 0x66, 0x5f, 0x68, 0x61, 0x74, 0x18, 0x06, 0x20,
-	0x01, 0x28, 0x0c, 0x52, 0x04, 0x66, 0x48, 0x61, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x61, 0x66, 0x66,
-	0x67, 0x5f, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x61,
-	0x66, 0x66, 0x67, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x12, 0x24, 0x0a, 0x0e, 0x61, 0x66, 0x66, 0x67,
-	0x5f, 0x68, 0x61, 0x74, 0x5f, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0c,
-	0x52, 0x0c, 0x61, 0x66, 0x66, 0x67, 0x48, 0x61, 0x74, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x12, 0x1b,
-	0x0a, 0x09, 0x6c, 0x6f, 0x67, 0x5f, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x18, 0x09, 0x20, 0x01, 0x28,
-	0x0c, 0x52, 0x08, 0x6c, 0x6f, 0x67, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x22, 0x63, 0x0a, 0x11, 0x73,
-	0x69, 0x67, 0x6e, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x33, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
-	0x12, 0x14, 0x0a, 0x05, 0x64, 0x65, 0x6c, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52,
-	0x05, 0x64, 0x65, 0x6c, 0x74, 0x61, 0x12, 0x1b, 0x0a, 0x09, 0x62, 0x69, 0x67, 0x5f, 0x64, 0x65,
-	0x6c, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x62, 0x69, 0x67, 0x44, 0x65,
-	0x6c, 0x74, 0x61, 0x12, 0x1b, 0x0a, 0x09, 0x6c, 0x6f, 0x67, 0x5f, 0x70, 0x72, 0x6f, 0x6f, 0x66,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x6c, 0x6f, 0x67, 0x50, 0x72, 0x6f, 0x6f, 0x66,
-	0x22, 0x29, 0x0a, 0x11, 0x53, 0x69, 0x67, 0x6e, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x34, 0x4d, 0x65,
-	0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x69, 0x67, 0x6d, 0x61, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x73, 0x69, 0x67, 0x6d, 0x61, 0x42, 0x0c, 0x5a, 0x0a, 0x65,
-	0x63, 0x64, 0x73, 0x61, 0x2f, 0x73, 0x69, 0x67, 0x6e, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x33,
+var File_protocols_cggmp_ecdsa_sign_sign_proto protoreflect.FileDescriptor
+
+var file_protocols_cggmp_ecdsa_sign_sign_proto_rawDesc = []byte{
+	0x0a, 0x25, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x73, 0x2f, 0x63, 0x67, 0x67, 0x6d,
+	0x70, 0x2f, 0x65, 0x63, 0x64, 0x73, 0x61, 0x2f, 0x73, 0x69, 0x67, 0x6e, 0x2f, 0x73, 0x69, 0x67,
+	0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x17, 0x74, 0x73, 0x73, 0x6c, 0x69, 0x62, 0x2e,
+	0x63, 0x67, 0x67, 0x6d, 0x70, 0x2e, 0x73, 0x69, 0x67, 0x6e, 0x2e, 0x65, 0x63, 0x64, 0x73, 0x61,
+	0x22, 0x62, 0x0a, 0x12, 0x53, 0x69, 0x67, 0x6e, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x31, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x31, 0x12, 0x21, 0x0a, 0x0c, 0x6b, 0x5f, 0x63, 0x69, 0x70, 0x68,
+	0x65, 0x72, 0x74, 0x65, 0x78, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0b, 0x6b, 0x43,
+	0x69, 0x70, 0x68, 0x65, 0x72, 0x74, 0x65, 0x78, 0x74, 0x12, 0x29, 0x0a, 0x10, 0x67, 0x61, 0x6d,
+	0x6d, 0x61, 0x5f, 0x63, 0x69, 0x70, 0x68, 0x65, 0x72, 0x74, 0x65, 0x78, 0x74, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x0f, 0x67, 0x61, 0x6d, 0x6d, 0x61, 0x43, 0x69, 0x70, 0x68, 0x65, 0x72,
+	0x74, 0x65, 0x78, 0x74, 0x22, 0x31, 0x0a, 0x12, 0x53, 0x69, 0x67, 0x6e, 0x52, 0x6f, 0x75, 0x6e,
+	0x64, 0x31, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x32, 0x12, 0x1b, 0x0a, 0x09, 0x65, 0x6e,
+	0x63, 0x5f, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x65,
+	0x6e, 0x63, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x22, 0x89, 0x03, 0x0a, 0x11, 0x53, 0x69, 0x67, 0x6e,
+	0x52, 0x6f, 0x75, 0x6e, 0x64, 0x32, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x12, 0x0a,
+	0x04, 0x73, 0x73, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x73, 0x73, 0x69,
+	0x64, 0x12, 0x1b, 0x0a, 0x09, 0x62, 0x69, 0x67, 0x5f, 0x67, 0x61, 0x6d, 0x6d, 0x61, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x62, 0x69, 0x67, 0x47, 0x61, 0x6d, 0x6d, 0x61, 0x12, 0x0c,
+	0x0a, 0x01, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x01, 0x64, 0x12, 0x0c, 0x0a, 0x01,
+	0x66, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x01, 0x66, 0x12, 0x13, 0x0a, 0x05, 0x64, 0x5f,
+	0x68, 0x61, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x48, 0x61, 0x74, 0x12,
+	0x13, 0x0a, 0x05, 0x66, 0x5f, 0x68, 0x61, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04,
+	0x66, 0x48, 0x61, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x61, 0x66, 0x66, 0x67, 0x5f, 0x70, 0x72, 0x6f,
+	0x6f, 0x66, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x61, 0x66, 0x66, 0x67, 0x50, 0x72,
+	0x6f, 0x6f, 0x66, 0x12, 0x24, 0x0a, 0x0e, 0x61, 0x66, 0x66, 0x67, 0x5f, 0x68, 0x61, 0x74, 0x5f,
+	0x70, 0x72, 0x6f, 0x6f, 0x66, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x61, 0x66, 0x66,
+	0x67, 0x48, 0x61, 0x74, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x12, 0x1b, 0x0a, 0x09, 0x6c, 0x6f, 0x67,
+	0x5f, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x6c, 0x6f,
+	0x67, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x12, 0x23, 0x0a, 0x0d, 0x61, 0x64, 0x61, 0x70, 0x74, 0x6f,
+	0x72, 0x5f, 0x67, 0x61, 0x6d, 0x6d, 0x61, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x61,
+	0x64, 0x61, 0x70, 0x74, 0x6f, 0x72, 0x47, 0x61, 0x6d, 0x6d, 0x61, 0x12, 0x26, 0x0a, 0x0f, 0x61,
+	0x64, 0x61, 0x70, 0x74, 0x6f, 0x72, 0x5f, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5f, 0x61, 0x18, 0x0b,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x0d, 0x61, 0x64, 0x61, 0x70, 0x74, 0x6f, 0x72, 0x50, 0x72, 0x6f,
+	0x6f, 0x66, 0x41, 0x12, 0x26, 0x0a, 0x0f, 0x61, 0x64, 0x61, 0x70, 0x74, 0x6f, 0x72, 0x5f, 0x70,
+	0x72, 0x6f, 0x6f, 0x66, 0x5f, 0x62, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0d, 0x61, 0x64,
+	0x61, 0x70, 0x74, 0x6f, 0x72, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x42, 0x12, 0x26, 0x0a, 0x0f, 0x61,
+	0x64, 0x61, 0x70, 0x74, 0x6f, 0x72, 0x5f, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5f, 0x7a, 0x18, 0x0d,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x0d, 0x61, 0x64, 0x61, 0x70, 0x74, 0x6f, 0x72, 0x50, 0x72, 0x6f,
+	0x6f, 0x66, 0x5a, 0x22, 0xc5, 0x01, 0x0a, 0x11, 0x53, 0x69, 0x67, 0x6e, 0x52, 0x6f, 0x75, 0x6e,
+	0x64, 0x33, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x64, 0x65, 0x6c,
+	0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x64, 0x65, 0x6c, 0x74, 0x61, 0x12,
+	0x1b, 0x0a, 0x09, 0x62, 0x69, 0x67, 0x5f, 0x64, 0x65, 0x6c, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x08, 0x62, 0x69, 0x67, 0x44, 0x65, 0x6c, 0x74, 0x61, 0x12, 0x1b, 0x0a, 0x09,
+	0x6c, 0x6f, 0x67, 0x5f, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x08, 0x6c, 0x6f, 0x67, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x12, 0x13, 0x0a, 0x05, 0x62, 0x69, 0x67,
+	0x5f, 0x6b, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x62, 0x69, 0x67, 0x4b, 0x12, 0x16,
+	0x0a, 0x07, 0x62, 0x69, 0x67, 0x5f, 0x6b, 0x5f, 0x78, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x05, 0x62, 0x69, 0x67, 0x4b, 0x58, 0x12, 0x17, 0x0a, 0x07, 0x6b, 0x5f, 0x70, 0x72, 0x6f, 0x6f,
+	0x66, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x6b, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x12,
+	0x1a, 0x0a, 0x09, 0x6b, 0x5f, 0x78, 0x5f, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x07, 0x6b, 0x58, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x22, 0xab, 0x01, 0x0a, 0x12,
+	0x53, 0x69, 0x67, 0x6e, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x33, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x32, 0x12, 0x0c, 0x0a, 0x01, 0x66, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x01, 0x66,
+	0x12, 0x12, 0x0a, 0x04, 0x6d, 0x61, 0x73, 0x6b, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x04,
+	0x6d, 0x61, 0x73, 0x6b, 0x12, 0x1d, 0x0a, 0x0a, 0x6d, 0x61, 0x73, 0x6b, 0x5f, 0x70, 0x72, 0x6f,
+	0x6f, 0x66, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x09, 0x6d, 0x61, 0x73, 0x6b, 0x50, 0x72,
+	0x6f, 0x6f, 0x66, 0x12, 0x13, 0x0a, 0x05, 0x66, 0x5f, 0x68, 0x61, 0x74, 0x18, 0x04, 0x20, 0x03,
+	0x28, 0x0c, 0x52, 0x04, 0x66, 0x48, 0x61, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x6d, 0x61, 0x73, 0x6b,
+	0x5f, 0x68, 0x61, 0x74, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x07, 0x6d, 0x61, 0x73, 0x6b,
+	0x48, 0x61, 0x74, 0x12, 0x24, 0x0a, 0x0e, 0x6d, 0x61, 0x73, 0x6b, 0x5f, 0x68, 0x61, 0x74, 0x5f,
+	0x70, 0x72, 0x6f, 0x6f, 0x66, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x0c, 0x6d, 0x61, 0x73,
+	0x6b, 0x48, 0x61, 0x74, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x22, 0x29, 0x0a, 0x11, 0x53, 0x69, 0x67,
+	0x6e, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x34, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x14,
+	0x0a, 0x05, 0x73, 0x69, 0x67, 0x6d, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x73,
+	0x69, 0x67, 0x6d, 0x61, 0x42, 0x0c, 0x5a, 0x0a, 0x65, 0x63, 0x64, 0x73, 0x61, 0x2f, 0x73, 0x69,
+	0x67, 0x6e, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
-	file_sign_proto_rawDescOnce sync.Once
-	file_sign_proto_rawDescData = file_sign_proto_rawDesc
+	file_protocols_cggmp_ecdsa_sign_sign_proto_rawDescOnce sync.Once
+	file_protocols_cggmp_ecdsa_sign_sign_proto_rawDescData = file_protocols_cggmp_ecdsa_sign_sign_proto_rawDesc
 )
 
-func file_sign_proto_rawDescGZIP() []byte {
-	file_sign_proto_rawDescOnce.Do(func() {
-		file_sign_proto_rawDescData = protoimpl.X.CompressGZIP(file_sign_proto_rawDescData)
+func file_protocols_cggmp_ecdsa_sign_sign_proto_rawDescGZIP() []byte {
+	file_protocols_cggmp_ecdsa_sign_sign_proto_rawDescOnce.Do(func() {
+		file_protocols_cggmp_ecdsa_sign_sign_proto_rawDescData = protoimpl.X.CompressGZIP(file_protocols_cggmp_ecdsa_sign_sign_proto_rawDescData)
 	})
-	return file_sign_proto_rawDescData
+	return file_protocols_cggmp_ecdsa_sign_sign_proto_rawDescData
 }
 
-var file_sign_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
-var file_sign_proto_goTypes = []interface{}{
-	(*SignRound1Message1)(nil), // 0: tsslib.cggmp.sign.ecdsa.signRound1Message1
-	(*SignRound1Message2)(nil), // 1: tsslib.cggmp.sign.ecdsa.signRound1Message2
-	(*SignRound2Message)(nil),  // 2: tsslib.cggmp.sign.ecdsa.signRound2Message
-	(*SignRound3Message)(nil),  // 3: tsslib.cggmp.sign.ecdsa.signRound3Message
-	(*SignRound4Message)(nil),  // 4: tsslib.cggmp.sign.ecdsa.SignRound4Message
+var file_protocols_cggmp_ecdsa_sign_sign_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_protocols_cggmp_ecdsa_sign_sign_proto_goTypes = []interface{}{
+	(*SignRound1Message1)(nil), // 0: tsslib.cggmp.sign.ecdsa.SignRound1Message1
+	(*SignRound1Message2)(nil), // 1: tsslib.cggmp.sign.ecdsa.SignRound1Message2
+	(*SignRound2Message)(nil),  // 2: tsslib.cggmp.sign.ecdsa.SignRound2Message
+	(*SignRound3Message)(nil),  // 3: tsslib.cggmp.sign.ecdsa.SignRound3Message
+	(*SignRound3Message2)(nil), // 4: tsslib.cggmp.sign.ecdsa.SignRound3Message2
+	(*SignRound4Message)(nil),  // 5: tsslib.cggmp.sign.ecdsa.SignRound4Message
 }
-var file_sign_proto_depIdxs = []int32{
+var file_protocols_cggmp_ecdsa_sign_sign_proto_depIdxs = []int32{
 	0, // [0:0] is the sub-list for method output_type
 	0, // [0:0] is the sub-list for method input_type
 	0, // [0:0] is the sub-list for extension type_name
@@ -419,13 +605,13 @@ var file_sign_proto_depIdxs = []int32{
 	0, // [0:0] is the sub-list for field type_name
 }
 
-func init() { file_sign_proto_init() }
-func file_sign_proto_init() {
-	if File_sign_proto != nil {
+func init() { file_protocols_cggmp_ecdsa_sign_sign_proto_init() }
+func file_protocols_cggmp_ecdsa_sign_sign_proto_init() {
+	if File_protocols_cggmp_ecdsa_sign_sign_proto != nil {
 		return
 	}
 	if !protoimpl.UnsafeEnabled {
-		file_sign_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+		file_protocols_cggmp_ecdsa_sign_sign_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*SignRound1Message1); i {
 			case 0:
 				return &v.state
@@ -437,7 +623,7 @@ func file_sign_proto_init() {
 				return nil
 			}
 		}
-		file_sign_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+		file_protocols_cggmp_ecdsa_sign_sign_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*SignRound1Message2); i {
 			case 0:
 				return &v.state
@@ -449,7 +635,7 @@ func file_sign_proto_init() {
 				return nil
 			}
 		}
-		file_sign_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+		file_protocols_cggmp_ecdsa_sign_sign_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*SignRound2Message); i {
 			case 0:
 				return &v.state
@@ -461,7 +647,7 @@ func file_sign_proto_init() {
 				return nil
 			}
 		}
-		file_sign_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+		file_protocols_cggmp_ecdsa_sign_sign_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*SignRound3Message); i {
 			case 0:
 				return &v.state
@@ -473,7 +659,19 @@ func file_sign_proto_init() {
 				return nil
 			}
 		}
-		file_sign_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+		file_protocols_cggmp_ecdsa_sign_sign_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SignRound3Message2); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocols_cggmp_ecdsa_sign_sign_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*SignRound4Message); i {
 			case 0:
 				return &v.state
@@ -490,18 +688,18 @@ func file_sign_proto_init() {
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
-			RawDescriptor: file_sign_proto_rawDesc,
+			RawDescriptor: file_protocols_cggmp_ecdsa_sign_sign_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   5,
+			NumMessages:   6,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
-		GoTypes:           file_sign_proto_goTypes,
-		DependencyIndexes: file_sign_proto_depIdxs,
-		MessageInfos:      file_sign_proto_msgTypes,
+		GoTypes:           file_protocols_cggmp_ecdsa_sign_sign_proto_goTypes,
+		DependencyIndexes: file_protocols_cggmp_ecdsa_sign_sign_proto_depIdxs,
+		MessageInfos:      file_protocols_cggmp_ecdsa_sign_sign_proto_msgTypes,
 	}.Build()
-	File_sign_proto = out.File
-	file_sign_proto_rawDesc = nil
-	file_sign_proto_goTypes = nil
-	file_sign_proto_depIdxs = nil
+	File_protocols_cggmp_ecdsa_sign_sign_proto = out.File
+	file_protocols_cggmp_ecdsa_sign_sign_proto_rawDesc = nil
+	file_protocols_cggmp_ecdsa_sign_sign_proto_goTypes = nil
+	file_protocols_cggmp_ecdsa_sign_sign_proto_depIdxs = nil
 }
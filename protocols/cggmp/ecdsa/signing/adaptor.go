@@ -0,0 +1,126 @@
+package signing
+
+import (
+	"crypto/elliptic"
+	"errors"
+	"math/big"
+
+	"github.com/felicityin/mpc-tss/common"
+	"github.com/felicityin/mpc-tss/crypto"
+	"github.com/felicityin/mpc-tss/protocols/cggmp/ecdsa/presign"
+	"github.com/felicityin/mpc-tss/tss"
+)
+
+// PreSignature is an ECDSA adaptor signature: s' = k * (m + r * x) with r = RT.x, which becomes the signature (r, s' / t)
+// with the discrete logarithm t of the adaptor point T, and gives away t to whoever has both.
+type PreSignature struct {
+	R        *crypto.ECPoint // k^-1 * G, the nonce point of the presignature
+	Adaptor  *presign.Adaptor
+	S        *big.Int
+	M        []byte
+	HashMode common.HashMode
+}
+
+// Verify checks the pre-signature against the public key, the group key or the child key that signed:
+// s'^-1 * (m * G + r * X) = R, and RT = k^-1 * T with the proof of the adaptor
+func (pre *PreSignature) Verify(pubkey *crypto.ECPoint) error {
+	if pre == nil || pre.R == nil || pre.S == nil || pubkey == nil || !tss.SameCurve(pubkey.Curve(), pre.R.Curve()) {
+		return errors.New("the pre-signature or the public key is missing")
+	}
+	if err := pre.Adaptor.Verify(pre.R); err != nil {
+		return err
+	}
+	ec := pubkey.Curve()
+	N := ec.Params().N
+	r := new(big.Int).Mod(pre.Adaptor.RT.X(), N)
+	if r.Sign() == 0 || pre.S.Sign() <= 0 || pre.S.Cmp(N) >= 0 {
+		return errors.New("the pre-signature has r or s' out of range")
+	}
+	modN := common.ModInt(N)
+	sInv := modN.ModInverse(pre.S)
+	mG := crypto.ScalarBaseMult(ec, modN.Mul(new(big.Int).SetBytes(pre.M), sInv))
+	R, err := mG.Add(pubkey.ScalarMult(modN.Mul(r, sInv)))
+	if err != nil {
+		return err
+	}
+	if !R.Equals(pre.R) {
+		return errors.New("the pre-signature does not verify against the public key")
+	}
+	return nil
+}
+
+// Complete returns the signature of the pre-signature with the discrete logarithm t of its adaptor point
+func (pre *PreSignature) Complete(t *big.Int) (*common.SignatureData, error) {
+	if pre == nil || pre.Adaptor == nil || pre.S == nil || t == nil {
+		return nil, errors.New("the pre-signature or t is missing")
+	}
+	ec := pre.Adaptor.T.Curve()
+	if !crypto.ScalarBaseMult(ec, t).Equals(pre.Adaptor.T) {
+		return nil, errors.New("t is not the discrete logarithm of the adaptor point")
+	}
+	modN := common.ModInt(ec.Params().N)
+	data := NewSignatureData(ec, pre.Adaptor.RT, modN.Mul(pre.S, modN.ModInverse(t)), pre.M)
+	data.HashMode = pre.HashMode
+	return data, nil
+}
+
+// Extract returns the discrete logarithm t of the adaptor point from the signature that completed the pre-signature
+func (pre *PreSignature) Extract(sig *common.SignatureData) (*big.Int, error) {
+	if pre == nil || pre.Adaptor == nil || pre.S == nil || sig == nil {
+		return nil, errors.New("the pre-signature or the signature is missing")
+	}
+	ec := pre.Adaptor.T.Curve()
+	N := ec.Params().N
+	s := new(big.Int).SetBytes(sig.S)
+	if new(big.Int).SetBytes(sig.R).Cmp(new(big.Int).Mod(pre.Adaptor.RT.X(), N)) != 0 || s.Sign() == 0 || s.Cmp(N) >= 0 {
+		return nil, errors.New("the signature is not of the nonce point of the pre-signature")
+	}
+	modN := common.ModInt(N)
+	t := modN.Mul(pre.S, modN.ModInverse(s))
+	// the signature may have been normalized to the low S form, with -s
+	if !crypto.ScalarBaseMult(ec, t).Equals(pre.Adaptor.T) {
+		t.Sub(N, t)
+		if !crypto.ScalarBaseMult(ec, t).Equals(pre.Adaptor.T) {
+			return nil, errors.New("the signature does not complete the pre-signature")
+		}
+	}
+	return t, nil
+}
+
+// NewSignatureData returns the signature (R.x, s) of m, in the low S form on secp256k1, with its recovery id
+func NewSignatureData(ec elliptic.Curve, R *crypto.ECPoint, s *big.Int, m []byte) *common.SignatureData {
+	N := ec.Params().N
+	r := new(big.Int).Mod(R.X(), N)
+	s = new(big.Int).Set(s)
+	recid := 0
+	// byte v = if(R.X >= curve.N) then 2 else 0) | (if R.Y.IsEven then 0 else 1);
+	if R.X().Cmp(N) >= 0 {
+		recid = 2
+	}
+	if R.Y().Bit(0) != 0 {
+		recid |= 1
+	}
+
+	// The low S form is a convention of secp256k1, the signatures on other curves such as P-256 are left as they are.
+	// This is copied from:
+	// https://github.com/btcsuite/btcd/blob/c26ffa870fd817666a857af1bf6498fabba1ffe3/btcec/signature.go#L442-L444
+	// This is needed because of tendermint checks here:
+	// https://github.com/tendermint/tendermint/blob/d9481e3648450cb99e15c6a070c1fb69aa0c255b/crypto/secp256k1/secp256k1_nocgo.go#L43-L47
+	if tss.SameCurve(ec, tss.S256()) {
+		secp256k1halfN := new(big.Int).Rsh(N, 1)
+		if s.Cmp(secp256k1halfN) > 0 {
+			s.Sub(N, s)
+			recid ^= 1
+		}
+	}
+
+	bitSizeInBytes := (ec.Params().BitSize + 7) / 8
+	data := &common.SignatureData{
+		R:                 padToLengthBytesInPlace(r.Bytes(), bitSizeInBytes),
+		S:                 padToLengthBytesInPlace(s.Bytes(), bitSizeInBytes),
+		SignatureRecovery: []byte{byte(recid)},
+		M:                 m,
+	}
+	data.Signature = append(append([]byte{}, data.R...), data.S...)
+	return data
+}
@@ -1,6 +1,7 @@
 package signing
 
 import (
+	"errors"
 	"fmt"
 	"math/big"
 
@@ -28,8 +29,9 @@ type (
 		data *common.SignatureData
 
 		// outbound messaging
-		out chan<- tss.Message
-		end chan<- *common.SignatureData
+		out    chan<- tss.Message
+		end    chan<- *common.SignatureData
+		preEnd chan<- *PreSignature
 	}
 
 	localMessageStore struct {
@@ -62,6 +64,52 @@ func NewLocalParty(
 	out chan<- tss.Message,
 	end chan<- *common.SignatureData,
 	fullBytesLen ...int,
+) (tss.Party, error) {
+	if pre.Adaptor != nil {
+		return nil, errors.New("the presignature makes pre-signatures for an adaptor point, see NewLocalPartyWithAdaptor")
+	}
+	return newLocalParty(msg, isThreshold, params, path, key, pre, out, end, nil, fullBytesLen...)
+}
+
+// NewLocalPartyWithAdaptor returns a party that makes the pre-signature of the digest of the message
+// with a presignature of presign.NewLocalPartyWithAdaptor, which it outputs on end once it is checked against the key.
+// The pre-signature is completed into a signature with the discrete logarithm of the adaptor point, see PreSignature.
+func NewLocalPartyWithAdaptor(
+	msg *common.Message,
+	isThreshold bool,
+	params *tss.Parameters,
+	path string,
+	key keygen.LocalPartySaveData,
+	pre presign.LocalPartySaveData,
+	out chan<- tss.Message,
+	end chan<- *PreSignature,
+) (tss.Party, error) {
+	if pre.Adaptor == nil {
+		return nil, errors.New("the presignature has no adaptor point")
+	}
+	digest, err := msg.ECDSADigest(params.EC())
+	if err != nil {
+		return nil, err
+	}
+	party, err := newLocalParty(new(big.Int).SetBytes(digest), isThreshold, params, path, key, pre, out, nil, end, len(digest))
+	if err != nil {
+		return nil, err
+	}
+	party.(*LocalParty).data.HashMode = msg.Mode
+	return party, nil
+}
+
+func newLocalParty(
+	msg *big.Int,
+	isThreshold bool,
+	params *tss.Parameters,
+	path string,
+	key keygen.LocalPartySaveData,
+	pre presign.LocalPartySaveData,
+	out chan<- tss.Message,
+	end chan<- *common.SignatureData,
+	preEnd chan<- *PreSignature,
+	fullBytesLen ...int,
 ) (tss.Party, error) {
 	key, err := keygen.BuildLocalSaveDataSubset(key, params.Parties().IDs())
 	if err != nil {
@@ -71,7 +119,7 @@ func NewLocalParty(
 	if err != nil {
 		return nil, err
 	}
-	err = PrepareForSigning(&key, &pre, path, isThreshold, params.Threshold())
+	err = PrepareForSigning(&key, &pre, path, isThreshold, params.Threshold(), params.KeyTweaks()...)
 	if err != nil {
 		return nil, err
 	}
@@ -85,6 +133,7 @@ func NewLocalParty(
 		data:      &common.SignatureData{},
 		out:       out,
 		end:       end,
+		preEnd:    preEnd,
 	}
 	// msgs init
 	p.temp.signRound1Messages = make([]tss.ParsedMessage, partyCount)
@@ -100,8 +149,32 @@ func NewLocalParty(
 	return p, nil
 }
 
+// NewLocalPartyWithMessage returns a party that signs the digest of the message, in the hash mode of the message:
+// PREHASHED, SHA256 or KECCAK256. The digest keeps its leading zero bytes, and its length is checked against the curve.
+func NewLocalPartyWithMessage(
+	msg *common.Message,
+	isThreshold bool,
+	params *tss.Parameters,
+	path string,
+	key keygen.LocalPartySaveData,
+	pre presign.LocalPartySaveData,
+	out chan<- tss.Message,
+	end chan<- *common.SignatureData,
+) (tss.Party, error) {
+	digest, err := msg.ECDSADigest(params.EC())
+	if err != nil {
+		return nil, err
+	}
+	party, err := NewLocalParty(new(big.Int).SetBytes(digest), isThreshold, params, path, key, pre, out, end, len(digest))
+	if err != nil {
+		return nil, err
+	}
+	party.(*LocalParty).data.HashMode = msg.Mode
+	return party, nil
+}
+
 func (p *LocalParty) FirstRound() tss.Round {
-	return newRound1(p.temp.isThreshold, p.params, &p.key, &p.pre, p.data, &p.temp, p.out, p.end)
+	return newRound1(p.temp.isThreshold, p.params, &p.key, &p.pre, p.data, &p.temp, p.out, p.end, p.preEnd)
 }
 
 func (p *LocalParty) Start() *tss.Error {
@@ -4,6 +4,7 @@ package signing
 
 import (
 	"crypto/ecdsa"
+	"crypto/rand"
 	"encoding/hex"
 	"fmt"
 	"math/big"
@@ -14,11 +15,15 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/felicityin/mpc-tss/common"
+	"github.com/felicityin/mpc-tss/crypto"
+	"github.com/felicityin/mpc-tss/crypto/sigfmt"
+	"github.com/felicityin/mpc-tss/protocols/cggmp/auxiliary"
 	"github.com/felicityin/mpc-tss/protocols/cggmp/ecdsa/presign"
 	"github.com/felicityin/mpc-tss/protocols/cggmp/keygen"
 	nonKeygen "github.com/felicityin/mpc-tss/protocols/cggmp/keygen/non_threshold"
 	tKeygen "github.com/felicityin/mpc-tss/protocols/cggmp/keygen/threshold"
 	"github.com/felicityin/mpc-tss/protocols/cggmp/test"
+	"github.com/felicityin/mpc-tss/protocols/utils"
 	"github.com/felicityin/mpc-tss/tss"
 )
 
@@ -141,15 +146,24 @@ SIGN:
 }
 
 func TestE2EThresholdConcurrent(t *testing.T) {
+	testE2EThresholdConcurrent(t, keygen.Ecdsa)
+}
+
+func TestE2EP256Concurrent(t *testing.T) {
+	testE2EThresholdConcurrent(t, keygen.EcdsaP256)
+}
+
+func testE2EThresholdConcurrent(t *testing.T, kind int) {
 	setUp("debug")
 
 	threshold := testThreshold
+	ec := tKeygen.TestCurve(kind)
 
 	// PHASE: load keygen fixtures
-	keys, signPIDs, err := tKeygen.LoadKeygenTestFixturesRandomSet(keygen.Ecdsa, testThreshold+1, testParticipants)
+	keys, signPIDs, err := tKeygen.LoadKeygenTestFixturesRandomSet(kind, testThreshold+1, testParticipants)
 	assert.NoError(t, err, "should load keygen fixtures")
 
-	pres, _, err := presign.LoadPreTestFixtures(true, testThreshold+1)
+	pres, _, err := presign.LoadPreTestFixturesOfKind(kind, true, testThreshold+1)
 	assert.NoError(t, err, "should load aux fixtures")
 
 	// PHASE: signing
@@ -167,17 +181,16 @@ func TestE2EThresholdConcurrent(t *testing.T) {
 
 	// init the parties
 	for i := 0; i < len(signPIDs); i++ {
-		params := tss.NewParameters(tss.S256(), p2pCtx, signPIDs[i], len(signPIDs), threshold)
+		params := tss.NewParameters(ec, p2pCtx, signPIDs[i], len(signPIDs), threshold)
 		party, err := NewLocalParty(new(big.Int).SetBytes(msg), false, params, path, keys[i], pres[i], outCh, endCh)
 		assert.NoError(t, err)
-		P := party.(*LocalParty)
-		parties = append(parties, P)
-
-		go func(P *LocalParty) {
-			if err := P.Start(); err != nil {
-				errCh <- err
-			}
-		}(P)
+		parties = append(parties, party.(*LocalParty))
+	}
+	// start every party before routing the messages, so that no party receives all the messages of round 1 before it starts
+	for _, P := range parties {
+		if err := P.Start(); err != nil {
+			assert.FailNow(t, err.Error())
+		}
 	}
 
 	var ended int32
@@ -214,7 +227,7 @@ SIGN:
 				r := parties[0].pre.R.X()
 				fmt.Printf("sign result: R(%s, %s), r=%s\n", R.X().String(), R.Y().String(), r.String())
 
-				modN := common.ModInt(tss.S256().Params().N)
+				modN := common.ModInt(ec.Params().N)
 
 				// BEGIN check s correctness
 				sumS := big.NewInt(0)
@@ -226,7 +239,7 @@ SIGN:
 
 				// BEGIN ECDSA verify
 				pk := ecdsa.PublicKey{
-					Curve: tss.S256(),
+					Curve: ec,
 					X:     parties[0].key.Pubkey.X(),
 					Y:     parties[0].key.Pubkey.Y(),
 				}
@@ -240,3 +253,195 @@ SIGN:
 		}
 	}
 }
+
+func TestE2ETweakedConcurrent(t *testing.T) {
+	setUp("info")
+
+	keys, signPIDs, err := tKeygen.LoadKeygenTestFixturesRandomSet(keygen.Ecdsa, testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	pres, _, err := presign.LoadPreTestFixtures(true, testThreshold+1)
+	assert.NoError(t, err, "should load aux fixtures")
+
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	parties := make([]*LocalParty, 0, len(signPIDs))
+
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+	endCh := make(chan *common.SignatureData, len(signPIDs))
+
+	msg, _ := hex.DecodeString("00f163ee51bcaeff9cdff5e0e3c1a646abd19885fffbab0b3b4236e0cf95c9f5")
+	path := "0/1/2/2/10"
+	tweaks := []*big.Int{big.NewInt(7), new(big.Int).Mod(common.SHA512_256i(big.NewInt(42)), tss.S256().Params().N)}
+
+	// the parties sign for the derived key shifted by the tweaks
+	_, derived, err := utils.DerivingPubkeyFromPath(keys[0].Pubkey, keys[0].ChainCode.Bytes(), path, tss.S256())
+	assert.NoError(t, err)
+	tweaked, err := utils.TweakPubkey(derived.PublicKey, tweaks...)
+	assert.NoError(t, err)
+
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(tss.S256(), p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+		params.SetKeyTweaks(tweaks...)
+		party, err := NewLocalParty(new(big.Int).SetBytes(msg), false, params, path, keys[i], pres[i], outCh, endCh)
+		assert.NoError(t, err)
+		P := party.(*LocalParty)
+		assert.True(t, tweaked.Equals(P.key.Pubkey))
+		parties = append(parties, P)
+	}
+	// start every party before routing the messages, so that no party receives all the messages of round 1 before it starts
+	for _, P := range parties {
+		if err := P.Start(); err != nil {
+			assert.FailNow(t, err.Error())
+		}
+	}
+
+	for ended := 0; ended < len(parties); {
+		select {
+		case err := <-errCh:
+			assert.FailNow(t, err.Error())
+
+		case msg := <-outCh:
+			for _, P := range parties {
+				if P.PartyID().Index == msg.GetFrom().Index {
+					continue
+				}
+				go test.SharedPartyUpdater(P, msg, errCh)
+			}
+
+		case data := <-endCh:
+			ended++
+			pk := ecdsa.PublicKey{
+				Curve: tss.S256(),
+				X:     tweaked.X(),
+				Y:     tweaked.Y(),
+			}
+			ok := ecdsa.Verify(&pk, msg, new(big.Int).SetBytes(data.R), new(big.Int).SetBytes(data.S))
+			assert.True(t, ok, "ecdsa verify must pass")
+			assert.NoError(t, sigfmt.CheckRecovery(data, tweaked), "the recovery id must recover the key")
+		}
+	}
+}
+
+func TestE2EAdaptorConcurrent(t *testing.T) {
+	setUp("info")
+
+	ec := tss.S256()
+	keys, signPIDs, err := tKeygen.LoadKeygenTestFixturesRandomSet(keygen.Ecdsa, testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	auxs, _, err := auxiliary.LoadAuxTestFixtures(keygen.Ecdsa, testThreshold+1)
+	assert.NoError(t, err, "should load aux fixtures")
+
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	errCh := make(chan *tss.Error, len(signPIDs))
+	// round 1 of the presigning sends a message to every other party
+	outCh := make(chan tss.Message, len(signPIDs)*len(signPIDs))
+
+	// PHASE: presigning for the adaptor point T = tG
+	secret := common.GetRandomPositiveInt(rand.Reader, ec.Params().N)
+	T := crypto.ScalarBaseMult(ec, secret)
+
+	preEndCh := make(chan *presign.LocalPartySaveData, len(signPIDs))
+	preParties := make([]tss.Party, 0, len(signPIDs))
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(ec, p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+		party, err := presign.NewLocalPartyWithAdaptor(T, true, params, keys[i], auxs[i], outCh, preEndCh)
+		assert.NoError(t, err)
+		preParties = append(preParties, party)
+	}
+	startParties(t, preParties)
+	pres := make([]presign.LocalPartySaveData, len(signPIDs))
+	for ended := 0; ended < len(signPIDs); {
+		select {
+		case err := <-errCh:
+			assert.FailNow(t, err.Error())
+
+		case msg := <-outCh:
+			routeMessage(preParties, msg, errCh)
+
+		case pre := <-preEndCh:
+			index, err := pre.OriginalIndex()
+			assert.NoError(t, err)
+			pres[index] = *pre
+			ended++
+		}
+	}
+
+	// a presignature for an adaptor point only makes pre-signatures
+	params := tss.NewParameters(ec, p2pCtx, signPIDs[0], len(signPIDs), testThreshold)
+	_, err = NewLocalParty(big.NewInt(1), true, params, "", keys[0], pres[0], outCh, make(chan *common.SignatureData))
+	assert.Error(t, err)
+
+	// PHASE: pre-signing
+	msg := common.NewSHA256Message([]byte("an atomic swap"))
+	path := "0/1/2/2/10"
+	_, derived, err := utils.DerivingPubkeyFromPath(keys[0].Pubkey, keys[0].ChainCode.Bytes(), path, ec)
+	assert.NoError(t, err)
+
+	endCh := make(chan *PreSignature, len(signPIDs))
+	parties := make([]tss.Party, 0, len(signPIDs))
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(ec, p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+		party, err := NewLocalPartyWithAdaptor(msg, true, params, path, keys[i], pres[i], outCh, endCh)
+		assert.NoError(t, err)
+		parties = append(parties, party)
+	}
+	startParties(t, parties)
+	preSigs := make([]*PreSignature, 0, len(signPIDs))
+	for len(preSigs) < len(signPIDs) {
+		select {
+		case err := <-errCh:
+			assert.FailNow(t, err.Error())
+
+		case msg := <-outCh:
+			routeMessage(parties, msg, errCh)
+
+		case preSig := <-endCh:
+			preSigs = append(preSigs, preSig)
+		}
+	}
+
+	for _, preSig := range preSigs {
+		assert.NoError(t, preSig.Verify(derived.PublicKey))
+		assert.Error(t, preSig.Verify(keys[0].Pubkey), "the pre-signature is bound to the key")
+
+		// the pre-signature is not a signature, and it is completed by the discrete logarithm of T only
+		_, err := preSig.Complete(new(big.Int).Add(secret, big.NewInt(1)))
+		assert.Error(t, err)
+		sig, err := preSig.Complete(secret)
+		if !assert.NoError(t, err) {
+			continue
+		}
+		digest, _ := msg.ECDSADigest(ec)
+		pk := ecdsa.PublicKey{Curve: ec, X: derived.PublicKey.X(), Y: derived.PublicKey.Y()}
+		assert.True(t, ecdsa.Verify(&pk, digest, new(big.Int).SetBytes(sig.R), new(big.Int).SetBytes(sig.S)))
+		assert.NoError(t, sigfmt.CheckRecovery(sig, derived.PublicKey), "the recovery id must recover the key")
+
+		// whoever sees the signature learns t
+		extracted, err := preSig.Extract(sig)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, secret.Cmp(extracted))
+	}
+}
+
+// startParties starts every party before the messages are routed, so that no party receives all the messages of round 1 before it starts
+func startParties(t *testing.T, parties []tss.Party) {
+	for _, P := range parties {
+		if err := P.Start(); err != nil {
+			assert.FailNow(t, err.Error())
+		}
+	}
+}
+
+func routeMessage(parties []tss.Party, msg tss.Message, errCh chan<- *tss.Error) {
+	if dest := msg.GetTo(); dest != nil {
+		go test.SharedPartyUpdater(parties[dest[0].Index], msg, errCh)
+		return
+	}
+	for _, P := range parties {
+		if P.PartyID().Index == msg.GetFrom().Index {
+			continue
+		}
+		go test.SharedPartyUpdater(P, msg, errCh)
+	}
+}
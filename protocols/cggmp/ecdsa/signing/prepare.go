@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"math/big"
 
+	"github.com/felicityin/mpc-tss/common"
 	"github.com/felicityin/mpc-tss/protocols/cggmp/ecdsa/presign"
 	"github.com/felicityin/mpc-tss/protocols/cggmp/keygen"
 	"github.com/felicityin/mpc-tss/protocols/utils"
@@ -15,18 +16,30 @@ func PrepareForSigning(
 	path string,
 	isThreshold bool,
 	threshold int,
+	tweaks ...*big.Int,
 ) error {
 	ec := key.Pubkey.Curve()
-	keyDerivationDelta, _, err := utils.DerivingPubkeyFromPath(key.Pubkey, key.ChainCode.Bytes(), path, ec)
+	keyDerivationDelta, _, err := utils.DerivingKeyFromPath(*key, path)
 	if err != nil {
 		return fmt.Errorf("there should not be an error deriving the child public key: %s", err.Error())
 	}
 
+	// the key is shifted by the derivation delta and the tweaks
 	shift := new(big.Int).Set(keyDerivationDelta)
+	for _, tweak := range tweaks {
+		if err = utils.CheckTweak(tweak, ec); err != nil {
+			return err
+		}
+		shift = shift.Add(shift, tweak)
+	}
+
+	// the party works on its own copy of the presignature, so that it can wipe it once it is consumed
 	shift = shift.Mul(shift, pre.K)
-	pre.Chi.Add(pre.Chi, shift)
+	pre.K = new(big.Int).Set(pre.K)
+	pre.Chi = new(big.Int).Add(pre.Chi, shift)
+	common.ZeroBigInts(shift)
 
-	err = utils.UpdateKeyForSigning(key, path, isThreshold, threshold)
+	err = utils.UpdateKeyForSigning(key, path, isThreshold, threshold, tweaks...)
 	if err != nil {
 		return err
 	}
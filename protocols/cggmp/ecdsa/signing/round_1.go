@@ -12,7 +12,9 @@ import (
 	"github.com/felicityin/mpc-tss/tss"
 )
 
-var ProofParameter = crypto.NewProofConfig(tss.S256().Params().N)
+// ProofParameter is the proof config of secp256k1.
+// The rounds use the config of the curve of the parameters, see crypto.CurveProofConfig.
+var ProofParameter = crypto.CurveProofConfig(tss.S256())
 
 // round 1 represents round 1 of the signing part of the EDDSA TSS spec
 func newRound1(
@@ -24,9 +26,10 @@ func newRound1(
 	temp *localTempData,
 	out chan<- tss.Message,
 	end chan<- *common.SignatureData,
+	preEnd chan<- *PreSignature,
 ) tss.Round {
 	return &round1{
-		&base{params, isThreshold, key, pre, data, temp, out, end, make([]bool, len(params.Parties().IDs())), false, 1},
+		&base{params, isThreshold, key, pre, data, temp, out, end, preEnd, make([]bool, len(params.Parties().IDs())), false, 1},
 	}
 }
 
@@ -51,7 +54,16 @@ func (round *round1) Start() *tss.Error {
 	common.Logger.Infof("[sign] party: %d, round_1 start", i)
 
 	modN := common.ModInt(round.EC().Params().N)
-	round.temp.si = modN.Add(modN.Mul(round.pre.K, round.temp.msg), modN.Mul(round.pre.R.X(), round.pre.Chi))
+	// the nonce point of a pre-signature is RT = k^-1 * T
+	R := round.pre.R
+	if round.pre.Adaptor != nil {
+		R = round.pre.Adaptor.RT
+	}
+	round.temp.si = modN.Add(modN.Mul(round.pre.K, round.temp.msg), modN.Mul(R.X(), round.pre.Chi))
+
+	// the presignature is single-use, wipe it together with the key share now that it has been consumed
+	round.pre.Destroy()
+	common.ZeroBigInts(round.key.PrivXi)
 
 	// broadcast sigma
 	common.Logger.Debugf("P[%d]: broadcast sigma", i)
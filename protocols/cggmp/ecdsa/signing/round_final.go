@@ -38,46 +38,35 @@ func (round *finalization) Start() *tss.Error {
 		sumS.Mod(sumS, round.EC().Params().N)
 	}
 
-	recid := 0
-	// byte v = if(R.X > curve.N) then 2 else 0) | (if R.Y.IsEven then 0 else 1);
-	if round.pre.R.X().Cmp(round.Params().EC().Params().N) > 0 {
-		recid = 2
-	}
-	if round.pre.R.Y().Bit(0) != 0 {
-		recid |= 1
+	var M []byte
+	if round.temp.fullBytesLen == 0 {
+		M = round.temp.msg.Bytes()
+	} else {
+		M = make([]byte, round.temp.fullBytesLen)
+		round.temp.msg.FillBytes(M)
 	}
 
-	// This is copied from:
-	// https://github.com/btcsuite/btcd/blob/c26ffa870fd817666a857af1bf6498fabba1ffe3/btcec/signature.go#L442-L444
-	// This is needed because of tendermint checks here:
-	// https://github.com/tendermint/tendermint/blob/d9481e3648450cb99e15c6a070c1fb69aa0c255b/crypto/secp256k1/secp256k1_nocgo.go#L43-L47
-	secp256k1halfN := new(big.Int).Rsh(round.Params().EC().Params().N, 1)
-	if sumS.Cmp(secp256k1halfN) > 0 {
-		sumS.Sub(round.Params().EC().Params().N, sumS)
-		recid ^= 1
+	// the pre-signature of an adaptor is checked against its nonce point R, it is no signature yet
+	if round.pre.Adaptor != nil {
+		preSig := &PreSignature{R: round.pre.R, Adaptor: round.pre.Adaptor, S: sumS, M: M, HashMode: round.data.HashMode}
+		if err := preSig.Verify(round.key.Pubkey); err != nil {
+			return round.WrapError(fmt.Errorf("pre-signature verification failed: %s", err.Error()))
+		}
+		round.preEnd <- preSig
+		return nil
 	}
 
 	// save the signature for final output
-	bitSizeInBytes := round.Params().EC().Params().BitSize / 8
-	round.data.R = padToLengthBytesInPlace(round.pre.R.X().Bytes(), bitSizeInBytes)
-	round.data.S = padToLengthBytesInPlace(sumS.Bytes(), bitSizeInBytes)
-	round.data.Signature = append(round.data.R, round.data.S...)
-	round.data.SignatureRecovery = []byte{byte(recid)}
-	if round.temp.fullBytesLen == 0 {
-		round.data.M = round.temp.msg.Bytes()
-	} else {
-		var mBytes = make([]byte, round.temp.fullBytesLen)
-		round.temp.msg.FillBytes(mBytes)
-		round.data.M = mBytes
-	}
+	data := NewSignatureData(round.EC(), round.pre.R, sumS, M)
+	round.data.R, round.data.S, round.data.M = data.R, data.S, data.M
+	round.data.Signature, round.data.SignatureRecovery = data.Signature, data.SignatureRecovery
 
 	pk := ecdsa.PublicKey{
 		Curve: round.Params().EC(),
 		X:     round.key.Pubkey.X(),
 		Y:     round.key.Pubkey.Y(),
 	}
-
-	ok := ecdsa.Verify(&pk, round.data.M, round.pre.R.X(), sumS)
+	ok := ecdsa.Verify(&pk, round.data.M, new(big.Int).SetBytes(data.R), new(big.Int).SetBytes(data.S))
 	if !ok {
 		return round.WrapError(fmt.Errorf("signature verification failed"))
 	}
@@ -25,7 +25,8 @@ type (
 		temp        *localTempData
 		out         chan<- tss.Message
 		end         chan<- *common.SignatureData
-		ok          []bool // `ok` tracks parties which have been verified by Update()
+		preEnd      chan<- *PreSignature // the output of the presignatures with an adaptor point
+		ok          []bool               // `ok` tracks parties which have been verified by Update()
 		started     bool
 		number      int
 	}
@@ -87,6 +87,7 @@ func NewLocalParty(
 	p.temp.signRound2Messages = make([]tss.ParsedMessage, partyCount)
 
 	p.temp.isThreshold = isThreshold
+	p.temp.ssidNonce = new(big.Int)
 	p.temp.kCiphertexts = make([]*big.Int, partyCount)
 	return p, nil
 }
@@ -51,7 +51,6 @@ func (round *round1) Start() *tss.Error {
 	round.save.Ks = ids
 	round.save.ShareID = ids[i]
 
-	round.temp.ssidNonce = new(big.Int).SetUint64(0)
 	var err error
 	round.temp.ssid, err = round.getSSID()
 	if err != nil {
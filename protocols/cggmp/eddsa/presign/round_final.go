@@ -1,7 +1,7 @@
 package presign
 
 import (
-	"math/big"
+	"fmt"
 
 	"github.com/agl/ed25519/edwards25519"
 	"github.com/pkg/errors"
@@ -26,8 +26,10 @@ func (round *round3) Start() *tss.Error {
 	var R edwards25519.ExtendedGroupElement
 	riBytes := bigIntToEncodedBytes(round.save.K)
 	edwards25519.GeScalarMultBase(&R, riBytes)
+	common.ZeroBytes(riBytes[:])
 
 	// verify received log proof and compute R
+	session := round.session()
 	for j, Pj := range round.Parties().IDs() {
 		round.ok[j] = true
 		if j == i {
@@ -37,31 +39,20 @@ func (round *round3) Start() *tss.Error {
 		msg := round.temp.signRound2Messages[j]
 		r2msg := msg.Content().(*sign.SignRound2Message)
 
-		logProof, err := r2msg.UnmarshalLogProof(round.Params().EC())
-		if err != nil {
-			common.Logger.Errorf("failed to unmarshal log proof: %s, party: %d", err, j)
-			return round.WrapError(errors.New("failed to unmarshal log proof"), Pj)
+		r1msg1 := round.temp.signRound1Message1s[j]
+		if err := sign.CheckLogProof(
+			round.EC(), session, round.temp.ssid, j, i, r1msg1.Content().(*sign.SignRound1Message1), r2msg,
+		); err != nil {
+			inputs := &sign.Inputs{Session: *session, Index: j}
+			common.Logger.Errorf("[j: %d] %s", j, err.Error())
+			return round.WrapEvidence(fmt.Errorf("[j: %d] %s", j, err.Error()), sign.EvidenceLogProof, inputs, r1msg1, msg)
 		}
-		common.Logger.Debugf("P[%d]: receive P[%d]'s log proof", i, j)
+		common.Logger.Debugf("P[%d]: verify P[%d]'s log proof ok", i, j)
 
 		Rj, err := r2msg.UnmarshalR(round.EC())
 		if err != nil {
-			common.Logger.Errorf("unmarshal R failed: %s, party: %d", err, j)
-			return round.WrapError(err)
-		}
-
-		contextJ := append(round.temp.ssid, big.NewInt(int64(j)).Bytes()...)
-
-		err = logProof.Verify(
-			ProofParameter, contextJ, round.temp.kCiphertexts[j],
-			round.aux.PaillierPKs[j].N, round.aux.PedersenPKs[i], Rj, nil,
-		)
-		if err != nil {
-			common.Logger.Errorf("verify log proof failed: %s, party: %d", err, j)
-			return round.WrapError(err)
+			return round.WrapError(err, Pj)
 		}
-		common.Logger.Debugf("P[%d]: verify P[%d]'s log proof ok", i, j)
-
 		Rj = Rj.EightInvEight()
 		if err != nil {
 			return round.WrapError(errors.Wrapf(err, "NewECPoint(Rj)"), Pj)
@@ -0,0 +1,189 @@
+package sign
+
+import (
+	"crypto/elliptic"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/felicityin/mpc-tss/common"
+	"github.com/felicityin/mpc-tss/crypto"
+	zkPaillier "github.com/felicityin/mpc-tss/crypto/alice/zkproof/paillier"
+	"github.com/felicityin/mpc-tss/tss"
+)
+
+// Kinds of the evidence against a party of the signing.
+// The presigning sends the same messages, so its evidence has the same kinds.
+const (
+	EvidenceEncProof = "cggmp/eddsa/sign/enc-proof"
+	EvidenceLogProof = "cggmp/eddsa/sign/log-proof"
+)
+
+func init() {
+	tss.RegisterEvidenceCheck(EvidenceEncProof, checkEncProofEvidence)
+	tss.RegisterEvidenceCheck(EvidenceLogProof, checkLogProofEvidence)
+}
+
+type (
+	// Session are the public values of a signing or a presigning that are fixed before it, which a verifier should check
+	// against what it knows of the key and the auxiliary data of the parties: the keys Ks of the signers, their key shares
+	// PubXj, the nonce of the ssid, which is the index of a presigning in its batch, and the paillier keys and pedersen
+	// parameters of the signers. The ssid is computed from them.
+	Session struct {
+		Curve      tss.CurveName                        `json:"curve"`
+		Ks         []*big.Int                           `json:"ks"`
+		PubXj      []*crypto.ECPoint                    `json:"pub_xj"`
+		Nonce      *big.Int                             `json:"nonce"`
+		PaillierNs []*big.Int                           `json:"paillier_ns"`
+		Pedersens  []*zkPaillier.PederssenOpenParameter `json:"pedersens"`
+	}
+
+	// Inputs are the public inputs of the checks of the messages of Pj. The verifier of the proofs of Pj is the victim
+	// of the evidence.
+	Inputs struct {
+		Session
+		Index int `json:"index"`
+	}
+)
+
+// SSID returns the ssid of the session, as the parties compute it in round 1
+func (s *Session) SSID(ec elliptic.Curve) ([]byte, error) {
+	ssidList := []*big.Int{ec.Params().P, ec.Params().N, ec.Params().Gx, ec.Params().Gy} // ec curve
+	ssidList = append(ssidList, s.Ks...)                                                 // parties
+	BigXjList, err := crypto.FlattenECPoints(s.PubXj)
+	if err != nil {
+		return nil, errors.New("read BigXj failed")
+	}
+	ssidList = append(ssidList, BigXjList...)  // BigXj
+	ssidList = append(ssidList, big.NewInt(1)) // round number
+	ssidList = append(ssidList, s.Nonce)
+	return common.SHA512_256i(ssidList...).Bytes(), nil
+}
+
+// validate checks that the session is on its curve with the data of every signer, and that the inputs are about the culprit
+func (s *Session) validate(ev *tss.Evidence, index int) (elliptic.Curve, error) {
+	if index != ev.Culprit.Index {
+		return nil, fmt.Errorf("the inputs are about party %d, not the culprit", index)
+	}
+	ec, ok := tss.GetCurveByName(s.Curve)
+	if !ok {
+		return nil, fmt.Errorf("unknown curve %s", s.Curve)
+	}
+	n := len(s.Ks)
+	if len(s.PubXj) != n || len(s.PaillierNs) != n || len(s.Pedersens) != n || s.Nonce == nil {
+		return nil, errors.New("expected the key share, the paillier key and the pedersen parameters of every signer")
+	}
+	for j := range s.Ks {
+		if s.Ks[j] == nil || s.PubXj[j] == nil || !tss.SameCurve(s.PubXj[j].Curve(), ec) || s.PaillierNs[j] == nil || !validPedersen(s.Pedersens[j]) {
+			return nil, fmt.Errorf("the data of party %d are missing", j)
+		}
+	}
+	if index < 0 || index >= n || s.Ks[index].Cmp(ev.Culprit.KeyInt()) != 0 {
+		return nil, errors.New("the keys of the signers do not hold the key of the culprit")
+	}
+	return ec, nil
+}
+
+// victim returns the index of the victim of the evidence, who verified the proofs of the culprit
+func (s *Session) victim(ev *tss.Evidence) (int, error) {
+	v := ev.Victim
+	if v == nil || v.Index < 0 || v.Index >= len(s.Ks) || v.KeyInt().Cmp(s.Ks[v.Index]) != 0 || v.Index == ev.Culprit.Index {
+		return 0, errors.New("the victim is not another signer of the session")
+	}
+	return v.Index, nil
+}
+
+// CheckEncProof checks the enc proof of Pj to the verifier v for its ciphertext K_j
+func CheckEncProof(s *Session, ssid []byte, j, v int, r1msg1 *SignRound1Message1, r1msg2 *SignRound1Message2) error {
+	encProof, err := r1msg2.UnmarshalEncProof()
+	if err != nil {
+		return fmt.Errorf("unmarshal enc proof failed: %s", err.Error())
+	}
+	if err := encProof.Verify(
+		ProofParameter, proofContext(ssid, j), r1msg1.UnmarshalK(), s.PaillierNs[j], s.Pedersens[v],
+	); err != nil {
+		return fmt.Errorf("verify enc proof failed: %s", err.Error())
+	}
+	return nil
+}
+
+// CheckLogProof checks the log proof of Pj to the verifier v that R_j = kj * G, where kj is encrypted in K_j
+func CheckLogProof(ec elliptic.Curve, s *Session, ssid []byte, j, v int, r1msg1 *SignRound1Message1, r2msg *SignRound2Message) error {
+	logProof, err := r2msg.UnmarshalLogProof(ec)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal log proof: %s", err.Error())
+	}
+	Rj, err := r2msg.UnmarshalR(ec)
+	if err != nil {
+		return fmt.Errorf("unmarshal R failed: %s", err.Error())
+	}
+	if err := logProof.Verify(
+		ProofParameter, proofContext(ssid, j), r1msg1.UnmarshalK(), s.PaillierNs[j], s.Pedersens[v], Rj, nil,
+	); err != nil {
+		return fmt.Errorf("verify log proof failed: %s", err.Error())
+	}
+	return nil
+}
+
+// proofContext returns the context (ssid, j) of the proofs of Pj
+func proofContext(ssid []byte, j int) []byte {
+	return append(append([]byte{}, ssid...), big.NewInt(int64(j)).Bytes()...)
+}
+
+// parseInputs decodes the inputs of the evidence, and returns their curve, the ssid of their session and the victim
+func parseInputs(ev *tss.Evidence, in *Inputs) (elliptic.Curve, []byte, int, error) {
+	if err := ev.UnmarshalInputs(in); err != nil {
+		return nil, nil, 0, err
+	}
+	ec, err := in.validate(ev, in.Index)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	ssid, err := in.SSID(ec)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	v, err := in.victim(ev)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	return ec, ssid, v, nil
+}
+
+func checkEncProofEvidence(ev *tss.Evidence, msgs []tss.ParsedMessage) (failure error, err error) {
+	in := new(Inputs)
+	_, ssid, v, err := parseInputs(ev, in)
+	if err != nil {
+		return nil, err
+	}
+	if len(msgs) != 2 {
+		return nil, errors.New("expected the round 1 messages")
+	}
+	r1msg1, ok1 := msgs[0].Content().(*SignRound1Message1)
+	r1msg2, ok2 := msgs[1].Content().(*SignRound1Message2)
+	if !ok1 || !ok2 {
+		return nil, errors.New("expected the round 1 messages")
+	}
+	return CheckEncProof(&in.Session, ssid, in.Index, v, r1msg1, r1msg2), nil
+}
+
+func checkLogProofEvidence(ev *tss.Evidence, msgs []tss.ParsedMessage) (failure error, err error) {
+	in := new(Inputs)
+	ec, ssid, v, err := parseInputs(ev, in)
+	if err != nil {
+		return nil, err
+	}
+	if len(msgs) != 2 {
+		return nil, errors.New("expected the round 1 broadcast and the round 2 message")
+	}
+	r1msg1, ok1 := msgs[0].Content().(*SignRound1Message1)
+	r2msg, ok2 := msgs[1].Content().(*SignRound2Message)
+	if !ok1 || !ok2 {
+		return nil, errors.New("expected the round 1 broadcast and the round 2 message")
+	}
+	return CheckLogProof(ec, &in.Session, ssid, in.Index, v, r1msg1, r2msg), nil
+}
+
+func validPedersen(ped *zkPaillier.PederssenOpenParameter) bool {
+	return ped != nil && ped.N != nil && ped.S != nil && ped.T != nil
+}
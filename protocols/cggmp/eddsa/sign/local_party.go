@@ -50,6 +50,7 @@ type (
 		kCiphertexts []*big.Int
 		m            *big.Int
 		fullBytesLen int
+		dom          []byte // dom2 of RFC 8032, empty for Ed25519
 
 		// round 2
 		si *[32]byte
@@ -77,7 +78,7 @@ func NewLocalParty(
 	if err != nil {
 		return nil, err
 	}
-	err = utils.UpdateKeyForSigning(&key, path, isThreshold, params.Threshold())
+	err = utils.UpdateKeyForSigning(&key, path, isThreshold, params.Threshold(), params.KeyTweaks()...)
 	if err != nil {
 		return nil, err
 	}
@@ -110,6 +111,33 @@ func NewLocalParty(
 	return p, nil
 }
 
+// NewLocalPartyWithMessage returns a party that signs the message with the variant of RFC 8032 of its hash mode:
+// ED25519, ED25519PH or ED25519CTX. The message keeps its leading zero bytes.
+func NewLocalPartyWithMessage(
+	msg *common.Message,
+	isThreshold bool,
+	params *tss.Parameters,
+	path string,
+	key keygen.LocalPartySaveData,
+	aux auxiliary.LocalPartySaveData,
+	out chan<- tss.Message,
+	end chan<- *common.SignatureData,
+) (tss.Party, error) {
+	dom, m, err := msg.EdDSAInput()
+	if err != nil {
+		return nil, err
+	}
+	party, err := NewLocalParty(new(big.Int).SetBytes(m), isThreshold, params, path, key, aux, out, end, len(m))
+	if err != nil {
+		return nil, err
+	}
+	p := party.(*LocalParty)
+	p.temp.dom = dom
+	p.data.HashMode = msg.Mode
+	p.data.Context = msg.Context
+	return p, nil
+}
+
 func (p *LocalParty) FirstRound() tss.Round {
 	return newRound1(p.temp.isThreshold, p.params, &p.keys, &p.auxs, p.data, &p.temp, p.out, p.end)
 }
@@ -2,6 +2,7 @@ package sign
 
 import (
 	"errors"
+	"fmt"
 	"math/big"
 
 	"google.golang.org/protobuf/proto"
@@ -25,6 +26,7 @@ func (round *round2) Start() *tss.Error {
 	common.Logger.Infof("[sign] party: %d, round_2 start", i)
 
 	// Verify received enc proof
+	session := round.session()
 	for j := range Ps {
 		if j == i {
 			continue
@@ -35,21 +37,13 @@ func (round *round2) Start() *tss.Error {
 		common.Logger.Debugf("P[%d]: receive P[%d]'s kCiphertext", i, j)
 
 		r1msg2 := round.temp.signRound1Message2s[j].Content().(*SignRound1Message2)
-		encProof, err := r1msg2.UnmarshalEncProof()
-		if err != nil {
-			common.Logger.Errorf("unmarshal enc proof failed, party: %d", j)
-			return round.WrapError(err)
-		}
-		common.Logger.Debugf("P[%d]: receive P[%d]'s enc proof", i, j)
-
-		contextJ := append(round.temp.ssid, big.NewInt(int64(j)).Bytes()...)
-
-		if err := encProof.Verify(
-			ProofParameter, contextJ, round.temp.kCiphertexts[j],
-			round.aux.PaillierPKs[j].N, round.aux.PedersenPKs[i],
-		); err != nil {
-			common.Logger.Errorf("verify enc proof failed, party: %d", j)
-			return round.WrapError(err)
+		if err := CheckEncProof(session, round.temp.ssid, j, i, r1msg1, r1msg2); err != nil {
+			inputs := &Inputs{Session: *session, Index: j}
+			common.Logger.Errorf("[j: %d] %s", j, err.Error())
+			return round.WrapEvidence(
+				fmt.Errorf("[j: %d] %s", j, err.Error()), EvidenceEncProof, inputs,
+				round.temp.signRound1Message1s[j], round.temp.signRound1Message2s[j],
+			)
 		}
 		common.Logger.Debugf("P[%d]: verify P[%d]'s enc proof ok", i, j)
 	}
@@ -2,7 +2,7 @@ package sign
 
 import (
 	"crypto/sha512"
-	"math/big"
+	"fmt"
 
 	"github.com/agl/ed25519/edwards25519"
 	"github.com/pkg/errors"
@@ -28,6 +28,7 @@ func (round *round3) Start() *tss.Error {
 	edwards25519.GeScalarMultBase(&R, riBytes)
 
 	// verify received log proof and compute R
+	session := round.session()
 	for j, Pj := range round.Parties().IDs() {
 		if j == i {
 			continue
@@ -36,31 +37,20 @@ func (round *round3) Start() *tss.Error {
 		msg := round.temp.signRound2Messages[j]
 		r2msg := msg.Content().(*SignRound2Message)
 
-		logProof, err := r2msg.UnmarshalLogProof(round.Params().EC())
-		if err != nil {
-			common.Logger.Errorf("failed to unmarshal log proof: %s, party: %d", err, j)
-			return round.WrapError(errors.New("failed to unmarshal log proof"), Pj)
+		r1msg1 := round.temp.signRound1Message1s[j]
+		if err := CheckLogProof(
+			round.EC(), session, round.temp.ssid, j, i, r1msg1.Content().(*SignRound1Message1), r2msg,
+		); err != nil {
+			inputs := &Inputs{Session: *session, Index: j}
+			common.Logger.Errorf("[j: %d] %s", j, err.Error())
+			return round.WrapEvidence(fmt.Errorf("[j: %d] %s", j, err.Error()), EvidenceLogProof, inputs, r1msg1, msg)
 		}
-		common.Logger.Debugf("P[%d]: receive P[%d]'s log proof", i, j)
+		common.Logger.Debugf("P[%d]: verify P[%d]'s log proof ok", i, j)
 
 		Rj, err := r2msg.UnmarshalR(round.EC())
 		if err != nil {
-			common.Logger.Errorf("unmarshal R failed: %s, party: %d", err, j)
-			return round.WrapError(err)
+			return round.WrapError(err, Pj)
 		}
-
-		contextJ := append(round.temp.ssid, big.NewInt(int64(j)).Bytes()...)
-
-		err = logProof.Verify(
-			ProofParameter, contextJ, round.temp.kCiphertexts[j],
-			round.aux.PaillierPKs[j].N, round.aux.PedersenPKs[i], Rj, nil,
-		)
-		if err != nil {
-			common.Logger.Errorf("verify log proof failed: %s, party: %d", err, j)
-			return round.WrapError(err)
-		}
-		common.Logger.Debugf("P[%d]: verify P[%d]'s log proof ok", i, j)
-
 		Rj = Rj.EightInvEight()
 		if err != nil {
 			return round.WrapError(errors.Wrapf(err, "NewECPoint(Rj)"), Pj)
@@ -75,9 +65,10 @@ func (round *round3) Start() *tss.Error {
 	R.ToBytes(&encodedR)
 	encodedPubKey := ecPointToEncodedBytes(round.key.Pubkey.X(), round.key.Pubkey.Y())
 
-	// h = hash512(R || X || M)
+	// h = hash512(dom2 || R || X || M)
 	h := sha512.New()
 	h.Reset()
+	h.Write(round.temp.dom)
 	h.Write(encodedR[:])
 	h.Write(encodedPubKey[:])
 	if round.temp.fullBytesLen == 0 {
@@ -95,7 +86,12 @@ func (round *round3) Start() *tss.Error {
 
 	// compute si
 	var localS [32]byte
-	edwards25519.ScMulAdd(&localS, &lambdaReduced, bigIntToEncodedBytes(round.key.PrivXi), riBytes)
+	xiBytes := bigIntToEncodedBytes(round.key.PrivXi)
+	edwards25519.ScMulAdd(&localS, &lambdaReduced, xiBytes, riBytes)
+
+	// Security: the nonces and the key share are no longer needed
+	common.ZeroBytes(xiBytes[:], riBytes[:])
+	common.ZeroBigInts(round.temp.k, round.temp.rho, round.key.PrivXi)
 
 	// store r3 message pieces
 	round.temp.si = &localS
@@ -6,6 +6,7 @@ import (
 	"math/big"
 
 	"github.com/felicityin/mpc-tss/common"
+	"github.com/felicityin/mpc-tss/crypto/sigfmt"
 	"github.com/felicityin/mpc-tss/tss"
 
 	"github.com/agl/ed25519/edwards25519"
@@ -59,7 +60,13 @@ func (round *finalization) Start() *tss.Error {
 		Y:     round.key.Pubkey.Y(),
 	}
 
-	ok := edwards.Verify(&pk, round.data.M, round.temp.r, s)
+	var ok bool
+	if len(round.temp.dom) == 0 {
+		ok = edwards.Verify(&pk, round.data.M, round.temp.r, s)
+	} else {
+		// edwards.Verify has no dom2, Ed25519ph and Ed25519ctx are verified with crypto/ed25519
+		ok = sigfmt.VerifyEd25519(round.key.Pubkey, round.data) == nil
+	}
 	if !ok {
 		return round.WrapError(fmt.Errorf("signature verification failed"))
 	}
@@ -2,7 +2,7 @@
 // versions:
 // 	protoc-gen-go v1.33.0
 // 	protoc        v3.21.4
-// source: sign.proto
+// source: protocols/cggmp/eddsa/sign/sign.proto
 
 package sign
 
@@ -32,7 +32,7 @@ type SignRound1Message1 struct {
 func (x *SignRound1Message1) Reset() {
 	*x = SignRound1Message1{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_sign_proto_msgTypes[0]
+		mi := &file_protocols_cggmp_eddsa_sign_sign_proto_msgTypes[0]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -45,7 +45,7 @@ func (x *SignRound1Message1) String() string {
 func (*SignRound1Message1) ProtoMessage() {}
 
 func (x *SignRound1Message1) ProtoReflect() protoreflect.Message {
-	mi := &file_sign_proto_msgTypes[0]
+	mi := &file_protocols_cggmp_eddsa_sign_sign_proto_msgTypes[0]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -58,7 +58,7 @@ func (x *SignRound1Message1) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SignRound1Message1.ProtoReflect.Descriptor instead.
 func (*SignRound1Message1) Descriptor() ([]byte, []int) {
-	return file_sign_proto_rawDescGZIP(), []int{0}
+	return file_protocols_cggmp_eddsa_sign_sign_proto_rawDescGZIP(), []int{0}
 }
 
 func (x *SignRound1Message1) GetBigK() []byte {
@@ -80,7 +80,7 @@ type SignRound1Message2 struct {
 func (x *SignRound1Message2) Reset() {
 	*x = SignRound1Message2{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_sign_proto_msgTypes[1]
+		mi := &file_protocols_cggmp_eddsa_sign_sign_proto_msgTypes[1]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -93,7 +93,7 @@ func (x *SignRound1Message2) String() string {
 func (*SignRound1Message2) ProtoMessage() {}
 
 func (x *SignRound1Message2) ProtoReflect() protoreflect.Message {
-	mi := &file_sign_proto_msgTypes[1]
+	mi := &file_protocols_cggmp_eddsa_sign_sign_proto_msgTypes[1]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -106,7 +106,7 @@ func (x *SignRound1Message2) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SignRound1Message2.ProtoReflect.Descriptor instead.
 func (*SignRound1Message2) Descriptor() ([]byte, []int) {
-	return file_sign_proto_rawDescGZIP(), []int{1}
+	return file_protocols_cggmp_eddsa_sign_sign_proto_rawDescGZIP(), []int{1}
 }
 
 func (x *SignRound1Message2) GetEncProof() []byte {
@@ -130,7 +130,7 @@ type SignRound2Message struct {
 func (x *SignRound2Message) Reset() {
 	*x = SignRound2Message{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_sign_proto_msgTypes[2]
+		mi := &file_protocols_cggmp_eddsa_sign_sign_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -143,7 +143,7 @@ func (x *SignRound2Message) String() string {
 func (*SignRound2Message) ProtoMessage() {}
 
 func (x *SignRound2Message) ProtoReflect() protoreflect.Message {
-	mi := &file_sign_proto_msgTypes[2]
+	mi := &file_protocols_cggmp_eddsa_sign_sign_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -156,7 +156,7 @@ func (x *SignRound2Message) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SignRound2Message.ProtoReflect.Descriptor instead.
 func (*SignRound2Message) Descriptor() ([]byte, []int) {
-	return file_sign_proto_rawDescGZIP(), []int{2}
+	return file_protocols_cggmp_eddsa_sign_sign_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *SignRound2Message) GetRX() []byte {
@@ -192,7 +192,7 @@ type SignRound3Message struct {
 func (x *SignRound3Message) Reset() {
 	*x = SignRound3Message{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_sign_proto_msgTypes[3]
+		mi := &file_protocols_cggmp_eddsa_sign_sign_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -205,7 +205,7 @@ func (x *SignRound3Message) String() string {
 func (*SignRound3Message) ProtoMessage() {}
 
 func (x *SignRound3Message) ProtoReflect() protoreflect.Message {
-	mi := &file_sign_proto_msgTypes[3]
+	mi := &file_protocols_cggmp_eddsa_sign_sign_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -218,7 +218,7 @@ func (x *SignRound3Message) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SignRound3Message.ProtoReflect.Descriptor instead.
 func (*SignRound3Message) Descriptor() ([]byte, []int) {
-	return file_sign_proto_rawDescGZIP(), []int{3}
+	return file_protocols_cggmp_eddsa_sign_sign_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *SignRound3Message) GetSigma() []byte {
@@ -228,49 +228,51 @@ func (x *SignRound3Message) GetSigma() []byte {
 	return nil
 }
 
-var File_sign_proto protoreflect.FileDescriptor
-
-var file_sign_proto_rawDesc = []byte{
-	0x0a, 0x0a, 0x73, 0x69, 0x67, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x17, 0x74, 0x73,
-	0x73, 0x6c, 0x69, 0x62, 0x2e, 0x63, 0x67, 0x67, 0x6d, 0x70, 0x2e, 0x73, 0x69, 0x67, 0x6e, 0x2e,
-	0x65, 0x64, 0x64, 0x73, 0x61, 0x22, 0x29, 0x0a, 0x12, 0x53, 0x69, 0x67, 0x6e, 0x52, 0x6f, 0x75,
-	0x6e, 0x64, 0x31, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x31, 0x12, 0x13, 0x0a, 0x05, 0x62,
-	0x69, 0x67, 0x5f, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x62, 0x69, 0x67, 0x4b,
-	0x22, 0x31, 0x0a, 0x12, 0x53, 0x69, 0x67, 0x6e, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x31, 0x4d, 0x65,
-	0x73, 0x73, 0x61, 0x67, 0x65, 0x32, 0x12, 0x1b, 0x0a, 0x09, 0x65, 0x6e, 0x63, 0x5f, 0x70, 0x72,
-	0x6f, 0x6f, 0x66, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x65, 0x6e, 0x63, 0x50, 0x72,
-	0x6f, 0x6f, 0x66, 0x22, 0x52, 0x0a, 0x11, 0x53, 0x69, 0x67, 0x6e, 0x52, 0x6f, 0x75, 0x6e, 0x64,
-	0x32, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x0f, 0x0a, 0x03, 0x72, 0x5f, 0x78, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x02, 0x72, 0x58, 0x12, 0x0f, 0x0a, 0x03, 0x72, 0x5f, 0x79,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x02, 0x72, 0x59, 0x12, 0x1b, 0x0a, 0x09, 0x6c, 0x6f,
-	0x67, 0x5f, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x6c,
-	0x6f, 0x67, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x22, 0x29, 0x0a, 0x11, 0x53, 0x69, 0x67, 0x6e, 0x52,
-	0x6f, 0x75, 0x6e, 0x64, 0x33, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x14, 0x0a, 0x05,
-	0x73, 0x69, 0x67, 0x6d, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x73, 0x69, 0x67,
-	0x6d, 0x61, 0x42, 0x0c, 0x5a, 0x0a, 0x65, 0x64, 0x64, 0x73, 0x61, 0x2f, 0x73, 0x69, 0x67, 0x6e,
-	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+var File_protocols_cggmp_eddsa_sign_sign_proto protoreflect.FileDescriptor
+
+var file_protocols_cggmp_eddsa_sign_sign_proto_rawDesc = []byte{
+	0x0a, 0x25, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x73, 0x2f, 0x63, 0x67, 0x67, 0x6d,
+	0x70, 0x2f, 0x65, 0x64, 0x64, 0x73, 0x61, 0x2f, 0x73, 0x69, 0x67, 0x6e, 0x2f, 0x73, 0x69, 0x67,
+	0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x17, 0x74, 0x73, 0x73, 0x6c, 0x69, 0x62, 0x2e,
+	0x63, 0x67, 0x67, 0x6d, 0x70, 0x2e, 0x73, 0x69, 0x67, 0x6e, 0x2e, 0x65, 0x64, 0x64, 0x73, 0x61,
+	0x22, 0x29, 0x0a, 0x12, 0x53, 0x69, 0x67, 0x6e, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x31, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x31, 0x12, 0x13, 0x0a, 0x05, 0x62, 0x69, 0x67, 0x5f, 0x6b, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x62, 0x69, 0x67, 0x4b, 0x22, 0x31, 0x0a, 0x12, 0x53,
+	0x69, 0x67, 0x6e, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x31, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x32, 0x12, 0x1b, 0x0a, 0x09, 0x65, 0x6e, 0x63, 0x5f, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x65, 0x6e, 0x63, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x22, 0x52,
+	0x0a, 0x11, 0x53, 0x69, 0x67, 0x6e, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x32, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x12, 0x0f, 0x0a, 0x03, 0x72, 0x5f, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x02, 0x72, 0x58, 0x12, 0x0f, 0x0a, 0x03, 0x72, 0x5f, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x02, 0x72, 0x59, 0x12, 0x1b, 0x0a, 0x09, 0x6c, 0x6f, 0x67, 0x5f, 0x70, 0x72, 0x6f,
+	0x6f, 0x66, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x6c, 0x6f, 0x67, 0x50, 0x72, 0x6f,
+	0x6f, 0x66, 0x22, 0x29, 0x0a, 0x11, 0x53, 0x69, 0x67, 0x6e, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x33,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x69, 0x67, 0x6d, 0x61,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x73, 0x69, 0x67, 0x6d, 0x61, 0x42, 0x0c, 0x5a,
+	0x0a, 0x65, 0x64, 0x64, 0x73, 0x61, 0x2f, 0x73, 0x69, 0x67, 0x6e, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
 }
 
 var (
-	file_sign_proto_rawDescOnce sync.Once
-	file_sign_proto_rawDescData = file_sign_proto_rawDesc
+	file_protocols_cggmp_eddsa_sign_sign_proto_rawDescOnce sync.Once
+	file_protocols_cggmp_eddsa_sign_sign_proto_rawDescData = file_protocols_cggmp_eddsa_sign_sign_proto_rawDesc
 )
 
-func file_sign_proto_rawDescGZIP() []byte {
-	file_sign_proto_rawDescOnce.Do(func() {
-		file_sign_proto_rawDescData = protoimpl.X.CompressGZIP(file_sign_proto_rawDescData)
+func file_protocols_cggmp_eddsa_sign_sign_proto_rawDescGZIP() []byte {
+	file_protocols_cggmp_eddsa_sign_sign_proto_rawDescOnce.Do(func() {
+		file_protocols_cggmp_eddsa_sign_sign_proto_rawDescData = protoimpl.X.CompressGZIP(file_protocols_cggmp_eddsa_sign_sign_proto_rawDescData)
 	})
-	return file_sign_proto_rawDescData
+	return file_protocols_cggmp_eddsa_sign_sign_proto_rawDescData
 }
 
-var file_sign_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
-var file_sign_proto_goTypes = []interface{}{
+var file_protocols_cggmp_eddsa_sign_sign_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_protocols_cggmp_eddsa_sign_sign_proto_goTypes = []interface{}{
 	(*SignRound1Message1)(nil), // 0: tsslib.cggmp.sign.eddsa.SignRound1Message1
 	(*SignRound1Message2)(nil), // 1: tsslib.cggmp.sign.eddsa.SignRound1Message2
 	(*SignRound2Message)(nil),  // 2: tsslib.cggmp.sign.eddsa.SignRound2Message
 	(*SignRound3Message)(nil),  // 3: tsslib.cggmp.sign.eddsa.SignRound3Message
 }
-var file_sign_proto_depIdxs = []int32{
+var file_protocols_cggmp_eddsa_sign_sign_proto_depIdxs = []int32{
 	0, // [0:0] is the sub-list for method output_type
 	0, // [0:0] is the sub-list for method input_type
 	0, // [0:0] is the sub-list for extension type_name
@@ -278,13 +280,13 @@ var file_sign_proto_depIdxs = []int32{
 	0, // [0:0] is the sub-list for field type_name
 }
 
-func init() { file_sign_proto_init() }
-func file_sign_proto_init() {
-	if File_sign_proto != nil {
+func init() { file_protocols_cggmp_eddsa_sign_sign_proto_init() }
+func file_protocols_cggmp_eddsa_sign_sign_proto_init() {
+	if File_protocols_cggmp_eddsa_sign_sign_proto != nil {
 		return
 	}
 	if !protoimpl.UnsafeEnabled {
-		file_sign_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+		file_protocols_cggmp_eddsa_sign_sign_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*SignRound1Message1); i {
 			case 0:
 				return &v.state
@@ -296,7 +298,7 @@ func file_sign_proto_init() {
 				return nil
 			}
 		}
-		file_sign_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+		file_protocols_cggmp_eddsa_sign_sign_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*SignRound1Message2); i {
 			case 0:
 				return &v.state
@@ -308,7 +310,7 @@ func file_sign_proto_init() {
 				return nil
 			}
 		}
-		file_sign_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+		file_protocols_cggmp_eddsa_sign_sign_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*SignRound2Message); i {
 			case 0:
 				return &v.state
@@ -320,7 +322,7 @@ func file_sign_proto_init() {
 				return nil
 			}
 		}
-		file_sign_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+		file_protocols_cggmp_eddsa_sign_sign_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*SignRound3Message); i {
 			case 0:
 				return &v.state
@@ -337,18 +339,18 @@ func file_sign_proto_init() {
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
-			RawDescriptor: file_sign_proto_rawDesc,
+			RawDescriptor: file_protocols_cggmp_eddsa_sign_sign_proto_rawDesc,
 			NumEnums:      0,
 			NumMessages:   4,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
-		GoTypes:           file_sign_proto_goTypes,
-		DependencyIndexes: file_sign_proto_depIdxs,
-		MessageInfos:      file_sign_proto_msgTypes,
+		GoTypes:           file_protocols_cggmp_eddsa_sign_sign_proto_goTypes,
+		DependencyIndexes: file_protocols_cggmp_eddsa_sign_sign_proto_depIdxs,
+		MessageInfos:      file_protocols_cggmp_eddsa_sign_sign_proto_msgTypes,
 	}.Build()
-	File_sign_proto = out.File
-	file_sign_proto_rawDesc = nil
-	file_sign_proto_goTypes = nil
-	file_sign_proto_depIdxs = nil
+	File_protocols_cggmp_eddsa_sign_sign_proto = out.File
+	file_protocols_cggmp_eddsa_sign_sign_proto_rawDesc = nil
+	file_protocols_cggmp_eddsa_sign_sign_proto_goTypes = nil
+	file_protocols_cggmp_eddsa_sign_sign_proto_depIdxs = nil
 }
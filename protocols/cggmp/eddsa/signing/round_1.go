@@ -55,9 +55,10 @@ func (round *round1) Start() *tss.Error {
 	encodedR := bigIntToEncodedBytes(round.pre.R)
 	encodedPubKey := ecPointToEncodedBytes(round.temp.pubW.X(), round.temp.pubW.Y())
 
-	// h = hash512(R || X || M)
+	// h = hash512(dom2 || R || X || M)
 	h := sha512.New()
 	h.Reset()
+	h.Write(round.temp.dom)
 	h.Write(encodedR[:])
 	h.Write(encodedPubKey[:])
 	if round.temp.fullBytesLen == 0 {
@@ -75,11 +76,18 @@ func (round *round1) Start() *tss.Error {
 
 	// compute si
 	var localS [32]byte
-	edwards25519.ScMulAdd(&localS, &lambdaReduced, bigIntToEncodedBytes(round.temp.wi), riBytes)
+	wiBytes := bigIntToEncodedBytes(round.temp.wi)
+	edwards25519.ScMulAdd(&localS, &lambdaReduced, wiBytes, riBytes)
+	common.ZeroBytes(wiBytes[:])
 
 	// store message pieces
 	round.temp.si = &localS
 
+	// the presignature is single-use, wipe it together with the key share now that it has been consumed
+	common.ZeroBytes(riBytes[:])
+	round.pre.Destroy()
+	common.ZeroBigInts(round.temp.wi, round.key.PrivXi)
+
 	// broadcast si to other parties
 	r1msg := sign.NewSignRound3Message(round.PartyID(), encodedBytesToBigInt(&localS))
 	round.temp.signRound1Messages[i] = r1msg
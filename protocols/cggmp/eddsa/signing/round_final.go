@@ -9,6 +9,7 @@ import (
 	edwards "github.com/decred/dcrd/dcrec/edwards/v2"
 
 	"github.com/felicityin/mpc-tss/common"
+	"github.com/felicityin/mpc-tss/crypto/sigfmt"
 	"github.com/felicityin/mpc-tss/protocols/cggmp/eddsa/sign"
 	"github.com/felicityin/mpc-tss/tss"
 )
@@ -60,7 +61,13 @@ func (round *finalization) Start() *tss.Error {
 		Y:     round.temp.pubW.Y(),
 	}
 
-	ok := edwards.Verify(&pk, round.data.M, round.pre.R, s)
+	var ok bool
+	if len(round.temp.dom) == 0 {
+		ok = edwards.Verify(&pk, round.data.M, round.pre.R, s)
+	} else {
+		// edwards.Verify has no dom2, Ed25519ph and Ed25519ctx are verified with crypto/ed25519
+		ok = sigfmt.VerifyEd25519(round.temp.pubW, round.data) == nil
+	}
 	if !ok {
 		return round.WrapError(fmt.Errorf("signature verification failed"))
 	}
@@ -0,0 +1,175 @@
+package keygen
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/felicityin/mpc-tss/common"
+	"github.com/felicityin/mpc-tss/crypto"
+	"github.com/felicityin/mpc-tss/crypto/ckd"
+	"github.com/felicityin/mpc-tss/tss"
+)
+
+type (
+	// GroupDescriptor is the public part of a key shared by a group of parties: it holds no share and is the same for all the parties.
+	// Verifiers and watch-only wallets can load it to check the signatures and to derive the child public keys.
+	GroupDescriptor struct {
+		Curve     tss.CurveName
+		Pubkey    *crypto.ECPoint
+		ChainCode []byte // ChainCodeLen bytes
+
+		// the HD derivation of the child keys, DerivationBIP32 if empty
+		Derivation string `json:",omitempty"`
+
+		// a threshold key is signed by any Threshold+1 parties, a non-threshold key by all the parties
+		IsThreshold bool
+		Threshold   int
+
+		Ks      []*big.Int
+		PubXj   []*crypto.ECPoint
+		Parties []PartyIdentity // the party of Ks[j] is Parties[j]
+	}
+
+	// PartyIdentity is the identity of a party, as in its tss.PartyID
+	PartyIdentity struct {
+		Id      string
+		Moniker string
+	}
+)
+
+// NewGroupDescriptor returns the group descriptor of the key of the save data, whose parties are parties.
+// The signing of a threshold key needs threshold+1 parties, see tss.Parameters.
+func NewGroupDescriptor(key LocalPartySaveData, parties tss.SortedPartyIDs, isThreshold bool, threshold int) (*GroupDescriptor, error) {
+	if key.Pubkey == nil || key.ChainCode == nil {
+		return nil, errors.New("save data is missing Pubkey or ChainCode")
+	}
+	curve, ok := tss.GetCurveName(key.Pubkey.Curve())
+	if !ok {
+		return nil, errors.New("the curve of the key is not registered")
+	}
+	if key.ChainCode.Sign() < 0 || len(key.ChainCode.Bytes()) > ChainCodeLen {
+		return nil, fmt.Errorf("save data ChainCode is longer than %d bytes", ChainCodeLen)
+	}
+
+	d := &GroupDescriptor{
+		Curve:       curve,
+		Pubkey:      key.Pubkey,
+		ChainCode:   common.PadToLengthBytesInPlace(key.ChainCode.Bytes(), ChainCodeLen),
+		Derivation:  key.Derivation,
+		IsThreshold: isThreshold,
+		Threshold:   threshold,
+		Ks:          key.Ks,
+		PubXj:       key.PubXj,
+		Parties:     make([]PartyIdentity, len(key.Ks)),
+	}
+	for j, kj := range key.Ks {
+		if kj == nil {
+			return nil, fmt.Errorf("save data Ks[%d] is nil", j)
+		}
+		Pj := parties.FindByKey(kj)
+		if Pj == nil {
+			return nil, fmt.Errorf("no party has the key of Ks[%d]: %s", j, hex.EncodeToString(kj.Bytes()))
+		}
+		d.Parties[j] = PartyIdentity{Id: Pj.Id, Moniker: Pj.Moniker}
+	}
+	if err := d.Validate(); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// Validate checks that the group descriptor is internally consistent, as LocalPartySaveData.Validate does for the public data,
+// and that the threshold can be met by the parties
+func (d *GroupDescriptor) Validate() error {
+	ec, ok := tss.GetCurveByName(d.Curve)
+	if !ok {
+		return fmt.Errorf("group descriptor curve %q is unknown", d.Curve)
+	}
+	if d.Pubkey == nil || !tss.SameCurve(d.Pubkey.Curve(), ec) {
+		return fmt.Errorf("group descriptor Pubkey is missing or not on %s", d.Curve)
+	}
+	if len(d.ChainCode) != ChainCodeLen {
+		return fmt.Errorf("group descriptor ChainCode is not %d bytes", ChainCodeLen)
+	}
+	if len(d.Parties) != len(d.Ks) {
+		return fmt.Errorf("group descriptor has mismatched Ks and Parties (%d != %d)", len(d.Ks), len(d.Parties))
+	}
+	if d.Threshold < 0 || len(d.Ks) <= d.Threshold {
+		return fmt.Errorf("group descriptor threshold %d cannot be met by %d parties", d.Threshold, len(d.Ks))
+	}
+	if err := validatePublicData(d.Pubkey, d.Ks, d.PubXj, d.Derivation); err != nil {
+		return fmt.Errorf("group descriptor %s", err.Error())
+	}
+	return nil
+}
+
+// ExtendedKey returns the extended public key of the master key, with the version bytes of a network,
+// e.g. chaincfg.MainNetParams.HDPublicKeyID. Its chain code has no leading zeros with DerivationBIP32,
+// as the signers derive the child keys.
+func (d *GroupDescriptor) ExtendedKey(version [4]byte) *ckd.ExtendedKey {
+	chainCode := d.ChainCode
+	if d.Derivation == DerivationBIP32 {
+		chainCode = new(big.Int).SetBytes(d.ChainCode).Bytes()
+	}
+	return &ckd.ExtendedKey{
+		PublicKey:  d.Pubkey,
+		Depth:      0,
+		ChildIndex: 0,
+		ChainCode:  chainCode,
+		ParentFP:   []byte{0x00, 0x00, 0x00, 0x00},
+		Version:    version[:],
+	}
+}
+
+// DeriveChildKey derives the child key of the non-hardened path with the derivation of the key.
+// It returns the shift of the private key, which the signers add to the key, and the child key.
+func (d *GroupDescriptor) DeriveChildKey(path string, version [4]byte) (*big.Int, *ckd.ExtendedKey, error) {
+	deltas, keys, err := d.DeriveChildKeys([]string{path}, version)
+	if err != nil {
+		return nil, nil, err
+	}
+	return deltas[0], keys[0], nil
+}
+
+// DeriveChildKeys derives the child keys of many non-hardened paths in a single call, as DeriveChildKey does.
+// The keys of the common prefixes of the paths are derived once.
+func (d *GroupDescriptor) DeriveChildKeys(paths []string, version [4]byte) ([]*big.Int, []*ckd.ExtendedKey, error) {
+	walletPaths := make([][]uint32, len(paths))
+	for i, path := range paths {
+		var err error
+		if walletPaths[i], err = ckd.ConvertPath(path); err != nil {
+			return nil, nil, err
+		}
+	}
+	switch d.Derivation {
+	case DerivationBIP32, DerivationBIP32V2:
+		ec := d.Pubkey.Curve()
+		return ckd.DeriveChildKeysFromHierarchies(walletPaths, d.ExtendedKey(version), ec.Params().N, ec)
+	case DerivationBIP32Ed25519:
+		return ckd.DeriveEd25519ChildKeysFromHierarchies(walletPaths, d.ExtendedKey(version))
+	default:
+		return nil, nil, fmt.Errorf("unknown key derivation %q", d.Derivation)
+	}
+}
+
+// Xpub returns the BIP32 serialization of the extended public key of the path, with the version bytes of a network,
+// e.g. chaincfg.MainNetParams.HDPublicKeyID. The path of the master key is "m".
+// BIP32 serializes the keys of the short Weierstrass curves only, so ed25519 keys have no xpub.
+// With DerivationBIP32, the master key has no xpub if its chain code has a leading zero byte: the signers derive
+// the child keys without it, so the wallets would derive other children from the xpub. The keys of DerivationBIP32V2
+// always have one.
+func (d *GroupDescriptor) Xpub(path string, version [4]byte) (string, error) {
+	if tss.SameCurve(d.Pubkey.Curve(), tss.Edwards()) {
+		return "", errors.New("ed25519 keys have no BIP32 serialization")
+	}
+	_, extendedKey, err := d.DeriveChildKey(path, version)
+	if err != nil {
+		return "", err
+	}
+	if len(extendedKey.ChainCode) != ChainCodeLen {
+		return "", errors.New("the chain code of the master key has a leading zero byte, which its xpub would keep; derive with DerivationBIP32V2")
+	}
+	return extendedKey.String(), nil
+}
@@ -0,0 +1,248 @@
+package keygen_test
+
+import (
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcutil/hdkeychain"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/felicityin/mpc-tss/crypto/ckd"
+	"github.com/felicityin/mpc-tss/protocols/cggmp/keygen"
+	tKeygen "github.com/felicityin/mpc-tss/protocols/cggmp/keygen/threshold"
+	"github.com/felicityin/mpc-tss/protocols/utils"
+)
+
+const (
+	testThreshold = 1
+	testPath      = "m/0/1/2/2/10"
+)
+
+func TestGroupDescriptor(t *testing.T) {
+	for _, kind := range []int{keygen.Ecdsa, keygen.Eddsa, keygen.EcdsaP256} {
+		keys, pIDs, err := tKeygen.LoadKeygenTestFixtures(kind, testParticipants)
+		assert.NoError(t, err, "should load threshold keygen fixtures")
+
+		d, err := keygen.NewGroupDescriptor(keys[0], pIDs, true, testThreshold)
+		if !assert.NoError(t, err) {
+			continue
+		}
+		bz, err := json.Marshal(d)
+		assert.NoError(t, err)
+		assert.NotContains(t, string(bz), "PrivXi", "the descriptor must hold no secret")
+		assert.NotContains(t, string(bz), "ShareID", "the descriptor must hold no secret")
+
+		// all the parties export the same descriptor
+		for _, key := range keys[1:] {
+			other, err := keygen.NewGroupDescriptor(key, pIDs, true, testThreshold)
+			assert.NoError(t, err)
+			otherBz, err := json.Marshal(other)
+			assert.NoError(t, err)
+			assert.Equal(t, string(bz), string(otherBz))
+		}
+
+		var loaded keygen.GroupDescriptor
+		assert.NoError(t, json.Unmarshal(bz, &loaded))
+		assert.NoError(t, loaded.Validate())
+		assert.True(t, loaded.Pubkey.Equals(keys[0].Pubkey))
+		for j, Pj := range pIDs {
+			assert.Equal(t, Pj.Id, loaded.Parties[j].Id)
+			assert.Equal(t, 0, Pj.KeyInt().Cmp(loaded.Ks[j]))
+		}
+
+		// the child keys of the descriptor are the child keys that the signers derive
+		delta, child, err := loaded.DeriveChildKey(testPath, chaincfg.MainNetParams.HDPublicKeyID)
+		assert.NoError(t, err)
+		wantDelta, wantChild, err := utils.DerivingKeyFromPath(keys[0], testPath)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, wantDelta.Cmp(delta))
+		assert.True(t, wantChild.PublicKey.Equals(child.PublicKey))
+
+		_, _, err = loaded.DeriveChildKey("m/44'/0", chaincfg.MainNetParams.HDPublicKeyID)
+		assert.Error(t, err, "hardened children cannot be derived from the descriptor")
+	}
+}
+
+func TestGroupDescriptorDeriveChildKeys(t *testing.T) {
+	paths := []string{"m/0/1", testPath, "m", "0/1/2", "m/2147483647/7"}
+	for _, derivation := range []string{keygen.DerivationBIP32, keygen.DerivationBIP32V2, keygen.DerivationBIP32Ed25519} {
+		keys, pIDs, err := tKeygen.LoadKeygenTestFixtures(keygen.Eddsa, testParticipants)
+		assert.NoError(t, err, "should load threshold keygen fixtures")
+		keys[0].Derivation = derivation
+		d, err := keygen.NewGroupDescriptor(keys[0], pIDs, true, testThreshold)
+		assert.NoError(t, err)
+
+		deltas, children, err := d.DeriveChildKeys(paths, chaincfg.TestNet3Params.HDPublicKeyID)
+		assert.NoError(t, err)
+		wantDeltas, wantChildren, err := utils.DerivingKeysFromPaths(keys[0], paths, chaincfg.TestNet3Params.HDPublicKeyID)
+		assert.NoError(t, err)
+		for i, path := range paths {
+			delta, child, err := utils.DerivingKeyFromPath(keys[0], path)
+			assert.NoError(t, err)
+			assert.Equal(t, 0, delta.Cmp(deltas[i]), path)
+			assert.Equal(t, 0, delta.Cmp(wantDeltas[i]), path)
+			assert.True(t, child.PublicKey.Equals(children[i].PublicKey), path)
+			assert.True(t, child.PublicKey.Equals(wantChildren[i].PublicKey), path)
+		}
+
+		_, _, err = d.DeriveChildKeys([]string{testPath, "m/44'"}, chaincfg.TestNet3Params.HDPublicKeyID)
+		assert.Error(t, err, "hardened children cannot be derived from the descriptor")
+	}
+}
+
+func TestGroupDescriptorBIP32Ed25519(t *testing.T) {
+	keys, pIDs, err := tKeygen.LoadKeygenTestFixtures(keygen.Eddsa, testParticipants)
+	assert.NoError(t, err, "should load threshold keygen fixtures")
+	keys[0].Derivation = keygen.DerivationBIP32Ed25519
+
+	d, err := keygen.NewGroupDescriptor(keys[0], pIDs, true, testThreshold)
+	assert.NoError(t, err)
+	delta, child, err := d.DeriveChildKey(testPath, [4]byte{})
+	assert.NoError(t, err)
+	wantDelta, wantChild, err := utils.DerivingKeyFromPath(keys[0], testPath)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, wantDelta.Cmp(delta))
+	assert.True(t, wantChild.PublicKey.Equals(child.PublicKey))
+
+	_, err = d.Xpub("", chaincfg.MainNetParams.HDPublicKeyID)
+	assert.Error(t, err, "ed25519 keys have no xpub")
+
+	// the descriptor keeps the leading zero bytes of the chain code, as the signers do
+	keys[0].ChainCode = new(big.Int).Rsh(keys[0].ChainCode, 8)
+	d, err = keygen.NewGroupDescriptor(keys[0], pIDs, true, testThreshold)
+	assert.NoError(t, err)
+	delta, child, err = d.DeriveChildKey(testPath, [4]byte{})
+	assert.NoError(t, err)
+	wantDelta, wantChild, err = utils.DerivingKeyFromPath(keys[0], testPath)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, wantDelta.Cmp(delta))
+	assert.True(t, wantChild.PublicKey.Equals(child.PublicKey))
+}
+
+func TestGroupDescriptorXpub(t *testing.T) {
+	keys, pIDs, err := tKeygen.LoadKeygenTestFixtures(keygen.Ecdsa, testParticipants)
+	assert.NoError(t, err, "should load threshold keygen fixtures")
+	d, err := keygen.NewGroupDescriptor(keys[0], pIDs, true, testThreshold)
+	assert.NoError(t, err)
+
+	for _, net := range []*chaincfg.Params{&chaincfg.MainNetParams, &chaincfg.TestNet3Params} {
+		xpub, err := d.Xpub("", net.HDPublicKeyID)
+		assert.NoError(t, err)
+		childXpub, err := d.Xpub(testPath, net.HDPublicKeyID)
+		assert.NoError(t, err)
+
+		// the wallets derive the same child from the xpub
+		wallet, err := hdkeychain.NewKeyFromString(xpub)
+		if !assert.NoError(t, err) {
+			continue
+		}
+		assert.True(t, wallet.IsForNet(net))
+		assert.False(t, wallet.IsPrivate())
+		path, err := ckd.ConvertPath(testPath)
+		assert.NoError(t, err)
+		for _, index := range path {
+			wallet, err = wallet.Derive(index)
+			assert.NoError(t, err)
+		}
+		assert.Equal(t, wallet.String(), childXpub)
+	}
+	xpub, err := d.Xpub("", chaincfg.MainNetParams.HDPublicKeyID)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(xpub, "xpub"))
+
+	// P-256 keys are serialized in the same way
+	keys, pIDs, err = tKeygen.LoadKeygenTestFixtures(keygen.EcdsaP256, testParticipants)
+	assert.NoError(t, err, "should load threshold keygen fixtures")
+	d, err = keygen.NewGroupDescriptor(keys[0], pIDs, true, testThreshold)
+	assert.NoError(t, err)
+	xpub, err = d.Xpub("", chaincfg.MainNetParams.HDPublicKeyID)
+	assert.NoError(t, err)
+	parsed, err := ckd.NewExtendedKeyFromString(xpub, tKeygen.TestCurve(keygen.EcdsaP256))
+	assert.NoError(t, err)
+	assert.True(t, parsed.PublicKey.Equals(d.Pubkey))
+	assert.Equal(t, d.ChainCode, parsed.ChainCode)
+
+	// the descriptor derives the children of a chain code with a leading zero byte as the signers do,
+	// and its master key has no xpub
+	keys[0].ChainCode = new(big.Int).Rsh(keys[0].ChainCode, 8)
+	d, err = keygen.NewGroupDescriptor(keys[0], pIDs, true, testThreshold)
+	assert.NoError(t, err)
+	_, child, err := d.DeriveChildKey(testPath, chaincfg.MainNetParams.HDPublicKeyID)
+	assert.NoError(t, err)
+	_, wantChild, err := utils.DerivingKeyFromPath(keys[0], testPath)
+	assert.NoError(t, err)
+	assert.True(t, wantChild.PublicKey.Equals(child.PublicKey))
+	_, err = d.Xpub("", chaincfg.MainNetParams.HDPublicKeyID)
+	assert.Error(t, err)
+	_, err = d.Xpub(testPath, chaincfg.MainNetParams.HDPublicKeyID)
+	assert.NoError(t, err)
+}
+
+// The master key of DerivationBIP32V2 has an xpub when its chain code has a leading zero byte,
+// from which the wallets derive the children that the signers sign for
+func TestGroupDescriptorXpubLeadingZeroChainCode(t *testing.T) {
+	keys, pIDs, err := tKeygen.LoadKeygenTestFixtures(keygen.Ecdsa, testParticipants)
+	assert.NoError(t, err, "should load threshold keygen fixtures")
+	keys[0].ChainCode = new(big.Int).Rsh(keys[0].ChainCode, 8)
+	keys[0].Derivation = keygen.DerivationBIP32V2
+	d, err := keygen.NewGroupDescriptor(keys[0], pIDs, true, testThreshold)
+	assert.NoError(t, err)
+
+	xpub, err := d.Xpub("", chaincfg.MainNetParams.HDPublicKeyID)
+	assert.NoError(t, err)
+	wallet, err := hdkeychain.NewKeyFromString(xpub)
+	assert.NoError(t, err)
+	assert.Equal(t, d.ChainCode, wallet.ChainCode())
+	assert.Equal(t, byte(0x00), wallet.ChainCode()[0])
+
+	path, err := ckd.ConvertPath(testPath)
+	assert.NoError(t, err)
+	for _, index := range path {
+		wallet, err = wallet.Derive(index)
+		assert.NoError(t, err)
+	}
+	walletPk, err := wallet.ECPubKey()
+	assert.NoError(t, err)
+
+	_, child, err := utils.DerivingKeyFromPath(keys[0], testPath)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, walletPk.X().Cmp(child.PublicKey.X()))
+	assert.Equal(t, 0, walletPk.Y().Cmp(child.PublicKey.Y()))
+	childXpub, err := d.Xpub(testPath, chaincfg.MainNetParams.HDPublicKeyID)
+	assert.NoError(t, err)
+	assert.Equal(t, wallet.String(), childXpub)
+
+	// the legacy derivation of the same key derives other children
+	keys[0].Derivation = keygen.DerivationBIP32
+	_, legacy, err := utils.DerivingKeyFromPath(keys[0], testPath)
+	assert.NoError(t, err)
+	assert.False(t, legacy.PublicKey.Equals(child.PublicKey))
+}
+
+func TestGroupDescriptorCorrupted(t *testing.T) {
+	keys, pIDs, err := tKeygen.LoadKeygenTestFixtures(keygen.Ecdsa, testParticipants)
+	assert.NoError(t, err, "should load threshold keygen fixtures")
+
+	_, err = keygen.NewGroupDescriptor(keys[0], pIDs[1:], true, testThreshold)
+	assert.Error(t, err, "every party of the key must have an identity")
+
+	_, err = keygen.NewGroupDescriptor(keys[0], pIDs, true, testParticipants)
+	assert.Error(t, err, "the threshold must be met by the parties")
+
+	d, err := keygen.NewGroupDescriptor(keys[0], pIDs, true, testThreshold)
+	assert.NoError(t, err)
+	corrupted := *d
+	corrupted.ChainCode = d.ChainCode[1:]
+	assert.Error(t, corrupted.Validate(), "ChainCode must be ChainCodeLen bytes")
+
+	corrupted = *d
+	corrupted.Curve = "ed25519"
+	assert.Error(t, corrupted.Validate(), "Pubkey must be on the curve")
+
+	corrupted = *d
+	corrupted.Ks = []*big.Int{d.Ks[0], d.Ks[0], d.Ks[2]}
+	assert.Error(t, corrupted.Validate(), "Ks must be unique")
+}
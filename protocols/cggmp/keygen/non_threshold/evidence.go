@@ -0,0 +1,224 @@
+package keygen
+
+import (
+	"bytes"
+	"crypto/elliptic"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/felicityin/mpc-tss/common"
+	"github.com/felicityin/mpc-tss/crypto"
+	"github.com/felicityin/mpc-tss/crypto/alice/utils"
+	"github.com/felicityin/mpc-tss/crypto/schnorr"
+	"github.com/felicityin/mpc-tss/tss"
+)
+
+// Kinds of the evidence against a party of the keygen
+const (
+	EvidenceDecommitment = "cggmp/keygen/non_threshold/decommitment"
+	EvidenceSchnorrProof = "cggmp/keygen/non_threshold/schnorr-proof"
+)
+
+func init() {
+	tss.RegisterEvidenceCheck(EvidenceDecommitment, checkDecommitmentEvidence)
+	tss.RegisterEvidenceCheck(EvidenceSchnorrProof, checkSchnorrProofEvidence)
+}
+
+type (
+	// DecommitmentInputs are the public inputs of the check that the round 2 message of Pj opens its round 1 commitment.
+	// Ks are the keys of the parties, which the ssid is computed from.
+	DecommitmentInputs struct {
+		Curve tss.CurveName `json:"curve"`
+		Index int           `json:"index"`
+		Ks    []*big.Int    `json:"ks"`
+	}
+
+	// SchnorrProofInputs are the public inputs of the check of the schnorr proof of Pj in round 3.
+	// The ssid is computed from the keys Ks of the parties, and srid from the round 1 and round 2 messages of all the parties.
+	SchnorrProofInputs struct {
+		Curve tss.CurveName `json:"curve"`
+		Index int           `json:"index"`
+		Ks    []*big.Int    `json:"ks"`
+	}
+)
+
+// computeSSID returns the ssid of the keygen of the parties of the keys ks
+func computeSSID(ec elliptic.Curve, ks []*big.Int, number int, nonce *big.Int) []byte {
+	ssidList := []*big.Int{ec.Params().P, ec.Params().N, ec.Params().Gx, ec.Params().Gy} // ec curve
+	ssidList = append(ssidList, ks...)
+	ssidList = append(ssidList, big.NewInt(int64(number))) // round number
+	ssidList = append(ssidList, nonce)
+	return common.SHA512_256i(ssidList...).Bytes()
+}
+
+// checkDecommitment checks that the round 2 message of Pj opens its round 1 commitment V_j
+func checkDecommitment(
+	ec elliptic.Curve, in *DecommitmentInputs, ssid []byte, r1msg *KGRound1Message, r2msg *KGRound2Message,
+) (*CmpKeyGenerationPayload, *crypto.ECPoint, error) {
+	payload, err := r2msg.UnmarshalPayload(ec)
+	if err != nil {
+		return nil, nil, err
+	}
+	pubXj, err := r2msg.UnmarshalPubXj(ec)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !bytes.Equal(payload.ssid, ssid) {
+		return nil, nil, errors.New("ssid verify failed")
+	}
+
+	v := common.SHA512_256(
+		ssid,
+		[]byte(strconv.Itoa(in.Index)),
+		payload.srid,
+		pubXj.X().Bytes(),
+		pubXj.Y().Bytes(),
+		payload.commitedA.X().Bytes(),
+		payload.commitedA.Y().Bytes(),
+		payload.u,
+		r2msg.GetChainCode(),
+	)
+	if !bytes.Equal(v, r1msg.GetCommitment()) {
+		return nil, nil, errors.New("commited v_i verify failed")
+	}
+	return payload, pubXj, nil
+}
+
+// checkSchnorrProof checks the schnorr proof of Pj for the key share X_j and the commitment A_j sent in round 2
+func checkSchnorrProof(ec elliptic.Curve, ssid, srid []byte, j int, r2msg *KGRound2Message, r3msg *KGRound3Message) error {
+	payload, err := r2msg.UnmarshalPayload(ec)
+	if err != nil {
+		return err
+	}
+	pubXj, err := r2msg.UnmarshalPubXj(ec)
+	if err != nil {
+		return err
+	}
+
+	challenge := common.RejectionSample(
+		ec.Params().N,
+		common.SHA512_256i_TAGGED(
+			append(append([]byte{}, ssid...), srid...),
+			big.NewInt(int64(j)),
+			pubXj.X(),
+			pubXj.Y(),
+			payload.commitedA.X(),
+			payload.commitedA.Y(),
+		),
+	)
+	schProof := schnorr.Proof{Proof: r3msg.UnmarshalSchProof()}
+	if !schProof.Verify(payload.commitedA, pubXj, challenge) {
+		return errors.New("schnorr proof verify failed")
+	}
+	return nil
+}
+
+func checkDecommitmentEvidence(ev *tss.Evidence, msgs []tss.ParsedMessage) (failure error, err error) {
+	in := new(DecommitmentInputs)
+	if err := ev.UnmarshalInputs(in); err != nil {
+		return nil, err
+	}
+	ec, err := evidenceCurve(ev, in.Curve, in.Index)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkKeys(ev, in.Ks, in.Index); err != nil {
+		return nil, err
+	}
+	if len(msgs) != 2 {
+		return nil, errors.New("expected the round 1 and round 2 messages")
+	}
+	r1msg, ok1 := msgs[0].Content().(*KGRound1Message)
+	r2msg, ok2 := msgs[1].Content().(*KGRound2Message)
+	if !ok1 || !ok2 {
+		return nil, errors.New("expected the round 1 and round 2 messages")
+	}
+	_, _, failure = checkDecommitment(ec, in, computeSSID(ec, in.Ks, 1, big.NewInt(0)), r1msg, r2msg)
+	return failure, nil
+}
+
+// checkSchnorrProofEvidence checks the round 3 message of the culprit, which the evidence holds first,
+// against the round 1 and round 2 messages of all the parties
+func checkSchnorrProofEvidence(ev *tss.Evidence, msgs []tss.ParsedMessage) (failure error, err error) {
+	in := new(SchnorrProofInputs)
+	if err := ev.UnmarshalInputs(in); err != nil {
+		return nil, err
+	}
+	ec, err := evidenceCurve(ev, in.Curve, in.Index)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkKeys(ev, in.Ks, in.Index); err != nil {
+		return nil, err
+	}
+	if len(msgs) == 0 {
+		return nil, errors.New("expected the round 3 message")
+	}
+	r3msg, ok := msgs[0].Content().(*KGRound3Message)
+	if !ok {
+		return nil, errors.New("expected the round 3 message")
+	}
+	r1msgs, err := messagesOfKeys(msgs[1:], in.Ks, (*KGRound1Message)(nil))
+	if err != nil {
+		return nil, err
+	}
+	r2msgs, err := messagesOfKeys(msgs[1:], in.Ks, (*KGRound2Message)(nil))
+	if err != nil {
+		return nil, err
+	}
+
+	// srid is the xor of the srid of all the parties
+	ssid := computeSSID(ec, in.Ks, 1, big.NewInt(0))
+	var srid []byte
+	for k := range in.Ks {
+		decommitInputs := &DecommitmentInputs{Curve: in.Curve, Index: k, Ks: in.Ks}
+		payload, _, err := checkDecommitment(ec, decommitInputs, ssid, r1msgs[k].Content().(*KGRound1Message), r2msgs[k].Content().(*KGRound2Message))
+		if err != nil {
+			// the party should be blamed for its commitment instead
+			return nil, fmt.Errorf("the messages of party %d are invalid: %s", k, err.Error())
+		}
+		srid = utils.Xor(srid, payload.srid)
+	}
+	return checkSchnorrProof(ec, ssid, srid, in.Index, r2msgs[in.Index].Content().(*KGRound2Message), r3msg), nil
+}
+
+// messagesOfKeys returns the messages of the content type of sample of the parties of the keys ks, in the order of their index
+func messagesOfKeys(msgs []tss.ParsedMessage, ks []*big.Int, sample tss.MessageContent) ([]tss.ParsedMessage, error) {
+	byIndex, err := tss.MessagesBySender(msgs, len(ks), sample)
+	if err != nil {
+		return nil, err
+	}
+	for j, msg := range byIndex {
+		if msg.GetFrom().KeyInt().Cmp(ks[j]) != 0 {
+			return nil, fmt.Errorf("the message of party %d is not from the party of its key", j)
+		}
+	}
+	return byIndex, nil
+}
+
+// checkKeys checks that the keys of the parties hold the key of the culprit at its index
+func checkKeys(ev *tss.Evidence, ks []*big.Int, index int) error {
+	if index < 0 || index >= len(ks) || ks[index] == nil || ks[index].Cmp(ev.Culprit.KeyInt()) != 0 {
+		return errors.New("the keys of the parties do not hold the key of the culprit")
+	}
+	for j, k := range ks {
+		if k == nil {
+			return fmt.Errorf("the key of party %d is missing", j)
+		}
+	}
+	return nil
+}
+
+// evidenceCurve returns the curve of the inputs of the evidence, which must be about the culprit
+func evidenceCurve(ev *tss.Evidence, curve tss.CurveName, index int) (elliptic.Curve, error) {
+	if index != ev.Culprit.Index {
+		return nil, fmt.Errorf("the inputs are about party %d, not the culprit", index)
+	}
+	ec, ok := tss.GetCurveByName(curve)
+	if !ok {
+		return nil, fmt.Errorf("unknown curve %s", curve)
+	}
+	return ec, nil
+}
@@ -273,6 +273,96 @@ func verifyEddsa(t *testing.T, parties []*LocalParty, save *save.LocalPartySaveD
 	t.Log("EDDSA signing test done.")
 }
 
+func TestEvidenceOfBadSchnorrProof(t *testing.T) {
+	setUp("info")
+
+	pIDs := tss.GenerateTestPartyIDs(testParticipants)
+	p2pCtx := tss.NewPeerContext(pIDs)
+	parties := make([]*LocalParty, 0, len(pIDs))
+
+	errCh := make(chan *tss.Error, len(pIDs))
+	outCh := make(chan tss.Message, len(pIDs))
+	endCh := make(chan *save.LocalPartySaveData, len(pIDs))
+
+	updater := test.SharedPartyUpdater
+
+	for i := 0; i < len(pIDs); i++ {
+		params := tss.NewParameters(tss.S256(), p2pCtx, pIDs[i], len(pIDs), testThreshold)
+		P := NewLocalParty(params, outCh, endCh).(*LocalParty)
+		parties = append(parties, P)
+		go func(P *LocalParty) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	// P0 cheats with its schnorr proof, so the others must blame it
+	var honest tss.Message
+	errs := make([]*tss.Error, 0, len(pIDs)-1)
+	for len(errs) < len(pIDs)-1 {
+		select {
+		case err := <-errCh:
+			errs = append(errs, err)
+
+		case msg := <-outCh:
+			if r3msg, ok := msg.(tss.ParsedMessage).Content().(*KGRound3Message); ok && msg.GetFrom().Index == 0 {
+				honest = msg
+				proof := new(big.Int).Add(r3msg.UnmarshalSchProof(), big.NewInt(1))
+				msg = NewKGRound3Message(msg.GetFrom(), proof.Bytes())
+			}
+			if msg.GetTo() == nil {
+				for _, P := range parties {
+					go updater(P, msg, errCh)
+				}
+			} else {
+				go updater(parties[msg.GetTo()[0].Index], msg, errCh)
+			}
+
+		case <-endCh:
+		}
+	}
+
+	for _, err := range errs {
+		assert.NotEqual(t, 0, err.Victim().Index)
+		if assert.Len(t, err.Culprits(), 1) {
+			assert.Equal(t, 0, err.Culprits()[0].Index)
+		}
+		if !assert.Len(t, err.Evidence(), 1) {
+			continue
+		}
+		ev := err.Evidence()[0]
+		assert.Equal(t, EvidenceSchnorrProof, ev.Kind)
+		assert.NoError(t, ev.Verify())
+
+		// anyone can verify the evidence from its JSON encoding
+		bz, jsonErr := json.Marshal(ev)
+		assert.NoError(t, jsonErr)
+		decoded := new(tss.Evidence)
+		assert.NoError(t, json.Unmarshal(bz, decoded))
+		assert.NoError(t, decoded.Verify())
+
+		// the honest message does not prove anything
+		wire, _, wireErr := honest.WireBytes()
+		assert.NoError(t, wireErr)
+		decoded.Messages[0].WireBytes = wire
+		assert.ErrorIs(t, decoded.Verify(), tss.ErrNoMisbehaviour)
+
+		// srid comes from the messages of all the parties, so the evidence needs all of them
+		msgs, parseErr := ev.ParseMessages()
+		if assert.NoError(t, parseErr) {
+			partial, evErr := tss.NewEvidence(ev.Kind, ev.Task, ev.Round, ev.Culprit, ev.Inputs, msgs[:len(msgs)-1]...)
+			if assert.NoError(t, evErr) {
+				assert.Error(t, partial.Verify())
+			}
+		}
+
+		// nor does evidence about another party
+		decoded.Culprit = pIDs[1]
+		assert.Error(t, decoded.Verify())
+	}
+}
+
 func tryWriteTestFixtureFile(t *testing.T, kind, index int, data save.LocalPartySaveData) {
 	fixtureFileName := makeTestFixtureFilePath(kind, index)
 
@@ -1,10 +1,8 @@
 package keygen
 
 import (
-	"bytes"
 	"errors"
 	"math/big"
-	"strconv"
 
 	"github.com/felicityin/mpc-tss/common"
 	"github.com/felicityin/mpc-tss/crypto/alice/utils"
@@ -29,40 +27,15 @@ func (round *round3) Start() *tss.Error {
 		}
 
 		r2msg := msg.Content().(*KGRound2Message)
+		r1msg := round.temp.kgRound1Messages[j]
 
-		var err error
-		round.temp.payload[j], err = r2msg.UnmarshalPayload(round.EC())
+		inputs := &DecommitmentInputs{Curve: round.curveName(), Index: j, Ks: round.Parties().IDs().Keys()}
+		payload, pubXj, err := checkDecommitment(round.EC(), inputs, round.temp.ssid, r1msg.Content().(*KGRound1Message), r2msg)
 		if err != nil {
-			return round.WrapError(err)
-		}
-		round.save.PubXj[j], err = r2msg.UnmarshalPubXj(round.EC())
-		if err != nil {
-			return round.WrapError(err)
-		}
-
-		if !bytes.Equal(round.temp.payload[j].ssid, round.temp.ssid) {
-			common.Logger.Errorf("payload.ssid != round.temp.ssid, party: %d", j)
-			return round.WrapError(errors.New("ssid verify failed"))
-		}
-
-		common.Logger.Debugf("party: %d, round_3, calc V", i)
-		v := common.SHA512_256(
-			round.temp.ssid,
-			[]byte(strconv.Itoa(j)),
-			round.temp.payload[j].srid,
-			round.save.PubXj[j].X().Bytes(),
-			round.save.PubXj[j].Y().Bytes(),
-			round.temp.payload[j].commitedA.X().Bytes(),
-			round.temp.payload[j].commitedA.Y().Bytes(),
-			round.temp.payload[j].u,
-			r2msg.GetChainCode(),
-		)
-
-		// Verify commited V_i
-		if !bytes.Equal(v, round.temp.V[j]) {
-			common.Logger.Errorf("hash != V, party: %d", j)
-			return round.WrapError(errors.New("commited v_i verify failed"))
+			common.Logger.Errorf("verify decommitment failed: %s, party: %d", err, j)
+			return round.WrapEvidence(err, EvidenceDecommitment, inputs, r1msg, msg)
 		}
+		round.temp.payload[j], round.save.PubXj[j] = payload, pubXj
 
 		// Set srid as xor of all party's srid_i
 		common.Logger.Debugf("party: %d, round_3, calc srid", i)
@@ -89,6 +62,7 @@ func (round *round3) Start() *tss.Error {
 	// Generate schnorr proof
 	common.Logger.Debugf("party: %d, round_3, calc schnorr proof", i)
 	schProof := schnorr.Prove(round.EC().Params().N, round.temp.tau, challenge, round.save.PrivXi)
+	common.ZeroBigInts(round.temp.tau)
 
 	// BROADCAST proofs
 	common.Logger.Infof("party: %d, round_3 broadcast", i)
@@ -3,10 +3,8 @@ package keygen
 import (
 	"encoding/hex"
 	"errors"
-	"math/big"
 
 	"github.com/felicityin/mpc-tss/common"
-	"github.com/felicityin/mpc-tss/crypto/schnorr"
 	"github.com/felicityin/mpc-tss/tss"
 )
 
@@ -26,28 +24,12 @@ func (round *round4) Start() *tss.Error {
 			continue
 		}
 
-		common.Logger.Debugf("round_4 calc challenge")
-		challenge := common.RejectionSample(
-			round.EC().Params().N,
-			common.SHA512_256i_TAGGED(
-				append(round.temp.ssid, round.temp.srid...),
-				big.NewInt(int64(j)),
-				round.save.PubXj[j].X(),
-				round.save.PubXj[j].Y(),
-				round.temp.payload[j].commitedA.X(),
-				round.temp.payload[j].commitedA.Y(),
-			),
-		)
-
-		common.Logger.Debugf("round_4 get proof")
-
-		schProof := schnorr.Proof{Proof: msg.Content().(*KGRound3Message).UnmarshalSchProof()}
-
-		common.Logger.Debugf("round_4 verify proof")
-
-		if !schProof.Verify(round.temp.payload[j].commitedA, round.save.PubXj[j], challenge) {
+		r2msg := round.temp.kgRound2Messages[j].Content().(*KGRound2Message)
+		if err := checkSchnorrProof(round.EC(), round.temp.ssid, round.temp.srid, j, r2msg, msg.Content().(*KGRound3Message)); err != nil {
 			common.Logger.Errorf("schnorr proof verify failed, party: %d", j)
-			return round.WrapError(errors.New("schnorr proof verify failed"))
+			inputs := &SchnorrProofInputs{Curve: round.curveName(), Index: j, Ks: round.Parties().IDs().Keys()}
+			msgs := append([]tss.ParsedMessage{msg}, round.temp.kgRound1Messages...)
+			return round.WrapEvidence(err, EvidenceSchnorrProof, inputs, append(msgs, round.temp.kgRound2Messages...)...)
 		}
 	}
 
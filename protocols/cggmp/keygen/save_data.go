@@ -12,8 +12,26 @@ import (
 )
 
 const (
-	Ecdsa = 0
-	Eddsa = 1
+	Ecdsa     = 0
+	Eddsa     = 1
+	EcdsaP256 = 2
+)
+
+const (
+	// ChainCodeLen is the byte length of the BIP32 chain code agreed on during keygen
+	ChainCodeLen = 32
+)
+
+// The HD derivations of the child keys of a key
+const (
+	// DerivationBIP32 is the public derivation of BIP32 on the curve of the key, the default
+	DerivationBIP32 = ""
+	// DerivationBIP32V2 is the public derivation of BIP32 whose chain code is the ChainCodeLen bytes of the HMAC key,
+	// leading zeros included, as the wallets derive from an xpub. DerivationBIP32 drops the leading zeros of the chain code,
+	// as the keys have always been derived, so that a master key whose chain code starts with a zero byte has no xpub.
+	DerivationBIP32V2 = "bip32-v2"
+	// DerivationBIP32Ed25519 is the public derivation of BIP32-Ed25519 for the ed25519 keys, as used by Cardano
+	DerivationBIP32Ed25519 = "bip32-ed25519"
 )
 
 type (
@@ -34,6 +52,9 @@ type (
 
 		// used for assertions and derive child
 		Pubkey *crypto.ECPoint // y
+
+		// the HD derivation of the child keys, DerivationBIP32 if empty
+		Derivation string `json:",omitempty"`
 	}
 )
 
@@ -60,6 +81,13 @@ func (save LocalPartySaveData) OriginalIndex() (int, error) {
 	return index, nil
 }
 
+// Destroy wipes the key share and the chain code from memory once the save data is no longer needed.
+// Parties created from the save data must not be used afterwards.
+func (save *LocalPartySaveData) Destroy() {
+	common.ZeroBigInts(save.PrivXi, save.ChainCode)
+	save.PrivXi, save.ChainCode = nil, nil
+}
+
 // BuildLocalSaveDataSubset re-creates the LocalPartySaveData to contain data for only the list of signing parties.
 func BuildLocalSaveDataSubset(sourceData LocalPartySaveData, sortedIDs tss.SortedPartyIDs) (newData LocalPartySaveData, err error) {
 	keysToIndices := make(map[string]int, len(sourceData.Ks))
@@ -69,6 +97,7 @@ func BuildLocalSaveDataSubset(sourceData LocalPartySaveData, sortedIDs tss.Sorte
 	newData = NewLocalPartySaveData(sortedIDs.Len())
 	newData.LocalKeygenSecrets = sourceData.LocalKeygenSecrets
 	newData.Pubkey = sourceData.Pubkey
+	newData.Derivation = sourceData.Derivation
 	for j, id := range sortedIDs {
 		savedIdx, ok := keysToIndices[hex.EncodeToString(id.Key)]
 		if !ok {
@@ -81,3 +110,118 @@ func BuildLocalSaveDataSubset(sourceData LocalPartySaveData, sortedIDs tss.Sorte
 	}
 	return newData, nil
 }
+
+// Validate checks that the save data is internally consistent before it is used for signing:
+// PrivXi*G matches this party's PubXj, Ks are unique and non-zero, PubXj combine to Pubkey
+// (Lagrange interpolation at zero for threshold keys, a plain sum for non-threshold keys)
+// and ChainCode fits in ChainCodeLen bytes.
+func (save LocalPartySaveData) Validate() error {
+	if save.PrivXi == nil || save.ShareID == nil || save.ChainCode == nil {
+		return errors.New("save data is missing PrivXi, ShareID or ChainCode")
+	}
+	if err := validatePublicData(save.Pubkey, save.Ks, save.PubXj, save.Derivation); err != nil {
+		return fmt.Errorf("save data %s", err.Error())
+	}
+
+	i, err := save.OriginalIndex()
+	if err != nil {
+		return err
+	}
+	ec := save.Pubkey.Curve()
+	if save.PrivXi.Sign() <= 0 || save.PrivXi.Cmp(ec.Params().N) >= 0 {
+		return errors.New("save data PrivXi is out of range")
+	}
+	if !crypto.ScalarBaseMult(ec, save.PrivXi).Equals(save.PubXj[i]) {
+		return fmt.Errorf("save data PrivXi*G does not match PubXj[%d]", i)
+	}
+
+	if save.ChainCode.Sign() < 0 || len(save.ChainCode.Bytes()) > ChainCodeLen {
+		return fmt.Errorf("save data ChainCode is longer than %d bytes", ChainCodeLen)
+	}
+	return nil
+}
+
+// validatePublicData checks the public data of a key: Ks are unique and non-zero, PubXj combine to Pubkey
+// and the derivation is known and fits the curve
+func validatePublicData(pubkey *crypto.ECPoint, ks []*big.Int, pubXj []*crypto.ECPoint, derivation string) error {
+	if pubkey == nil || !pubkey.ValidateBasic() {
+		return errors.New("Pubkey is missing or not on the curve")
+	}
+	if len(ks) == 0 || len(ks) != len(pubXj) {
+		return fmt.Errorf("has mismatched Ks and PubXj (%d != %d)", len(ks), len(pubXj))
+	}
+	ec := pubkey.Curve()
+	q := ec.Params().N
+
+	visited := make(map[string]struct{}, len(ks))
+	for j, kj := range ks {
+		if kj == nil {
+			return fmt.Errorf("Ks[%d] is nil", j)
+		}
+		kjMod := new(big.Int).Mod(kj, q)
+		if kjMod.Sign() == 0 {
+			return fmt.Errorf("Ks[%d] is zero", j)
+		}
+		if _, ok := visited[kjMod.String()]; ok {
+			return fmt.Errorf("Ks[%d] is a duplicate", j)
+		}
+		visited[kjMod.String()] = struct{}{}
+		if !pubXj[j].ValidateBasic() || !tss.SameCurve(pubXj[j].Curve(), ec) {
+			return fmt.Errorf("PubXj[%d] is missing or not on the curve", j)
+		}
+	}
+
+	switch derivation {
+	case DerivationBIP32, DerivationBIP32V2:
+	case DerivationBIP32Ed25519:
+		if !tss.SameCurve(ec, tss.Edwards()) {
+			return errors.New("Derivation BIP32-Ed25519 needs an ed25519 key")
+		}
+	default:
+		return fmt.Errorf("Derivation %q is unknown", derivation)
+	}
+
+	interpolated, err := interpolatePubXj(q, ks, pubXj)
+	if err != nil {
+		return err
+	}
+	if interpolated.Equals(pubkey) {
+		return nil
+	}
+	sum := pubXj[0]
+	for j := 1; j < len(pubXj); j++ {
+		if sum, err = sum.Add(pubXj[j]); err != nil {
+			return fmt.Errorf("PubXj sum failed: %s", err.Error())
+		}
+	}
+	if !sum.Equals(pubkey) {
+		return errors.New("PubXj do not combine to Pubkey")
+	}
+	return nil
+}
+
+// interpolatePubXj evaluates the public polynomial defined by (Ks[j], PubXj[j]) at zero
+func interpolatePubXj(q *big.Int, ks []*big.Int, pubXj []*crypto.ECPoint) (*crypto.ECPoint, error) {
+	modQ := common.ModInt(q)
+	var sum *crypto.ECPoint
+	for j := range ks {
+		coef := big.NewInt(1)
+		for c := range ks {
+			if c == j {
+				continue
+			}
+			// lambda_j = prod ks[c] / (ks[c] - ks[j])
+			coef = modQ.Mul(coef, modQ.Mul(ks[c], modQ.ModInverse(modQ.Sub(ks[c], ks[j]))))
+		}
+		term := pubXj[j].ScalarMult(coef)
+		if sum == nil {
+			sum = term
+			continue
+		}
+		var err error
+		if sum, err = sum.Add(term); err != nil {
+			return nil, fmt.Errorf("PubXj interpolation failed: %s", err.Error())
+		}
+	}
+	return sum, nil
+}
@@ -0,0 +1,60 @@
+package keygen_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/felicityin/mpc-tss/crypto"
+	"github.com/felicityin/mpc-tss/protocols/cggmp/keygen"
+	nonKeygen "github.com/felicityin/mpc-tss/protocols/cggmp/keygen/non_threshold"
+	tKeygen "github.com/felicityin/mpc-tss/protocols/cggmp/keygen/threshold"
+)
+
+const testParticipants = 3
+
+func TestValidateFixtures(t *testing.T) {
+	for _, kind := range []int{keygen.Ecdsa, keygen.Eddsa} {
+		keys, _, err := tKeygen.LoadKeygenTestFixturesRandomSet(kind, testParticipants, testParticipants)
+		assert.NoError(t, err, "should load threshold keygen fixtures")
+		for _, key := range keys {
+			assert.NoError(t, key.Validate())
+		}
+
+		keys, _, err = nonKeygen.LoadKeygenTestFixtures(kind, testParticipants)
+		assert.NoError(t, err, "should load non-threshold keygen fixtures")
+		for _, key := range keys {
+			assert.NoError(t, key.Validate())
+		}
+	}
+}
+
+func TestValidateCorrupted(t *testing.T) {
+	keys, _, err := tKeygen.LoadKeygenTestFixturesRandomSet(keygen.Ecdsa, testParticipants, testParticipants)
+	assert.NoError(t, err, "should load threshold keygen fixtures")
+
+	key := keys[0]
+	key.PrivXi = new(big.Int).Add(key.PrivXi, big.NewInt(1))
+	assert.Error(t, key.Validate(), "PrivXi must match PubXj")
+
+	key = keys[0]
+	key.Ks = []*big.Int{key.Ks[0], key.Ks[0], key.Ks[2]}
+	assert.Error(t, key.Validate(), "Ks must be unique")
+
+	key = keys[0]
+	key.Pubkey = crypto.ScalarBaseMult(key.Pubkey.Curve(), big.NewInt(7))
+	assert.Error(t, key.Validate(), "PubXj must combine to Pubkey")
+
+	key = keys[0]
+	key.ChainCode = new(big.Int).Lsh(big.NewInt(1), 8*keygen.ChainCodeLen)
+	assert.Error(t, key.Validate(), "ChainCode must fit in ChainCodeLen bytes")
+
+	key = keys[0]
+	key.Derivation = keygen.DerivationBIP32Ed25519
+	assert.Error(t, key.Validate(), "BIP32-Ed25519 needs an ed25519 key")
+
+	key = keys[0]
+	key.Derivation = "slip10"
+	assert.Error(t, key.Validate(), "Derivation must be known")
+}
@@ -0,0 +1,402 @@
+package keygen
+
+import (
+	"bytes"
+	"crypto/elliptic"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/felicityin/mpc-tss/common"
+	"github.com/felicityin/mpc-tss/crypto"
+	"github.com/felicityin/mpc-tss/crypto/alice/utils"
+	"github.com/felicityin/mpc-tss/crypto/commitments"
+	"github.com/felicityin/mpc-tss/crypto/schnorr"
+	"github.com/felicityin/mpc-tss/crypto/vss"
+	"github.com/felicityin/mpc-tss/tss"
+)
+
+// Kinds of the evidence against a party of the keygen
+const (
+	EvidenceDecommitment = "cggmp/keygen/threshold/decommitment"
+	EvidenceVssShare     = "cggmp/keygen/threshold/vss-share"
+	EvidenceSchnorrProof = "cggmp/keygen/threshold/schnorr-proof"
+	EvidenceKeyShare     = "cggmp/keygen/threshold/key-share"
+)
+
+func init() {
+	tss.RegisterEvidenceCheck(EvidenceDecommitment, checkDecommitmentEvidence)
+	tss.RegisterEvidenceCheck(EvidenceVssShare, checkVssShareEvidence)
+	tss.RegisterEvidenceCheck(EvidenceSchnorrProof, checkSchnorrProofEvidence)
+	tss.RegisterEvidenceCheck(EvidenceKeyShare, checkKeyShareEvidence)
+}
+
+type (
+	// DecommitmentInputs are the public inputs of the check that the round 2 broadcast of Pj opens its round 1 commitments.
+	// Ks are the keys of the parties, which the ssid is computed from.
+	DecommitmentInputs struct {
+		Curve     tss.CurveName `json:"curve"`
+		Threshold int           `json:"threshold"`
+		Index     int           `json:"index"`
+		Ks        []*big.Int    `json:"ks"`
+	}
+
+	// VssShareInputs are the public inputs of the check of the vss share that Pj sent to the victim, whose key is the share id
+	VssShareInputs struct {
+		Curve     tss.CurveName `json:"curve"`
+		Threshold int           `json:"threshold"`
+		Index     int           `json:"index"`
+	}
+
+	// SchnorrProofInputs are the public inputs of the check of the schnorr proof of Pj for its key share X_j.
+	// The ssid is computed from the keys Ks of the parties, and srid and X_j from the round 1 and round 2 broadcasts of all the parties.
+	SchnorrProofInputs struct {
+		Curve     tss.CurveName `json:"curve"`
+		Threshold int           `json:"threshold"`
+		Index     int           `json:"index"`
+		Ks        []*big.Int    `json:"ks"`
+	}
+
+	// KeyShareInputs are the public inputs of the check that the polynomial of Pj, evaluated at the given share id,
+	// adds a point of the curve to the sum of the evaluations of the polynomials added before it,
+	// which are computed from the round 1 and round 2 broadcasts of their parties
+	KeyShareInputs struct {
+		Curve     tss.CurveName `json:"curve"`
+		Threshold int           `json:"threshold"`
+		Index     int           `json:"index"`
+		ShareID   *big.Int      `json:"share_id"`
+	}
+)
+
+// computeSSID returns the ssid of the keygen of the parties of the keys ks
+func computeSSID(ec elliptic.Curve, ks []*big.Int, number int, nonce *big.Int) []byte {
+	ssidList := []*big.Int{ec.Params().P, ec.Params().N, ec.Params().Gx, ec.Params().Gy} // ec curve
+	ssidList = append(ssidList, ks...)
+	ssidList = append(ssidList, big.NewInt(int64(number))) // round number
+	ssidList = append(ssidList, nonce)
+	return common.SHA512_256i(ssidList...).Bytes()
+}
+
+// checkDecommitment checks that the round 2 broadcast of Pj opens its round 1 commitments,
+// and returns the commitments to the polynomial and to the schnorr nonce of Pj
+func checkDecommitment(
+	ec elliptic.Curve, in *DecommitmentInputs, ssid []byte, r1msg *TKgRound1Message, r2msg1 *TKgRound2Message1,
+) (vss.Vs, *crypto.ECPoint, error) {
+	PjVs, err := openPolyCommitment(ec, in.Threshold, r1msg, r2msg1)
+	if err != nil {
+		return nil, nil, err
+	}
+	commitmentA, err := r2msg1.UnmarshalSchCommitment()
+	if err != nil {
+		return nil, nil, errors.New("unmalshal commitment failed")
+	}
+	if name, _ := tss.GetCurveName(commitmentA.Curve()); name != in.Curve {
+		return nil, nil, errors.New("the schnorr commitment is not on the curve of the keygen")
+	}
+	if !bytes.Equal(r2msg1.GetSsid(), ssid) {
+		return nil, nil, errors.New("ssid verify failed")
+	}
+
+	Vj := common.SHA512_256(
+		r2msg1.GetSsid(),
+		[]byte(strconv.Itoa(len(in.Ks))),
+		[]byte(strconv.Itoa(in.Index)),
+		[]byte(strconv.Itoa(in.Threshold)),
+		r2msg1.GetSrid(),
+		r1msg.GetPolyCommitment(),
+		commitmentA.X().Bytes(),
+		commitmentA.Y().Bytes(),
+		r2msg1.GetU(),
+		r2msg1.GetChainCode(),
+	)
+	if !bytes.Equal(Vj, r1msg.GetHash()) {
+		return nil, nil, errors.New("commited v_i verify failed")
+	}
+	return PjVs, commitmentA, nil
+}
+
+// checkVssShare checks the vss share that Pj sent to the party of the share id against the polynomial that it committed to
+func checkVssShare(ec elliptic.Curve, in *VssShareInputs, shareID *big.Int, r1msg *TKgRound1Message, r2msg1 *TKgRound2Message1, r2msg2 *TKgRound2Message2) error {
+	PjVs, err := openPolyCommitment(ec, in.Threshold, r1msg, r2msg1)
+	if err != nil {
+		return err
+	}
+	share := vss.Share{
+		Threshold: in.Threshold,
+		ID:        shareID,
+		Share:     r2msg2.UnmarshalShare(),
+	}
+	if !share.Verify(ec, in.Threshold, PjVs) {
+		return errors.New("vss verify failed")
+	}
+	return nil
+}
+
+// checkSchnorrProof checks the schnorr proof of Pj for its key share X_j and the commitment A_j sent in round 2
+func checkSchnorrProof(ec elliptic.Curve, ssid, srid []byte, j int, PubXj *crypto.ECPoint, r2msg1 *TKgRound2Message1, r3msg *TKgRound3Message) error {
+	commitmentA, err := r2msg1.UnmarshalSchCommitment()
+	if err != nil {
+		return errors.New("unmalshal commitment failed")
+	}
+	challenge := common.RejectionSample(
+		ec.Params().N,
+		common.SHA512_256i_TAGGED(
+			append(append([]byte{}, ssid...), srid...),
+			big.NewInt(int64(j)),
+			PubXj.X(),
+			PubXj.Y(),
+			commitmentA.X(),
+			commitmentA.Y(),
+		),
+	)
+	schProof := schnorr.Proof{Proof: r3msg.UnmarshalSchProof()}
+	if !schProof.Verify(commitmentA, PubXj, challenge) {
+		return errors.New("schnorr proof verify failed")
+	}
+	return nil
+}
+
+// addKeyShare adds to sum, nil if none, the evaluation at the share id of the polynomial committed to by PjVs
+func addKeyShare(ec elliptic.Curve, in *KeyShareInputs, sum *crypto.ECPoint, PjVs vss.Vs) (*crypto.ECPoint, error) {
+	modQ := common.ModInt(ec.Params().N)
+	eval, z := PjVs[0], big.NewInt(1)
+	for c := 1; c <= in.Threshold; c++ {
+		z = modQ.Mul(z, in.ShareID)
+		var err error
+		if eval, err = eval.Add(PjVs[c].ScalarMult(z)); err != nil {
+			return nil, errors.New("the evaluation of the polynomial is not a point of the curve")
+		}
+	}
+	if sum == nil {
+		return eval, nil
+	}
+	sum, err := sum.Add(eval)
+	if err != nil {
+		return nil, errors.New("the evaluation of the polynomial cancels the key share")
+	}
+	return sum, nil
+}
+
+// openPolyCommitment opens the commitment of Pj to its polynomial
+func openPolyCommitment(ec elliptic.Curve, threshold int, r1msg *TKgRound1Message, r2msg1 *TKgRound2Message1) (vss.Vs, error) {
+	cmtDeCmt := commitments.HashCommitDecommit{C: r1msg.UnmarshalPolyCommitment(), D: r2msg1.UnmarshalDeCommitment()}
+	ok, flatPolyGs := cmtDeCmt.DeCommit()
+	if !ok || flatPolyGs == nil {
+		return nil, errors.New("de-commitment verify failed")
+	}
+	PjVs, err := crypto.UnFlattenECPoints(ec, flatPolyGs)
+	if err != nil {
+		return nil, fmt.Errorf("UnFlattenECPoints err: %s", err.Error())
+	}
+	if len(PjVs) != threshold+1 {
+		return nil, fmt.Errorf("expected a polynomial of degree %d, got %d", threshold, len(PjVs)-1)
+	}
+	return PjVs, nil
+}
+
+// broadcastsBySender returns the round 1 and round 2 broadcasts in msgs, by the index of their sender.
+// With ks, every party of the keys ks must have sent both; without, the parties that sent one must have sent the other.
+func broadcastsBySender(msgs []tss.ParsedMessage, ks []*big.Int) (map[int]*TKgRound1Message, map[int]*TKgRound2Message1, error) {
+	r1msgs, r2msg1s := make(map[int]*TKgRound1Message), make(map[int]*TKgRound2Message1)
+	for _, msg := range msgs {
+		j := msg.GetFrom().Index
+		if ks != nil && (j < 0 || j >= len(ks) || msg.GetFrom().KeyInt().Cmp(ks[j]) != 0) {
+			return nil, nil, fmt.Errorf("the broadcast of party %d is not from the party of its key", j)
+		}
+		switch content := msg.Content().(type) {
+		case *TKgRound1Message:
+			if _, ok := r1msgs[j]; ok {
+				return nil, nil, fmt.Errorf("party %d has two round 1 broadcasts", j)
+			}
+			r1msgs[j] = content
+		case *TKgRound2Message1:
+			if _, ok := r2msg1s[j]; ok {
+				return nil, nil, fmt.Errorf("party %d has two round 2 broadcasts", j)
+			}
+			r2msg1s[j] = content
+		default:
+			return nil, nil, errors.New("expected the round 1 and round 2 broadcasts")
+		}
+	}
+	if len(r1msgs) != len(r2msg1s) || (ks != nil && len(r1msgs) != len(ks)) {
+		return nil, nil, errors.New("expected the round 1 and round 2 broadcasts of every party")
+	}
+	for j := range r1msgs {
+		if _, ok := r2msg1s[j]; !ok {
+			return nil, nil, fmt.Errorf("the round 2 broadcast of party %d is missing", j)
+		}
+	}
+	return r1msgs, r2msg1s, nil
+}
+
+func checkDecommitmentEvidence(ev *tss.Evidence, msgs []tss.ParsedMessage) (failure error, err error) {
+	in := new(DecommitmentInputs)
+	if err := ev.UnmarshalInputs(in); err != nil {
+		return nil, err
+	}
+	ec, err := evidenceCurve(ev, in.Curve, in.Index)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkKeys(ev, in.Ks, in.Index); err != nil {
+		return nil, err
+	}
+	if len(msgs) != 2 {
+		return nil, errors.New("expected the round 1 and round 2 broadcasts")
+	}
+	r1msg, ok1 := msgs[0].Content().(*TKgRound1Message)
+	r2msg1, ok2 := msgs[1].Content().(*TKgRound2Message1)
+	if !ok1 || !ok2 {
+		return nil, errors.New("expected the round 1 and round 2 broadcasts")
+	}
+	_, _, failure = checkDecommitment(ec, in, computeSSID(ec, in.Ks, 1, big.NewInt(0)), r1msg, r2msg1)
+	return failure, nil
+}
+
+func checkVssShareEvidence(ev *tss.Evidence, msgs []tss.ParsedMessage) (failure error, err error) {
+	in := new(VssShareInputs)
+	if err := ev.UnmarshalInputs(in); err != nil {
+		return nil, err
+	}
+	ec, err := evidenceCurve(ev, in.Curve, in.Index)
+	if err != nil {
+		return nil, err
+	}
+	if len(msgs) != 3 {
+		return nil, errors.New("expected the round 1 and round 2 broadcasts and the round 2 share")
+	}
+	r1msg, ok1 := msgs[0].Content().(*TKgRound1Message)
+	r2msg1, ok2 := msgs[1].Content().(*TKgRound2Message1)
+	r2msg2, ok3 := msgs[2].Content().(*TKgRound2Message2)
+	if !ok1 || !ok2 || !ok3 {
+		return nil, errors.New("expected the round 1 and round 2 broadcasts and the round 2 share")
+	}
+	if len(msgs[2].GetTo()) != 1 {
+		return nil, errors.New("the recipient of the round 2 share is missing")
+	}
+	if _, err := openPolyCommitment(ec, in.Threshold, r1msg, r2msg1); err != nil {
+		// the culprit should be blamed for its commitments instead
+		return nil, err
+	}
+	return checkVssShare(ec, in, msgs[2].GetTo()[0].KeyInt(), r1msg, r2msg1, r2msg2), nil
+}
+
+// checkSchnorrProofEvidence checks the round 3 message of the culprit, which the evidence holds first,
+// against the round 1 and round 2 broadcasts of all the parties
+func checkSchnorrProofEvidence(ev *tss.Evidence, msgs []tss.ParsedMessage) (failure error, err error) {
+	in := new(SchnorrProofInputs)
+	if err := ev.UnmarshalInputs(in); err != nil {
+		return nil, err
+	}
+	ec, err := evidenceCurve(ev, in.Curve, in.Index)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkKeys(ev, in.Ks, in.Index); err != nil {
+		return nil, err
+	}
+	if len(msgs) == 0 {
+		return nil, errors.New("expected the round 3 message")
+	}
+	r3msg, ok := msgs[0].Content().(*TKgRound3Message)
+	if !ok {
+		return nil, errors.New("expected the round 3 message")
+	}
+	r1msgs, r2msg1s, err := broadcastsBySender(msgs[1:], in.Ks)
+	if err != nil {
+		return nil, err
+	}
+
+	// srid is the xor of the srid of all the parties, and X_j the sum of the evaluations of their polynomials at the key of Pj
+	ssid := computeSSID(ec, in.Ks, 1, big.NewInt(0))
+	var srid []byte
+	var PubXj *crypto.ECPoint
+	keyShare := &KeyShareInputs{Curve: in.Curve, Threshold: in.Threshold, Index: in.Index, ShareID: in.Ks[in.Index]}
+	for k := range in.Ks {
+		decommitInputs := &DecommitmentInputs{Curve: in.Curve, Threshold: in.Threshold, Index: k, Ks: in.Ks}
+		PkVs, _, err := checkDecommitment(ec, decommitInputs, ssid, r1msgs[k], r2msg1s[k])
+		if err != nil {
+			// the party should be blamed for its commitments instead
+			return nil, fmt.Errorf("the broadcasts of party %d are invalid: %s", k, err.Error())
+		}
+		srid = utils.Xor(srid, r2msg1s[k].GetSrid())
+		if PubXj, err = addKeyShare(ec, keyShare, PubXj, PkVs); err != nil {
+			return nil, err
+		}
+	}
+	return checkSchnorrProof(ec, ssid, srid, in.Index, PubXj, r2msg1s[in.Index], r3msg), nil
+}
+
+// checkKeyShareEvidence checks the polynomial of the culprit, whose round 1 and round 2 broadcasts the evidence holds first,
+// against the polynomials of the parties added before it, whose broadcasts follow
+func checkKeyShareEvidence(ev *tss.Evidence, msgs []tss.ParsedMessage) (failure error, err error) {
+	in := new(KeyShareInputs)
+	if err := ev.UnmarshalInputs(in); err != nil {
+		return nil, err
+	}
+	ec, err := evidenceCurve(ev, in.Curve, in.Index)
+	if err != nil {
+		return nil, err
+	}
+	if in.ShareID == nil {
+		return nil, errors.New("the share id is missing")
+	}
+	if len(msgs) < 2 {
+		return nil, errors.New("expected the round 1 and round 2 broadcasts")
+	}
+	r1msg, ok1 := msgs[0].Content().(*TKgRound1Message)
+	r2msg1, ok2 := msgs[1].Content().(*TKgRound2Message1)
+	if !ok1 || !ok2 {
+		return nil, errors.New("expected the round 1 and round 2 broadcasts")
+	}
+	PjVs, err := openPolyCommitment(ec, in.Threshold, r1msg, r2msg1)
+	if err != nil {
+		// the culprit should be blamed for its commitments instead
+		return nil, err
+	}
+	r1msgs, r2msg1s, err := broadcastsBySender(msgs[2:], nil)
+	if err != nil {
+		return nil, err
+	}
+	var sum *crypto.ECPoint
+	for k, r1msg := range r1msgs {
+		if k == in.Index {
+			return nil, errors.New("the polynomial of the culprit is added twice")
+		}
+		PkVs, err := openPolyCommitment(ec, in.Threshold, r1msg, r2msg1s[k])
+		if err != nil {
+			return nil, fmt.Errorf("the broadcasts of party %d are invalid: %s", k, err.Error())
+		}
+		if sum, err = addKeyShare(ec, in, sum, PkVs); err != nil {
+			return nil, fmt.Errorf("the polynomial of party %d is invalid: %s", k, err.Error())
+		}
+	}
+	_, failure = addKeyShare(ec, in, sum, PjVs)
+	return failure, nil
+}
+
+// checkKeys checks that the keys of the parties hold the key of the culprit at its index
+func checkKeys(ev *tss.Evidence, ks []*big.Int, index int) error {
+	if index < 0 || index >= len(ks) || ks[index] == nil || ks[index].Cmp(ev.Culprit.KeyInt()) != 0 {
+		return errors.New("the keys of the parties do not hold the key of the culprit")
+	}
+	for j, k := range ks {
+		if k == nil {
+			return fmt.Errorf("the key of party %d is missing", j)
+		}
+	}
+	return nil
+}
+
+// evidenceCurve returns the curve of the inputs of the evidence, which must be about the culprit
+func evidenceCurve(ev *tss.Evidence, curve tss.CurveName, index int) (elliptic.Curve, error) {
+	if index != ev.Culprit.Index {
+		return nil, fmt.Errorf("the inputs are about party %d, not the culprit", index)
+	}
+	ec, ok := tss.GetCurveByName(curve)
+	if !ok {
+		return nil, fmt.Errorf("unknown curve %s", curve)
+	}
+	return ec, nil
+}
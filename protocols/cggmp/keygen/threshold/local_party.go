@@ -43,7 +43,6 @@ type (
 	localTempData struct {
 		localMessageStore
 
-		s0            *big.Int
 		KGCs          []cmt.HashCommitment
 		vs            vss.Vs
 		shares        vss.Shares
@@ -75,7 +74,6 @@ func NewLocalParty(
 		common.Logger.Errorf("set log level, err: %s", err.Error())
 		return nil
 	}
-	tss.SetCurve(tss.S256())
 
 	partyCount := params.PartyCount()
 	data := save.NewLocalPartySaveData(partyCount)
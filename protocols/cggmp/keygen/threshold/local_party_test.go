@@ -10,6 +10,7 @@ import (
 	"math/big"
 	"os"
 	"runtime"
+	"strconv"
 	"sync/atomic"
 	"testing"
 
@@ -19,6 +20,7 @@ import (
 
 	"github.com/felicityin/mpc-tss/common"
 	"github.com/felicityin/mpc-tss/crypto"
+	cmts "github.com/felicityin/mpc-tss/crypto/commitments"
 	"github.com/felicityin/mpc-tss/crypto/vss"
 	save "github.com/felicityin/mpc-tss/protocols/cggmp/keygen"
 	"github.com/felicityin/mpc-tss/protocols/cggmp/test"
@@ -44,6 +46,10 @@ func TestEddsaE2EConcurrentAndSaveFixtures(t *testing.T) {
 	testE2EConcurrentAndSaveFixtures(t, Eddsa)
 }
 
+func TestEcdsaP256E2EConcurrentAndSaveFixtures(t *testing.T) {
+	testE2EConcurrentAndSaveFixtures(t, EcdsaP256)
+}
+
 func testE2EConcurrentAndSaveFixtures(t *testing.T, kind int) {
 	setUp("debug")
 
@@ -67,12 +73,7 @@ func testE2EConcurrentAndSaveFixtures(t *testing.T, kind int) {
 
 	// init the parties
 	for i := 0; i < len(pIDs); i++ {
-		var params *tss.Parameters
-		if kind == Ecdsa {
-			params = tss.NewParameters(tss.S256(), p2pCtx, pIDs[i], len(pIDs), threshold)
-		} else {
-			params = tss.NewParameters(tss.Edwards(), p2pCtx, pIDs[i], len(pIDs), threshold)
-		}
+		params := tss.NewParameters(TestCurve(kind), p2pCtx, pIDs[i], len(pIDs), threshold)
 		P := NewLocalParty(params, outCh, endCh).(*LocalParty)
 		parties = append(parties, P)
 		go func(P *LocalParty) {
@@ -154,12 +155,7 @@ keygen:
 }
 
 func verifyKeygen(t *testing.T, kind, threshold int, parties []*LocalParty, save *save.LocalPartySaveData) {
-	var ec elliptic.Curve
-	if kind == Ecdsa {
-		ec = tss.S256()
-	} else {
-		ec = tss.Edwards()
-	}
+	ec := TestCurve(kind)
 
 	// combine shares for each Pj to get u
 	u := big.NewInt(0)
@@ -179,7 +175,6 @@ func verifyKeygen(t *testing.T, kind, threshold int, parties []*LocalParty, save
 		assert.NoError(t, err, "vss.ReConstruct should not throw error")
 
 		// uG test: u*G[j] == V[0]
-		assert.Equal(t, uj, Pj.temp.s0)
 		uG := crypto.ScalarBaseMult(ec, uj)
 		assert.True(t, uG.Equals(Pj.temp.vs[0]), "ensure u*G[j] == V_0")
 
@@ -195,7 +190,6 @@ func verifyKeygen(t *testing.T, kind, threshold int, parties []*LocalParty, save
 			badShares[len(badShares)-1].Share.Set(big.NewInt(0))
 			uj, err := pShares[:threshold].ReConstruct(ec)
 			assert.NoError(t, err)
-			assert.NotEqual(t, parties[j].temp.s0, uj)
 			BigXjX, BigXjY := ec.ScalarBaseMult(uj.Bytes())
 			assert.NotEqual(t, BigXjX, Pj.temp.vs[0].X())
 			assert.NotEqual(t, BigXjY, Pj.temp.vs[0].Y())
@@ -223,18 +217,18 @@ func verifyKeygen(t *testing.T, kind, threshold int, parties []*LocalParty, save
 	}
 	t.Log("Public key distribution test done.")
 
-	if kind == Ecdsa {
-		verifyEcdsa(t, save, u)
-	} else {
+	if kind == Eddsa {
 		verifyEddsa(t, save, u)
+	} else {
+		verifyEcdsa(t, ec, save, u)
 	}
 }
 
-func verifyEcdsa(t *testing.T, save *save.LocalPartySaveData, u *big.Int) {
+func verifyEcdsa(t *testing.T, ec elliptic.Curve, save *save.LocalPartySaveData, u *big.Int) {
 	// build key pair
 	pkX, pkY := save.Pubkey.X(), save.Pubkey.Y()
 	pk := ecdsa.PublicKey{
-		Curve: tss.EC(),
+		Curve: ec,
 		X:     pkX,
 		Y:     pkY,
 	}
@@ -284,6 +278,123 @@ func verifyEddsa(t *testing.T, save *save.LocalPartySaveData, u *big.Int) {
 	t.Log("EDDSA signing test done.")
 }
 
+func TestEvidenceOfCancellingPolynomial(t *testing.T) {
+	setUp("info")
+
+	pIDs := tss.GenerateTestPartyIDs(testParticipants)
+	p2pCtx := tss.NewPeerContext(pIDs)
+	parties := make([]*LocalParty, 0, len(pIDs))
+
+	errCh := make(chan *tss.Error, 2*len(pIDs))
+	outCh := make(chan tss.Message, 4*len(pIDs))
+	endCh := make(chan *save.LocalPartySaveData, len(pIDs))
+
+	updater := test.SharedPartyUpdater
+
+	for i := 0; i < len(pIDs); i++ {
+		params := tss.NewParameters(tss.S256(), p2pCtx, pIDs[i], len(pIDs), testThreshold)
+		P := NewLocalParty(params, outCh, endCh).(*LocalParty)
+		parties = append(parties, P)
+		assert.Nil(t, P.Start())
+	}
+
+	// the last party commits to a polynomial that cancels the sum of the others at the id of P1, so that X_1 is infinity
+	cheater := parties[len(pIDs)-1]
+	ec, modQ, k1 := tss.S256(), common.ModInt(tss.S256().Params().N), pIDs[1].KeyInt()
+	coeffs := make([]*big.Int, testThreshold+1)
+	coeffs[0] = new(big.Int).Neg(modQ.Add(parties[0].temp.shares[1].Share, parties[1].temp.shares[1].Share))
+	for c := 1; c <= testThreshold; c++ {
+		coeffs[c] = common.GetRandomPositiveInt(rand.Reader, ec.Params().N)
+		coeffs[0] = modQ.Sub(coeffs[0], modQ.Mul(coeffs[c], modQ.Exp(k1, big.NewInt(int64(c)))))
+	}
+	vs := make(vss.Vs, len(coeffs))
+	for c, coeff := range coeffs {
+		vs[c] = crypto.ScalarBaseMult(ec, coeff)
+	}
+	for p, share := range cheater.temp.shares {
+		share.Share = big.NewInt(0)
+		for c, coeff := range coeffs {
+			share.Share = modQ.Add(share.Share, modQ.Mul(coeff, modQ.Exp(pIDs[p].KeyInt(), big.NewInt(int64(c)))))
+		}
+	}
+	flat, err := crypto.FlattenECPoints(vs)
+	assert.NoError(t, err)
+	polyCmt := cmts.NewHashCommitment(rand.Reader, flat...)
+	cheater.temp.vs, cheater.temp.deCommitPolyG, cheater.temp.KGCs[len(pIDs)-1] = vs, polyCmt.D, polyCmt.C
+	Vi := common.SHA512_256(
+		cheater.temp.ssid,
+		[]byte(strconv.Itoa(len(pIDs))),
+		[]byte(strconv.Itoa(len(pIDs)-1)),
+		[]byte(strconv.Itoa(testThreshold)),
+		cheater.temp.srid,
+		polyCmt.C.Bytes(),
+		cheater.temp.commitedA[len(pIDs)-1].X().Bytes(),
+		cheater.temp.commitedA[len(pIDs)-1].Y().Bytes(),
+		cheater.temp.u,
+		cheater.temp.chainCode,
+	)
+	r1msg := NewKGRound1Message(cheater.PartyID(), Vi, polyCmt.C)
+	cheater.temp.kgRound1Messages[len(pIDs)-1] = r1msg
+
+	// the honest parties must blame the cheater
+	errs := make([]*tss.Error, 0, len(pIDs)-1)
+	for len(errs) < len(pIDs)-1 {
+		select {
+		case err := <-errCh:
+			if err.Victim().Index != cheater.PartyID().Index {
+				errs = append(errs, err)
+			}
+
+		case msg := <-outCh:
+			if _, ok := msg.(tss.ParsedMessage).Content().(*TKgRound1Message); ok && msg.GetFrom().Index == len(pIDs)-1 {
+				msg = r1msg
+			}
+			if msg.GetTo() == nil {
+				for _, P := range parties {
+					go updater(P, msg, errCh)
+				}
+			} else {
+				go updater(parties[msg.GetTo()[0].Index], msg, errCh)
+			}
+
+		case <-endCh:
+			t.Fatal("the keygen should not end with an infinite key share")
+		}
+	}
+
+	for _, err := range errs {
+		if assert.Len(t, err.Culprits(), 1) {
+			assert.Equal(t, len(pIDs)-1, err.Culprits()[0].Index)
+		}
+		if !assert.Len(t, err.Evidence(), 1) {
+			continue
+		}
+		ev := err.Evidence()[0]
+		assert.Equal(t, EvidenceKeyShare, ev.Kind)
+		assert.NoError(t, ev.Verify())
+
+		// anyone can verify the evidence from its JSON encoding
+		bz, jsonErr := json.Marshal(ev)
+		assert.NoError(t, jsonErr)
+		decoded := new(tss.Evidence)
+		assert.NoError(t, json.Unmarshal(bz, decoded))
+		assert.NoError(t, decoded.Verify())
+
+		// the sum of the other polynomials comes from their broadcasts: without them, the polynomial of the culprit alone is valid
+		msgs, parseErr := ev.ParseMessages()
+		if assert.NoError(t, parseErr) {
+			alone, evErr := tss.NewEvidence(ev.Kind, ev.Task, ev.Round, ev.Culprit, ev.Inputs, msgs[:2]...)
+			if assert.NoError(t, evErr) {
+				assert.ErrorIs(t, alone.Verify(), tss.ErrNoMisbehaviour)
+			}
+		}
+
+		// it does not prove anything about another party
+		decoded.Culprit = pIDs[0]
+		assert.Error(t, decoded.Verify())
+	}
+}
+
 func tryWriteTestFixtureFile(t *testing.T, kind, index int, data save.LocalPartySaveData) {
 	fixtureFileName := makeTestFixtureFilePath(kind, index)
 
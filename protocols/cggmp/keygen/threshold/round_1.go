@@ -13,8 +13,6 @@ import (
 	"github.com/felicityin/mpc-tss/tss"
 )
 
-var zero = big.NewInt(0)
-
 // round 1 represents round 1 of the keygen part of the TSS spec
 func newRound1(
 	params *tss.Parameters,
@@ -42,7 +40,6 @@ func (round *round1) Start() *tss.Error {
 
 	// Calculate "partial" key share s0
 	s0 := common.GetRandomPositiveInt(round.PartialKeyRand(), round.EC().Params().N)
-	round.temp.s0 = s0
 
 	// Compute the vss shares
 	ids := round.Parties().IDs().Keys()
@@ -55,8 +52,7 @@ func (round *round1) Start() *tss.Error {
 	round.temp.shares = shares
 
 	// Security: the original u_i may be discarded
-	s0 = zero // clears the secret data from memory
-	_ = s0    // silences a linter warning
+	common.ZeroBigInts(s0)
 
 	// Make commitment -> (C, D)
 	pGFlat, err := crypto.FlattenECPoints(vs)
@@ -1,16 +1,13 @@
 package keygen
 
 import (
-	"bytes"
 	"errors"
 	"fmt"
 	"math/big"
-	"strconv"
 
 	"github.com/felicityin/mpc-tss/common"
 	"github.com/felicityin/mpc-tss/crypto"
 	"github.com/felicityin/mpc-tss/crypto/alice/utils"
-	"github.com/felicityin/mpc-tss/crypto/commitments"
 	"github.com/felicityin/mpc-tss/crypto/schnorr"
 	"github.com/felicityin/mpc-tss/crypto/vss"
 	"github.com/felicityin/mpc-tss/tss"
@@ -35,61 +32,35 @@ func (round *round3) Start() *tss.Error {
 			continue
 		}
 
+		r1msg := round.temp.kgRound1Messages[j]
+		r2msg2 := round.temp.kgRound2Message2s[j]
 		r2msg1 := msg.Content().(*TKgRound2Message1)
 
-		commitmentA, err := r2msg1.UnmarshalSchCommitment()
-		if err != nil {
-			return round.WrapError(fmt.Errorf("[j: %d] unmalshal commitment failed", j))
-		}
-		round.temp.commitedA[j] = commitmentA
-
-		KGDj := r2msg1.UnmarshalDeCommitment()
-		cmtDeCmt := commitments.HashCommitDecommit{C: round.temp.KGCs[j], D: KGDj}
-		ok, flatPolyGs := cmtDeCmt.DeCommit()
-		if !ok || flatPolyGs == nil {
-			return round.WrapError(fmt.Errorf("[j: %d] de-commitment verify failed", j))
+		// Verify the decommitment and the commited V_j
+		decommitInputs := &DecommitmentInputs{
+			Curve:     round.curveName(),
+			Threshold: round.Threshold(),
+			Index:     j,
+			Ks:        round.Parties().IDs().Keys(),
 		}
-
-		PjVs, err := crypto.UnFlattenECPoints(round.Params().EC(), flatPolyGs)
+		PjVs, commitmentA, err := checkDecommitment(round.EC(), decommitInputs, round.temp.ssid, r1msg.Content().(*TKgRound1Message), r2msg1)
 		if err != nil {
-			return round.WrapError(fmt.Errorf("[j: %d] UnFlattenECPoints err: %s", j, err.Error()))
+			common.Logger.Errorf("[j: %d] %s", j, err.Error())
+			return round.WrapEvidence(fmt.Errorf("[j: %d] %s", j, err.Error()), EvidenceDecommitment, decommitInputs, r1msg, msg)
 		}
+		round.temp.commitedA[j] = commitmentA
 		pjVss[j] = PjVs
 
-		if !bytes.Equal(r2msg1.GetSsid(), round.temp.ssid) {
-			common.Logger.Errorf("[%d] payload.ssid != round.temp.ssid", j)
-			return round.WrapError(fmt.Errorf("[%d] ssid verify failed", j))
-		}
-
-		// Verify commited V_j
-		common.Logger.Debugf("[j: %d]round_3, calc V", j)
-		Vj := common.SHA512_256(
-			r2msg1.GetSsid(),
-			[]byte(strconv.Itoa(round.PartyCount())),
-			[]byte(strconv.Itoa(j)),
-			[]byte(strconv.Itoa(round.Threshold())),
-			r2msg1.GetSrid(),
-			cmtDeCmt.C.Bytes(),
-			commitmentA.X().Bytes(),
-			commitmentA.Y().Bytes(),
-			r2msg1.GetU(),
-			r2msg1.GetChainCode(),
-		)
-		if !bytes.Equal(Vj, round.temp.V[j]) {
-			common.Logger.Errorf("[j: %d] hash != V", j)
-			return round.WrapError(fmt.Errorf("[%d] commited v_i verify failed", j))
-		}
-
-		r2msg2 := round.temp.kgRound2Message2s[j].Content().(*TKgRound2Message2)
-		share := r2msg2.UnmarshalShare()
-		PjShare := vss.Share{
+		// Verify the vss share
+		vssInputs := &VssShareInputs{
+			Curve:     round.curveName(),
 			Threshold: round.Threshold(),
-			ID:        round.PartyID().KeyInt(),
-			Share:     share,
+			Index:     j,
 		}
-		if ok = PjShare.Verify(round.Params().EC(), round.Threshold(), PjVs); !ok {
-			return round.WrapError(fmt.Errorf("[j: %d] vss verify failed", j))
+		if err := checkVssShare(round.EC(), vssInputs, round.PartyID().KeyInt(), r1msg.Content().(*TKgRound1Message), r2msg1, r2msg2.Content().(*TKgRound2Message2)); err != nil {
+			return round.WrapEvidence(fmt.Errorf("[j: %d] %s", j, err.Error()), EvidenceVssShare, vssInputs, r1msg, msg, r2msg2)
 		}
+		share := r2msg2.Content().(*TKgRound2Message2).UnmarshalShare()
 
 		// Calculate private key
 		xi = xi.Add(xi, share)
@@ -121,27 +92,45 @@ func (round *round3) Start() *tss.Error {
 				Vc[c], err = Vc[c].Add(PjVs[c])
 				if err != nil {
 					common.Logger.Errorf("calc F(x) err: %s", err.Error())
-					return round.WrapError(fmt.Errorf("calc F(x) err: %s", err.Error()))
+					return round.WrapError(fmt.Errorf("calc F(x) err: %s", err.Error()), round.Parties().IDs()[j])
 				}
 			}
 		}
 	}
 
-	// Compute Xj for each Pj
+	// Compute Xj for each Pj, as the sum of the evaluations at kj of the polynomials of the parties, ours first,
+	// so that the party whose polynomial cancels the sum is blamed
 	{
-		var err error
-		modQ := common.ModInt(round.Params().EC().Params().N)
+		pjVss[i] = round.temp.vs
+		order := make([]int, 0, round.PartyCount())
+		order = append(order, i)
+		for p := 0; p < round.PartyCount(); p++ {
+			if p != i {
+				order = append(order, p)
+			}
+		}
 		bigXj := round.save.PubXj
-		for j := 0; j < round.PartyCount(); j++ {
-			Pj := round.Parties().IDs()[j]
-			kj := Pj.KeyInt()
-			BigXj := Vc[0]
-			z := new(big.Int).SetInt64(int64(1))
-			for c := 1; c <= round.Threshold(); c++ {
-				z = modQ.Mul(z, kj)
-				BigXj, err = BigXj.Add(Vc[c].ScalarMult(z))
-				if err != nil {
-					return round.WrapError(errors.New("adding Vc[c].ScalarMult(z) to BigXj resulted in a point not on the curve"))
+		for j, Pj := range round.Parties().IDs() {
+			var BigXj *crypto.ECPoint
+			inputs := &KeyShareInputs{
+				Curve:     round.curveName(),
+				Threshold: round.Threshold(),
+				ShareID:   Pj.KeyInt(),
+			}
+			for n, p := range order {
+				var err error
+				if BigXj, err = addKeyShare(round.EC(), inputs, BigXj, pjVss[p]); err != nil {
+					common.Logger.Errorf("[j: %d] calc X of %d err: %s", p, j, err.Error())
+					if p == i {
+						return round.WrapError(fmt.Errorf("calc X of %d err: %s", j, err.Error()))
+					}
+					// the evidence holds the broadcasts of the culprit, then those of the parties added before it
+					inputs.Index = p
+					msgs := []tss.ParsedMessage{round.temp.kgRound1Messages[p], round.temp.kgRound2Message1s[p]}
+					for _, k := range order[:n] {
+						msgs = append(msgs, round.temp.kgRound1Messages[k], round.temp.kgRound2Message1s[k])
+					}
+					return round.WrapEvidence(fmt.Errorf("[j: %d] calc X of %d err: %s", p, j, err.Error()), EvidenceKeyShare, inputs, msgs...)
 				}
 			}
 			bigXj[j] = BigXj
@@ -149,11 +138,8 @@ func (round *round3) Start() *tss.Error {
 		round.save.PubXj = bigXj
 	}
 
-	// Compute and save the public key
-	pubKey, err := crypto.NewECPoint(round.Params().EC(), Vc[0].X(), Vc[0].Y())
-	if err != nil {
-		return round.WrapError(fmt.Errorf("public key is not on the curve: %s", err.Error()))
-	}
+	// Compute and save the public key, which Add has already checked to be on the curve
+	pubKey := Vc[0]
 	round.save.Pubkey = pubKey
 
 	common.Logger.Debugf("party: %d, round_3, calc challenge", i)
@@ -173,6 +159,12 @@ func (round *round3) Start() *tss.Error {
 	common.Logger.Debugf("party: %d, round_3, calc schnorr proof", i)
 	schProof := schnorr.Prove(round.EC().Params().N, round.temp.tau, challenge, round.save.PrivXi)
 
+	// Security: the vss shares have been sent and the schnorr nonce has been used
+	for _, share := range round.temp.shares {
+		common.ZeroBigInts(share.Share)
+	}
+	common.ZeroBigInts(round.temp.tau)
+
 	// BROADCAST proofs
 	common.Logger.Infof("party: %d, round_3 broadcast", i)
 	{
@@ -2,10 +2,8 @@ package keygen
 
 import (
 	"errors"
-	"math/big"
 
 	"github.com/felicityin/mpc-tss/common"
-	"github.com/felicityin/mpc-tss/crypto/schnorr"
 	"github.com/felicityin/mpc-tss/tss"
 )
 
@@ -25,28 +23,17 @@ func (round *round4) Start() *tss.Error {
 			continue
 		}
 
-		common.Logger.Debugf("round_4 calc challenge")
-		challenge := common.RejectionSample(
-			round.EC().Params().N,
-			common.SHA512_256i_TAGGED(
-				append(round.temp.ssid, round.temp.srid...),
-				big.NewInt(int64(j)),
-				round.save.PubXj[j].X(),
-				round.save.PubXj[j].Y(),
-				round.temp.commitedA[j].X(),
-				round.temp.commitedA[j].Y(),
-			),
-		)
-
-		common.Logger.Debugf("round_4 get proof")
-
-		schProof := schnorr.Proof{Proof: msg.Content().(*TKgRound3Message).UnmarshalSchProof()}
-
-		common.Logger.Debugf("round_4 verify proof")
-
-		if !schProof.Verify(round.temp.commitedA[j], round.save.PubXj[j], challenge) {
+		r2msg1 := round.temp.kgRound2Message1s[j].Content().(*TKgRound2Message1)
+		if err := checkSchnorrProof(round.EC(), round.temp.ssid, round.temp.srid, j, round.save.PubXj[j], r2msg1, msg.Content().(*TKgRound3Message)); err != nil {
 			common.Logger.Errorf("schnorr proof verify failed, party: %d", j)
-			return round.WrapError(errors.New("schnorr proof verify failed"))
+			inputs := &SchnorrProofInputs{
+				Curve:     round.curveName(),
+				Threshold: round.Threshold(),
+				Index:     j,
+				Ks:        round.Parties().IDs().Keys(),
+			}
+			msgs := append([]tss.ParsedMessage{msg}, round.temp.kgRound1Messages...)
+			return round.WrapEvidence(err, EvidenceSchnorrProof, inputs, append(msgs, round.temp.kgRound2Message1s...)...)
 		}
 	}
 
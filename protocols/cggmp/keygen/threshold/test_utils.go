@@ -1,6 +1,7 @@
 package keygen
 
 import (
+	"crypto/elliptic"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -24,11 +25,13 @@ const (
 	TestThreshold    = test.TestParticipants / 2
 	Ecdsa            = 0
 	Eddsa            = 1
+	EcdsaP256        = 2
 )
 const (
-	testFixtureDirFormat       = "%s/../../test/_keygen_fixtures/threshold"
-	testEcdsaFixtureFileFormat = "ecdsa_keygen_data_%d.json"
-	testEddsaFixtureFileFormat = "eddsa_keygen_data_%d.json"
+	testFixtureDirFormat           = "%s/../../test/_keygen_fixtures/threshold"
+	testEcdsaFixtureFileFormat     = "ecdsa_keygen_data_%d.json"
+	testEddsaFixtureFileFormat     = "eddsa_keygen_data_%d.json"
+	testEcdsaP256FixtureFileFormat = "ecdsa_p256_keygen_data_%d.json"
 )
 
 func LoadKeygenTestFixtures(kind, qty int, optionalStart ...int) ([]save.LocalPartySaveData, tss.SortedPartyIDs, error) {
@@ -53,9 +56,9 @@ func LoadKeygenTestFixtures(kind, qty int, optionalStart ...int) ([]save.LocalPa
 				i, fixtureFilePath)
 		}
 		for _, kbxj := range key.PubXj {
-			kbxj.SetCurve(tss.Edwards())
+			kbxj.SetCurve(TestCurve(kind))
 		}
-		key.Pubkey.SetCurve(tss.Edwards())
+		key.Pubkey.SetCurve(TestCurve(kind))
 		keys = append(keys, key)
 	}
 	partyIDs := make(tss.UnSortedPartyIDs, len(keys))
@@ -106,12 +109,28 @@ func LoadKeygenTestFixturesRandomSet(kind, qty, fixtureCount int) ([]save.LocalP
 	return keys, sortedPIDs, nil
 }
 
+// TestCurve returns the curve of the keys of the test fixtures of the kind
+func TestCurve(kind int) elliptic.Curve {
+	switch kind {
+	case Ecdsa:
+		return tss.S256()
+	case EcdsaP256:
+		return tss.P256()
+	default:
+		return tss.Edwards()
+	}
+}
+
 func makeTestFixtureFilePath(kind int, partyIndex int) string {
 	_, callerFileName, _, _ := runtime.Caller(0)
 	srcDirName := filepath.Dir(callerFileName)
 	fixtureDirName := fmt.Sprintf(testFixtureDirFormat, srcDirName)
-	if kind == Ecdsa {
+	switch kind {
+	case Ecdsa:
 		return fmt.Sprintf("%s/"+testEcdsaFixtureFileFormat, fixtureDirName, partyIndex)
+	case EcdsaP256:
+		return fmt.Sprintf("%s/"+testEcdsaP256FixtureFileFormat, fixtureDirName, partyIndex)
+	default:
+		return fmt.Sprintf("%s/"+testEddsaFixtureFileFormat, fixtureDirName, partyIndex)
 	}
-	return fmt.Sprintf("%s/"+testEddsaFixtureFileFormat, fixtureDirName, partyIndex)
 }
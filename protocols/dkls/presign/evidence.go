@@ -0,0 +1,170 @@
+package presign
+
+import (
+	"crypto/elliptic"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/felicityin/mpc-tss/crypto"
+	cmt "github.com/felicityin/mpc-tss/crypto/commitments"
+	"github.com/felicityin/mpc-tss/crypto/dleq"
+	"github.com/felicityin/mpc-tss/tss"
+)
+
+// EvidenceU is the kind of the evidence against a party whose share of u does not match the shares of the multiplications
+const EvidenceU = "dkls-presign/u"
+
+func init() {
+	tss.RegisterEvidenceCheck(EvidenceU, checkUEvidence)
+}
+
+// UInputs are the public inputs of the check of the share u_j of Pj: the public data of the key, which is fixed before the presigning.
+// Ks are the share ids of the signers and PubXj their public key shares. The ssid, the nonce point R, the mask point Phi_j and the points c_u * G
+// of the multiplications are computed again from the round 1 and round 3 broadcasts of all the signers.
+type UInputs struct {
+	Curve tss.CurveName     `json:"curve"`
+	Index int               `json:"index"`
+	Ks    []*big.Int        `json:"ks"`
+	PubXj []*crypto.ECPoint `json:"pub_xj"`
+}
+
+// openCommitment opens the nonce point Rj and the mask point Phi_j that the party of the share id committed to in the session
+func openCommitment(ec elliptic.Curve, ssid []byte, shareID *big.Int, r1msg *PresignRound1Message1, r3msg1 *PresignRound3Message1) (*crypto.ECPoint, *crypto.ECPoint, error) {
+	cmtDeCmt := cmt.HashCommitDecommit{C: r1msg.UnmarshalCommitment(), D: r3msg1.UnmarshalDeCommitment()}
+	ok, secrets := cmtDeCmt.DeCommit()
+	if !ok || len(secrets) != 6 {
+		return nil, nil, errors.New("de-commitment of the nonce point failed")
+	}
+	if new(big.Int).SetBytes(ssid).Cmp(secrets[0]) != 0 || shareID.Cmp(secrets[1]) != 0 {
+		return nil, nil, errors.New("the nonce point is committed to for another session or party")
+	}
+	Rj, err := crypto.NewECPoint(ec, secrets[2], secrets[3])
+	if err != nil {
+		return nil, nil, fmt.Errorf("the nonce point is not on the curve: %s", err.Error())
+	}
+	Phij, err := crypto.NewECPoint(ec, secrets[4], secrets[5])
+	if err != nil {
+		return nil, nil, fmt.Errorf("the mask point is not on the curve: %s", err.Error())
+	}
+	return Rj, Phij, nil
+}
+
+// CheckU checks that u_j * G + sum(c_u * G of Pk for phi_j) = Psi_j + sum(c_u * G of Pj), with the DLEQ proof that Psi_j = phi_j * R
+// for the mask point Phi_j that Pj committed to. gammasU[k][l] is the point c_u * G that Pk broadcast for its multiplication of r_k and phi_l.
+func CheckU(ec elliptic.Curve, ssid []byte, j int, R, Phij *crypto.ECPoint, gammasU [][]*crypto.ECPoint, r4msg *PresignRound4Message) error {
+	Psij, err := r4msg.UnmarshalPsi()
+	if err != nil {
+		return fmt.Errorf("err: Psi_j: %s", err.Error())
+	}
+	proof, err := r4msg.UnmarshalProof()
+	if err != nil {
+		return fmt.Errorf("err: DLEQ proof: %s", err.Error())
+	}
+	if err := dleq.VerifyShare(ec, ssid, Phij, R, Psij, proof); err != nil {
+		return fmt.Errorf("err: Psi_j: %s", err.Error())
+	}
+
+	u := new(big.Int).Mod(r4msg.UnmarshalU(), ec.Params().N)
+	if u.Sign() == 0 {
+		return errors.New("u_j is zero")
+	}
+	lhs, rhs := crypto.ScalarBaseMult(ec, u), Psij
+	for k := range gammasU {
+		if k == j {
+			continue
+		}
+		if lhs, err = lhs.Add(gammasU[k][j]); err != nil {
+			return fmt.Errorf("u_j does not match the shares of Alice: %s", err.Error())
+		}
+		if rhs, err = rhs.Add(gammasU[j][k]); err != nil {
+			return fmt.Errorf("u_j does not match the shares of Alice: %s", err.Error())
+		}
+	}
+	if !lhs.Equals(rhs) {
+		return errors.New("u_j does not match the shares of Alice")
+	}
+	return nil
+}
+
+// parseUEvidence returns the round 4 broadcast of the culprit, and the round 1 and round 3 broadcasts of all the signers
+// in the order of the parties, which are sent by the parties of the share ids Ks. The evidence holds the round 4 broadcast first.
+func parseUEvidence(in *UInputs, msgs []tss.ParsedMessage) (*PresignRound4Message, []*PresignRound1Message1, []*PresignRound3Message1, error) {
+	if len(msgs) == 0 {
+		return nil, nil, nil, errors.New("expected the round 4 broadcast")
+	}
+	r4msg, ok := msgs[0].Content().(*PresignRound4Message)
+	if !ok {
+		return nil, nil, nil, errors.New("expected the round 4 broadcast")
+	}
+	r1msgs, err := tss.MessagesBySender(msgs[1:], len(in.Ks), (*PresignRound1Message1)(nil))
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	r3msgs, err := tss.MessagesBySender(msgs[1:], len(in.Ks), (*PresignRound3Message1)(nil))
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	r1msg1s := make([]*PresignRound1Message1, len(in.Ks))
+	r3msg1s := make([]*PresignRound3Message1, len(in.Ks))
+	for j := range in.Ks {
+		if r1msgs[j].GetFrom().KeyInt().Cmp(in.Ks[j]) != 0 || r3msgs[j].GetFrom().KeyInt().Cmp(in.Ks[j]) != 0 {
+			return nil, nil, nil, fmt.Errorf("the broadcasts of party %d are not from the signer of its share id", j)
+		}
+		r1msg1s[j] = r1msgs[j].Content().(*PresignRound1Message1)
+		r3msg1s[j] = r3msgs[j].Content().(*PresignRound3Message1)
+	}
+	return r4msg, r1msg1s, r3msg1s, nil
+}
+
+func checkUEvidence(ev *tss.Evidence, msgs []tss.ParsedMessage) (failure error, err error) {
+	in := new(UInputs)
+	if err := ev.UnmarshalInputs(in); err != nil {
+		return nil, err
+	}
+	if in.Index != ev.Culprit.Index || in.Index < 0 || in.Index >= len(in.PubXj) {
+		return nil, fmt.Errorf("the inputs are about party %d, not the culprit", in.Index)
+	}
+	ec, ok := tss.GetCurveByName(in.Curve)
+	if !ok {
+		return nil, fmt.Errorf("unknown curve %s", in.Curve)
+	}
+	if len(in.Ks) != len(in.PubXj) {
+		return nil, errors.New("expected a share id for every key share")
+	}
+	for j, Xj := range in.PubXj {
+		if Xj == nil || !tss.SameCurve(Xj.Curve(), ec) || in.Ks[j] == nil {
+			return nil, fmt.Errorf("the key share or the share id of party %d are missing", j)
+		}
+	}
+	r4msg, r1msg1s, r3msg1s, err := parseUEvidence(in, msgs)
+	if err != nil {
+		return nil, err
+	}
+	ssid, err := computeSSID(ec, in.Ks, in.PubXj, 1, big.NewInt(0))
+	if err != nil {
+		return nil, err
+	}
+
+	// the nonce point R is the sum of the nonce points that the signers opened
+	var R, Phij *crypto.ECPoint
+	gammasU := make([][]*crypto.ECPoint, len(in.Ks))
+	for k := range in.Ks {
+		Rk, Phik, err := openCommitment(ec, ssid, in.Ks[k], r1msg1s[k], r3msg1s[k])
+		if err != nil {
+			return nil, fmt.Errorf("the broadcasts of party %d are invalid: %s", k, err.Error())
+		}
+		if gammasU[k], err = r3msg1s[k].UnmarshalGammasU(ec, len(in.Ks), k); err != nil {
+			return nil, fmt.Errorf("the broadcasts of party %d are invalid: %s", k, err.Error())
+		}
+		if k == in.Index {
+			Phij = Phik
+		}
+		if R == nil {
+			R = Rk
+		} else if R, err = R.Add(Rk); err != nil {
+			return nil, fmt.Errorf("sum of the nonce points: %s", err.Error())
+		}
+	}
+	return CheckU(ec, ssid, in.Index, R, Phij, gammasU, r4msg), nil
+}
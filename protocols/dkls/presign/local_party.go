@@ -0,0 +1,244 @@
+// Package presign implements a presign of threshold ECDSA after DKLs23 ("Threshold ECDSA in Three Rounds"),
+// whose multiplications run over oblivious transfers instead of the Paillier encryption of CGGMP:
+// it needs no auxiliary data, so the keys of keygen can sign without the Paillier keys and the ring-Pedersen parameters.
+//
+// Each signer samples a nonce share r_i and a mask phi_i. For each pair of signers, the two multiplications of crypto/ot
+// share r_i * phi_j and x_i * phi_j, and Bob checks the shares of Alice in the exponent against R_i and X_i,
+// which binds Alice to the inputs that the other signers see.
+// The signers then open u = r * phi, and the presignature of signer i is K_i = phi_i / u, Chi_i = v_i / u with v = x * phi,
+// so that K = 1 / r and Chi = x / r for the nonce point R = r * G.
+// The share u_i = phi_i * r + sum(cu_ij) - sum(cu_ji) is checked in the exponent against Psi_i = phi_i * R, whose DLEQ proof
+// binds it to the committed Phi_i = phi_i * G, and against the points c_u * G that Alice broadcasts, so a wrong share of u is blamed on its sender.
+//
+// The presignatures are the presignatures of cggmp presign: the signing, the pool and the signer take them unchanged,
+// and NewBackendLocalParty switches between the two engines.
+//
+// The base OTs run in every presign, for every pair of signers, with no state kept between the sessions.
+package presign
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/felicityin/mpc-tss/common"
+	"github.com/felicityin/mpc-tss/crypto"
+	cmt "github.com/felicityin/mpc-tss/crypto/commitments"
+	"github.com/felicityin/mpc-tss/crypto/ot"
+	"github.com/felicityin/mpc-tss/protocols/cggmp/auxiliary"
+	cggmpPresign "github.com/felicityin/mpc-tss/protocols/cggmp/ecdsa/presign"
+	"github.com/felicityin/mpc-tss/protocols/cggmp/keygen"
+	"github.com/felicityin/mpc-tss/protocols/utils"
+	"github.com/felicityin/mpc-tss/tss"
+)
+
+// Implements Party
+// Implements Stringer
+var _ tss.Party = (*LocalParty)(nil)
+var _ fmt.Stringer = (*LocalParty)(nil)
+
+// Backend is the engine of the presign of threshold ECDSA
+type Backend int
+
+const (
+	// BackendCGGMP multiplies the shares with Paillier encryption and range proofs, and needs the auxiliary data
+	BackendCGGMP Backend = iota
+	// BackendDKLs multiplies the shares with oblivious transfers, and needs no auxiliary data
+	BackendDKLs
+)
+
+type (
+	LocalParty struct {
+		*tss.BaseParty
+		params *tss.Parameters
+
+		keys keygen.LocalPartySaveData
+		temp localTempData
+		data cggmpPresign.LocalPartySaveData
+
+		// outbound messaging
+		out chan<- tss.Message
+		end chan<- *cggmpPresign.LocalPartySaveData
+	}
+
+	localMessageStore struct {
+		presignRound1Message1s,
+		presignRound1Message2s,
+		presignRound2Messages,
+		presignRound3Message1s,
+		presignRound3Message2s,
+		presignRound4Messages []tss.ParsedMessage
+	}
+
+	localTempData struct {
+		localMessageStore
+
+		isThreshold bool
+
+		// round 1
+		r, phi *big.Int
+		R, Phi *crypto.ECPoint
+		cmt    *cmt.HashCommitDecommit
+		alices []*ot.Alice // the multiplications of r_i and x_i with phi_j
+
+		// round 2
+		bobs []*ot.Bob // the multiplications of r_j and x_j with phi_i
+
+		// round 3
+		cu, cv  []*big.Int          // the shares of Alice of r_i * phi_j and x_i * phi_j
+		gammasU [][]*crypto.ECPoint // cu * G of the multiplications of r_j and phi_k, by j and k
+
+		// round 4
+		bigPhis []*crypto.ECPoint // the mask points of the parties
+		sumR    *crypto.ECPoint
+		v       *big.Int
+
+		ssid      []byte
+		ssidNonce *big.Int
+	}
+)
+
+// NewLocalParty returns a party that outputs its share of a presignature on end, for the signing of cggmp
+func NewLocalParty(
+	isThreshold bool,
+	params *tss.Parameters,
+	key keygen.LocalPartySaveData,
+	out chan<- tss.Message,
+	end chan<- *cggmpPresign.LocalPartySaveData,
+) (tss.Party, error) {
+	key, err := keygen.BuildLocalSaveDataSubset(key, params.Parties().IDs())
+	if err != nil {
+		return nil, err
+	}
+	err = utils.UpdateKeyForSigning(&key, "", isThreshold, params.Threshold())
+	if err != nil {
+		return nil, err
+	}
+
+	partyCount := len(params.Parties().IDs())
+	data := cggmpPresign.NewLocalPartySaveData(partyCount)
+	p := &LocalParty{
+		BaseParty: new(tss.BaseParty),
+		params:    params,
+		keys:      key,
+		temp:      localTempData{},
+		data:      data,
+		out:       out,
+		end:       end,
+	}
+	// msgs init
+	p.temp.presignRound1Message1s = make([]tss.ParsedMessage, partyCount)
+	p.temp.presignRound1Message2s = make([]tss.ParsedMessage, partyCount)
+	p.temp.presignRound2Messages = make([]tss.ParsedMessage, partyCount)
+	p.temp.presignRound3Message1s = make([]tss.ParsedMessage, partyCount)
+	p.temp.presignRound3Message2s = make([]tss.ParsedMessage, partyCount)
+	p.temp.presignRound4Messages = make([]tss.ParsedMessage, partyCount)
+
+	p.temp.isThreshold = isThreshold
+	p.temp.ssidNonce = new(big.Int)
+	p.temp.alices = make([]*ot.Alice, partyCount)
+	p.temp.bobs = make([]*ot.Bob, partyCount)
+	p.temp.cu = make([]*big.Int, partyCount)
+	p.temp.cv = make([]*big.Int, partyCount)
+	p.temp.gammasU = make([][]*crypto.ECPoint, partyCount)
+	p.temp.bigPhis = make([]*crypto.ECPoint, partyCount)
+	return p, nil
+}
+
+// NewBackendLocalParty returns a presign party of the backend. aux is only used by BackendCGGMP, and may be nil for BackendDKLs.
+func NewBackendLocalParty(
+	backend Backend,
+	isThreshold bool,
+	params *tss.Parameters,
+	key keygen.LocalPartySaveData,
+	aux *auxiliary.LocalPartySaveData,
+	out chan<- tss.Message,
+	end chan<- *cggmpPresign.LocalPartySaveData,
+) (tss.Party, error) {
+	switch backend {
+	case BackendCGGMP:
+		if aux == nil {
+			return nil, errors.New("the CGGMP presign needs the auxiliary data")
+		}
+		return cggmpPresign.NewLocalParty(isThreshold, params, key, *aux, out, end)
+	case BackendDKLs:
+		return NewLocalParty(isThreshold, params, key, out, end)
+	default:
+		return nil, fmt.Errorf("unknown presign backend %d", backend)
+	}
+}
+
+func (p *LocalParty) FirstRound() tss.Round {
+	return newRound1(p.temp.isThreshold, p.params, &p.keys, &p.data, &p.temp, p.out, p.end)
+}
+
+func (p *LocalParty) Start() *tss.Error {
+	return tss.BaseStart(p, TaskName)
+}
+
+func (p *LocalParty) Update(msg tss.ParsedMessage) (ok bool, err *tss.Error) {
+	return tss.BaseUpdate(p, msg, TaskName)
+}
+
+func (p *LocalParty) UpdateFromBytes(wireBytes []byte, from *tss.PartyID, isBroadcast bool) (bool, *tss.Error) {
+	msg, err := tss.ParseWireMessage(wireBytes, from, isBroadcast)
+	if err != nil {
+		return false, p.WrapError(err)
+	}
+	return p.Update(msg)
+}
+
+func (p *LocalParty) ValidateMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	if msg.GetFrom() == nil || !msg.GetFrom().ValidateBasic() {
+		return false, p.WrapError(fmt.Errorf("received msg with an invalid sender: %s", msg))
+	}
+	// check that the message's "from index" will fit into the array
+	if maxFromIdx := len(p.params.Parties().IDs()) - 1; maxFromIdx < msg.GetFrom().Index {
+		return false, p.WrapError(fmt.Errorf("received msg with a sender index too great (%d <= %d)",
+			maxFromIdx, msg.GetFrom().Index), msg.GetFrom())
+	}
+	return p.BaseParty.ValidateMessage(msg)
+}
+
+func (p *LocalParty) StoreMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	// ValidateBasic is cheap; double-check the message here in case the public StoreMessage was called externally
+	if ok, err := p.ValidateMessage(msg); !ok || err != nil {
+		return ok, err
+	}
+	fromPIdx := msg.GetFrom().Index
+
+	// switch/case is necessary to store any messages beyond current round
+	// this does not handle message replays. we expect the caller to apply replay and spoofing protection.
+	switch msg.Content().(type) {
+	case *PresignRound1Message1:
+		p.temp.presignRound1Message1s[fromPIdx] = msg
+
+	case *PresignRound1Message2:
+		p.temp.presignRound1Message2s[fromPIdx] = msg
+
+	case *PresignRound2Message:
+		p.temp.presignRound2Messages[fromPIdx] = msg
+
+	case *PresignRound3Message1:
+		p.temp.presignRound3Message1s[fromPIdx] = msg
+
+	case *PresignRound3Message2:
+		p.temp.presignRound3Message2s[fromPIdx] = msg
+
+	case *PresignRound4Message:
+		p.temp.presignRound4Messages[fromPIdx] = msg
+
+	default: // unrecognised message, just ignore!
+		common.Logger.Warningf("unrecognised message ignored: %v", msg)
+		return false, nil
+	}
+	return true, nil
+}
+
+func (p *LocalParty) PartyID() *tss.PartyID {
+	return p.params.PartyID()
+}
+
+func (p *LocalParty) String() string {
+	return fmt.Sprintf("id: %s, %s", p.PartyID(), p.BaseParty.String())
+}
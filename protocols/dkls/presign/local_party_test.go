@@ -0,0 +1,292 @@
+package presign
+
+import (
+	"crypto/ecdsa"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/ipfs/go-log"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/felicityin/mpc-tss/common"
+	"github.com/felicityin/mpc-tss/crypto"
+	"github.com/felicityin/mpc-tss/protocols/cggmp/auxiliary"
+	cggmpPresign "github.com/felicityin/mpc-tss/protocols/cggmp/ecdsa/presign"
+	"github.com/felicityin/mpc-tss/protocols/cggmp/ecdsa/signing"
+	"github.com/felicityin/mpc-tss/protocols/cggmp/keygen"
+	tKeygen "github.com/felicityin/mpc-tss/protocols/cggmp/keygen/threshold"
+	"github.com/felicityin/mpc-tss/protocols/cggmp/test"
+	"github.com/felicityin/mpc-tss/protocols/utils"
+	"github.com/felicityin/mpc-tss/tss"
+)
+
+const (
+	testParticipants = 3
+	testThreshold    = 2
+	testPath         = "0/1/2/2/10"
+)
+
+func setUp(level string) {
+	if err := log.SetLogLevel("tss-lib", level); err != nil {
+		panic(err)
+	}
+}
+
+// runPresign runs the presign of the threshold fixtures of the kind, and returns the presignatures in the order of the parties.
+// tamper, if not nil, changes the messages to the last party.
+func runPresign(
+	t *testing.T,
+	kind int,
+	tamper func(msg tss.ParsedMessage) tss.ParsedMessage,
+) (keys []keygen.LocalPartySaveData, signPIDs tss.SortedPartyIDs, pres []cggmpPresign.LocalPartySaveData, tssErr *tss.Error) {
+	keys, signPIDs, err := tKeygen.LoadKeygenTestFixturesRandomSet(kind, testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	parties := make([]*LocalParty, 0, len(signPIDs))
+
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs)*len(signPIDs))
+	endCh := make(chan *cggmpPresign.LocalPartySaveData, len(signPIDs))
+
+	updater := test.SharedPartyUpdater
+
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(tKeygen.TestCurve(kind), p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+		party, err := NewLocalParty(true, params, keys[i], outCh, endCh)
+		assert.NoError(t, err)
+		parties = append(parties, party.(*LocalParty))
+	}
+	// start every party before routing the messages, so that no party receives all the messages of round 1 before it starts
+	for _, P := range parties {
+		if err := P.Start(); err != nil {
+			assert.FailNow(t, err.Error())
+		}
+	}
+
+	victim := len(signPIDs) - 1
+	route := func(Pj *LocalParty, msg tss.Message) {
+		if tamper != nil && Pj.PartyID().Index == victim {
+			go updater(Pj, tamper(msg.(tss.ParsedMessage)), errCh)
+			return
+		}
+		go updater(Pj, msg, errCh)
+	}
+
+	pres = make([]cggmpPresign.LocalPartySaveData, len(signPIDs))
+	for ended := 0; ended < len(signPIDs); {
+		select {
+		case err := <-errCh:
+			return keys, signPIDs, nil, err
+
+		case msg := <-outCh:
+			dest := msg.GetTo()
+			if dest != nil {
+				if dest[0].Index == msg.GetFrom().Index {
+					t.Fatalf("party %d tried to send a message to itself (%d)", dest[0].Index, msg.GetFrom().Index)
+				}
+				route(parties[dest[0].Index], msg)
+				continue
+			}
+			for _, Pj := range parties {
+				if Pj.PartyID().Index == msg.GetFrom().Index {
+					continue
+				}
+				route(Pj, msg)
+			}
+
+		case save := <-endCh:
+			index, err := save.OriginalIndex()
+			assert.NoError(t, err)
+			pres[index] = *save
+			ended++
+		}
+	}
+	return keys, signPIDs, pres, nil
+}
+
+// runSigning signs msg with the presignatures for the key of the path, and returns the signature of the first party
+func runSigning(
+	t *testing.T,
+	kind int,
+	keys []keygen.LocalPartySaveData,
+	signPIDs tss.SortedPartyIDs,
+	pres []cggmpPresign.LocalPartySaveData,
+	path string,
+	msg *big.Int,
+) *common.SignatureData {
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	parties := make([]tss.Party, 0, len(signPIDs))
+
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+	endCh := make(chan *common.SignatureData, len(signPIDs))
+
+	updater := test.SharedPartyUpdater
+
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(tKeygen.TestCurve(kind), p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+		party, err := signing.NewLocalParty(msg, true, params, path, keys[i], pres[i], outCh, endCh)
+		assert.NoError(t, err)
+		parties = append(parties, party)
+	}
+	for _, P := range parties {
+		if err := P.Start(); err != nil {
+			assert.FailNow(t, err.Error())
+		}
+	}
+
+	var sigs []*common.SignatureData
+	for len(sigs) < len(signPIDs) {
+		select {
+		case err := <-errCh:
+			assert.FailNow(t, err.Error())
+
+		case msg := <-outCh:
+			for _, Pj := range parties {
+				if Pj.PartyID().Index == msg.GetFrom().Index {
+					continue
+				}
+				go updater(Pj, msg, errCh)
+			}
+
+		case sig := <-endCh:
+			sigs = append(sigs, sig)
+		}
+	}
+	return sigs[0]
+}
+
+func TestE2EThresholdConcurrent(t *testing.T) {
+	setUp("info")
+
+	msg, _ := hex.DecodeString("00f163ee51bcaeff9cdff5e0e3c1a646abd19885fffbab0b3b4236e0cf95c9f5")
+	for _, kind := range []int{keygen.Ecdsa, keygen.EcdsaP256} {
+		for _, path := range []string{"", testPath} {
+			keys, signPIDs, pres, err := runPresign(t, kind, nil)
+			if !assert.Nil(t, err) {
+				continue
+			}
+			ec := tKeygen.TestCurve(kind)
+
+			// K = 1 / r for R = r * G
+			modN := common.ModInt(ec.Params().N)
+			sumK := big.NewInt(0)
+			for _, pre := range pres {
+				assert.True(t, pres[0].R.Equals(pre.R), "the parties output the same nonce point")
+				sumK = modN.Add(sumK, pre.K)
+			}
+			assert.True(t, pres[0].R.ScalarMult(sumK).Equals(crypto.ScalarBaseMult(ec, big.NewInt(1))))
+
+			pubkey := keys[0].Pubkey
+			if path != "" {
+				_, extendedKey, err := utils.DerivingKeyFromPath(keys[0], path)
+				assert.NoError(t, err)
+				pubkey = extendedKey.PublicKey
+			}
+			sig := runSigning(t, kind, keys, signPIDs, pres, path, new(big.Int).SetBytes(msg))
+			pk := ecdsa.PublicKey{Curve: ec, X: pubkey.X(), Y: pubkey.Y()}
+			ok := ecdsa.Verify(&pk, msg, new(big.Int).SetBytes(sig.R), new(big.Int).SetBytes(sig.S))
+			assert.True(t, ok, "ecdsa verify must pass")
+		}
+	}
+}
+
+func TestCulpritOfBadShare(t *testing.T) {
+	setUp("info")
+
+	// P0 sends P2 a share of r_0 * phi_2 that does not match R_0, P2 must blame it
+	_, _, _, tssErr := runPresign(t, keygen.Ecdsa, func(msg tss.ParsedMessage) tss.ParsedMessage {
+		r3msg1, ok := msg.Content().(*PresignRound3Message1)
+		if !ok || msg.GetFrom().Index != 0 {
+			return msg
+		}
+		GammasU, _ := r3msg1.UnmarshalGammasU(tss.S256(), testThreshold+1, 0)
+		GammasU[testThreshold], _ = GammasU[testThreshold].Add(crypto.ScalarBaseMult(tss.S256(), big.NewInt(1)))
+		tampered, _ := NewPresignRound3Message1(msg.GetFrom(), r3msg1.UnmarshalDeCommitment(), GammasU)
+		return tampered
+	})
+	if assert.NotNil(t, tssErr) {
+		assert.Equal(t, testThreshold, tssErr.Victim().Index)
+		if assert.Len(t, tssErr.Culprits(), 1) {
+			assert.Equal(t, 0, tssErr.Culprits()[0].Index)
+		}
+	}
+}
+
+func TestEvidenceOfBadU(t *testing.T) {
+	setUp("info")
+
+	// P0 broadcasts a share of u that does not match its shares of the multiplications, P2 must blame it with the evidence
+	_, _, _, tssErr := runPresign(t, keygen.Ecdsa, func(msg tss.ParsedMessage) tss.ParsedMessage {
+		r4msg, ok := msg.Content().(*PresignRound4Message)
+		if !ok || msg.GetFrom().Index != 0 {
+			return msg
+		}
+		Psi, _ := r4msg.UnmarshalPsi()
+		proof, _ := r4msg.UnmarshalProof()
+		tampered, _ := NewPresignRound4Message(msg.GetFrom(), new(big.Int).Add(r4msg.UnmarshalU(), big.NewInt(1)), Psi, proof)
+		return tampered
+	})
+	if assert.NotNil(t, tssErr) {
+		assert.Equal(t, testThreshold, tssErr.Victim().Index)
+		if assert.Len(t, tssErr.Culprits(), 1) {
+			assert.Equal(t, 0, tssErr.Culprits()[0].Index)
+		}
+		if assert.Len(t, tssErr.Evidence(), 1) {
+			ev := tssErr.Evidence()[0]
+			assert.Equal(t, EvidenceU, ev.Kind)
+			assert.NoError(t, ev.Verify(), "anyone can check the evidence")
+
+			// the same messages with the share of u that P0 computed show no misbehaviour
+			msgs, err := ev.ParseMessages()
+			if assert.NoError(t, err) && assert.Len(t, msgs, 1+2*(testThreshold+1)) {
+				r4msg := msgs[0].Content().(*PresignRound4Message)
+				Psi, _ := r4msg.UnmarshalPsi()
+				proof, _ := r4msg.UnmarshalProof()
+				honest, _ := NewPresignRound4Message(msgs[0].GetFrom(), new(big.Int).Sub(r4msg.UnmarshalU(), big.NewInt(1)), Psi, proof)
+				in := new(UInputs)
+				assert.NoError(t, ev.UnmarshalInputs(in))
+				honestEv, err := tss.NewEvidence(EvidenceU, TaskName, ev.Round, ev.Culprit, in, append([]tss.ParsedMessage{honest}, msgs[1:]...)...)
+				if assert.NoError(t, err) {
+					assert.ErrorIs(t, honestEv.Verify(), tss.ErrNoMisbehaviour)
+				}
+
+				// R and the shares of Alice are computed from the broadcasts of all the signers, not taken from the accuser
+				partial, err := tss.NewEvidence(EvidenceU, TaskName, ev.Round, ev.Culprit, in, msgs[:len(msgs)-1]...)
+				if assert.NoError(t, err) {
+					err := partial.Verify()
+					assert.Error(t, err)
+					assert.NotErrorIs(t, err, tss.ErrNoMisbehaviour)
+				}
+			}
+		}
+	}
+}
+
+func TestBackend(t *testing.T) {
+	setUp("info")
+
+	keys, signPIDs, err := tKeygen.LoadKeygenTestFixturesRandomSet(keygen.Ecdsa, testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	auxs, _, err := auxiliary.LoadAuxTestFixtures(keygen.Ecdsa, testThreshold+1)
+	assert.NoError(t, err, "should load aux fixtures")
+
+	params := tss.NewParameters(tss.S256(), tss.NewPeerContext(signPIDs), signPIDs[0], len(signPIDs), testThreshold)
+	out := make(chan tss.Message, len(signPIDs))
+	end := make(chan *cggmpPresign.LocalPartySaveData, 1)
+
+	party, err := NewBackendLocalParty(BackendDKLs, true, params, keys[0], nil, out, end)
+	if assert.NoError(t, err, "the DKLs presign needs no auxiliary data") {
+		assert.IsType(t, &LocalParty{}, party)
+	}
+	party, err = NewBackendLocalParty(BackendCGGMP, true, params, keys[0], &auxs[0], out, end)
+	if assert.NoError(t, err) {
+		assert.IsType(t, &cggmpPresign.LocalParty{}, party)
+	}
+	_, err = NewBackendLocalParty(BackendCGGMP, true, params, keys[0], nil, out, end)
+	assert.Error(t, err)
+	_, err = NewBackendLocalParty(Backend(7), true, params, keys[0], nil, out, end)
+	assert.Error(t, err)
+}
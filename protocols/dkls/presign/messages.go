@@ -0,0 +1,275 @@
+package presign
+
+import (
+	"crypto/elliptic"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/felicityin/mpc-tss/common"
+	"github.com/felicityin/mpc-tss/crypto"
+	cmt "github.com/felicityin/mpc-tss/crypto/commitments"
+	"github.com/felicityin/mpc-tss/crypto/dleq"
+	"github.com/felicityin/mpc-tss/tss"
+)
+
+// These messages were generated from Protocol Buffers definitions into presign.pb.go
+// The following messages are registered on the Protocol Buffers "wire"
+
+var (
+	// Ensure that presign messages implement ValidateBasic
+	_ = []tss.MessageContent{
+		(*PresignRound1Message1)(nil),
+		(*PresignRound1Message2)(nil),
+		(*PresignRound2Message)(nil),
+		(*PresignRound3Message1)(nil),
+		(*PresignRound3Message2)(nil),
+		(*PresignRound4Message)(nil),
+	}
+)
+
+// ----- //
+
+func NewPresignRound1Message1(from *tss.PartyID, commitment cmt.HashCommitment) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &PresignRound1Message1{
+		Commitment: commitment.Bytes(),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *PresignRound1Message1) ValidateBasic() bool {
+	return m != nil && common.NonEmptyBytes(m.GetCommitment())
+}
+
+func (m *PresignRound1Message1) UnmarshalCommitment() cmt.HashCommitment {
+	return new(big.Int).SetBytes(m.GetCommitment())
+}
+
+// ----- //
+
+func NewPresignRound1Message2(to, from *tss.PartyID, A *crypto.ECPoint) (tss.ParsedMessage, error) {
+	bz, err := A.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	meta := tss.MessageRouting{
+		From:        from,
+		To:          []*tss.PartyID{to},
+		IsBroadcast: false,
+	}
+	content := &PresignRound1Message2{
+		A: bz,
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg), nil
+}
+
+func (m *PresignRound1Message2) ValidateBasic() bool {
+	return m != nil && common.NonEmptyBytes(m.GetA())
+}
+
+func (m *PresignRound1Message2) UnmarshalA() (*crypto.ECPoint, error) {
+	return crypto.UnmarshalJSONPoint(m.GetA())
+}
+
+// ----- //
+
+func NewPresignRound2Message(to, from *tss.PartyID, Bs []*crypto.ECPoint) (tss.ParsedMessage, error) {
+	flattened, err := crypto.FlattenECPoints(Bs)
+	if err != nil {
+		return nil, err
+	}
+	meta := tss.MessageRouting{
+		From:        from,
+		To:          []*tss.PartyID{to},
+		IsBroadcast: false,
+	}
+	content := &PresignRound2Message{
+		B: common.BigIntsToBytes(flattened),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg), nil
+}
+
+func (m *PresignRound2Message) ValidateBasic() bool {
+	return m != nil && common.NonEmptyMultiBytes(m.GetB())
+}
+
+func (m *PresignRound2Message) UnmarshalB(ec elliptic.Curve) ([]*crypto.ECPoint, error) {
+	return crypto.UnFlattenECPoints(ec, common.MultiBytesToBigInts(m.GetB()))
+}
+
+// ----- //
+
+func NewPresignRound3Message1(from *tss.PartyID, deCommitment cmt.HashDeCommitment, GammasU []*crypto.ECPoint) (tss.ParsedMessage, error) {
+	gammasU := make([][]byte, len(GammasU))
+	for j, GammaU := range GammasU {
+		if GammaU == nil {
+			gammasU[j] = []byte{}
+			continue
+		}
+		bz, err := GammaU.MarshalJSON()
+		if err != nil {
+			return nil, err
+		}
+		gammasU[j] = bz
+	}
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &PresignRound3Message1{
+		DeCommitment: common.BigIntsToBytes(deCommitment),
+		GammasU:      gammasU,
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg), nil
+}
+
+func (m *PresignRound3Message1) ValidateBasic() bool {
+	return m != nil && common.NonEmptyMultiBytes(m.GetDeCommitment()) && len(m.GetGammasU()) > 1
+}
+
+func (m *PresignRound3Message1) UnmarshalDeCommitment() cmt.HashDeCommitment {
+	return cmt.NewHashDeCommitmentFromBytes(m.GetDeCommitment())
+}
+
+// UnmarshalGammasU returns the points c_u * G of the sender, one for each of the count parties, with nil for the sender at index from
+func (m *PresignRound3Message1) UnmarshalGammasU(ec elliptic.Curve, count, from int) ([]*crypto.ECPoint, error) {
+	if len(m.GetGammasU()) != count {
+		return nil, fmt.Errorf("expected %d shares of Alice, got %d", count, len(m.GetGammasU()))
+	}
+	GammasU := make([]*crypto.ECPoint, count)
+	for j, bz := range m.GetGammasU() {
+		if j == from {
+			if len(bz) != 0 {
+				return nil, errors.New("the sender has a share of Alice with itself")
+			}
+			continue
+		}
+		GammaU, err := crypto.UnmarshalJSONPoint(bz)
+		if err != nil {
+			return nil, fmt.Errorf("the share %d of Alice: %s", j, err.Error())
+		}
+		if !tss.SameCurve(GammaU.Curve(), ec) {
+			return nil, fmt.Errorf("the share %d of Alice is not on the curve", j)
+		}
+		GammasU[j] = GammaU
+	}
+	return GammasU, nil
+}
+
+// ----- //
+
+func NewPresignRound3Message2(
+	to, from *tss.PartyID,
+	corrections [][]*big.Int,
+	GammaV *crypto.ECPoint,
+) (tss.ParsedMessage, error) {
+	var flattened []*big.Int
+	for _, tau := range corrections {
+		flattened = append(flattened, tau...)
+	}
+	gammaV, err := GammaV.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	meta := tss.MessageRouting{
+		From:        from,
+		To:          []*tss.PartyID{to},
+		IsBroadcast: false,
+	}
+	content := &PresignRound3Message2{
+		Corrections: common.BigIntsToBytes(flattened),
+		GammaV:      gammaV,
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg), nil
+}
+
+func (m *PresignRound3Message2) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyMultiBytes(m.GetCorrections()) &&
+		common.NonEmptyBytes(m.GetGammaV())
+}
+
+// UnmarshalCorrections returns the corrections of the OTs, of width values each
+func (m *PresignRound3Message2) UnmarshalCorrections(width int) ([][]*big.Int, error) {
+	flattened := common.MultiBytesToBigInts(m.GetCorrections())
+	if len(flattened)%width != 0 {
+		return nil, fmt.Errorf("the %d corrections are not a multiple of %d", len(flattened), width)
+	}
+	corrections := make([][]*big.Int, len(flattened)/width)
+	for k := range corrections {
+		corrections[k] = flattened[k*width : (k+1)*width]
+	}
+	return corrections, nil
+}
+
+func (m *PresignRound3Message2) UnmarshalGammaV() (*crypto.ECPoint, error) {
+	return crypto.UnmarshalJSONPoint(m.GetGammaV())
+}
+
+// ----- //
+
+func NewPresignRound4Message(from *tss.PartyID, u *big.Int, Psi *crypto.ECPoint, proof *dleq.Proof) (tss.ParsedMessage, error) {
+	psi, err := Psi.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	a, err := proof.A.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	b, err := proof.B.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &PresignRound4Message{
+		U:      u.Bytes(),
+		Psi:    psi,
+		ProofA: a,
+		ProofB: b,
+		ProofZ: proof.Z.Bytes(),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg), nil
+}
+
+func (m *PresignRound4Message) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetU()) &&
+		common.NonEmptyBytes(m.GetPsi()) &&
+		common.NonEmptyBytes(m.GetProofA()) &&
+		common.NonEmptyBytes(m.GetProofB()) &&
+		common.NonEmptyBytes(m.GetProofZ())
+}
+
+func (m *PresignRound4Message) UnmarshalU() *big.Int {
+	return new(big.Int).SetBytes(m.GetU())
+}
+
+func (m *PresignRound4Message) UnmarshalPsi() (*crypto.ECPoint, error) {
+	return crypto.UnmarshalJSONPoint(m.GetPsi())
+}
+
+func (m *PresignRound4Message) UnmarshalProof() (*dleq.Proof, error) {
+	A, err := crypto.UnmarshalJSONPoint(m.GetProofA())
+	if err != nil {
+		return nil, err
+	}
+	B, err := crypto.UnmarshalJSONPoint(m.GetProofB())
+	if err != nil {
+		return nil, err
+	}
+	return &dleq.Proof{A: A, B: B, Z: new(big.Int).SetBytes(m.GetProofZ())}, nil
+}
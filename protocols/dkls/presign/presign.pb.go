@@ -0,0 +1,531 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.33.0
+// 	protoc        v3.21.4
+// source: protocols/dkls/presign/presign.proto
+
+package presign
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Represents a BROADCAST message sent to all parties during Round 1 of the DKLs presign:
+// the hash commitment to the nonce point R_i = r_i * G of the party.
+type PresignRound1Message1 struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Commitment []byte `protobuf:"bytes,1,opt,name=commitment,proto3" json:"commitment,omitempty"`
+}
+
+func (x *PresignRound1Message1) Reset() {
+	*x = PresignRound1Message1{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocols_dkls_presign_presign_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PresignRound1Message1) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PresignRound1Message1) ProtoMessage() {}
+
+func (x *PresignRound1Message1) ProtoReflect() protoreflect.Message {
+	mi := &file_protocols_dkls_presign_presign_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PresignRound1Message1.ProtoReflect.Descriptor instead.
+func (*PresignRound1Message1) Descriptor() ([]byte, []int) {
+	return file_protocols_dkls_presign_presign_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *PresignRound1Message1) GetCommitment() []byte {
+	if x != nil {
+		return x.Commitment
+	}
+	return nil
+}
+
+// Represents a P2P message sent to each party during Round 1 of the DKLs presign:
+// the point A of the base OTs of the multiplication in which the sender is Alice.
+type PresignRound1Message2 struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	A []byte `protobuf:"bytes,1,opt,name=a,proto3" json:"a,omitempty"`
+}
+
+func (x *PresignRound1Message2) Reset() {
+	*x = PresignRound1Message2{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocols_dkls_presign_presign_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PresignRound1Message2) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PresignRound1Message2) ProtoMessage() {}
+
+func (x *PresignRound1Message2) ProtoReflect() protoreflect.Message {
+	mi := &file_protocols_dkls_presign_presign_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PresignRound1Message2.ProtoReflect.Descriptor instead.
+func (*PresignRound1Message2) Descriptor() ([]byte, []int) {
+	return file_protocols_dkls_presign_presign_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PresignRound1Message2) GetA() []byte {
+	if x != nil {
+		return x.A
+	}
+	return nil
+}
+
+// Represents a P2P message sent to each party during Round 2 of the DKLs presign:
+// the points B_k of the base OTs of the multiplication in which the sender is Bob, flattened.
+type PresignRound2Message struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	B [][]byte `protobuf:"bytes,1,rep,name=b,proto3" json:"b,omitempty"`
+}
+
+func (x *PresignRound2Message) Reset() {
+	*x = PresignRound2Message{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocols_dkls_presign_presign_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PresignRound2Message) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PresignRound2Message) ProtoMessage() {}
+
+func (x *PresignRound2Message) ProtoReflect() protoreflect.Message {
+	mi := &file_protocols_dkls_presign_presign_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PresignRound2Message.ProtoReflect.Descriptor instead.
+func (*PresignRound2Message) Descriptor() ([]byte, []int) {
+	return file_protocols_dkls_presign_presign_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PresignRound2Message) GetB() [][]byte {
+	if x != nil {
+		return x.B
+	}
+	return nil
+}
+
+// Represents a BROADCAST message sent to all parties during Round 3 of the DKLs presign:
+// the de-commitment to the nonce point R_i and the mask point Phi_i, and the points c_u * G of the shares of Alice
+// of r_i * phi_j for each party, empty for the sender.
+type PresignRound3Message1 struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DeCommitment [][]byte `protobuf:"bytes,1,rep,name=de_commitment,json=deCommitment,proto3" json:"de_commitment,omitempty"`
+	GammasU      [][]byte `protobuf:"bytes,2,rep,name=gammas_u,json=gammasU,proto3" json:"gammas_u,omitempty"`
+}
+
+func (x *PresignRound3Message1) Reset() {
+	*x = PresignRound3Message1{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocols_dkls_presign_presign_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PresignRound3Message1) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PresignRound3Message1) ProtoMessage() {}
+
+func (x *PresignRound3Message1) ProtoReflect() protoreflect.Message {
+	mi := &file_protocols_dkls_presign_presign_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PresignRound3Message1.ProtoReflect.Descriptor instead.
+func (*PresignRound3Message1) Descriptor() ([]byte, []int) {
+	return file_protocols_dkls_presign_presign_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *PresignRound3Message1) GetDeCommitment() [][]byte {
+	if x != nil {
+		return x.DeCommitment
+	}
+	return nil
+}
+
+func (x *PresignRound3Message1) GetGammasU() [][]byte {
+	if x != nil {
+		return x.GammasU
+	}
+	return nil
+}
+
+// Represents a P2P message sent to each party during Round 3 of the DKLs presign:
+// the corrections of the base OTs, flattened, and the point c_v * G of the share of Alice.
+type PresignRound3Message2 struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Corrections [][]byte `protobuf:"bytes,1,rep,name=corrections,proto3" json:"corrections,omitempty"`
+	GammaV      []byte   `protobuf:"bytes,3,opt,name=gamma_v,json=gammaV,proto3" json:"gamma_v,omitempty"`
+}
+
+func (x *PresignRound3Message2) Reset() {
+	*x = PresignRound3Message2{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocols_dkls_presign_presign_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PresignRound3Message2) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PresignRound3Message2) ProtoMessage() {}
+
+func (x *PresignRound3Message2) ProtoReflect() protoreflect.Message {
+	mi := &file_protocols_dkls_presign_presign_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PresignRound3Message2.ProtoReflect.Descriptor instead.
+func (*PresignRound3Message2) Descriptor() ([]byte, []int) {
+	return file_protocols_dkls_presign_presign_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *PresignRound3Message2) GetCorrections() [][]byte {
+	if x != nil {
+		return x.Corrections
+	}
+	return nil
+}
+
+func (x *PresignRound3Message2) GetGammaV() []byte {
+	if x != nil {
+		return x.GammaV
+	}
+	return nil
+}
+
+// Represents a BROADCAST message sent to all parties during Round 4 of the DKLs presign:
+// the share u_i of u = r * phi, and Psi_i = phi_i * R with the DLEQ proof (A, B, z) that it has the discrete logarithm of Phi_i.
+type PresignRound4Message struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	U      []byte `protobuf:"bytes,1,opt,name=u,proto3" json:"u,omitempty"`
+	Psi    []byte `protobuf:"bytes,2,opt,name=psi,proto3" json:"psi,omitempty"`
+	ProofA []byte `protobuf:"bytes,3,opt,name=proof_a,json=proofA,proto3" json:"proof_a,omitempty"`
+	ProofB []byte `protobuf:"bytes,4,opt,name=proof_b,json=proofB,proto3" json:"proof_b,omitempty"`
+	ProofZ []byte `protobuf:"bytes,5,opt,name=proof_z,json=proofZ,proto3" json:"proof_z,omitempty"`
+}
+
+func (x *PresignRound4Message) Reset() {
+	*x = PresignRound4Message{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocols_dkls_presign_presign_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PresignRound4Message) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PresignRound4Message) ProtoMessage() {}
+
+func (x *PresignRound4Message) ProtoReflect() protoreflect.Message {
+	mi := &file_protocols_dkls_presign_presign_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PresignRound4Message.ProtoReflect.Descriptor instead.
+func (*PresignRound4Message) Descriptor() ([]byte, []int) {
+	return file_protocols_dkls_presign_presign_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *PresignRound4Message) GetU() []byte {
+	if x != nil {
+		return x.U
+	}
+	return nil
+}
+
+func (x *PresignRound4Message) GetPsi() []byte {
+	if x != nil {
+		return x.Psi
+	}
+	return nil
+}
+
+func (x *PresignRound4Message) GetProofA() []byte {
+	if x != nil {
+		return x.ProofA
+	}
+	return nil
+}
+
+func (x *PresignRound4Message) GetProofB() []byte {
+	if x != nil {
+		return x.ProofB
+	}
+	return nil
+}
+
+func (x *PresignRound4Message) GetProofZ() []byte {
+	if x != nil {
+		return x.ProofZ
+	}
+	return nil
+}
+
+var File_protocols_dkls_presign_presign_proto protoreflect.FileDescriptor
+
+var file_protocols_dkls_presign_presign_proto_rawDesc = []byte{
+	0x0a, 0x24, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x73, 0x2f, 0x64, 0x6b, 0x6c, 0x73,
+	0x2f, 0x70, 0x72, 0x65, 0x73, 0x69, 0x67, 0x6e, 0x2f, 0x70, 0x72, 0x65, 0x73, 0x69, 0x67, 0x6e,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x13, 0x74, 0x73, 0x73, 0x6c, 0x69, 0x62, 0x2e, 0x64,
+	0x6b, 0x6c, 0x73, 0x2e, 0x70, 0x72, 0x65, 0x73, 0x69, 0x67, 0x6e, 0x22, 0x37, 0x0a, 0x15, 0x50,
+	0x72, 0x65, 0x73, 0x69, 0x67, 0x6e, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x31, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x31, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65,
+	0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74,
+	0x6d, 0x65, 0x6e, 0x74, 0x22, 0x25, 0x0a, 0x15, 0x50, 0x72, 0x65, 0x73, 0x69, 0x67, 0x6e, 0x52,
+	0x6f, 0x75, 0x6e, 0x64, 0x31, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x32, 0x12, 0x0c, 0x0a,
+	0x01, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x01, 0x61, 0x22, 0x24, 0x0a, 0x14, 0x50,
+	0x72, 0x65, 0x73, 0x69, 0x67, 0x6e, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x32, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x12, 0x0c, 0x0a, 0x01, 0x62, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x01,
+	0x62, 0x22, 0x57, 0x0a, 0x15, 0x50, 0x72, 0x65, 0x73, 0x69, 0x67, 0x6e, 0x52, 0x6f, 0x75, 0x6e,
+	0x64, 0x33, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x31, 0x12, 0x23, 0x0a, 0x0d, 0x64, 0x65,
+	0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0c, 0x52, 0x0c, 0x64, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x12,
+	0x19, 0x0a, 0x08, 0x67, 0x61, 0x6d, 0x6d, 0x61, 0x73, 0x5f, 0x75, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x0c, 0x52, 0x07, 0x67, 0x61, 0x6d, 0x6d, 0x61, 0x73, 0x55, 0x22, 0x58, 0x0a, 0x15, 0x50, 0x72,
+	0x65, 0x73, 0x69, 0x67, 0x6e, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x33, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x32, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x6f, 0x72, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x0b, 0x63, 0x6f, 0x72, 0x72, 0x65, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x17, 0x0a, 0x07, 0x67, 0x61, 0x6d, 0x6d, 0x61, 0x5f, 0x76,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x67, 0x61, 0x6d, 0x6d, 0x61, 0x56, 0x4a, 0x04,
+	0x08, 0x02, 0x10, 0x03, 0x22, 0x81, 0x01, 0x0a, 0x14, 0x50, 0x72, 0x65, 0x73, 0x69, 0x67, 0x6e,
+	0x52, 0x6f, 0x75, 0x6e, 0x64, 0x34, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x0c, 0x0a,
+	0x01, 0x75, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x01, 0x75, 0x12, 0x10, 0x0a, 0x03, 0x70,
+	0x73, 0x69, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x70, 0x73, 0x69, 0x12, 0x17, 0x0a,
+	0x07, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5f, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06,
+	0x70, 0x72, 0x6f, 0x6f, 0x66, 0x41, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5f,
+	0x62, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x42, 0x12,
+	0x17, 0x0a, 0x07, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5f, 0x7a, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x06, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5a, 0x42, 0x18, 0x5a, 0x16, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x63, 0x6f, 0x6c, 0x73, 0x2f, 0x64, 0x6b, 0x6c, 0x73, 0x2f, 0x70, 0x72, 0x65, 0x73, 0x69,
+	0x67, 0x6e, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_protocols_dkls_presign_presign_proto_rawDescOnce sync.Once
+	file_protocols_dkls_presign_presign_proto_rawDescData = file_protocols_dkls_presign_presign_proto_rawDesc
+)
+
+func file_protocols_dkls_presign_presign_proto_rawDescGZIP() []byte {
+	file_protocols_dkls_presign_presign_proto_rawDescOnce.Do(func() {
+		file_protocols_dkls_presign_presign_proto_rawDescData = protoimpl.X.CompressGZIP(file_protocols_dkls_presign_presign_proto_rawDescData)
+	})
+	return file_protocols_dkls_presign_presign_proto_rawDescData
+}
+
+var file_protocols_dkls_presign_presign_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_protocols_dkls_presign_presign_proto_goTypes = []interface{}{
+	(*PresignRound1Message1)(nil), // 0: tsslib.dkls.presign.PresignRound1Message1
+	(*PresignRound1Message2)(nil), // 1: tsslib.dkls.presign.PresignRound1Message2
+	(*PresignRound2Message)(nil),  // 2: tsslib.dkls.presign.PresignRound2Message
+	(*PresignRound3Message1)(nil), // 3: tsslib.dkls.presign.PresignRound3Message1
+	(*PresignRound3Message2)(nil), // 4: tsslib.dkls.presign.PresignRound3Message2
+	(*PresignRound4Message)(nil),  // 5: tsslib.dkls.presign.PresignRound4Message
+}
+var file_protocols_dkls_presign_presign_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_protocols_dkls_presign_presign_proto_init() }
+func file_protocols_dkls_presign_presign_proto_init() {
+	if File_protocols_dkls_presign_presign_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_protocols_dkls_presign_presign_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PresignRound1Message1); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocols_dkls_presign_presign_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PresignRound1Message2); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocols_dkls_presign_presign_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PresignRound2Message); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocols_dkls_presign_presign_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PresignRound3Message1); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocols_dkls_presign_presign_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PresignRound3Message2); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocols_dkls_presign_presign_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PresignRound4Message); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_protocols_dkls_presign_presign_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_protocols_dkls_presign_presign_proto_goTypes,
+		DependencyIndexes: file_protocols_dkls_presign_presign_proto_depIdxs,
+		MessageInfos:      file_protocols_dkls_presign_presign_proto_msgTypes,
+	}.Build()
+	File_protocols_dkls_presign_presign_proto = out.File
+	file_protocols_dkls_presign_presign_proto_rawDesc = nil
+	file_protocols_dkls_presign_presign_proto_goTypes = nil
+	file_protocols_dkls_presign_presign_proto_depIdxs = nil
+}
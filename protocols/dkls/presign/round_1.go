@@ -0,0 +1,125 @@
+package presign
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/felicityin/mpc-tss/common"
+	"github.com/felicityin/mpc-tss/crypto"
+	cmt "github.com/felicityin/mpc-tss/crypto/commitments"
+	"github.com/felicityin/mpc-tss/crypto/ot"
+	cggmpPresign "github.com/felicityin/mpc-tss/protocols/cggmp/ecdsa/presign"
+	"github.com/felicityin/mpc-tss/protocols/cggmp/keygen"
+	"github.com/felicityin/mpc-tss/tss"
+)
+
+func newRound1(
+	isThreshold bool,
+	params *tss.Parameters,
+	key *keygen.LocalPartySaveData,
+	data *cggmpPresign.LocalPartySaveData,
+	temp *localTempData,
+	out chan<- tss.Message,
+	end chan<- *cggmpPresign.LocalPartySaveData,
+) tss.Round {
+	return &round1{
+		&base{params, isThreshold, key, data, temp, out, end, make([]bool, len(params.Parties().IDs())), false, 1},
+	}
+}
+
+func (round *round1) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+
+	round.number = 1
+	round.started = true
+	round.resetOK()
+
+	Pi := round.PartyID()
+	i := Pi.Index
+	common.Logger.Infof("[dkls-presign] party: %d, round_1 start", i)
+
+	ids := round.Parties().IDs().Keys()
+	round.save.Ks = ids
+	round.save.ShareID = ids[i]
+
+	var err error
+	round.temp.ssid, err = round.getSSID()
+	if err != nil {
+		return round.WrapError(err)
+	}
+
+	// r_i, phi_i in F_q
+	round.temp.r = common.GetRandomPositiveInt(round.Rand(), round.EC().Params().N)
+	round.temp.phi = common.GetRandomPositiveInt(round.Rand(), round.EC().Params().N)
+	round.temp.R = crypto.ScalarBaseMult(round.EC(), round.temp.r)
+	round.temp.Phi = crypto.ScalarBaseMult(round.EC(), round.temp.phi)
+	round.temp.bigPhis[i] = round.temp.Phi
+
+	// Ri is committed to until the OTs are done, so that no party chooses its nonce share after seeing the others,
+	// and Phi_i along with it, so that the share of u is checked against the mask of the OTs
+	round.temp.cmt = cmt.NewHashCommitment(
+		round.Rand(), new(big.Int).SetBytes(round.temp.ssid), round.save.ShareID,
+		round.temp.R.X(), round.temp.R.Y(), round.temp.Phi.X(), round.temp.Phi.Y(),
+	)
+
+	// broadcast the commitment to Ri and Phi_i
+	common.Logger.Debugf("P[%d]: round_1 broadcast", i)
+	r1msg1 := NewPresignRound1Message1(round.PartyID(), round.temp.cmt.C)
+	round.temp.presignRound1Message1s[i] = r1msg1
+	round.out <- r1msg1
+
+	// p2p send A of the multiplications of ri and xi with phi_j to Pj
+	for j, Pj := range round.Parties().IDs() {
+		if j == i {
+			round.ok[j] = true
+			continue
+		}
+		alice, A := ot.NewAlice(round.EC(), round.pairSSID(i, j), []*big.Int{round.temp.r, round.key.PrivXi}, round.Rand())
+		round.temp.alices[j] = alice
+
+		r1msg2, err := NewPresignRound1Message2(Pj, round.PartyID(), A)
+		if err != nil {
+			return round.WrapError(err)
+		}
+		round.out <- r1msg2
+	}
+
+	return nil
+}
+
+func (round *round1) Update() (bool, *tss.Error) {
+	ret := true
+	for j, msg := range round.temp.presignRound1Message1s {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			ret = false
+			continue
+		}
+		msg2 := round.temp.presignRound1Message2s[j]
+		if msg2 == nil || !round.CanAccept(msg2) {
+			ret = false
+			continue
+		}
+		round.ok[j] = true
+	}
+	return ret, nil
+}
+
+func (round *round1) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*PresignRound1Message1); ok {
+		return msg.IsBroadcast()
+	}
+	if _, ok := msg.Content().(*PresignRound1Message2); ok {
+		return !msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round1) NextRound() tss.Round {
+	round.started = false
+	return &round2{round}
+}
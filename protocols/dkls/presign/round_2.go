@@ -0,0 +1,75 @@
+package presign
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/felicityin/mpc-tss/common"
+	"github.com/felicityin/mpc-tss/crypto/ot"
+	"github.com/felicityin/mpc-tss/tss"
+)
+
+func (round *round2) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+
+	round.number = 2
+	round.started = true
+	round.resetOK()
+
+	i := round.PartyID().Index
+	common.Logger.Infof("[dkls-presign] party: %d, round_2 start", i)
+
+	// p2p send the B_k of the multiplications of rj and xj with phi_i to Pj
+	for j, Pj := range round.Parties().IDs() {
+		if j == i {
+			round.ok[j] = true
+			continue
+		}
+		A, err := round.temp.presignRound1Message2s[j].Content().(*PresignRound1Message2).UnmarshalA()
+		if err != nil {
+			return round.WrapError(fmt.Errorf("[j: %d] unmarshal A err: %s", j, err.Error()), Pj)
+		}
+		bob, Bs, err := ot.NewBob(round.EC(), round.pairSSID(j, i), round.temp.phi, A, round.Rand())
+		if err != nil {
+			return round.WrapError(fmt.Errorf("[j: %d] %s", j, err.Error()), Pj)
+		}
+		round.temp.bobs[j] = bob
+
+		r2msg, err := NewPresignRound2Message(Pj, round.PartyID(), Bs)
+		if err != nil {
+			return round.WrapError(err)
+		}
+		round.out <- r2msg
+	}
+
+	return nil
+}
+
+func (round *round2) Update() (bool, *tss.Error) {
+	ret := true
+	for j, msg := range round.temp.presignRound2Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			ret = false
+			continue
+		}
+		round.ok[j] = true
+	}
+	return ret, nil
+}
+
+func (round *round2) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*PresignRound2Message); ok {
+		return !msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round2) NextRound() tss.Round {
+	round.started = false
+	return &round3{round}
+}
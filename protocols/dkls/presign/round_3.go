@@ -0,0 +1,98 @@
+package presign
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/felicityin/mpc-tss/common"
+	"github.com/felicityin/mpc-tss/crypto"
+	"github.com/felicityin/mpc-tss/tss"
+)
+
+func (round *round3) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+
+	round.number = 3
+	round.started = true
+	round.resetOK()
+
+	i := round.PartyID().Index
+	common.Logger.Infof("[dkls-presign] party: %d, round_3 start", i)
+
+	// p2p send the corrections of the OTs to Pj, with cv * G that Pj checks its share against
+	GammasU := make([]*crypto.ECPoint, round.PartyCount())
+	for j, Pj := range round.Parties().IDs() {
+		if j == i {
+			round.ok[j] = true
+			continue
+		}
+		Bs, err := round.temp.presignRound2Messages[j].Content().(*PresignRound2Message).UnmarshalB(round.EC())
+		if err != nil {
+			return round.WrapError(fmt.Errorf("[j: %d] unmarshal B err: %s", j, err.Error()), Pj)
+		}
+		corrections, shares, err := round.temp.alices[j].Transfer(Bs)
+		if err != nil {
+			return round.WrapError(fmt.Errorf("[j: %d] %s", j, err.Error()), Pj)
+		}
+		round.temp.alices[j] = nil
+		round.temp.cu[j], round.temp.cv[j] = shares[0], shares[1]
+
+		GammasU[j] = crypto.ScalarBaseMult(round.EC(), round.temp.cu[j])
+		GammaV := crypto.ScalarBaseMult(round.EC(), round.temp.cv[j])
+		r3msg2, err := NewPresignRound3Message2(Pj, round.PartyID(), corrections, GammaV)
+		if err != nil {
+			return round.WrapError(err)
+		}
+		round.out <- r3msg2
+	}
+	round.temp.gammasU[i] = GammasU
+
+	// broadcast the de-commitment to Ri and Phi_i, with the cu * G of every Pj, which Pj checks its share against
+	// and everyone checks the shares of u against
+	common.Logger.Debugf("P[%d]: round_3 broadcast", i)
+	r3msg1, err := NewPresignRound3Message1(round.PartyID(), round.temp.cmt.D, GammasU)
+	if err != nil {
+		return round.WrapError(err)
+	}
+	round.temp.presignRound3Message1s[i] = r3msg1
+	round.out <- r3msg1
+
+	return nil
+}
+
+func (round *round3) Update() (bool, *tss.Error) {
+	ret := true
+	for j, msg := range round.temp.presignRound3Message1s {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			ret = false
+			continue
+		}
+		msg2 := round.temp.presignRound3Message2s[j]
+		if msg2 == nil || !round.CanAccept(msg2) {
+			ret = false
+			continue
+		}
+		round.ok[j] = true
+	}
+	return ret, nil
+}
+
+func (round *round3) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*PresignRound3Message1); ok {
+		return msg.IsBroadcast()
+	}
+	if _, ok := msg.Content().(*PresignRound3Message2); ok {
+		return !msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round3) NextRound() tss.Round {
+	round.started = false
+	return &round4{round}
+}
@@ -0,0 +1,156 @@
+package presign
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/felicityin/mpc-tss/common"
+	"github.com/felicityin/mpc-tss/crypto"
+	"github.com/felicityin/mpc-tss/crypto/dleq"
+	"github.com/felicityin/mpc-tss/tss"
+)
+
+func (round *round4) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+
+	round.number = 4
+	round.started = true
+	round.resetOK()
+
+	i := round.PartyID().Index
+	common.Logger.Infof("[dkls-presign] party: %d, round_4 start", i)
+
+	modN := common.ModInt(round.EC().Params().N)
+	phi := round.temp.phi
+
+	// ui = ri * phi_i + sum(cu_j + du_j), vi = xi * phi_i + sum(cv_j + dv_j)
+	u := modN.Mul(round.temp.r, phi)
+	v := modN.Mul(round.key.PrivXi, phi)
+	sumR := round.temp.R
+
+	// check the shares of every party, so that all the cheaters are blamed at once and can be excluded from the next attempt
+	var errs []*tss.Error
+	for j, Pj := range round.Parties().IDs() {
+		if j == i {
+			continue
+		}
+		Rj, du, dv, err := round.receive(j)
+		if err != nil {
+			common.Logger.Errorf("[j: %d] %s", j, err.Error())
+			errs = append(errs, round.WrapError(fmt.Errorf("[j: %d] %s", j, err.Error()), Pj))
+			continue
+		}
+		u = modN.Add(u, modN.Add(round.temp.cu[j], du))
+		v = modN.Add(v, modN.Add(round.temp.cv[j], dv))
+		if sumR, err = sumR.Add(Rj); err != nil {
+			return round.WrapError(fmt.Errorf("sum of the nonce points: %s", err.Error()), Pj)
+		}
+	}
+	if err := tss.JoinErrors(errs...); err != nil {
+		return err
+	}
+	round.temp.sumR = sumR
+	round.temp.v = v
+
+	// Security: the nonce share and the shares of the products are no longer needed
+	common.ZeroBigInts(append([]*big.Int{round.temp.r, round.key.PrivXi}, append(round.temp.cu, round.temp.cv...)...)...)
+
+	// Psi_i = phi_i * R, with the proof that it has the discrete logarithm of Phi_i
+	Psi, proof, err := dleq.NewShare(round.temp.ssid, phi, round.temp.Phi, sumR, round.Rand())
+	if err != nil {
+		return round.WrapError(fmt.Errorf("create the DLEQ proof: %s", err.Error()))
+	}
+
+	// broadcast ui
+	common.Logger.Debugf("P[%d]: round_4 broadcast", i)
+	r4msg, err := NewPresignRound4Message(round.PartyID(), u, Psi, proof)
+	if err != nil {
+		return round.WrapError(err)
+	}
+	round.temp.presignRound4Messages[i] = r4msg
+	round.out <- r4msg
+
+	return nil
+}
+
+// receive opens the nonce point Rj and the mask point Phi_j of Pj, and returns the shares of Bob of rj * phi_i and xj * phi_i
+// once they are checked against Rj, Xj and the shares of Pj in the exponent
+func (round *round4) receive(j int) (*crypto.ECPoint, *big.Int, *big.Int, error) {
+	ec := round.EC()
+	r1msg1 := round.temp.presignRound1Message1s[j].Content().(*PresignRound1Message1)
+	r3msg1 := round.temp.presignRound3Message1s[j].Content().(*PresignRound3Message1)
+	r3msg2 := round.temp.presignRound3Message2s[j].Content().(*PresignRound3Message2)
+
+	Rj, Phij, err := openCommitment(ec, round.temp.ssid, round.save.Ks[j], r1msg1, r3msg1)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	round.temp.bigPhis[j] = Phij
+	if round.temp.gammasU[j], err = r3msg1.UnmarshalGammasU(ec, round.PartyCount(), j); err != nil {
+		return nil, nil, nil, err
+	}
+
+	corrections, err := r3msg2.UnmarshalCorrections(2)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	shares, err := round.temp.bobs[j].Receive(corrections)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	round.temp.bobs[j] = nil
+	GammaU := round.temp.gammasU[j][round.PartyID().Index]
+	GammaV, err := r3msg2.UnmarshalGammaV()
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("unmarshal the shares of Alice err: %s", err.Error())
+	}
+
+	// du * G + cu * G = phi_i * Rj, dv * G + cv * G = phi_i * Xj
+	for k, check := range []struct {
+		share  *big.Int
+		Gamma  *crypto.ECPoint
+		target *crypto.ECPoint
+	}{
+		{shares[0], GammaU, Rj},
+		{shares[1], GammaV, round.key.PubXj[j]},
+	} {
+		if !tss.SameCurve(check.Gamma.Curve(), ec) {
+			return nil, nil, nil, fmt.Errorf("the share %d of Alice is not on the curve", k)
+		}
+		lhs, err := crypto.ScalarBaseMult(ec, check.share).Add(check.Gamma)
+		if err != nil || !lhs.Equals(check.target.ScalarMult(round.temp.phi)) {
+			return nil, nil, nil, fmt.Errorf("the share %d of Alice does not match the inputs she committed to", k)
+		}
+	}
+	return Rj, shares[0], shares[1], nil
+}
+
+func (round *round4) Update() (bool, *tss.Error) {
+	ret := true
+	for j, msg := range round.temp.presignRound4Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			ret = false
+			continue
+		}
+		round.ok[j] = true
+	}
+	return ret, nil
+}
+
+func (round *round4) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*PresignRound4Message); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round4) NextRound() tss.Round {
+	round.started = false
+	return &finalization{round}
+}
@@ -0,0 +1,73 @@
+package presign
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/felicityin/mpc-tss/common"
+	"github.com/felicityin/mpc-tss/tss"
+)
+
+func (round *finalization) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 5
+	round.started = true
+	round.resetOK()
+
+	i := round.PartyID().Index
+	common.Logger.Infof("[dkls-presign] party: %d, round_final start", i)
+
+	// u = r * phi, once the share of every party is checked, so that all the cheaters are blamed at once
+	modN := common.ModInt(round.EC().Params().N)
+	u := round.temp.presignRound4Messages[i].Content().(*PresignRound4Message).UnmarshalU()
+	var errs []*tss.Error
+	for j := range round.Parties().IDs() {
+		round.ok[j] = true
+		if j == i {
+			continue
+		}
+		r4msg := round.temp.presignRound4Messages[j]
+		if err := CheckU(round.EC(), round.temp.ssid, j, round.temp.sumR, round.temp.bigPhis[j], round.temp.gammasU, r4msg.Content().(*PresignRound4Message)); err != nil {
+			common.Logger.Errorf("[j: %d] %s", j, err.Error())
+			msgs := append([]tss.ParsedMessage{r4msg}, round.temp.presignRound1Message1s...)
+			msgs = append(msgs, round.temp.presignRound3Message1s...)
+			errs = append(errs, round.WrapEvidence(fmt.Errorf("[j: %d] %s", j, err.Error()), EvidenceU, round.uInputs(j), msgs...))
+			continue
+		}
+		u = modN.Add(u, r4msg.Content().(*PresignRound4Message).UnmarshalU())
+	}
+	if err := tss.JoinErrors(errs...); err != nil {
+		return err
+	}
+	if u.Sign() == 0 {
+		return round.WrapError(errors.New("u is zero"))
+	}
+
+	// Ki = phi_i / u and Chi_i = vi / u, which sum to K = 1 / r and Chi = x / r
+	uInv := modN.ModInverse(u)
+	round.save.K = modN.Mul(round.temp.phi, uInv)
+	round.save.Chi = modN.Mul(round.temp.v, uInv)
+	round.save.R = round.temp.sumR
+
+	// Security: the mask and the share of x * phi are no longer needed
+	common.ZeroBigInts(round.temp.phi, round.temp.v)
+
+	round.end <- round.save
+	return nil
+}
+
+func (round *finalization) CanAccept(msg tss.ParsedMessage) bool {
+	// not expecting any incoming messages in this round
+	return false
+}
+
+func (round *finalization) Update() (bool, *tss.Error) {
+	// not expecting any incoming messages in this round
+	return false, nil
+}
+
+func (round *finalization) NextRound() tss.Round {
+	return nil // finished!
+}
@@ -0,0 +1,185 @@
+// Package presign implements the preprocessing of the two-party ECDSA, for the 2-of-2 keys of a device and a server.
+// It is the two-party case of dkls presign, whose multiplications run over the oblivious transfers of crypto/ot:
+// there is no Paillier key, no auxiliary data and no zero-knowledge range proof.
+//
+// Each party samples a nonce share r_i and a mask phi_i, and is Alice in the multiplication of r_i and x_i with phi_j,
+// and Bob in that of r_j and x_j with phi_i. The three messages of both multiplications are sent in parallel,
+// one message to the other party per round, together with a commitment to R_i in round 1 and its opening in round 3.
+// Each party checks the shares of the other in the exponent against R_j and X_j, and outputs phi_i, u_i and v_i,
+// its shares of u = r * phi and v = x * phi, for the nonce point R = r * G.
+//
+// Unlike dkls presign, u is not opened here: the two-party signing opens it with the shares of s in its single message.
+// The mask points Phi_k = phi_k * G, committed to with R_k, and the points c_u * G of the shares of Alice are saved
+// with the presignature, so that the signing checks the share of u of the other party in the exponent.
+package presign
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/felicityin/mpc-tss/common"
+	"github.com/felicityin/mpc-tss/crypto"
+	cmt "github.com/felicityin/mpc-tss/crypto/commitments"
+	"github.com/felicityin/mpc-tss/crypto/ot"
+	"github.com/felicityin/mpc-tss/protocols/cggmp/keygen"
+	"github.com/felicityin/mpc-tss/protocols/utils"
+	"github.com/felicityin/mpc-tss/tss"
+)
+
+// Implements Party
+// Implements Stringer
+var _ tss.Party = (*LocalParty)(nil)
+var _ fmt.Stringer = (*LocalParty)(nil)
+
+type (
+	LocalParty struct {
+		*tss.BaseParty
+		params *tss.Parameters
+
+		keys keygen.LocalPartySaveData
+		temp localTempData
+		data LocalPartySaveData
+
+		// outbound messaging
+		out chan<- tss.Message
+		end chan<- *LocalPartySaveData
+	}
+
+	localMessageStore struct {
+		presignRound1Messages,
+		presignRound2Messages,
+		presignRound3Messages []tss.ParsedMessage
+	}
+
+	localTempData struct {
+		localMessageStore
+
+		isThreshold bool
+
+		// round 1
+		r   *big.Int
+		R   *crypto.ECPoint
+		Phi *crypto.ECPoint // phi_i * G
+		cmt *cmt.HashCommitDecommit
+		// the multiplication of r_i and x_i with phi_j
+		alice *ot.Alice
+
+		// round 2
+		// the multiplication of r_j and x_j with phi_i
+		bob *ot.Bob
+
+		// round 3
+		cu, cv *big.Int // the shares of Alice of r_i * phi_j and x_i * phi_j
+
+		ssid      []byte
+		ssidNonce *big.Int
+	}
+)
+
+// NewLocalParty returns a party that outputs its share of a presignature on end, for the two-party signing.
+// The parameters must have exactly two parties.
+func NewLocalParty(
+	isThreshold bool,
+	params *tss.Parameters,
+	key keygen.LocalPartySaveData,
+	out chan<- tss.Message,
+	end chan<- *LocalPartySaveData,
+) (tss.Party, error) {
+	partyCount := len(params.Parties().IDs())
+	if partyCount != 2 {
+		return nil, fmt.Errorf("the two-party presign needs exactly 2 parties, got %d", partyCount)
+	}
+	key, err := keygen.BuildLocalSaveDataSubset(key, params.Parties().IDs())
+	if err != nil {
+		return nil, err
+	}
+	err = utils.UpdateKeyForSigning(&key, "", isThreshold, params.Threshold())
+	if err != nil {
+		return nil, err
+	}
+
+	data := NewLocalPartySaveData(partyCount)
+	p := &LocalParty{
+		BaseParty: new(tss.BaseParty),
+		params:    params,
+		keys:      key,
+		temp:      localTempData{},
+		data:      data,
+		out:       out,
+		end:       end,
+	}
+	// msgs init
+	p.temp.presignRound1Messages = make([]tss.ParsedMessage, partyCount)
+	p.temp.presignRound2Messages = make([]tss.ParsedMessage, partyCount)
+	p.temp.presignRound3Messages = make([]tss.ParsedMessage, partyCount)
+
+	p.temp.isThreshold = isThreshold
+	p.temp.ssidNonce = new(big.Int)
+	return p, nil
+}
+
+func (p *LocalParty) FirstRound() tss.Round {
+	return newRound1(p.temp.isThreshold, p.params, &p.keys, &p.data, &p.temp, p.out, p.end)
+}
+
+func (p *LocalParty) Start() *tss.Error {
+	return tss.BaseStart(p, TaskName)
+}
+
+func (p *LocalParty) Update(msg tss.ParsedMessage) (ok bool, err *tss.Error) {
+	return tss.BaseUpdate(p, msg, TaskName)
+}
+
+func (p *LocalParty) UpdateFromBytes(wireBytes []byte, from *tss.PartyID, isBroadcast bool) (bool, *tss.Error) {
+	msg, err := tss.ParseWireMessage(wireBytes, from, isBroadcast)
+	if err != nil {
+		return false, p.WrapError(err)
+	}
+	return p.Update(msg)
+}
+
+func (p *LocalParty) ValidateMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	if msg.GetFrom() == nil || !msg.GetFrom().ValidateBasic() {
+		return false, p.WrapError(fmt.Errorf("received msg with an invalid sender: %s", msg))
+	}
+	// check that the message's "from index" will fit into the array
+	if maxFromIdx := len(p.params.Parties().IDs()) - 1; maxFromIdx < msg.GetFrom().Index {
+		return false, p.WrapError(fmt.Errorf("received msg with a sender index too great (%d <= %d)",
+			maxFromIdx, msg.GetFrom().Index), msg.GetFrom())
+	}
+	return p.BaseParty.ValidateMessage(msg)
+}
+
+func (p *LocalParty) StoreMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	// ValidateBasic is cheap; double-check the message here in case the public StoreMessage was called externally
+	if ok, err := p.ValidateMessage(msg); !ok || err != nil {
+		return ok, err
+	}
+	fromPIdx := msg.GetFrom().Index
+
+	// switch/case is necessary to store any messages beyond current round
+	// this does not handle message replays. we expect the caller to apply replay and spoofing protection.
+	switch msg.Content().(type) {
+	case *PresignRound1Message:
+		p.temp.presignRound1Messages[fromPIdx] = msg
+
+	case *PresignRound2Message:
+		p.temp.presignRound2Messages[fromPIdx] = msg
+
+	case *PresignRound3Message:
+		p.temp.presignRound3Messages[fromPIdx] = msg
+
+	default: // unrecognised message, just ignore!
+		common.Logger.Warningf("unrecognised message ignored: %v", msg)
+		return false, nil
+	}
+	return true, nil
+}
+
+func (p *LocalParty) PartyID() *tss.PartyID {
+	return p.params.PartyID()
+}
+
+func (p *LocalParty) String() string {
+	return fmt.Sprintf("id: %s, %s", p.PartyID(), p.BaseParty.String())
+}
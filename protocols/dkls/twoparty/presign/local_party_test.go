@@ -0,0 +1,91 @@
+package presign
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ipfs/go-log"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/felicityin/mpc-tss/common"
+	"github.com/felicityin/mpc-tss/crypto"
+	"github.com/felicityin/mpc-tss/protocols/cggmp/keygen"
+	tKeygen "github.com/felicityin/mpc-tss/protocols/cggmp/keygen/threshold"
+	"github.com/felicityin/mpc-tss/tss"
+)
+
+func setUp(level string) {
+	if err := log.SetLogLevel("tss-lib", level); err != nil {
+		panic(err)
+	}
+}
+
+func TestE2EConcurrent(t *testing.T) {
+	setUp("info")
+
+	for _, kind := range []int{keygen.Ecdsa, keygen.EcdsaP256} {
+		keys, pIDs, err := GenerateTestKeys(kind)
+		if !assert.NoError(t, err, "should generate the 2-of-2 keys") {
+			continue
+		}
+		pres, err := GenerateTestPresignatures(kind, keys, pIDs)
+		if !assert.NoError(t, err) {
+			continue
+		}
+		ec := tKeygen.TestCurve(kind)
+
+		// v / u = x / r, so that v * R = u * X
+		modN := common.ModInt(ec.Params().N)
+		u, v := modN.Add(pres[0].U, pres[1].U), modN.Add(pres[0].V, pres[1].V)
+		assert.True(t, pres[0].R.Equals(pres[1].R), "the parties output the same nonce point")
+		assert.True(t, pres[0].R.ScalarMult(v).Equals(keys[0].Pubkey.ScalarMult(u)))
+
+		// both parties save the same points to check the shares of u against
+		for k := range pres {
+			assert.True(t, pres[0].Phis[k].Equals(pres[1].Phis[k]))
+			assert.True(t, pres[0].GammasU[k].Equals(pres[1].GammasU[k]))
+			assert.True(t, pres[k].Phis[k].Equals(crypto.ScalarBaseMult(ec, pres[k].Phi)))
+		}
+
+		id0, err := pres[0].ID()
+		assert.NoError(t, err)
+		id1, _ := pres[1].ID()
+		assert.Equal(t, id0, id1)
+	}
+}
+
+func TestCulpritOfBadShare(t *testing.T) {
+	setUp("info")
+
+	keys, pIDs, err := GenerateTestKeys(keygen.Ecdsa)
+	assert.NoError(t, err, "should generate the 2-of-2 keys")
+
+	// P0 sends P1 a share of r_0 * phi_1 that does not match R_0, P1 must blame it
+	_, tssErr := runTestPresign(keygen.Ecdsa, keys, pIDs, func(msg tss.ParsedMessage) tss.ParsedMessage {
+		r3msg, ok := msg.Content().(*PresignRound3Message)
+		if !ok {
+			return msg
+		}
+		GammaU, GammaV, _ := r3msg.UnmarshalGammas()
+		GammaU, _ = GammaU.Add(crypto.ScalarBaseMult(GammaU.Curve(), big.NewInt(1)))
+		corrections, _ := r3msg.UnmarshalCorrections(2)
+		tampered, _ := NewPresignRound3Message(msg.GetTo()[0], msg.GetFrom(), r3msg.UnmarshalDeCommitment(), corrections, GammaU, GammaV)
+		return tampered
+	})
+	if assert.NotNil(t, tssErr) {
+		assert.Equal(t, 1, tssErr.Victim().Index)
+		if assert.Len(t, tssErr.Culprits(), 1) {
+			assert.Equal(t, 0, tssErr.Culprits()[0].Index)
+		}
+	}
+}
+
+func TestNotTwoParties(t *testing.T) {
+	setUp("info")
+
+	keys, signPIDs, err := tKeygen.LoadKeygenTestFixturesRandomSet(keygen.Ecdsa, 3, 3)
+	assert.NoError(t, err, "should load keygen fixtures")
+	params := tss.NewParameters(tss.S256(), tss.NewPeerContext(signPIDs), signPIDs[0], len(signPIDs), 2)
+	_, err = NewLocalParty(true, params, keys[0], make(chan tss.Message, 1), make(chan *LocalPartySaveData, 1))
+	assert.Error(t, err)
+}
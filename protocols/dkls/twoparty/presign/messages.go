@@ -0,0 +1,159 @@
+package presign
+
+import (
+	"crypto/elliptic"
+	"fmt"
+	"math/big"
+
+	"github.com/felicityin/mpc-tss/common"
+	"github.com/felicityin/mpc-tss/crypto"
+	cmt "github.com/felicityin/mpc-tss/crypto/commitments"
+	"github.com/felicityin/mpc-tss/tss"
+)
+
+// These messages were generated from Protocol Buffers definitions into presign.pb.go
+// The following messages are registered on the Protocol Buffers "wire"
+
+var (
+	// Ensure that presign messages implement ValidateBasic
+	_ = []tss.MessageContent{
+		(*PresignRound1Message)(nil),
+		(*PresignRound2Message)(nil),
+		(*PresignRound3Message)(nil),
+	}
+)
+
+// ----- //
+
+func NewPresignRound1Message(
+	to, from *tss.PartyID,
+	commitment cmt.HashCommitment,
+	A *crypto.ECPoint,
+) (tss.ParsedMessage, error) {
+	bz, err := A.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	meta := tss.MessageRouting{
+		From:        from,
+		To:          []*tss.PartyID{to},
+		IsBroadcast: false,
+	}
+	content := &PresignRound1Message{
+		Commitment: commitment.Bytes(),
+		A:          bz,
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg), nil
+}
+
+func (m *PresignRound1Message) ValidateBasic() bool {
+	return m != nil && common.NonEmptyBytes(m.GetCommitment()) && common.NonEmptyBytes(m.GetA())
+}
+
+func (m *PresignRound1Message) UnmarshalCommitment() cmt.HashCommitment {
+	return new(big.Int).SetBytes(m.GetCommitment())
+}
+
+func (m *PresignRound1Message) UnmarshalA() (*crypto.ECPoint, error) {
+	return crypto.UnmarshalJSONPoint(m.GetA())
+}
+
+// ----- //
+
+func NewPresignRound2Message(to, from *tss.PartyID, Bs []*crypto.ECPoint) (tss.ParsedMessage, error) {
+	flattened, err := crypto.FlattenECPoints(Bs)
+	if err != nil {
+		return nil, err
+	}
+	meta := tss.MessageRouting{
+		From:        from,
+		To:          []*tss.PartyID{to},
+		IsBroadcast: false,
+	}
+	content := &PresignRound2Message{
+		B: common.BigIntsToBytes(flattened),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg), nil
+}
+
+func (m *PresignRound2Message) ValidateBasic() bool {
+	return m != nil && common.NonEmptyMultiBytes(m.GetB())
+}
+
+func (m *PresignRound2Message) UnmarshalB(ec elliptic.Curve) ([]*crypto.ECPoint, error) {
+	return crypto.UnFlattenECPoints(ec, common.MultiBytesToBigInts(m.GetB()))
+}
+
+// ----- //
+
+func NewPresignRound3Message(
+	to, from *tss.PartyID,
+	deCommitment cmt.HashDeCommitment,
+	corrections [][]*big.Int,
+	GammaU, GammaV *crypto.ECPoint,
+) (tss.ParsedMessage, error) {
+	var flattened []*big.Int
+	for _, tau := range corrections {
+		flattened = append(flattened, tau...)
+	}
+	gammaU, err := GammaU.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	gammaV, err := GammaV.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	meta := tss.MessageRouting{
+		From:        from,
+		To:          []*tss.PartyID{to},
+		IsBroadcast: false,
+	}
+	content := &PresignRound3Message{
+		DeCommitment: common.BigIntsToBytes(deCommitment),
+		Corrections:  common.BigIntsToBytes(flattened),
+		GammaU:       gammaU,
+		GammaV:       gammaV,
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg), nil
+}
+
+func (m *PresignRound3Message) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyMultiBytes(m.GetDeCommitment()) &&
+		common.NonEmptyMultiBytes(m.GetCorrections()) &&
+		common.NonEmptyBytes(m.GetGammaU()) &&
+		common.NonEmptyBytes(m.GetGammaV())
+}
+
+func (m *PresignRound3Message) UnmarshalDeCommitment() cmt.HashDeCommitment {
+	return cmt.NewHashDeCommitmentFromBytes(m.GetDeCommitment())
+}
+
+// UnmarshalCorrections returns the corrections of the OTs, of width values each
+func (m *PresignRound3Message) UnmarshalCorrections(width int) ([][]*big.Int, error) {
+	flattened := common.MultiBytesToBigInts(m.GetCorrections())
+	if len(flattened)%width != 0 {
+		return nil, fmt.Errorf("the %d corrections are not a multiple of %d", len(flattened), width)
+	}
+	corrections := make([][]*big.Int, len(flattened)/width)
+	for k := range corrections {
+		corrections[k] = flattened[k*width : (k+1)*width]
+	}
+	return corrections, nil
+}
+
+func (m *PresignRound3Message) UnmarshalGammas() (*crypto.ECPoint, *crypto.ECPoint, error) {
+	GammaU, err := crypto.UnmarshalJSONPoint(m.GetGammaU())
+	if err != nil {
+		return nil, nil, err
+	}
+	GammaV, err := crypto.UnmarshalJSONPoint(m.GetGammaV())
+	if err != nil {
+		return nil, nil, err
+	}
+	return GammaU, GammaV, nil
+}
@@ -0,0 +1,103 @@
+package presign
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/felicityin/mpc-tss/common"
+	"github.com/felicityin/mpc-tss/crypto"
+	cmt "github.com/felicityin/mpc-tss/crypto/commitments"
+	"github.com/felicityin/mpc-tss/crypto/ot"
+	"github.com/felicityin/mpc-tss/protocols/cggmp/keygen"
+	"github.com/felicityin/mpc-tss/tss"
+)
+
+func newRound1(
+	isThreshold bool,
+	params *tss.Parameters,
+	key *keygen.LocalPartySaveData,
+	data *LocalPartySaveData,
+	temp *localTempData,
+	out chan<- tss.Message,
+	end chan<- *LocalPartySaveData,
+) tss.Round {
+	return &round1{
+		&base{params, isThreshold, key, data, temp, out, end, make([]bool, len(params.Parties().IDs())), false, 1},
+	}
+}
+
+func (round *round1) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+
+	round.number = 1
+	round.started = true
+	round.resetOK()
+
+	i, j := round.PartyID().Index, round.peer()
+	common.Logger.Infof("[two-party-presign] party: %d, round_1 start", i)
+
+	ids := round.Parties().IDs().Keys()
+	round.save.Ks = ids
+	round.save.ShareID = ids[i]
+
+	var err error
+	round.temp.ssid, err = round.getSSID()
+	if err != nil {
+		return round.WrapError(err)
+	}
+
+	// r_i, phi_i in F_q
+	round.temp.r = common.GetRandomPositiveInt(round.Rand(), round.EC().Params().N)
+	round.save.Phi = common.GetRandomPositiveInt(round.Rand(), round.EC().Params().N)
+	round.temp.R = crypto.ScalarBaseMult(round.EC(), round.temp.r)
+	round.temp.Phi = crypto.ScalarBaseMult(round.EC(), round.save.Phi)
+
+	// Ri and Phi_i are committed to until the OTs are done, so that no party chooses its nonce share after seeing the other
+	round.temp.cmt = cmt.NewHashCommitment(
+		round.Rand(), new(big.Int).SetBytes(round.temp.ssid), round.save.ShareID,
+		round.temp.R.X(), round.temp.R.Y(), round.temp.Phi.X(), round.temp.Phi.Y(),
+	)
+
+	// p2p send the commitment to Ri, and A of the multiplications of ri and xi with phi_j
+	alice, A := ot.NewAlice(round.EC(), round.pairSSID(i, j), []*big.Int{round.temp.r, round.key.PrivXi}, round.Rand())
+	round.temp.alice = alice
+
+	common.Logger.Debugf("P[%d]: round_1 p2p send", i)
+	r1msg, err := NewPresignRound1Message(round.Parties().IDs()[j], round.PartyID(), round.temp.cmt.C, A)
+	if err != nil {
+		return round.WrapError(err)
+	}
+	round.ok[i] = true
+	round.out <- r1msg
+
+	return nil
+}
+
+func (round *round1) Update() (bool, *tss.Error) {
+	ret := true
+	for j, msg := range round.temp.presignRound1Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			ret = false
+			continue
+		}
+		round.ok[j] = true
+	}
+	return ret, nil
+}
+
+func (round *round1) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*PresignRound1Message); ok {
+		return !msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round1) NextRound() tss.Round {
+	round.started = false
+	return &round2{round}
+}
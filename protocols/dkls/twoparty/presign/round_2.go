@@ -0,0 +1,72 @@
+package presign
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/felicityin/mpc-tss/common"
+	"github.com/felicityin/mpc-tss/crypto/ot"
+	"github.com/felicityin/mpc-tss/tss"
+)
+
+func (round *round2) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+
+	round.number = 2
+	round.started = true
+	round.resetOK()
+
+	i, j := round.PartyID().Index, round.peer()
+	Pj := round.Parties().IDs()[j]
+	common.Logger.Infof("[two-party-presign] party: %d, round_2 start", i)
+
+	// p2p send the B_k of the multiplications of rj and xj with phi_i
+	A, err := round.temp.presignRound1Messages[j].Content().(*PresignRound1Message).UnmarshalA()
+	if err != nil {
+		return round.WrapError(fmt.Errorf("unmarshal A err: %s", err.Error()), Pj)
+	}
+	bob, Bs, err := ot.NewBob(round.EC(), round.pairSSID(j, i), round.save.Phi, A, round.Rand())
+	if err != nil {
+		return round.WrapError(err, Pj)
+	}
+	round.temp.bob = bob
+
+	common.Logger.Debugf("P[%d]: round_2 p2p send", i)
+	r2msg, err := NewPresignRound2Message(Pj, round.PartyID(), Bs)
+	if err != nil {
+		return round.WrapError(err)
+	}
+	round.ok[i] = true
+	round.out <- r2msg
+
+	return nil
+}
+
+func (round *round2) Update() (bool, *tss.Error) {
+	ret := true
+	for j, msg := range round.temp.presignRound2Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			ret = false
+			continue
+		}
+		round.ok[j] = true
+	}
+	return ret, nil
+}
+
+func (round *round2) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*PresignRound2Message); ok {
+		return !msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round2) NextRound() tss.Round {
+	round.started = false
+	return &round3{round}
+}
@@ -0,0 +1,76 @@
+package presign
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/felicityin/mpc-tss/common"
+	"github.com/felicityin/mpc-tss/crypto"
+	"github.com/felicityin/mpc-tss/tss"
+)
+
+func (round *round3) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+
+	round.number = 3
+	round.started = true
+	round.resetOK()
+
+	i, j := round.PartyID().Index, round.peer()
+	Pj := round.Parties().IDs()[j]
+	common.Logger.Infof("[two-party-presign] party: %d, round_3 start", i)
+
+	Bs, err := round.temp.presignRound2Messages[j].Content().(*PresignRound2Message).UnmarshalB(round.EC())
+	if err != nil {
+		return round.WrapError(fmt.Errorf("unmarshal B err: %s", err.Error()), Pj)
+	}
+	corrections, shares, err := round.temp.alice.Transfer(Bs)
+	if err != nil {
+		return round.WrapError(err, Pj)
+	}
+	round.temp.alice = nil
+	round.temp.cu, round.temp.cv = shares[0], shares[1]
+
+	// p2p send the de-commitment to Ri and the corrections of the OTs, with cu * G and cv * G that Pj checks its shares against
+	GammaU := crypto.ScalarBaseMult(round.EC(), round.temp.cu)
+	GammaV := crypto.ScalarBaseMult(round.EC(), round.temp.cv)
+
+	common.Logger.Debugf("P[%d]: round_3 p2p send", i)
+	r3msg, err := NewPresignRound3Message(Pj, round.PartyID(), round.temp.cmt.D, corrections, GammaU, GammaV)
+	if err != nil {
+		return round.WrapError(err)
+	}
+	round.ok[i] = true
+	round.out <- r3msg
+
+	return nil
+}
+
+func (round *round3) Update() (bool, *tss.Error) {
+	ret := true
+	for j, msg := range round.temp.presignRound3Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			ret = false
+			continue
+		}
+		round.ok[j] = true
+	}
+	return ret, nil
+}
+
+func (round *round3) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*PresignRound3Message); ok {
+		return !msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round3) NextRound() tss.Round {
+	round.started = false
+	return &finalization{round}
+}
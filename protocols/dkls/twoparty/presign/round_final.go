@@ -0,0 +1,125 @@
+package presign
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/felicityin/mpc-tss/common"
+	"github.com/felicityin/mpc-tss/crypto"
+	cmt "github.com/felicityin/mpc-tss/crypto/commitments"
+	"github.com/felicityin/mpc-tss/tss"
+)
+
+func (round *finalization) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 4
+	round.started = true
+	round.resetOK()
+
+	i, j := round.PartyID().Index, round.peer()
+	Pj := round.Parties().IDs()[j]
+	common.Logger.Infof("[two-party-presign] party: %d, round_final start", i)
+
+	for k := range round.ok {
+		round.ok[k] = true
+	}
+	Rj, Phij, GammaUj, du, dv, err := round.receive(j)
+	if err != nil {
+		common.Logger.Errorf("[j: %d] %s", j, err.Error())
+		return round.WrapError(err, Pj)
+	}
+
+	// ui = ri * phi_i + cu + du, vi = xi * phi_i + cv + dv
+	modN := common.ModInt(round.EC().Params().N)
+	phi := round.save.Phi
+	round.save.U = modN.Add(modN.Mul(round.temp.r, phi), modN.Add(round.temp.cu, du))
+	round.save.V = modN.Add(modN.Mul(round.key.PrivXi, phi), modN.Add(round.temp.cv, dv))
+	if round.save.R, err = round.temp.R.Add(Rj); err != nil {
+		return round.WrapError(fmt.Errorf("sum of the nonce points: %s", err.Error()), Pj)
+	}
+	round.save.Phis[i], round.save.Phis[j] = round.temp.Phi, Phij
+	round.save.GammasU[i], round.save.GammasU[j] = crypto.ScalarBaseMult(round.EC(), round.temp.cu), GammaUj
+
+	// Security: the nonce share, the key share and the shares of the products are no longer needed
+	common.ZeroBigInts(round.temp.r, round.key.PrivXi, round.temp.cu, round.temp.cv, du, dv)
+
+	round.end <- round.save
+	return nil
+}
+
+// receive opens the nonce point Rj and the mask point Phi_j of Pj, and returns them with the point c_u * G of the share of Pj
+// and the shares of Bob of rj * phi_i and xj * phi_i, once they are checked against Rj, Xj and the shares of Pj in the exponent
+func (round *finalization) receive(j int) (*crypto.ECPoint, *crypto.ECPoint, *crypto.ECPoint, *big.Int, *big.Int, error) {
+	ec := round.EC()
+	r3msg := round.temp.presignRound3Messages[j].Content().(*PresignRound3Message)
+
+	cmtDeCmt := cmt.HashCommitDecommit{
+		C: round.temp.presignRound1Messages[j].Content().(*PresignRound1Message).UnmarshalCommitment(),
+		D: r3msg.UnmarshalDeCommitment(),
+	}
+	ok, secrets := cmtDeCmt.DeCommit()
+	if !ok || len(secrets) != 6 {
+		return nil, nil, nil, nil, nil, errors.New("de-commitment of the nonce point failed")
+	}
+	if new(big.Int).SetBytes(round.temp.ssid).Cmp(secrets[0]) != 0 || round.save.Ks[j].Cmp(secrets[1]) != 0 {
+		return nil, nil, nil, nil, nil, errors.New("the nonce point is committed to for another session or party")
+	}
+	Rj, err := crypto.NewECPoint(ec, secrets[2], secrets[3])
+	if err != nil {
+		return nil, nil, nil, nil, nil, fmt.Errorf("the nonce point is not on the curve: %s", err.Error())
+	}
+	Phij, err := crypto.NewECPoint(ec, secrets[4], secrets[5])
+	if err != nil {
+		return nil, nil, nil, nil, nil, fmt.Errorf("the mask point is not on the curve: %s", err.Error())
+	}
+
+	corrections, err := r3msg.UnmarshalCorrections(2)
+	if err != nil {
+		return nil, nil, nil, nil, nil, err
+	}
+	shares, err := round.temp.bob.Receive(corrections)
+	if err != nil {
+		return nil, nil, nil, nil, nil, err
+	}
+	round.temp.bob = nil
+	GammaU, GammaV, err := r3msg.UnmarshalGammas()
+	if err != nil {
+		return nil, nil, nil, nil, nil, fmt.Errorf("unmarshal the shares of Alice err: %s", err.Error())
+	}
+
+	// du * G + cu * G = phi_i * Rj, dv * G + cv * G = phi_i * Xj
+	for k, check := range []struct {
+		share  *big.Int
+		Gamma  *crypto.ECPoint
+		target *crypto.ECPoint
+	}{
+		{shares[0], GammaU, Rj},
+		{shares[1], GammaV, round.key.PubXj[j]},
+	} {
+		if !tss.SameCurve(check.Gamma.Curve(), ec) {
+			return nil, nil, nil, nil, nil, fmt.Errorf("the share %d of Alice is not on the curve", k)
+		}
+		lhs, err := crypto.ScalarBaseMult(ec, check.share).Add(check.Gamma)
+		if err != nil || !lhs.Equals(check.target.ScalarMult(round.save.Phi)) {
+			return nil, nil, nil, nil, nil, fmt.Errorf("the share %d of Alice does not match the inputs she committed to", k)
+		}
+	}
+	return Rj, Phij, GammaU, shares[0], shares[1], nil
+}
+
+func (round *finalization) CanAccept(msg tss.ParsedMessage) bool {
+	// not expecting any incoming messages in this round
+	return false
+}
+
+func (round *finalization) Update() (bool, *tss.Error) {
+	// not expecting any incoming messages in this round
+	return false, nil
+}
+
+func (round *finalization) NextRound() tss.Round {
+	return nil // finished!
+}
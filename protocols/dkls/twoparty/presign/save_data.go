@@ -0,0 +1,104 @@
+package presign
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/felicityin/mpc-tss/common"
+	"github.com/felicityin/mpc-tss/crypto"
+	"github.com/felicityin/mpc-tss/tss"
+)
+
+type (
+	LocalSecrets struct {
+		Phi     *big.Int // the mask phi_i
+		U       *big.Int // the share u_i of u = r * phi
+		V       *big.Int // the share v_i of v = x * phi
+		R       *crypto.ECPoint
+		ShareID *big.Int
+	}
+
+	// Everything in LocalPartySaveData is saved locally to user's HD when done
+	LocalPartySaveData struct {
+		LocalSecrets
+
+		// original indexes (ki in signing preparation phase)
+		Ks []*big.Int
+
+		// the mask points phi_j * G, and the points c_u * G of the shares of Alice of r_j * phi_k of the parties,
+		// which the signing checks the shares of u against
+		Phis    []*crypto.ECPoint
+		GammasU []*crypto.ECPoint
+	}
+)
+
+func NewLocalPartySaveData(partyCount int) (saveData LocalPartySaveData) {
+	saveData.Ks = make([]*big.Int, partyCount)
+	saveData.Phis = make([]*crypto.ECPoint, partyCount)
+	saveData.GammasU = make([]*crypto.ECPoint, partyCount)
+	return
+}
+
+// recovers a party's original index in the set of parties during keygen
+func (save LocalPartySaveData) OriginalIndex() (int, error) {
+	index := -1
+	ki := save.ShareID
+	for j, kj := range save.Ks {
+		if kj.Cmp(ki) != 0 {
+			continue
+		}
+		index = j
+		break
+	}
+	if index < 0 {
+		return -1, errors.New("a party index could not be recovered from Ks")
+	}
+	return index, nil
+}
+
+// ID returns an identifier of the presignature derived from its public part (Ks and R),
+// which is the same for both parties holding a share of it.
+func (save LocalPartySaveData) ID() (string, error) {
+	for j, kj := range save.Ks {
+		if kj == nil {
+			return "", fmt.Errorf("presign save data Ks[%d] is missing", j)
+		}
+	}
+	if save.R == nil || !save.R.ValidateBasic() {
+		return "", errors.New("presign save data is missing R")
+	}
+	ids := append([]*big.Int{}, save.Ks...)
+	ids = append(ids, save.R.X(), save.R.Y())
+	return hex.EncodeToString(common.SHA512_256i_TAGGED([]byte("two-party-ecdsa-presign"), ids...).Bytes()), nil
+}
+
+// Destroy wipes the secrets of the presignature from memory. Signing calls it once the presignature is consumed.
+func (save *LocalPartySaveData) Destroy() {
+	common.ZeroBigInts(save.Phi, save.U, save.V)
+	save.Phi, save.U, save.V = nil, nil, nil
+}
+
+// BuildLocalSaveDataSubset re-creates the LocalPartySaveData in the order of the signing parties.
+func BuildLocalSaveDataSubset(sourceData LocalPartySaveData, sortedIDs tss.SortedPartyIDs) (newData LocalPartySaveData, err error) {
+	keysToIndices := make(map[string]int, len(sourceData.Ks))
+	for j, kj := range sourceData.Ks {
+		keysToIndices[hex.EncodeToString(kj.Bytes())] = j
+	}
+	newData = NewLocalPartySaveData(sortedIDs.Len())
+	newData.LocalSecrets = sourceData.LocalSecrets
+	for j, id := range sortedIDs {
+		savedIdx, ok := keysToIndices[hex.EncodeToString(id.Key)]
+		if !ok {
+			common.Logger.Errorf("unable to find a signer party in the presign local save data: %s", hex.EncodeToString(id.Key))
+			err = fmt.Errorf("unable to find a signer party in the presign local save data: %s", hex.EncodeToString(id.Key))
+			return
+		}
+		newData.Ks[j] = sourceData.Ks[savedIdx]
+		if savedIdx < len(sourceData.Phis) && savedIdx < len(sourceData.GammasU) {
+			newData.Phis[j], newData.GammasU[j] = sourceData.Phis[savedIdx], sourceData.GammasU[savedIdx]
+		}
+	}
+	return newData, nil
+}
@@ -0,0 +1,128 @@
+package presign
+
+import (
+	"github.com/felicityin/mpc-tss/protocols/cggmp/keygen"
+	tKeygen "github.com/felicityin/mpc-tss/protocols/cggmp/keygen/threshold"
+	"github.com/felicityin/mpc-tss/protocols/cggmp/test"
+	"github.com/felicityin/mpc-tss/tss"
+)
+
+// GenerateTestKeys runs the threshold keygen of a 2-of-2 key of the kind, keygen.Ecdsa or keygen.EcdsaP256,
+// as there are no 2-of-2 keygen fixtures. It returns the keys in the order of the parties.
+func GenerateTestKeys(kind int) ([]keygen.LocalPartySaveData, tss.SortedPartyIDs, error) {
+	pIDs := tss.GenerateTestPartyIDs(2)
+	p2pCtx := tss.NewPeerContext(pIDs)
+	parties := make([]tss.Party, 0, len(pIDs))
+
+	errCh := make(chan *tss.Error, len(pIDs))
+	outCh := make(chan tss.Message, len(pIDs)*len(pIDs))
+	endCh := make(chan *keygen.LocalPartySaveData, len(pIDs))
+
+	for i := 0; i < len(pIDs); i++ {
+		params := tss.NewParameters(tKeygen.TestCurve(kind), p2pCtx, pIDs[i], len(pIDs), 1)
+		parties = append(parties, tKeygen.NewLocalParty(params, outCh, endCh))
+	}
+	if err := startTestParties(parties); err != nil {
+		return nil, nil, err
+	}
+
+	keys := make([]keygen.LocalPartySaveData, len(pIDs))
+	for ended := 0; ended < len(pIDs); {
+		select {
+		case err := <-errCh:
+			return nil, nil, err
+		case msg := <-outCh:
+			routeTestMessage(parties, msg, nil, errCh)
+		case key := <-endCh:
+			index, err := key.OriginalIndex()
+			if err != nil {
+				return nil, nil, err
+			}
+			keys[index] = *key
+			ended++
+		}
+	}
+	return keys, pIDs, nil
+}
+
+// GenerateTestPresignatures runs the two-party presign of the keys of GenerateTestKeys,
+// and returns the presignatures in the order of the parties
+func GenerateTestPresignatures(kind int, keys []keygen.LocalPartySaveData, pIDs tss.SortedPartyIDs) ([]LocalPartySaveData, error) {
+	pres, err := runTestPresign(kind, keys, pIDs, nil)
+	if err != nil {
+		return nil, err
+	}
+	return pres, nil
+}
+
+// runTestPresign runs the presign, where tamper, if not nil, changes the messages to the last party
+func runTestPresign(
+	kind int,
+	keys []keygen.LocalPartySaveData,
+	pIDs tss.SortedPartyIDs,
+	tamper func(msg tss.ParsedMessage) tss.ParsedMessage,
+) ([]LocalPartySaveData, *tss.Error) {
+	p2pCtx := tss.NewPeerContext(pIDs)
+	parties := make([]tss.Party, 0, len(pIDs))
+
+	errCh := make(chan *tss.Error, len(pIDs))
+	outCh := make(chan tss.Message, len(pIDs))
+	endCh := make(chan *LocalPartySaveData, len(pIDs))
+
+	for i := 0; i < len(pIDs); i++ {
+		params := tss.NewParameters(tKeygen.TestCurve(kind), p2pCtx, pIDs[i], len(pIDs), 1)
+		party, err := NewLocalParty(true, params, keys[i], outCh, endCh)
+		if err != nil {
+			return nil, tss.NewError(err, TaskName, 0, pIDs[i])
+		}
+		parties = append(parties, party)
+	}
+	if err := startTestParties(parties); err != nil {
+		return nil, err
+	}
+
+	pres := make([]LocalPartySaveData, len(pIDs))
+	for ended := 0; ended < len(pIDs); {
+		select {
+		case err := <-errCh:
+			return nil, err
+		case msg := <-outCh:
+			routeTestMessage(parties, msg, tamper, errCh)
+		case pre := <-endCh:
+			index, err := pre.OriginalIndex()
+			if err != nil {
+				return nil, tss.NewError(err, TaskName, 0, nil)
+			}
+			pres[index] = *pre
+			ended++
+		}
+	}
+	return pres, nil
+}
+
+// startTestParties starts every party before the messages are routed, so that no party receives all the messages of round 1 before it starts
+func startTestParties(parties []tss.Party) *tss.Error {
+	for _, P := range parties {
+		if err := P.Start(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func routeTestMessage(parties []tss.Party, msg tss.Message, tamper func(msg tss.ParsedMessage) tss.ParsedMessage, errCh chan<- *tss.Error) {
+	victim := len(parties) - 1
+	for _, Pj := range parties {
+		if Pj.PartyID().Index == msg.GetFrom().Index {
+			continue
+		}
+		if dest := msg.GetTo(); dest != nil && dest[0].Index != Pj.PartyID().Index {
+			continue
+		}
+		if tamper != nil && Pj.PartyID().Index == victim {
+			go test.SharedPartyUpdater(Pj, tamper(msg.(tss.ParsedMessage)), errCh)
+			continue
+		}
+		go test.SharedPartyUpdater(Pj, msg, errCh)
+	}
+}
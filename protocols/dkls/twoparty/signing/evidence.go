@@ -0,0 +1,123 @@
+package signing
+
+import (
+	"crypto/elliptic"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/felicityin/mpc-tss/crypto"
+	"github.com/felicityin/mpc-tss/crypto/dleq"
+	"github.com/felicityin/mpc-tss/protocols/dkls/twoparty/presign"
+	"github.com/felicityin/mpc-tss/tss"
+)
+
+// EvidenceU is the kind of the evidence against a party whose share of u does not match the presignature
+const EvidenceU = "two-party-sign/u"
+
+func init() {
+	tss.RegisterEvidenceCheck(EvidenceU, checkUEvidence)
+}
+
+// UInputs are the public inputs of the check of the share u_j of Pj: the public part of the presignature,
+// which both parties saved, with the nonce point R, the mask points Phis[k] = phi_k * G and the points GammasU[k] = c_u * G
+// of the shares of Alice of the parties
+type UInputs struct {
+	Curve   tss.CurveName     `json:"curve"`
+	Index   int               `json:"index"`
+	Ks      []*big.Int        `json:"ks"`
+	R       *crypto.ECPoint   `json:"r"`
+	Phis    []*crypto.ECPoint `json:"phis"`
+	GammasU []*crypto.ECPoint `json:"gammas_u"`
+}
+
+// session returns the session of the DLEQ proofs, the ID of the presignature
+func (in *UInputs) session() ([]byte, error) {
+	pre := presign.LocalPartySaveData{LocalSecrets: presign.LocalSecrets{R: in.R}, Ks: in.Ks}
+	id, err := pre.ID()
+	if err != nil {
+		return nil, err
+	}
+	return []byte(id), nil
+}
+
+// CheckU checks that u_j * G + GammasU[k] = Psi_j + GammasU[j] for the other party Pk, with the DLEQ proof that Psi_j = phi_j * R
+// for the mask point Phis[j] of Pj
+func CheckU(ec elliptic.Curve, in *UInputs, r1msg *SignRound1Message) error {
+	session, err := in.session()
+	if err != nil {
+		return err
+	}
+	Psij, err := r1msg.UnmarshalPsi()
+	if err != nil {
+		return fmt.Errorf("err: Psi_j: %s", err.Error())
+	}
+	proof, err := r1msg.UnmarshalProof()
+	if err != nil {
+		return fmt.Errorf("err: DLEQ proof: %s", err.Error())
+	}
+	if err := dleq.VerifyShare(ec, session, in.Phis[in.Index], in.R, Psij, proof); err != nil {
+		return fmt.Errorf("err: Psi_j: %s", err.Error())
+	}
+
+	u := new(big.Int).Mod(r1msg.UnmarshalU(), ec.Params().N)
+	if u.Sign() == 0 {
+		return errors.New("u_j is zero")
+	}
+	lhs, err := crypto.ScalarBaseMult(ec, u).Add(in.GammasU[1-in.Index])
+	if err != nil {
+		return fmt.Errorf("u_j does not match the presignature: %s", err.Error())
+	}
+	rhs, err := Psij.Add(in.GammasU[in.Index])
+	if err != nil {
+		return fmt.Errorf("u_j does not match the presignature: %s", err.Error())
+	}
+	if !lhs.Equals(rhs) {
+		return errors.New("u_j does not match the presignature")
+	}
+	return nil
+}
+
+func checkUEvidence(ev *tss.Evidence, msgs []tss.ParsedMessage) (failure error, err error) {
+	in := new(UInputs)
+	if err := ev.UnmarshalInputs(in); err != nil {
+		return nil, err
+	}
+	if in.Index != ev.Culprit.Index || in.Index < 0 || in.Index > 1 {
+		return nil, fmt.Errorf("the inputs are about party %d, not the culprit", in.Index)
+	}
+	ec, ok := tss.GetCurveByName(in.Curve)
+	if !ok {
+		return nil, fmt.Errorf("unknown curve %s", in.Curve)
+	}
+	if err := validatePublicPresign(ec, in.Ks, in.R, in.Phis, in.GammasU); err != nil {
+		return nil, err
+	}
+	if len(msgs) != 1 {
+		return nil, errors.New("expected the round 1 broadcast")
+	}
+	r1msg, ok := msgs[0].Content().(*SignRound1Message)
+	if !ok {
+		return nil, errors.New("expected the round 1 broadcast")
+	}
+	return CheckU(ec, in, r1msg), nil
+}
+
+// validatePublicPresign checks that the public part of a presignature of two parties is complete and on the curve ec
+func validatePublicPresign(ec elliptic.Curve, Ks []*big.Int, R *crypto.ECPoint, Phis, GammasU []*crypto.ECPoint) error {
+	if len(Ks) != 2 || Ks[0] == nil || Ks[1] == nil {
+		return errors.New("the presignature does not have the ids of two parties")
+	}
+	if R == nil || !tss.SameCurve(R.Curve(), ec) {
+		return errors.New("the nonce point of the presignature is missing or not on the curve")
+	}
+	if len(Phis) != 2 || len(GammasU) != 2 {
+		return errors.New("the presignature has no points to check the shares of u against, run the presign again")
+	}
+	for k := range Phis {
+		if Phis[k] == nil || GammasU[k] == nil || !tss.SameCurve(Phis[k].Curve(), ec) || !tss.SameCurve(GammasU[k].Curve(), ec) {
+			return fmt.Errorf("the points of party %d to check its share of u against are missing or not on the curve", k)
+		}
+	}
+	return nil
+}
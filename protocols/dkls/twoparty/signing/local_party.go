@@ -0,0 +1,191 @@
+// Package signing implements the online phase of the two-party ECDSA, with a presignature of the two-party presign:
+// each party sends a single message, its shares w_i = m * phi_i + R.x * v_i and u_i,
+// and both parties output the signature s = (w_0 + w_1) / (u_0 + u_1) = (m + R.x * x) / r once it verifies against the key.
+//
+// The share u_j of the other party is checked in the exponent against the presignature, with Psi_j = phi_j * R and its DLEQ proof
+// against the mask point Phi_j, and a wrong share is blamed with evidence.
+// The presignature is single-use, and a wrong share of w gives a signature that does not verify.
+package signing
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/felicityin/mpc-tss/common"
+	"github.com/felicityin/mpc-tss/protocols/cggmp/keygen"
+	"github.com/felicityin/mpc-tss/protocols/dkls/twoparty/presign"
+	"github.com/felicityin/mpc-tss/tss"
+)
+
+// Implements Party
+// Implements Stringer
+var _ tss.Party = (*LocalParty)(nil)
+var _ fmt.Stringer = (*LocalParty)(nil)
+
+type (
+	LocalParty struct {
+		*tss.BaseParty
+		params *tss.Parameters
+
+		key  keygen.LocalPartySaveData
+		pre  presign.LocalPartySaveData
+		temp localTempData
+		data *common.SignatureData
+
+		// outbound messaging
+		out chan<- tss.Message
+		end chan<- *common.SignatureData
+	}
+
+	localMessageStore struct {
+		signRound1Messages []tss.ParsedMessage
+	}
+
+	localTempData struct {
+		localMessageStore
+
+		isThreshold  bool
+		msg          *big.Int
+		fullBytesLen int
+
+		// round 1
+		w, u *big.Int
+	}
+)
+
+// NewLocalParty returns a party that signs the digest msg with the presignature, with the key of the path, or the master key if path is empty
+func NewLocalParty(
+	msg *big.Int,
+	isThreshold bool,
+	params *tss.Parameters,
+	path string,
+	key keygen.LocalPartySaveData,
+	pre presign.LocalPartySaveData,
+	out chan<- tss.Message,
+	end chan<- *common.SignatureData,
+	fullBytesLen ...int,
+) (tss.Party, error) {
+	partyCount := len(params.Parties().IDs())
+	if partyCount != 2 {
+		return nil, fmt.Errorf("the two-party signing needs exactly 2 parties, got %d", partyCount)
+	}
+	key, err := keygen.BuildLocalSaveDataSubset(key, params.Parties().IDs())
+	if err != nil {
+		return nil, err
+	}
+	pre, err = presign.BuildLocalSaveDataSubset(pre, params.Parties().IDs())
+	if err != nil {
+		return nil, err
+	}
+	if err = validatePublicPresign(params.EC(), pre.Ks, pre.R, pre.Phis, pre.GammasU); err != nil {
+		return nil, err
+	}
+	err = PrepareForSigning(&key, &pre, path, isThreshold, params.Threshold(), params.KeyTweaks()...)
+	if err != nil {
+		return nil, err
+	}
+	p := &LocalParty{
+		BaseParty: new(tss.BaseParty),
+		params:    params,
+		key:       key,
+		pre:       pre,
+		temp:      localTempData{},
+		data:      &common.SignatureData{},
+		out:       out,
+		end:       end,
+	}
+	// msgs init
+	p.temp.signRound1Messages = make([]tss.ParsedMessage, partyCount)
+
+	// temp data init
+	p.temp.msg = msg
+	if len(fullBytesLen) > 0 {
+		p.temp.fullBytesLen = fullBytesLen[0]
+	}
+	p.temp.isThreshold = isThreshold
+	return p, nil
+}
+
+// NewLocalPartyWithMessage returns a party that signs the digest of the message, in the hash mode of the message:
+// PREHASHED, SHA256 or KECCAK256. The digest keeps its leading zero bytes, and its length is checked against the curve.
+func NewLocalPartyWithMessage(
+	msg *common.Message,
+	isThreshold bool,
+	params *tss.Parameters,
+	path string,
+	key keygen.LocalPartySaveData,
+	pre presign.LocalPartySaveData,
+	out chan<- tss.Message,
+	end chan<- *common.SignatureData,
+) (tss.Party, error) {
+	digest, err := msg.ECDSADigest(params.EC())
+	if err != nil {
+		return nil, err
+	}
+	party, err := NewLocalParty(new(big.Int).SetBytes(digest), isThreshold, params, path, key, pre, out, end, len(digest))
+	if err != nil {
+		return nil, err
+	}
+	party.(*LocalParty).data.HashMode = msg.Mode
+	return party, nil
+}
+
+func (p *LocalParty) FirstRound() tss.Round {
+	return newRound1(p.temp.isThreshold, p.params, &p.key, &p.pre, p.data, &p.temp, p.out, p.end)
+}
+
+func (p *LocalParty) Start() *tss.Error {
+	return tss.BaseStart(p, TaskName)
+}
+
+func (p *LocalParty) Update(msg tss.ParsedMessage) (ok bool, err *tss.Error) {
+	return tss.BaseUpdate(p, msg, TaskName)
+}
+
+func (p *LocalParty) UpdateFromBytes(wireBytes []byte, from *tss.PartyID, isBroadcast bool) (bool, *tss.Error) {
+	msg, err := tss.ParseWireMessage(wireBytes, from, isBroadcast)
+	if err != nil {
+		return false, p.WrapError(err)
+	}
+	return p.Update(msg)
+}
+
+func (p *LocalParty) ValidateMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	if msg.GetFrom() == nil || !msg.GetFrom().ValidateBasic() {
+		return false, p.WrapError(fmt.Errorf("received msg with an invalid sender: %s", msg))
+	}
+	// check that the message's "from index" will fit into the array
+	if maxFromIdx := len(p.params.Parties().IDs()) - 1; maxFromIdx < msg.GetFrom().Index {
+		return false, p.WrapError(fmt.Errorf("received msg with a sender index too great (%d <= %d)",
+			maxFromIdx, msg.GetFrom().Index), msg.GetFrom())
+	}
+	return p.BaseParty.ValidateMessage(msg)
+}
+
+func (p *LocalParty) StoreMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	// ValidateBasic is cheap; double-check the message here in case the public StoreMessage was called externally
+	if ok, err := p.ValidateMessage(msg); !ok || err != nil {
+		return ok, err
+	}
+	fromPIdx := msg.GetFrom().Index
+
+	// switch/case is necessary to store any messages beyond current round
+	// this does not handle message replays. we expect the caller to apply replay and spoofing protection.
+	switch msg.Content().(type) {
+	case *SignRound1Message:
+		p.temp.signRound1Messages[fromPIdx] = msg
+
+	default: // unrecognised message, just ignore!
+		common.Logger.Warningf("unrecognised message ignored: %v", msg)
+		return false, nil
+	}
+	return true, nil
+}
+
+func (p *LocalParty) PartyID() *tss.PartyID {
+	return p.params.PartyID()
+}
+
+func (p *LocalParty) String() string {
+	return fmt.Sprintf("id: %s, %s", p.PartyID(), p.BaseParty.String())
+}
@@ -0,0 +1,167 @@
+package signing
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+
+	"github.com/ipfs/go-log"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/felicityin/mpc-tss/common"
+	"github.com/felicityin/mpc-tss/protocols/cggmp/keygen"
+	tKeygen "github.com/felicityin/mpc-tss/protocols/cggmp/keygen/threshold"
+	"github.com/felicityin/mpc-tss/protocols/cggmp/test"
+	"github.com/felicityin/mpc-tss/protocols/dkls/twoparty/presign"
+	"github.com/felicityin/mpc-tss/protocols/utils"
+	"github.com/felicityin/mpc-tss/tss"
+)
+
+func setUp(level string) {
+	if err := log.SetLogLevel("tss-lib", level); err != nil {
+		panic(err)
+	}
+}
+
+func TestE2EConcurrent(t *testing.T) {
+	setUp("info")
+
+	for _, kind := range []int{keygen.Ecdsa, keygen.EcdsaP256} {
+		keys, pIDs, err := presign.GenerateTestKeys(kind)
+		if !assert.NoError(t, err, "should generate the 2-of-2 keys") {
+			continue
+		}
+		for _, path := range []string{"", "0/1/2/2/10"} {
+			pres, err := presign.GenerateTestPresignatures(kind, keys, pIDs)
+			if !assert.NoError(t, err) {
+				continue
+			}
+			msg := big.NewInt(42)
+			data, tssErr := runTestSign(kind, msg, path, keys, pres, pIDs, nil)
+			if !assert.Nil(t, tssErr) {
+				continue
+			}
+
+			// verify against the child public key
+			_, childPk, err := utils.DerivingKeyFromPath(keys[0], path)
+			assert.NoError(t, err)
+			pk := ecdsa.PublicKey{
+				Curve: tKeygen.TestCurve(kind),
+				X:     childPk.PublicKey.X(),
+				Y:     childPk.PublicKey.Y(),
+			}
+			ok := ecdsa.Verify(&pk, msg.Bytes(), new(big.Int).SetBytes(data.R), new(big.Int).SetBytes(data.S))
+			assert.True(t, ok, "ecdsa verify must pass")
+		}
+	}
+}
+
+func TestBadShareOfU(t *testing.T) {
+	setUp("info")
+
+	keys, pIDs, err := presign.GenerateTestKeys(keygen.Ecdsa)
+	assert.NoError(t, err, "should generate the 2-of-2 keys")
+	pres, err := presign.GenerateTestPresignatures(keygen.Ecdsa, keys, pIDs)
+	assert.NoError(t, err)
+
+	// P0 sends P1 a share of u that does not match the presignature, P1 must blame it with the evidence
+	_, tssErr := runTestSign(keygen.Ecdsa, big.NewInt(42), "", keys, pres, pIDs, func(msg tss.ParsedMessage) tss.ParsedMessage {
+		r1msg, ok := msg.Content().(*SignRound1Message)
+		if !ok {
+			return msg
+		}
+		return withU(msg, new(big.Int).Add(r1msg.UnmarshalU(), big.NewInt(1)))
+	})
+	if assert.NotNil(t, tssErr) {
+		assert.Equal(t, 1, tssErr.Victim().Index)
+		if assert.Len(t, tssErr.Culprits(), 1) {
+			assert.Equal(t, 0, tssErr.Culprits()[0].Index)
+		}
+		if assert.Len(t, tssErr.Evidence(), 1) {
+			ev := tssErr.Evidence()[0]
+			assert.Equal(t, EvidenceU, ev.Kind)
+			assert.NoError(t, ev.Verify(), "anyone can check the evidence")
+
+			// the same message with the share of u that P0 computed shows no misbehaviour
+			msgs, err := ev.ParseMessages()
+			if assert.NoError(t, err) && assert.Len(t, msgs, 1) {
+				r1msg := msgs[0].Content().(*SignRound1Message)
+				honest := withU(msgs[0], new(big.Int).Sub(r1msg.UnmarshalU(), big.NewInt(1)))
+				in := new(UInputs)
+				assert.NoError(t, ev.UnmarshalInputs(in))
+				honestEv, err := tss.NewEvidence(EvidenceU, TaskName, ev.Round, ev.Culprit, in, honest)
+				if assert.NoError(t, err) {
+					assert.ErrorIs(t, honestEv.Verify(), tss.ErrNoMisbehaviour)
+				}
+			}
+		}
+	}
+}
+
+// withU returns the round 1 message msg with the share of u replaced by u
+func withU(msg tss.ParsedMessage, u *big.Int) tss.ParsedMessage {
+	r1msg := msg.Content().(*SignRound1Message)
+	Psi, _ := r1msg.UnmarshalPsi()
+	proof, _ := r1msg.UnmarshalProof()
+	tampered, _ := NewSignRound1Message(msg.GetFrom(), r1msg.UnmarshalW(), u, Psi, proof)
+	return tampered
+}
+
+// runTestSign signs msg, where tamper, if not nil, changes the messages to the last party. It returns the signature of the first party.
+func runTestSign(
+	kind int,
+	msg *big.Int,
+	path string,
+	keys []keygen.LocalPartySaveData,
+	pres []presign.LocalPartySaveData,
+	pIDs tss.SortedPartyIDs,
+	tamper func(msg tss.ParsedMessage) tss.ParsedMessage,
+) (*common.SignatureData, *tss.Error) {
+	p2pCtx := tss.NewPeerContext(pIDs)
+	parties := make([]tss.Party, 0, len(pIDs))
+
+	errCh := make(chan *tss.Error, len(pIDs))
+	outCh := make(chan tss.Message, len(pIDs))
+	endCh := make(chan *common.SignatureData, len(pIDs))
+
+	for i := 0; i < len(pIDs); i++ {
+		params := tss.NewParameters(tKeygen.TestCurve(kind), p2pCtx, pIDs[i], len(pIDs), 1)
+		party, err := NewLocalParty(msg, true, params, path, keys[i], pres[i], outCh, endCh)
+		if err != nil {
+			return nil, tss.NewError(err, TaskName, 0, pIDs[i])
+		}
+		parties = append(parties, party)
+	}
+	// start every party before routing the messages, so that no party receives all the messages of round 1 before it starts
+	for _, P := range parties {
+		if err := P.Start(); err != nil {
+			return nil, err
+		}
+	}
+
+	var first *common.SignatureData
+	victim := len(parties) - 1
+	for ended := 0; ended < len(pIDs); {
+		select {
+		case err := <-errCh:
+			return nil, err
+		case msg := <-outCh:
+			for _, Pj := range parties {
+				if Pj.PartyID().Index == msg.GetFrom().Index {
+					continue
+				}
+				if tamper != nil && Pj.PartyID().Index == victim {
+					go test.SharedPartyUpdater(Pj, tamper(msg.(tss.ParsedMessage)), errCh)
+					continue
+				}
+				go test.SharedPartyUpdater(Pj, msg, errCh)
+			}
+		case data := <-endCh:
+			if first == nil {
+				first = data
+			}
+			ended++
+		}
+	}
+	return first, nil
+}
@@ -0,0 +1,85 @@
+package signing
+
+import (
+	"math/big"
+
+	"github.com/felicityin/mpc-tss/common"
+	"github.com/felicityin/mpc-tss/crypto"
+	"github.com/felicityin/mpc-tss/crypto/dleq"
+	"github.com/felicityin/mpc-tss/tss"
+)
+
+// These messages were generated from Protocol Buffers definitions into signing.pb.go
+// The following messages are registered on the Protocol Buffers "wire"
+
+var (
+	// Ensure that signing messages implement ValidateBasic
+	_ = []tss.MessageContent{
+		(*SignRound1Message)(nil),
+	}
+)
+
+// ----- //
+
+func NewSignRound1Message(from *tss.PartyID, w, u *big.Int, Psi *crypto.ECPoint, proof *dleq.Proof) (tss.ParsedMessage, error) {
+	psi, err := Psi.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	a, err := proof.A.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	b, err := proof.B.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &SignRound1Message{
+		W:      w.Bytes(),
+		U:      u.Bytes(),
+		Psi:    psi,
+		ProofA: a,
+		ProofB: b,
+		ProofZ: proof.Z.Bytes(),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg), nil
+}
+
+func (m *SignRound1Message) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetW()) &&
+		common.NonEmptyBytes(m.GetU()) &&
+		common.NonEmptyBytes(m.GetPsi()) &&
+		common.NonEmptyBytes(m.GetProofA()) &&
+		common.NonEmptyBytes(m.GetProofB()) &&
+		common.NonEmptyBytes(m.GetProofZ())
+}
+
+func (m *SignRound1Message) UnmarshalW() *big.Int {
+	return new(big.Int).SetBytes(m.GetW())
+}
+
+func (m *SignRound1Message) UnmarshalU() *big.Int {
+	return new(big.Int).SetBytes(m.GetU())
+}
+
+func (m *SignRound1Message) UnmarshalPsi() (*crypto.ECPoint, error) {
+	return crypto.UnmarshalJSONPoint(m.GetPsi())
+}
+
+func (m *SignRound1Message) UnmarshalProof() (*dleq.Proof, error) {
+	A, err := crypto.UnmarshalJSONPoint(m.GetProofA())
+	if err != nil {
+		return nil, err
+	}
+	B, err := crypto.UnmarshalJSONPoint(m.GetProofB())
+	if err != nil {
+		return nil, err
+	}
+	return &dleq.Proof{A: A, B: B, Z: new(big.Int).SetBytes(m.GetProofZ())}, nil
+}
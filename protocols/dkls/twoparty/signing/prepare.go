@@ -0,0 +1,45 @@
+package signing
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/felicityin/mpc-tss/common"
+	"github.com/felicityin/mpc-tss/protocols/cggmp/keygen"
+	"github.com/felicityin/mpc-tss/protocols/dkls/twoparty/presign"
+	"github.com/felicityin/mpc-tss/protocols/utils"
+)
+
+// PrepareForSigning shifts the presignature to the key of the path and the tweaks, and updates the key to the child key
+func PrepareForSigning(
+	key *keygen.LocalPartySaveData,
+	pre *presign.LocalPartySaveData,
+	path string,
+	isThreshold bool,
+	threshold int,
+	tweaks ...*big.Int,
+) error {
+	ec := key.Pubkey.Curve()
+	keyDerivationDelta, _, err := utils.DerivingKeyFromPath(*key, path)
+	if err != nil {
+		return fmt.Errorf("there should not be an error deriving the child public key: %s", err.Error())
+	}
+
+	// the key is shifted by the derivation delta and the tweaks
+	shift := new(big.Int).Set(keyDerivationDelta)
+	for _, tweak := range tweaks {
+		if err = utils.CheckTweak(tweak, ec); err != nil {
+			return err
+		}
+		shift = shift.Add(shift, tweak)
+	}
+
+	// v = x * phi becomes (x + shift) * phi, on the own copy of the presignature of the party so that it can wipe it once it is consumed
+	shift = shift.Mul(shift, pre.Phi)
+	pre.Phi = new(big.Int).Set(pre.Phi)
+	pre.U = new(big.Int).Set(pre.U)
+	pre.V = new(big.Int).Add(pre.V, shift)
+	common.ZeroBigInts(shift)
+
+	return utils.UpdateKeyForSigning(key, path, isThreshold, threshold, tweaks...)
+}
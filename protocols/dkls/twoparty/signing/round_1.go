@@ -0,0 +1,97 @@
+package signing
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/felicityin/mpc-tss/common"
+	"github.com/felicityin/mpc-tss/crypto/dleq"
+	"github.com/felicityin/mpc-tss/protocols/cggmp/keygen"
+	"github.com/felicityin/mpc-tss/protocols/dkls/twoparty/presign"
+	"github.com/felicityin/mpc-tss/tss"
+)
+
+func newRound1(
+	isThreshold bool,
+	params *tss.Parameters,
+	key *keygen.LocalPartySaveData,
+	pre *presign.LocalPartySaveData,
+	data *common.SignatureData,
+	temp *localTempData,
+	out chan<- tss.Message,
+	end chan<- *common.SignatureData,
+) tss.Round {
+	return &round1{
+		&base{params, isThreshold, key, pre, data, temp, out, end, make([]bool, len(params.Parties().IDs())), false, 1},
+	}
+}
+
+func (round *round1) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 1
+	round.started = true
+	round.resetOK()
+
+	i := round.PartyID().Index
+	common.Logger.Infof("[two-party-sign] party: %d, round_1 start", i)
+
+	// wi = m * phi_i + R.x * vi
+	modN := common.ModInt(round.EC().Params().N)
+	round.temp.w = modN.Add(modN.Mul(round.temp.msg, round.pre.Phi), modN.Mul(round.pre.R.X(), round.pre.V))
+	round.temp.u = new(big.Int).Set(round.pre.U)
+
+	// Psi_i = phi_i * R, with the proof that it has the discrete logarithm of Phi_i, which the other party checks ui against
+	session, err := round.uInputs(i).session()
+	if err != nil {
+		return round.WrapError(err)
+	}
+	Psi, proof, err := dleq.NewShare(session, round.pre.Phi, round.pre.Phis[i], round.pre.R, round.Rand())
+	if err != nil {
+		return round.WrapError(fmt.Errorf("create the DLEQ proof: %s", err.Error()))
+	}
+
+	// the presignature is single-use, wipe it together with the key share now that it has been consumed
+	round.pre.Destroy()
+	common.ZeroBigInts(round.key.PrivXi)
+
+	// broadcast wi, ui
+	common.Logger.Debugf("P[%d]: round_1 broadcast", i)
+	r1msg, err := NewSignRound1Message(round.PartyID(), round.temp.w, round.temp.u, Psi, proof)
+	if err != nil {
+		return round.WrapError(err)
+	}
+	round.temp.signRound1Messages[i] = r1msg
+	round.out <- r1msg
+
+	return nil
+}
+
+func (round *round1) Update() (bool, *tss.Error) {
+	ret := true
+	for j, msg := range round.temp.signRound1Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			ret = false
+			continue
+		}
+		round.ok[j] = true
+	}
+	return ret, nil
+}
+
+func (round *round1) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*SignRound1Message); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round1) NextRound() tss.Round {
+	round.started = false
+	return &finalization{round}
+}
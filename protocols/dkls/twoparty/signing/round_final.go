@@ -0,0 +1,85 @@
+package signing
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/felicityin/mpc-tss/common"
+	cggmpSigning "github.com/felicityin/mpc-tss/protocols/cggmp/ecdsa/signing"
+	"github.com/felicityin/mpc-tss/tss"
+)
+
+func (round *finalization) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 2
+	round.started = true
+	round.resetOK()
+
+	i := round.PartyID().Index
+	common.Logger.Infof("[two-party-sign] party: %d, round final start", i)
+
+	// s = (w0 + w1) / (u0 + u1), once the share of u of the other party is checked against the presignature
+	modN := common.ModInt(round.EC().Params().N)
+	w, u := round.temp.w, round.temp.u
+	for j := range round.Parties().IDs() {
+		round.ok[j] = true
+		if j == i {
+			continue
+		}
+		msg := round.temp.signRound1Messages[j]
+		r1msg := msg.Content().(*SignRound1Message)
+		inputs := round.uInputs(j)
+		if err := CheckU(round.EC(), inputs, r1msg); err != nil {
+			common.Logger.Errorf("[j: %d] %s", j, err.Error())
+			return round.WrapEvidence(fmt.Errorf("[j: %d] %s", j, err.Error()), EvidenceU, inputs, msg)
+		}
+		w = modN.Add(w, r1msg.UnmarshalW())
+		u = modN.Add(u, r1msg.UnmarshalU())
+	}
+	if u.Sign() == 0 {
+		return round.WrapError(errors.New("u is zero"))
+	}
+	s := modN.Mul(w, modN.ModInverse(u))
+
+	M := round.temp.msg.Bytes()
+	if round.temp.fullBytesLen != 0 {
+		M = make([]byte, round.temp.fullBytesLen)
+		round.temp.msg.FillBytes(M)
+	}
+
+	// save the signature for final output
+	data := cggmpSigning.NewSignatureData(round.EC(), round.pre.R, s, M)
+	round.data.R, round.data.S, round.data.M = data.R, data.S, data.M
+	round.data.Signature, round.data.SignatureRecovery = data.Signature, data.SignatureRecovery
+
+	pk := ecdsa.PublicKey{
+		Curve: round.Params().EC(),
+		X:     round.key.Pubkey.X(),
+		Y:     round.key.Pubkey.Y(),
+	}
+	ok := ecdsa.Verify(&pk, round.data.M, new(big.Int).SetBytes(data.R), new(big.Int).SetBytes(data.S))
+	if !ok {
+		return round.WrapError(fmt.Errorf("signature verification failed"))
+	}
+
+	round.end <- round.data
+	return nil
+}
+
+func (round *finalization) CanAccept(msg tss.ParsedMessage) bool {
+	// not expecting any incoming messages in this round
+	return false
+}
+
+func (round *finalization) Update() (bool, *tss.Error) {
+	// not expecting any incoming messages in this round
+	return false, nil
+}
+
+func (round *finalization) NextRound() tss.Round {
+	return nil // finished!
+}
@@ -0,0 +1,196 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.33.0
+// 	protoc        v3.21.4
+// source: protocols/dkls/twoparty/signing/signing.proto
+
+package signing
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Represents a BROADCAST message sent to the other party during Round 1 of the two-party signing:
+// the share w_i = m * phi_i + R.x * v_i of the numerator of s, the share u_i of its denominator u = r * phi,
+// and Psi_i = phi_i * R with the DLEQ proof (A, B, z) that it has the discrete logarithm of Phi_i.
+type SignRound1Message struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	W      []byte `protobuf:"bytes,1,opt,name=w,proto3" json:"w,omitempty"`
+	U      []byte `protobuf:"bytes,2,opt,name=u,proto3" json:"u,omitempty"`
+	Psi    []byte `protobuf:"bytes,3,opt,name=psi,proto3" json:"psi,omitempty"`
+	ProofA []byte `protobuf:"bytes,4,opt,name=proof_a,json=proofA,proto3" json:"proof_a,omitempty"`
+	ProofB []byte `protobuf:"bytes,5,opt,name=proof_b,json=proofB,proto3" json:"proof_b,omitempty"`
+	ProofZ []byte `protobuf:"bytes,6,opt,name=proof_z,json=proofZ,proto3" json:"proof_z,omitempty"`
+}
+
+func (x *SignRound1Message) Reset() {
+	*x = SignRound1Message{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocols_dkls_twoparty_signing_signing_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SignRound1Message) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignRound1Message) ProtoMessage() {}
+
+func (x *SignRound1Message) ProtoReflect() protoreflect.Message {
+	mi := &file_protocols_dkls_twoparty_signing_signing_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignRound1Message.ProtoReflect.Descriptor instead.
+func (*SignRound1Message) Descriptor() ([]byte, []int) {
+	return file_protocols_dkls_twoparty_signing_signing_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SignRound1Message) GetW() []byte {
+	if x != nil {
+		return x.W
+	}
+	return nil
+}
+
+func (x *SignRound1Message) GetU() []byte {
+	if x != nil {
+		return x.U
+	}
+	return nil
+}
+
+func (x *SignRound1Message) GetPsi() []byte {
+	if x != nil {
+		return x.Psi
+	}
+	return nil
+}
+
+func (x *SignRound1Message) GetProofA() []byte {
+	if x != nil {
+		return x.ProofA
+	}
+	return nil
+}
+
+func (x *SignRound1Message) GetProofB() []byte {
+	if x != nil {
+		return x.ProofB
+	}
+	return nil
+}
+
+func (x *SignRound1Message) GetProofZ() []byte {
+	if x != nil {
+		return x.ProofZ
+	}
+	return nil
+}
+
+var File_protocols_dkls_twoparty_signing_signing_proto protoreflect.FileDescriptor
+
+var file_protocols_dkls_twoparty_signing_signing_proto_rawDesc = []byte{
+	0x0a, 0x2d, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x73, 0x2f, 0x64, 0x6b, 0x6c, 0x73,
+	0x2f, 0x74, 0x77, 0x6f, 0x70, 0x61, 0x72, 0x74, 0x79, 0x2f, 0x73, 0x69, 0x67, 0x6e, 0x69, 0x6e,
+	0x67, 0x2f, 0x73, 0x69, 0x67, 0x6e, 0x69, 0x6e, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
+	0x1c, 0x74, 0x73, 0x73, 0x6c, 0x69, 0x62, 0x2e, 0x64, 0x6b, 0x6c, 0x73, 0x2e, 0x74, 0x77, 0x6f,
+	0x70, 0x61, 0x72, 0x74, 0x79, 0x2e, 0x73, 0x69, 0x67, 0x6e, 0x69, 0x6e, 0x67, 0x22, 0x8c, 0x01,
+	0x0a, 0x11, 0x53, 0x69, 0x67, 0x6e, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x31, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x12, 0x0c, 0x0a, 0x01, 0x77, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x01,
+	0x77, 0x12, 0x0c, 0x0a, 0x01, 0x75, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x01, 0x75, 0x12,
+	0x10, 0x0a, 0x03, 0x70, 0x73, 0x69, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x70, 0x73,
+	0x69, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5f, 0x61, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x06, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x41, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x72,
+	0x6f, 0x6f, 0x66, 0x5f, 0x62, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x70, 0x72, 0x6f,
+	0x6f, 0x66, 0x42, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5f, 0x7a, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5a, 0x42, 0x21, 0x5a, 0x1f,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x73, 0x2f, 0x64, 0x6b, 0x6c, 0x73, 0x2f, 0x74,
+	0x77, 0x6f, 0x70, 0x61, 0x72, 0x74, 0x79, 0x2f, 0x73, 0x69, 0x67, 0x6e, 0x69, 0x6e, 0x67, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_protocols_dkls_twoparty_signing_signing_proto_rawDescOnce sync.Once
+	file_protocols_dkls_twoparty_signing_signing_proto_rawDescData = file_protocols_dkls_twoparty_signing_signing_proto_rawDesc
+)
+
+func file_protocols_dkls_twoparty_signing_signing_proto_rawDescGZIP() []byte {
+	file_protocols_dkls_twoparty_signing_signing_proto_rawDescOnce.Do(func() {
+		file_protocols_dkls_twoparty_signing_signing_proto_rawDescData = protoimpl.X.CompressGZIP(file_protocols_dkls_twoparty_signing_signing_proto_rawDescData)
+	})
+	return file_protocols_dkls_twoparty_signing_signing_proto_rawDescData
+}
+
+var file_protocols_dkls_twoparty_signing_signing_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_protocols_dkls_twoparty_signing_signing_proto_goTypes = []interface{}{
+	(*SignRound1Message)(nil), // 0: tsslib.dkls.twoparty.signing.SignRound1Message
+}
+var file_protocols_dkls_twoparty_signing_signing_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_protocols_dkls_twoparty_signing_signing_proto_init() }
+func file_protocols_dkls_twoparty_signing_signing_proto_init() {
+	if File_protocols_dkls_twoparty_signing_signing_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_protocols_dkls_twoparty_signing_signing_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SignRound1Message); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_protocols_dkls_twoparty_signing_signing_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_protocols_dkls_twoparty_signing_signing_proto_goTypes,
+		DependencyIndexes: file_protocols_dkls_twoparty_signing_signing_proto_depIdxs,
+		MessageInfos:      file_protocols_dkls_twoparty_signing_signing_proto_msgTypes,
+	}.Build()
+	File_protocols_dkls_twoparty_signing_signing_proto = out.File
+	file_protocols_dkls_twoparty_signing_signing_proto_rawDesc = nil
+	file_protocols_dkls_twoparty_signing_signing_proto_goTypes = nil
+	file_protocols_dkls_twoparty_signing_signing_proto_depIdxs = nil
+}
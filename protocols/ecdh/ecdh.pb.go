@@ -0,0 +1,173 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.33.0
+// 	protoc        v3.21.4
+// source: protocols/ecdh/ecdh.proto
+
+package ecdh
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Represents a BROADCAST message sent to all parties during Round 1 of the threshold ECDH:
+// the share Y_i = x_i * P of the party, with the DLEQ proof (A, B, z) that it has the discrete logarithm of its key share.
+type ECDHRound1Message struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Y      []byte `protobuf:"bytes,1,opt,name=y,proto3" json:"y,omitempty"`
+	ProofA []byte `protobuf:"bytes,2,opt,name=proof_a,json=proofA,proto3" json:"proof_a,omitempty"`
+	ProofB []byte `protobuf:"bytes,3,opt,name=proof_b,json=proofB,proto3" json:"proof_b,omitempty"`
+	ProofZ []byte `protobuf:"bytes,4,opt,name=proof_z,json=proofZ,proto3" json:"proof_z,omitempty"`
+}
+
+func (x *ECDHRound1Message) Reset() {
+	*x = ECDHRound1Message{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocols_ecdh_ecdh_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ECDHRound1Message) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ECDHRound1Message) ProtoMessage() {}
+
+func (x *ECDHRound1Message) ProtoReflect() protoreflect.Message {
+	mi := &file_protocols_ecdh_ecdh_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ECDHRound1Message.ProtoReflect.Descriptor instead.
+func (*ECDHRound1Message) Descriptor() ([]byte, []int) {
+	return file_protocols_ecdh_ecdh_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ECDHRound1Message) GetY() []byte {
+	if x != nil {
+		return x.Y
+	}
+	return nil
+}
+
+func (x *ECDHRound1Message) GetProofA() []byte {
+	if x != nil {
+		return x.ProofA
+	}
+	return nil
+}
+
+func (x *ECDHRound1Message) GetProofB() []byte {
+	if x != nil {
+		return x.ProofB
+	}
+	return nil
+}
+
+func (x *ECDHRound1Message) GetProofZ() []byte {
+	if x != nil {
+		return x.ProofZ
+	}
+	return nil
+}
+
+var File_protocols_ecdh_ecdh_proto protoreflect.FileDescriptor
+
+var file_protocols_ecdh_ecdh_proto_rawDesc = []byte{
+	0x0a, 0x19, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x73, 0x2f, 0x65, 0x63, 0x64, 0x68,
+	0x2f, 0x65, 0x63, 0x64, 0x68, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0b, 0x74, 0x73, 0x73,
+	0x6c, 0x69, 0x62, 0x2e, 0x65, 0x63, 0x64, 0x68, 0x22, 0x6c, 0x0a, 0x11, 0x45, 0x43, 0x44, 0x48,
+	0x52, 0x6f, 0x75, 0x6e, 0x64, 0x31, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x0c, 0x0a,
+	0x01, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x01, 0x79, 0x12, 0x17, 0x0a, 0x07, 0x70,
+	0x72, 0x6f, 0x6f, 0x66, 0x5f, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x70, 0x72,
+	0x6f, 0x6f, 0x66, 0x41, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5f, 0x62, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x42, 0x12, 0x17, 0x0a,
+	0x07, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5f, 0x7a, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06,
+	0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5a, 0x42, 0x10, 0x5a, 0x0e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63,
+	0x6f, 0x6c, 0x73, 0x2f, 0x65, 0x63, 0x64, 0x68, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_protocols_ecdh_ecdh_proto_rawDescOnce sync.Once
+	file_protocols_ecdh_ecdh_proto_rawDescData = file_protocols_ecdh_ecdh_proto_rawDesc
+)
+
+func file_protocols_ecdh_ecdh_proto_rawDescGZIP() []byte {
+	file_protocols_ecdh_ecdh_proto_rawDescOnce.Do(func() {
+		file_protocols_ecdh_ecdh_proto_rawDescData = protoimpl.X.CompressGZIP(file_protocols_ecdh_ecdh_proto_rawDescData)
+	})
+	return file_protocols_ecdh_ecdh_proto_rawDescData
+}
+
+var file_protocols_ecdh_ecdh_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_protocols_ecdh_ecdh_proto_goTypes = []interface{}{
+	(*ECDHRound1Message)(nil), // 0: tsslib.ecdh.ECDHRound1Message
+}
+var file_protocols_ecdh_ecdh_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_protocols_ecdh_ecdh_proto_init() }
+func file_protocols_ecdh_ecdh_proto_init() {
+	if File_protocols_ecdh_ecdh_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_protocols_ecdh_ecdh_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ECDHRound1Message); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_protocols_ecdh_ecdh_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_protocols_ecdh_ecdh_proto_goTypes,
+		DependencyIndexes: file_protocols_ecdh_ecdh_proto_depIdxs,
+		MessageInfos:      file_protocols_ecdh_ecdh_proto_msgTypes,
+	}.Build()
+	File_protocols_ecdh_ecdh_proto = out.File
+	file_protocols_ecdh_ecdh_proto_rawDesc = nil
+	file_protocols_ecdh_ecdh_proto_goTypes = nil
+	file_protocols_ecdh_ecdh_proto_depIdxs = nil
+}
@@ -0,0 +1,80 @@
+package ecdh
+
+import (
+	"crypto/elliptic"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/felicityin/mpc-tss/crypto"
+	"github.com/felicityin/mpc-tss/crypto/dleq"
+	"github.com/felicityin/mpc-tss/tss"
+)
+
+// EvidenceShare is the kind of the evidence against a party whose ECDH share has an invalid DLEQ proof
+const EvidenceShare = "ecdh/share"
+
+func init() {
+	tss.RegisterEvidenceCheck(EvidenceShare, checkShareEvidence)
+}
+
+// ShareInputs are the public inputs of the check of the ECDH share Y_j of Pj: the point P of the peer and the public data
+// of the key prepared for the signing, which are fixed before the session. PubXj are the public key shares of the signers
+// weighted by their Lagrange coefficients, and Ks the keys of the signers. The ssid is computed again from them.
+type ShareInputs struct {
+	Curve tss.CurveName     `json:"curve"`
+	Index int               `json:"index"`
+	P     *crypto.ECPoint   `json:"p"`
+	Ks    []*big.Int        `json:"ks"`
+	PubXj []*crypto.ECPoint `json:"pub_xj"`
+}
+
+// CheckShare checks the DLEQ proof that Y_j = x_j * P for Xj = x_j * G, and that Y_j is not of small order on ed25519
+func CheckShare(ec elliptic.Curve, ssid []byte, P, Xj *crypto.ECPoint, r1msg *ECDHRound1Message) error {
+	Yj, err := r1msg.UnmarshalY()
+	if err != nil {
+		return fmt.Errorf("err: Yj: %s", err.Error())
+	}
+	proof, err := r1msg.UnmarshalProof()
+	if err != nil {
+		return fmt.Errorf("err: DLEQ proof: %s", err.Error())
+	}
+	if err := dleq.VerifyShare(ec, ssid, Xj, P, Yj, proof); err != nil {
+		return fmt.Errorf("err: Yj: %s", err.Error())
+	}
+	return nil
+}
+
+func checkShareEvidence(ev *tss.Evidence, msgs []tss.ParsedMessage) (failure error, err error) {
+	in := new(ShareInputs)
+	if err := ev.UnmarshalInputs(in); err != nil {
+		return nil, err
+	}
+	if in.Index != ev.Culprit.Index || in.Index < 0 || in.Index >= len(in.PubXj) {
+		return nil, fmt.Errorf("the inputs are about party %d, not the culprit", in.Index)
+	}
+	ec, ok := tss.GetCurveByName(in.Curve)
+	if !ok {
+		return nil, fmt.Errorf("unknown curve %s", in.Curve)
+	}
+	if in.P == nil || !tss.SameCurve(in.P.Curve(), ec) || len(in.Ks) != len(in.PubXj) {
+		return nil, errors.New("the point or the keys of the signers are missing")
+	}
+	for j, Xj := range in.PubXj {
+		if Xj == nil || !tss.SameCurve(Xj.Curve(), ec) || in.Ks[j] == nil {
+			return nil, fmt.Errorf("the key share or the key of party %d are missing", j)
+		}
+	}
+	ssid, err := computeSSID(ec, in.Ks, in.PubXj, in.P, 1, big.NewInt(0))
+	if err != nil {
+		return nil, err
+	}
+	if len(msgs) != 1 {
+		return nil, errors.New("expected the round 1 message")
+	}
+	r1msg, ok := msgs[0].Content().(*ECDHRound1Message)
+	if !ok {
+		return nil, errors.New("expected the round 1 message")
+	}
+	return CheckShare(ec, ssid, in.P, in.PubXj[in.Index], r1msg), nil
+}
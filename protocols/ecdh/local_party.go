@@ -0,0 +1,182 @@
+// Package ecdh implements the threshold ECDH: the signers of a key agree on x * P for the point P of a peer,
+// where x is the key of the signers, without reconstructing x.
+//
+// Each signer broadcasts its share Y_i = lambda_i * x_i * P with a DLEQ proof against its public key share,
+// so that a wrong share is attributed to its sender, and the shares add up to x * P.
+// The key may be a child key of a derivation path, as in the signing.
+// The keys of secp256k1 agree with the ECDH of secp256k1, and the keys of ed25519 with X25519, see X25519Point.
+package ecdh
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/felicityin/mpc-tss/common"
+	"github.com/felicityin/mpc-tss/crypto"
+	"github.com/felicityin/mpc-tss/protocols/cggmp/keygen"
+	"github.com/felicityin/mpc-tss/protocols/utils"
+	"github.com/felicityin/mpc-tss/tss"
+)
+
+// Implements Party
+// Implements Stringer
+var _ tss.Party = (*LocalParty)(nil)
+var _ fmt.Stringer = (*LocalParty)(nil)
+
+type (
+	LocalParty struct {
+		*tss.BaseParty
+		params *tss.Parameters
+
+		keys keygen.LocalPartySaveData
+		temp localTempData
+
+		// outbound messaging
+		out chan<- tss.Message
+		end chan<- *crypto.ECPoint
+	}
+
+	localMessageStore struct {
+		ecdhRound1Messages []tss.ParsedMessage
+	}
+
+	localTempData struct {
+		localMessageStore
+
+		isThreshold bool
+		point       *crypto.ECPoint
+
+		// round 1
+		Yj []*crypto.ECPoint
+
+		ssid      []byte
+		ssidNonce *big.Int
+	}
+)
+
+// NewLocalParty returns a party that outputs x * P on end, where x is the key of the path, or the master key if path is empty.
+// P is a point of the curve of the parameters, in the prime-order subgroup for ed25519.
+func NewLocalParty(
+	point *crypto.ECPoint,
+	isThreshold bool,
+	params *tss.Parameters,
+	path string,
+	key keygen.LocalPartySaveData,
+	out chan<- tss.Message,
+	end chan<- *crypto.ECPoint,
+) (tss.Party, error) {
+	if err := validatePoint(params, point); err != nil {
+		return nil, err
+	}
+	key, err := keygen.BuildLocalSaveDataSubset(key, params.Parties().IDs())
+	if err != nil {
+		return nil, err
+	}
+	err = utils.UpdateKeyForSigning(&key, path, isThreshold, params.Threshold(), params.KeyTweaks()...)
+	if err != nil {
+		return nil, err
+	}
+	partyCount := len(params.Parties().IDs())
+	p := &LocalParty{
+		BaseParty: new(tss.BaseParty),
+		params:    params,
+		keys:      key,
+		temp:      localTempData{},
+		out:       out,
+		end:       end,
+	}
+	// msgs init
+	p.temp.ecdhRound1Messages = make([]tss.ParsedMessage, partyCount)
+
+	// temp data init
+	p.temp.isThreshold = isThreshold
+	p.temp.point = point
+	p.temp.Yj = make([]*crypto.ECPoint, partyCount)
+	return p, nil
+}
+
+func (p *LocalParty) FirstRound() tss.Round {
+	return newRound1(p.temp.isThreshold, p.params, &p.keys, &p.temp, p.out, p.end)
+}
+
+func (p *LocalParty) Start() *tss.Error {
+	if err := tss.BaseStart(p, TaskName); err != nil {
+		return err
+	}
+	// the shares of the peers may all arrive before Start, and the ECDH has no later round whose messages would update round 1
+	return tss.BaseProceed(p, TaskName)
+}
+
+func (p *LocalParty) Update(msg tss.ParsedMessage) (ok bool, err *tss.Error) {
+	return tss.BaseUpdate(p, msg, TaskName)
+}
+
+func (p *LocalParty) UpdateFromBytes(wireBytes []byte, from *tss.PartyID, isBroadcast bool) (bool, *tss.Error) {
+	msg, err := tss.ParseWireMessage(wireBytes, from, isBroadcast)
+	if err != nil {
+		return false, p.WrapError(err)
+	}
+	return p.Update(msg)
+}
+
+func (p *LocalParty) ValidateMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	if msg.GetFrom() == nil || !msg.GetFrom().ValidateBasic() {
+		return false, p.WrapError(fmt.Errorf("received msg with an invalid sender: %s", msg))
+	}
+	// check that the message's "from index" will fit into the array
+	if maxFromIdx := len(p.params.Parties().IDs()) - 1; maxFromIdx < msg.GetFrom().Index {
+		return false, p.WrapError(fmt.Errorf("received msg with a sender index too great (%d <= %d)",
+			maxFromIdx, msg.GetFrom().Index), msg.GetFrom())
+	}
+	return p.BaseParty.ValidateMessage(msg)
+}
+
+func (p *LocalParty) StoreMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	// ValidateBasic is cheap; double-check the message here in case the public StoreMessage was called externally
+	if ok, err := p.ValidateMessage(msg); !ok || err != nil {
+		return ok, err
+	}
+	fromPIdx := msg.GetFrom().Index
+
+	// switch/case is necessary to store any messages beyond current round
+	// this does not handle message replays. we expect the caller to apply replay and spoofing protection.
+	switch msg.Content().(type) {
+
+	case *ECDHRound1Message:
+		p.temp.ecdhRound1Messages[fromPIdx] = msg
+
+	default: // unrecognised message, just ignore!
+		common.Logger.Warningf("unrecognised message ignored: %v", msg)
+		return false, nil
+	}
+	return true, nil
+}
+
+func (p *LocalParty) PartyID() *tss.PartyID {
+	return p.params.PartyID()
+}
+
+func (p *LocalParty) String() string {
+	return fmt.Sprintf("id: %s, %s", p.PartyID(), p.BaseParty.String())
+}
+
+// validatePoint checks that the point of the peer is on the curve of the parameters and, for ed25519,
+// that it is not of small order: its component of small order would leak x mod 8 through the shares
+func validatePoint(params *tss.Parameters, point *crypto.ECPoint) error {
+	if point == nil || !point.ValidateBasic() {
+		return errors.New("the point is missing or not on its curve")
+	}
+	if !tss.SameCurve(point.Curve(), params.EC()) {
+		return errors.New("the point is not on the curve of the parameters")
+	}
+	if tss.SameCurve(params.EC(), tss.Edwards()) {
+		if point.X().Sign() == 0 && point.Y().Cmp(big.NewInt(1)) == 0 {
+			return errors.New("the point is the identity")
+		}
+		if !point.EightInvEight().Equals(point) {
+			return errors.New("the point is not in the prime-order subgroup")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,258 @@
+package ecdh
+
+import (
+	stdecdh "crypto/ecdh"
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/ipfs/go-log"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/felicityin/mpc-tss/common"
+	"github.com/felicityin/mpc-tss/crypto"
+	"github.com/felicityin/mpc-tss/protocols/cggmp/keygen"
+	tKeygen "github.com/felicityin/mpc-tss/protocols/cggmp/keygen/threshold"
+	"github.com/felicityin/mpc-tss/protocols/cggmp/test"
+	"github.com/felicityin/mpc-tss/protocols/utils"
+	"github.com/felicityin/mpc-tss/tss"
+)
+
+const (
+	testParticipants = 3
+	testThreshold    = 2
+	testPath         = "0/1/2/2/10"
+)
+
+func setUp(level string) {
+	if err := log.SetLogLevel("tss-lib", level); err != nil {
+		panic(err)
+	}
+}
+
+// runECDH runs the ECDH of the threshold fixtures of the kind with the point, and returns the outputs of the parties.
+// tamper, if not nil, changes the messages to the last party.
+func runECDH(
+	t *testing.T,
+	kind int,
+	point func(ec *crypto.ECPoint) *crypto.ECPoint,
+	tamper func(msg tss.ParsedMessage) tss.ParsedMessage,
+) (childPubkey *crypto.ECPoint, P *crypto.ECPoint, outputs []*crypto.ECPoint, tssErr *tss.Error) {
+	keys, signPIDs, err := tKeygen.LoadKeygenTestFixturesRandomSet(kind, testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	_, extendedKey, err := utils.DerivingKeyFromPath(keys[0], testPath)
+	assert.NoError(t, err)
+	childPubkey = extendedKey.PublicKey
+	P = point(childPubkey)
+
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	parties := make([]*LocalParty, 0, len(signPIDs))
+
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+	endCh := make(chan *crypto.ECPoint, len(signPIDs))
+
+	updater := test.SharedPartyUpdater
+
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(tKeygen.TestCurve(kind), p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+		party, err := NewLocalParty(P, true, params, testPath, keys[i], outCh, endCh)
+		assert.NoError(t, err)
+		parties = append(parties, party.(*LocalParty))
+
+		go func(P *LocalParty) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(party.(*LocalParty))
+	}
+
+	victim := len(signPIDs) - 1
+	for len(outputs) < len(signPIDs) {
+		select {
+		case err := <-errCh:
+			return childPubkey, P, outputs, err
+
+		case msg := <-outCh:
+			for _, Pj := range parties {
+				if Pj.PartyID().Index == msg.GetFrom().Index {
+					continue
+				}
+				if tamper != nil && Pj.PartyID().Index == victim {
+					go updater(Pj, tamper(msg.(tss.ParsedMessage)), errCh)
+					continue
+				}
+				go updater(Pj, msg, errCh)
+			}
+
+		case S := <-endCh:
+			outputs = append(outputs, S)
+		}
+	}
+	return childPubkey, P, outputs, nil
+}
+
+func TestE2EThresholdConcurrent(t *testing.T) {
+	setUp("info")
+
+	for _, kind := range []int{keygen.Ecdsa, keygen.Eddsa} {
+		// the peer has the key e, and agrees on e * Pubkey
+		e := common.GetRandomPositiveInt(rand.Reader, tKeygen.TestCurve(kind).Params().N)
+		childPubkey, _, outputs, err := runECDH(t, kind, func(pub *crypto.ECPoint) *crypto.ECPoint {
+			return crypto.ScalarBaseMult(pub.Curve(), e)
+		}, nil)
+		if !assert.Nil(t, err) {
+			continue
+		}
+		expected := childPubkey.ScalarMult(e)
+		for _, S := range outputs {
+			assert.True(t, expected.Equals(S), "the parties agree on e * Pubkey of the child key")
+		}
+	}
+}
+
+func TestX25519(t *testing.T) {
+	setUp("info")
+
+	peer, err := stdecdh.X25519().GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	childPubkey, _, outputs, tssErr := runECDH(t, keygen.Eddsa, func(*crypto.ECPoint) *crypto.ECPoint {
+		P, err := X25519Point(peer.PublicKey().Bytes())
+		assert.NoError(t, err)
+		return P
+	}, nil)
+	if !assert.Nil(t, tssErr) {
+		return
+	}
+
+	u, err := X25519PublicKey(childPubkey)
+	assert.NoError(t, err)
+	groupKey, err := stdecdh.X25519().NewPublicKey(u)
+	assert.NoError(t, err)
+	expected, err := peer.ECDH(groupKey)
+	assert.NoError(t, err)
+	for _, S := range outputs {
+		secret, err := X25519PublicKey(S)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, secret, "the parties agree with X25519 of the peer")
+	}
+}
+
+func TestX25519Point(t *testing.T) {
+	ec := tss.Edwards()
+	k := common.GetRandomPositiveInt(rand.Reader, ec.Params().N)
+	Q := crypto.ScalarBaseMult(ec, k)
+	u, err := X25519PublicKey(Q)
+	assert.NoError(t, err)
+	P, err := X25519Point(u)
+	assert.NoError(t, err)
+	assert.Equal(t, Q.Y(), P.Y())
+	assert.Equal(t, uint(0), P.X().Bit(0), "the point with the even x")
+
+	// u = 9 is the base point of RFC 7748
+	nine := make([]byte, X25519Len)
+	nine[0] = 9
+	P, err = X25519Point(nine)
+	assert.NoError(t, err)
+	assert.Equal(t, ec.Params().Gy, P.Y())
+
+	minusOne := reverse(new(big.Int).Sub(ec.Params().P, big.NewInt(1)).FillBytes(make([]byte, X25519Len)))
+	_, err = X25519Point(minusOne)
+	assert.Error(t, err, "u = -1 has no ed25519 point")
+	_, err = X25519Point(nine[1:])
+	assert.Error(t, err)
+}
+
+func TestSmallOrderPoint(t *testing.T) {
+	keys, signPIDs, err := tKeygen.LoadKeygenTestFixturesRandomSet(keygen.Eddsa, testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	params := tss.NewParameters(tss.Edwards(), tss.NewPeerContext(signPIDs), signPIDs[0], len(signPIDs), testThreshold)
+
+	_, err = NewLocalParty(nil, true, params, "", keys[0], nil, nil)
+	assert.Error(t, err)
+	// u = 0 is the point (0, -1) of order 2
+	P, err := X25519Point(make([]byte, X25519Len))
+	assert.NoError(t, err)
+	_, err = NewLocalParty(P, true, params, "", keys[0], nil, nil)
+	assert.Error(t, err, "the point is of small order")
+	identity, err := crypto.NewECPoint(tss.Edwards(), big.NewInt(0), big.NewInt(1))
+	assert.NoError(t, err)
+	_, err = NewLocalParty(identity, true, params, "", keys[0], nil, nil)
+	assert.Error(t, err, "the point is the identity")
+	_, err = NewLocalParty(crypto.ScalarBaseMult(tss.S256(), big.NewInt(2)), true, params, "", keys[0], nil, nil)
+	assert.Error(t, err, "the point is not on the curve of the key")
+}
+
+func TestCulpritsOfBadShares(t *testing.T) {
+	setUp("info")
+
+	// P0 and P1 both send a wrong share to P2, which must blame both of them
+	_, _, _, tssErr := runECDH(t, keygen.Ecdsa, func(pub *crypto.ECPoint) *crypto.ECPoint {
+		return crypto.ScalarBaseMult(pub.Curve(), big.NewInt(7))
+	}, func(msg tss.ParsedMessage) tss.ParsedMessage {
+		r1msg := msg.Content().(*ECDHRound1Message)
+		Y, _ := r1msg.UnmarshalY()
+		Y, _ = Y.Add(crypto.ScalarBaseMult(Y.Curve(), big.NewInt(1)))
+		y, _ := Y.MarshalJSON()
+		content := &ECDHRound1Message{Y: y, ProofA: r1msg.ProofA, ProofB: r1msg.ProofB, ProofZ: r1msg.ProofZ}
+		meta := tss.MessageRouting{From: msg.GetFrom(), IsBroadcast: true}
+		return tss.NewMessage(meta, content, tss.NewMessageWrapper(meta, content))
+	})
+	if !assert.NotNil(t, tssErr) {
+		return
+	}
+	assert.Equal(t, testThreshold, tssErr.Victim().Index)
+	if assert.Len(t, tssErr.Culprits(), 2) {
+		assert.Equal(t, 0, tssErr.Culprits()[0].Index)
+		assert.Equal(t, 1, tssErr.Culprits()[1].Index)
+	}
+	if assert.Len(t, tssErr.Evidence(), 2) {
+		for _, ev := range tssErr.Evidence() {
+			assert.Equal(t, EvidenceShare, ev.Kind)
+			assert.NoError(t, ev.Verify())
+		}
+	}
+}
+
+func TestMessagesBeforeStart(t *testing.T) {
+	setUp("info")
+
+	keys, signPIDs, err := tKeygen.LoadKeygenTestFixturesRandomSet(keygen.Ecdsa, testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	P := crypto.ScalarBaseMult(tss.S256(), big.NewInt(7))
+
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	parties := make([]tss.Party, 0, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+	endCh := make(chan *crypto.ECPoint, len(signPIDs))
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(tss.S256(), p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+		party, err := NewLocalParty(P, true, params, "", keys[i], outCh, endCh)
+		assert.NoError(t, err)
+		parties = append(parties, party)
+	}
+	route := func(msg tss.Message) {
+		for _, Pj := range parties {
+			if Pj.PartyID().Index == msg.GetFrom().Index {
+				continue
+			}
+			_, err := Pj.Update(msg.(tss.ParsedMessage))
+			assert.Nil(t, err)
+		}
+	}
+
+	// the last party receives the shares of all the others before it starts, and must still finish in Start
+	last := parties[len(parties)-1]
+	for _, Pi := range parties[:len(parties)-1] {
+		assert.Nil(t, Pi.Start())
+		route(<-outCh)
+	}
+	assert.Nil(t, last.Start())
+	route(<-outCh)
+
+	assert.Len(t, endCh, len(parties), "every party outputs x * P")
+	expected := <-endCh
+	for len(endCh) > 0 {
+		assert.True(t, expected.Equals(<-endCh))
+	}
+}
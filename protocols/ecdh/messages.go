@@ -0,0 +1,76 @@
+package ecdh
+
+import (
+	"math/big"
+
+	"github.com/felicityin/mpc-tss/common"
+	"github.com/felicityin/mpc-tss/crypto"
+	"github.com/felicityin/mpc-tss/crypto/dleq"
+	"github.com/felicityin/mpc-tss/tss"
+)
+
+// These messages were generated from Protocol Buffers definitions into ecdh.pb.go
+// The following messages are registered on the Protocol Buffers "wire"
+
+var (
+	// Ensure that ECDH messages implement ValidateBasic
+	_ = []tss.MessageContent{
+		(*ECDHRound1Message)(nil),
+	}
+)
+
+func NewECDHRound1Message(
+	from *tss.PartyID,
+	Y *crypto.ECPoint,
+	proof *dleq.Proof,
+) (tss.ParsedMessage, error) {
+	y, err := Y.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	a, err := proof.A.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	b, err := proof.B.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &ECDHRound1Message{
+		Y:      y,
+		ProofA: a,
+		ProofB: b,
+		ProofZ: proof.Z.Bytes(),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg), nil
+}
+
+func (m *ECDHRound1Message) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetY()) &&
+		common.NonEmptyBytes(m.GetProofA()) &&
+		common.NonEmptyBytes(m.GetProofB()) &&
+		common.NonEmptyBytes(m.GetProofZ())
+}
+
+func (m *ECDHRound1Message) UnmarshalY() (*crypto.ECPoint, error) {
+	return crypto.UnmarshalJSONPoint(m.GetY())
+}
+
+func (m *ECDHRound1Message) UnmarshalProof() (*dleq.Proof, error) {
+	A, err := crypto.UnmarshalJSONPoint(m.GetProofA())
+	if err != nil {
+		return nil, err
+	}
+	B, err := crypto.UnmarshalJSONPoint(m.GetProofB())
+	if err != nil {
+		return nil, err
+	}
+	return &dleq.Proof{A: A, B: B, Z: new(big.Int).SetBytes(m.GetProofZ())}, nil
+}
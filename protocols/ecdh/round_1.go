@@ -0,0 +1,91 @@
+package ecdh
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/felicityin/mpc-tss/common"
+	"github.com/felicityin/mpc-tss/crypto"
+	"github.com/felicityin/mpc-tss/crypto/dleq"
+	"github.com/felicityin/mpc-tss/protocols/cggmp/keygen"
+	"github.com/felicityin/mpc-tss/tss"
+)
+
+func newRound1(
+	isThreshold bool,
+	params *tss.Parameters,
+	key *keygen.LocalPartySaveData,
+	temp *localTempData,
+	out chan<- tss.Message,
+	end chan<- *crypto.ECPoint,
+) tss.Round {
+	return &round1{
+		&base{params, isThreshold, key, temp, out, end, make([]bool, len(params.Parties().IDs())), false, 1},
+	}
+}
+
+func (round *round1) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+
+	round.number = 1
+	round.started = true
+	round.resetOK()
+
+	Pi := round.PartyID()
+	i := Pi.Index
+	common.Logger.Infof("[ecdh] party: %d, round_1 start", i)
+
+	round.temp.ssidNonce = new(big.Int).SetUint64(0)
+	var err error
+	round.temp.ssid, err = round.getSSID()
+	if err != nil {
+		return round.WrapError(err)
+	}
+
+	Yi, proof, err := dleq.NewShare(round.temp.ssid, round.key.PrivXi, round.key.PubXj[i], round.temp.point, round.Rand())
+	if err != nil {
+		return round.WrapError(err)
+	}
+	common.ZeroBigInts(round.key.PrivXi)
+	round.temp.Yj[i] = Yi
+
+	// broadcast
+	common.Logger.Debugf("P[%d]: round_1 broadcast", i)
+	r1msg, err := NewECDHRound1Message(round.PartyID(), Yi, proof)
+	if err != nil {
+		return round.WrapError(err)
+	}
+	round.temp.ecdhRound1Messages[i] = r1msg
+	round.out <- r1msg
+
+	return nil
+}
+
+func (round *round1) Update() (bool, *tss.Error) {
+	ret := true
+	for j, msg := range round.temp.ecdhRound1Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			ret = false
+			continue
+		}
+		round.ok[j] = true
+	}
+	return ret, nil
+}
+
+func (round *round1) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*ECDHRound1Message); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round1) NextRound() tss.Round {
+	round.started = false
+	return &finalization{round}
+}
@@ -0,0 +1,69 @@
+package ecdh
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/felicityin/mpc-tss/common"
+	"github.com/felicityin/mpc-tss/tss"
+)
+
+func (round *finalization) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 2
+	round.started = true
+	round.resetOK()
+
+	Pi := round.PartyID()
+	i := Pi.Index
+
+	common.Logger.Infof("[ecdh] party: %d, round_final start", i)
+
+	var errs []*tss.Error
+	for j := range round.Parties().IDs() {
+		round.ok[j] = true
+		if j == i {
+			continue
+		}
+
+		msg := round.temp.ecdhRound1Messages[j]
+		if err := CheckShare(round.EC(), round.temp.ssid, round.temp.point, round.key.PubXj[j], msg.Content().(*ECDHRound1Message)); err != nil {
+			common.Logger.Errorf("[j: %d] %s", j, err.Error())
+			errs = append(errs, round.WrapEvidence(err, EvidenceShare, round.shareInputs(j), msg))
+			continue
+		}
+		round.temp.Yj[j], _ = msg.Content().(*ECDHRound1Message).UnmarshalY()
+	}
+	if err := tss.JoinErrors(errs...); err != nil {
+		return err
+	}
+
+	S := round.temp.Yj[0]
+	for j := 1; j < len(round.temp.Yj); j++ {
+		var err error
+		if S, err = S.Add(round.temp.Yj[j]); err != nil {
+			return round.WrapError(fmt.Errorf("sum of the shares: %s", err.Error()))
+		}
+	}
+
+	common.Logger.Infof("party: %d, round 2 end", i)
+	round.end <- S
+
+	return nil
+}
+
+func (round *finalization) CanAccept(msg tss.ParsedMessage) bool {
+	// not expecting any incoming messages in this round
+	return false
+}
+
+func (round *finalization) Update() (bool, *tss.Error) {
+	// not expecting any incoming messages in this round
+	return false, nil
+}
+
+func (round *finalization) NextRound() tss.Round {
+	return nil // finished!
+}
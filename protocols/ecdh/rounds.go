@@ -0,0 +1,141 @@
+package ecdh
+
+import (
+	"crypto/elliptic"
+	"errors"
+	"math/big"
+
+	"github.com/felicityin/mpc-tss/common"
+	"github.com/felicityin/mpc-tss/crypto"
+	"github.com/felicityin/mpc-tss/protocols/cggmp/keygen"
+	"github.com/felicityin/mpc-tss/tss"
+)
+
+const (
+	TaskName = "ecdh"
+)
+
+type (
+	base struct {
+		*tss.Parameters
+		isThreshold bool
+		key         *keygen.LocalPartySaveData
+		temp        *localTempData
+		out         chan<- tss.Message
+		end         chan<- *crypto.ECPoint
+		ok          []bool // `ok` tracks parties which have been verified by Update()
+		started     bool
+		number      int
+	}
+	round1 struct {
+		*base
+	}
+	finalization struct {
+		*round1
+	}
+)
+
+var (
+	_ tss.Round = (*round1)(nil)
+	_ tss.Round = (*finalization)(nil)
+)
+
+// ----- //
+
+func (round *base) Params() *tss.Parameters {
+	return round.Parameters
+}
+
+func (round *base) RoundNumber() int {
+	return round.number
+}
+
+// CanProceed is inherited by other rounds
+func (round *base) CanProceed() bool {
+	if !round.started {
+		return false
+	}
+	for _, ok := range round.ok {
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// WaitingFor is called by a Party for reporting back to the caller
+func (round *base) WaitingFor() []*tss.PartyID {
+	Ps := round.Parties().IDs()
+	ids := make([]*tss.PartyID, 0, len(round.ok))
+	for j, ok := range round.ok {
+		if ok {
+			continue
+		}
+		ids = append(ids, Ps[j])
+	}
+	return ids
+}
+
+func (round *base) WrapError(err error, culprits ...*tss.PartyID) *tss.Error {
+	return tss.NewError(err, TaskName, round.number, round.PartyID(), culprits...)
+}
+
+// WrapEvidence returns an error blaming the sender of msgs, with the evidence that the check `kind` failed on them
+func (round *base) WrapEvidence(err error, kind string, inputs interface{}, msgs ...tss.ParsedMessage) *tss.Error {
+	culprit := msgs[0].GetFrom()
+	ev, evErr := tss.NewEvidence(kind, TaskName, round.number, culprit, inputs, msgs...)
+	if evErr != nil {
+		common.Logger.Errorf("failed to create the evidence %s against %s: %s", kind, culprit, evErr)
+		return round.WrapError(err, culprit)
+	}
+	return tss.NewEvidenceError(err, TaskName, round.number, round.PartyID(), []*tss.Evidence{ev})
+}
+
+// ----- //
+
+// `ok` tracks parties which have been verified by Update()
+func (round *base) resetOK() {
+	for j := range round.ok {
+		round.ok[j] = false
+	}
+}
+
+func (round *base) curveName() tss.CurveName {
+	name, _ := tss.GetCurveName(round.EC())
+	return name
+}
+
+// shareInputs returns the inputs of the check of the ECDH share of Pj
+func (round *base) shareInputs(j int) *ShareInputs {
+	return &ShareInputs{
+		Curve: round.curveName(),
+		Index: j,
+		P:     round.temp.point,
+		Ks:    round.Parties().IDs().Keys(),
+		PubXj: round.key.PubXj,
+	}
+}
+
+// get ssid from local params, with the point of the peer so that the proofs are bound to it
+func (round *base) getSSID() ([]byte, error) {
+	ssid, err := computeSSID(round.EC(), round.Parties().IDs().Keys(), round.key.PubXj, round.temp.point, round.number, round.temp.ssidNonce)
+	if err != nil {
+		return nil, round.WrapError(err, round.PartyID())
+	}
+	return ssid, nil
+}
+
+func computeSSID(ec elliptic.Curve, ks []*big.Int, pubXj []*crypto.ECPoint, P *crypto.ECPoint, number int, nonce *big.Int) ([]byte, error) {
+	ssidList := []*big.Int{ec.Params().P, ec.Params().N, ec.Params().Gx, ec.Params().Gy} // ec curve
+	ssidList = append(ssidList, ks...)                                                   // parties
+	BigXjList, err := crypto.FlattenECPoints(pubXj)
+	if err != nil {
+		return nil, errors.New("read BigXj failed")
+	}
+	ssidList = append(ssidList, BigXjList...)              // BigXj
+	ssidList = append(ssidList, P.X(), P.Y())              // P
+	ssidList = append(ssidList, big.NewInt(int64(number))) // round number
+	ssidList = append(ssidList, nonce)
+	ssid := common.SHA512_256i(ssidList...).Bytes()
+	return ssid, nil
+}
@@ -0,0 +1,71 @@
+package ecdh
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	edwards "github.com/decred/dcrd/dcrec/edwards/v2"
+
+	"github.com/felicityin/mpc-tss/crypto"
+	"github.com/felicityin/mpc-tss/tss"
+)
+
+// X25519Len is the byte length of the X25519 public keys and shared secrets of RFC 7748
+const X25519Len = 32
+
+// X25519Point returns the ed25519 point of the X25519 public key u, the little-endian u-coordinate of RFC 7748,
+// through the birational map y = (u - 1) / (u + 1) of RFC 7748 section 4.1.
+// Of the two points of u, it returns the one with the even x: x * P and x * -P have the same u-coordinate.
+func X25519Point(u []byte) (*crypto.ECPoint, error) {
+	if len(u) != X25519Len {
+		return nil, fmt.Errorf("the X25519 public key has %d bytes, expected %d", len(u), X25519Len)
+	}
+	p := tss.Edwards().Params().P
+	// the most significant bit is masked, as X25519 does
+	be := reverse(u)
+	be[0] &= 0x7f
+	uInt := new(big.Int).Mod(new(big.Int).SetBytes(be), p)
+
+	den := new(big.Int).Add(uInt, big.NewInt(1))
+	den.Mod(den, p)
+	if den.Sign() == 0 {
+		return nil, errors.New("the X25519 public key has no ed25519 point")
+	}
+	y := new(big.Int).Sub(uInt, big.NewInt(1))
+	y.Mul(y, new(big.Int).ModInverse(den, p))
+	y.Mod(y, p)
+
+	// the ed25519 encoding is the little-endian y, with the sign of x in the most significant bit
+	pk, err := edwards.ParsePubKey(reverse(y.FillBytes(make([]byte, X25519Len))))
+	if err != nil {
+		return nil, fmt.Errorf("the X25519 public key has no ed25519 point: %s", err.Error())
+	}
+	return crypto.NewECPoint(tss.Edwards(), pk.X, pk.Y)
+}
+
+// X25519PublicKey returns the little-endian u-coordinate u = (1 + y) / (1 - y) of the ed25519 point:
+// the X25519 public key of an ed25519 key, or the X25519 shared secret of the output of the ECDH.
+func X25519PublicKey(P *crypto.ECPoint) ([]byte, error) {
+	if P == nil || !P.ValidateBasic() || !tss.SameCurve(P.Curve(), tss.Edwards()) {
+		return nil, errors.New("the point is not an ed25519 point")
+	}
+	p := tss.Edwards().Params().P
+	den := new(big.Int).Sub(big.NewInt(1), P.Y())
+	den.Mod(den, p)
+	if den.Sign() == 0 {
+		return nil, errors.New("the point is the identity")
+	}
+	u := new(big.Int).Add(big.NewInt(1), P.Y())
+	u.Mul(u, new(big.Int).ModInverse(den, p))
+	u.Mod(u, p)
+	return reverse(u.FillBytes(make([]byte, X25519Len))), nil
+}
+
+func reverse(b []byte) []byte {
+	r := make([]byte, len(b))
+	for i := range b {
+		r[len(b)-1-i] = b[i]
+	}
+	return r
+}
@@ -0,0 +1,156 @@
+// Package ecies implements the ECIES encryption to a threshold key, and its threshold decryption.
+//
+// Anyone encrypts to the group key with Encrypt, which needs no party: the ephemeral key r gives R = r * G and
+// the shared point S = r * X, the key and the nonce of AES-256-GCM are derived from R and S with HKDF-SHA256,
+// and the ciphertext is the encoding of R followed by the sealed plaintext.
+// The signers of the key decrypt it together with NewLocalParty, which runs the threshold ECDH of protocols/ecdh on R:
+// each signer proves its decryption share against its public key share, and the invalid shares are blamed with evidence.
+//
+// R is the 33-byte compressed point of SEC 1 on secp256k1 and P-256, and the 32-byte encoding of RFC 8032 on ed25519.
+package ecies
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	edwards "github.com/decred/dcrd/dcrec/edwards/v2"
+	"golang.org/x/crypto/hkdf"
+
+	"github.com/felicityin/mpc-tss/common"
+	"github.com/felicityin/mpc-tss/crypto"
+	"github.com/felicityin/mpc-tss/tss"
+)
+
+const (
+	keyLen   = 32 // AES-256
+	nonceLen = 12 // the standard nonce of GCM
+	tagLen   = 16
+)
+
+// Encrypt encrypts the plaintext to the public key, the group key or one of its child keys.
+// The info is bound to the ciphertext: the decryption needs the same info, e.g. the purpose of the plaintext.
+func Encrypt(rand io.Reader, pubkey *crypto.ECPoint, plaintext, info []byte) ([]byte, error) {
+	if pubkey == nil || !pubkey.ValidateBasic() {
+		return nil, errors.New("the public key is missing or not on its curve")
+	}
+	ec := pubkey.Curve()
+	r := common.GetRandomPositiveInt(rand, ec.Params().N)
+	R := crypto.ScalarBaseMult(ec, r)
+	S := pubkey.ScalarMult(r)
+	common.ZeroBigInts(r)
+
+	Rbz := encodePoint(R)
+	aead, nonce, err := newAEAD(Rbz, S, info)
+	if err != nil {
+		return nil, err
+	}
+	return aead.Seal(Rbz, nonce, plaintext, nil), nil
+}
+
+// EphemeralKey returns the point R of the ciphertext, on which the signers run the ECDH
+func EphemeralKey(ec elliptic.Curve, ciphertext []byte) (*crypto.ECPoint, error) {
+	R, _, err := split(ec, ciphertext)
+	return R, err
+}
+
+// Decrypt decrypts the ciphertext with the shared point S = x * R that the signers of the key x agreed on
+func Decrypt(ciphertext, info []byte, S *crypto.ECPoint) ([]byte, error) {
+	if S == nil || !S.ValidateBasic() {
+		return nil, errors.New("the shared point is missing or not on its curve")
+	}
+	R, sealed, err := split(S.Curve(), ciphertext)
+	if err != nil {
+		return nil, err
+	}
+	Rbz := encodePoint(R)
+	aead, nonce, err := newAEAD(Rbz, S, info)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := aead.Open(nil, nonce, sealed, nil)
+	if err != nil {
+		return nil, errors.New("the ciphertext does not decrypt with the key and the info")
+	}
+	return plaintext, nil
+}
+
+// newAEAD derives the key and the nonce of AES-256-GCM from R and S with HKDF-SHA256.
+// A key encrypts a single plaintext, as R is fresh for each encryption, so that its nonce can be derived with it.
+func newAEAD(Rbz []byte, S *crypto.ECPoint, info []byte) (cipher.AEAD, []byte, error) {
+	Sbz := encodePoint(S)
+	okm := make([]byte, keyLen+nonceLen)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, Sbz, Rbz, info), okm); err != nil {
+		return nil, nil, err
+	}
+	defer common.ZeroBytes(Sbz, okm)
+
+	block, err := aes.NewCipher(okm[:keyLen])
+	if err != nil {
+		return nil, nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, nil, err
+	}
+	return aead, append([]byte{}, okm[keyLen:]...), nil
+}
+
+// split returns the point R of the ciphertext and the sealed plaintext
+func split(ec elliptic.Curve, ciphertext []byte) (*crypto.ECPoint, []byte, error) {
+	n := pointLen(ec)
+	if len(ciphertext) < n+tagLen {
+		return nil, nil, errors.New("the ciphertext is too short")
+	}
+	R, err := parsePoint(ec, ciphertext[:n])
+	if err != nil {
+		return nil, nil, fmt.Errorf("the ephemeral key of the ciphertext: %s", err.Error())
+	}
+	return R, ciphertext[n:], nil
+}
+
+func pointLen(ec elliptic.Curve) int {
+	if tss.SameCurve(ec, tss.Edwards()) {
+		return 32
+	}
+	return 1 + (ec.Params().BitSize+7)/8
+}
+
+func encodePoint(P *crypto.ECPoint) []byte {
+	ec := P.Curve()
+	if tss.SameCurve(ec, tss.Edwards()) {
+		return edwards.NewPublicKey(P.X(), P.Y()).Serialize()
+	}
+	return elliptic.MarshalCompressed(ec, P.X(), P.Y())
+}
+
+func parsePoint(ec elliptic.Curve, bz []byte) (*crypto.ECPoint, error) {
+	switch {
+	case tss.SameCurve(ec, tss.Edwards()):
+		pk, err := edwards.ParsePubKey(bz)
+		if err != nil {
+			return nil, err
+		}
+		return crypto.NewECPoint(ec, pk.X, pk.Y)
+
+	case tss.SameCurve(ec, tss.S256()):
+		// elliptic.UnmarshalCompressed is for the curves with a = -3
+		pk, err := btcec.ParsePubKey(bz)
+		if err != nil {
+			return nil, err
+		}
+		return crypto.NewECPoint(ec, pk.X(), pk.Y())
+
+	default:
+		x, y := elliptic.UnmarshalCompressed(ec, bz)
+		if x == nil {
+			return nil, errors.New("invalid compressed point")
+		}
+		return crypto.NewECPoint(ec, x, y)
+	}
+}
@@ -0,0 +1,43 @@
+package ecies
+
+import (
+	"crypto/elliptic"
+	"crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/felicityin/mpc-tss/common"
+	"github.com/felicityin/mpc-tss/crypto"
+	"github.com/felicityin/mpc-tss/tss"
+)
+
+func TestEncryptDecrypt(t *testing.T) {
+	msg := []byte("hello")
+	info := []byte("info")
+	for _, ec := range []elliptic.Curve{tss.S256(), tss.P256(), tss.Edwards()} {
+		x := common.GetRandomPositiveInt(rand.Reader, ec.Params().N)
+		X := crypto.ScalarBaseMult(ec, x)
+
+		ciphertext, err := Encrypt(rand.Reader, X, msg, info)
+		assert.NoError(t, err)
+		assert.Len(t, ciphertext, pointLen(ec)+len(msg)+tagLen)
+
+		R, err := EphemeralKey(ec, ciphertext)
+		assert.NoError(t, err)
+		plaintext, err := Decrypt(ciphertext, info, R.ScalarMult(x))
+		assert.NoError(t, err)
+		assert.Equal(t, msg, plaintext)
+
+		_, err = Decrypt(ciphertext, nil, R.ScalarMult(x))
+		assert.Error(t, err, "the info is bound to the ciphertext")
+		_, err = Decrypt(ciphertext, info, R)
+		assert.Error(t, err, "the shared point of another key")
+		tampered := append([]byte{}, ciphertext...)
+		tampered[len(tampered)-1] ^= 1
+		_, err = Decrypt(tampered, info, R.ScalarMult(x))
+		assert.Error(t, err, "the ciphertext is authenticated")
+		_, err = EphemeralKey(ec, ciphertext[:pointLen(ec)+tagLen-1])
+		assert.Error(t, err, "the ciphertext is too short")
+	}
+}
@@ -0,0 +1,107 @@
+package ecies
+
+import (
+	"fmt"
+
+	"github.com/felicityin/mpc-tss/crypto"
+	"github.com/felicityin/mpc-tss/protocols/cggmp/keygen"
+	"github.com/felicityin/mpc-tss/protocols/ecdh"
+	"github.com/felicityin/mpc-tss/tss"
+)
+
+const (
+	TaskName = "ecies-decrypt"
+)
+
+// Implements Party
+// Implements Stringer
+var _ tss.Party = (*LocalParty)(nil)
+var _ fmt.Stringer = (*LocalParty)(nil)
+
+type (
+	// LocalParty decrypts a ciphertext of Encrypt with the other signers of the key.
+	//
+	// It runs the ECDH party on the ephemeral key R of the ciphertext, whose messages it sends as they are,
+	// and decrypts the ciphertext once the ECDH party has output x * R.
+	LocalParty struct {
+		tss.Party
+
+		ciphertext []byte
+		info       []byte
+
+		shared chan *crypto.ECPoint
+		end    chan<- []byte
+	}
+)
+
+// NewLocalParty returns a party that outputs the plaintext of the ciphertext on end.
+// The key is that of the path, or the master key if path is empty, and info is that of the encryption.
+func NewLocalParty(
+	ciphertext []byte,
+	info []byte,
+	isThreshold bool,
+	params *tss.Parameters,
+	path string,
+	key keygen.LocalPartySaveData,
+	out chan<- tss.Message,
+	end chan<- []byte,
+) (tss.Party, error) {
+	R, err := EphemeralKey(params.EC(), ciphertext)
+	if err != nil {
+		return nil, err
+	}
+	// the ECDH party outputs once, in the Start or Update call that finishes it
+	shared := make(chan *crypto.ECPoint, 1)
+	party, err := ecdh.NewLocalParty(R, isThreshold, params, path, key, out, shared)
+	if err != nil {
+		return nil, err
+	}
+	return &LocalParty{
+		Party:      party,
+		ciphertext: ciphertext,
+		info:       info,
+		shared:     shared,
+		end:        end,
+	}, nil
+}
+
+func (p *LocalParty) Start() *tss.Error {
+	if err := p.Party.Start(); err != nil {
+		return err
+	}
+	return p.decrypt()
+}
+
+func (p *LocalParty) Update(msg tss.ParsedMessage) (ok bool, err *tss.Error) {
+	if ok, err = p.Party.Update(msg); err != nil {
+		return ok, err
+	}
+	return ok, p.decrypt()
+}
+
+func (p *LocalParty) UpdateFromBytes(wireBytes []byte, from *tss.PartyID, isBroadcast bool) (bool, *tss.Error) {
+	msg, err := tss.ParseWireMessage(wireBytes, from, isBroadcast)
+	if err != nil {
+		return false, p.WrapError(err)
+	}
+	return p.Update(msg)
+}
+
+func (p *LocalParty) WrapError(err error, culprits ...*tss.PartyID) *tss.Error {
+	return tss.NewError(err, TaskName, -1, p.PartyID(), culprits...)
+}
+
+// decrypt decrypts the ciphertext if the ECDH party has output x * R.
+// The shares of x * R were all proven, so a ciphertext that does not decrypt was not encrypted to the key with the info.
+func (p *LocalParty) decrypt() *tss.Error {
+	select {
+	case S := <-p.shared:
+		plaintext, err := Decrypt(p.ciphertext, p.info, S)
+		if err != nil {
+			return p.WrapError(err)
+		}
+		p.end <- plaintext
+	default:
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+package ecies
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/ipfs/go-log"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/felicityin/mpc-tss/crypto"
+	"github.com/felicityin/mpc-tss/protocols/cggmp/keygen"
+	tKeygen "github.com/felicityin/mpc-tss/protocols/cggmp/keygen/threshold"
+	"github.com/felicityin/mpc-tss/protocols/cggmp/test"
+	"github.com/felicityin/mpc-tss/protocols/ecdh"
+	"github.com/felicityin/mpc-tss/protocols/utils"
+	"github.com/felicityin/mpc-tss/tss"
+)
+
+const (
+	testParticipants = 3
+	testThreshold    = 2
+	testPath         = "0/1/2/2/10"
+)
+
+func setUp(level string) {
+	if err := log.SetLogLevel("tss-lib", level); err != nil {
+		panic(err)
+	}
+}
+
+// decrypt decrypts the ciphertext with the threshold fixtures of the kind, with the key of testPath.
+// encrypt encrypts to the child key of the fixtures, and tamper, if not nil, changes the messages to the last party.
+func decrypt(
+	t *testing.T,
+	kind int,
+	encrypt func(childPubkey *crypto.ECPoint) []byte,
+	info []byte,
+	tamper func(msg tss.ParsedMessage) tss.ParsedMessage,
+) (plaintexts [][]byte, tssErr *tss.Error) {
+	keys, signPIDs, err := tKeygen.LoadKeygenTestFixturesRandomSet(kind, testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	_, extendedKey, err := utils.DerivingKeyFromPath(keys[0], testPath)
+	assert.NoError(t, err)
+	ciphertext := encrypt(extendedKey.PublicKey)
+
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	parties := make([]tss.Party, 0, len(signPIDs))
+
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+	endCh := make(chan []byte, len(signPIDs))
+
+	updater := test.SharedPartyUpdater
+
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(tKeygen.TestCurve(kind), p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+		party, err := NewLocalParty(ciphertext, info, true, params, testPath, keys[i], outCh, endCh)
+		if !assert.NoError(t, err) {
+			return nil, nil
+		}
+		parties = append(parties, party)
+
+		go func(P tss.Party) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(party)
+	}
+
+	victim := len(signPIDs) - 1
+	for len(plaintexts) < len(signPIDs) {
+		select {
+		case err := <-errCh:
+			return plaintexts, err
+
+		case msg := <-outCh:
+			for _, Pj := range parties {
+				if Pj.PartyID().Index == msg.GetFrom().Index {
+					continue
+				}
+				if tamper != nil && Pj.PartyID().Index == victim {
+					go updater(Pj, tamper(msg.(tss.ParsedMessage)), errCh)
+					continue
+				}
+				go updater(Pj, msg, errCh)
+			}
+
+		case plaintext := <-endCh:
+			plaintexts = append(plaintexts, plaintext)
+		}
+	}
+	return plaintexts, nil
+}
+
+func TestE2EThresholdConcurrent(t *testing.T) {
+	setUp("info")
+
+	secret := []byte("the backup of the customer")
+	info := []byte("backup/v1")
+	for _, kind := range []int{keygen.Ecdsa, keygen.EcdsaP256, keygen.Eddsa} {
+		plaintexts, err := decrypt(t, kind, func(childPubkey *crypto.ECPoint) []byte {
+			ciphertext, err := Encrypt(rand.Reader, childPubkey, secret, info)
+			assert.NoError(t, err)
+			return ciphertext
+		}, info, nil)
+		if !assert.Nil(t, err) {
+			continue
+		}
+		for _, plaintext := range plaintexts {
+			assert.Equal(t, secret, plaintext)
+		}
+	}
+}
+
+func TestWrongInfo(t *testing.T) {
+	setUp("info")
+
+	_, err := decrypt(t, keygen.Ecdsa, func(childPubkey *crypto.ECPoint) []byte {
+		ciphertext, err := Encrypt(rand.Reader, childPubkey, []byte("secret"), []byte("backup/v1"))
+		assert.NoError(t, err)
+		return ciphertext
+	}, []byte("backup/v2"), nil)
+	if assert.NotNil(t, err, "the info is bound to the ciphertext") {
+		assert.Empty(t, err.Culprits(), "no party is to blame")
+	}
+}
+
+func TestCulpritsOfBadShares(t *testing.T) {
+	setUp("info")
+
+	// P0 and P1 both send a wrong decryption share to P2, which must blame both of them
+	_, tssErr := decrypt(t, keygen.Eddsa, func(childPubkey *crypto.ECPoint) []byte {
+		ciphertext, err := Encrypt(rand.Reader, childPubkey, []byte("secret"), nil)
+		assert.NoError(t, err)
+		return ciphertext
+	}, nil, func(msg tss.ParsedMessage) tss.ParsedMessage {
+		r1msg := msg.Content().(*ecdh.ECDHRound1Message)
+		content := &ecdh.ECDHRound1Message{Y: r1msg.ProofB, ProofA: r1msg.ProofA, ProofB: r1msg.ProofB, ProofZ: r1msg.ProofZ}
+		meta := tss.MessageRouting{From: msg.GetFrom(), IsBroadcast: true}
+		return tss.NewMessage(meta, content, tss.NewMessageWrapper(meta, content))
+	})
+	if !assert.NotNil(t, tssErr) {
+		return
+	}
+	if assert.Len(t, tssErr.Culprits(), 2) {
+		assert.Equal(t, 0, tssErr.Culprits()[0].Index)
+		assert.Equal(t, 1, tssErr.Culprits()[1].Index)
+	}
+	for _, ev := range tssErr.Evidence() {
+		assert.Equal(t, ecdh.EvidenceShare, ev.Kind)
+		assert.NoError(t, ev.Verify())
+	}
+}
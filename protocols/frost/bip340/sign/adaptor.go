@@ -0,0 +1,109 @@
+package sign
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+
+	"github.com/felicityin/mpc-tss/common"
+	"github.com/felicityin/mpc-tss/crypto"
+	"github.com/felicityin/mpc-tss/tss"
+)
+
+// PreSignature is a BIP-340 adaptor signature: the signers commit to the nonce point R + T, negated with their nonces
+// if it has an odd Y, so that s' = ±k + c * x becomes the signature (R + T, s' ± t) with the discrete logarithm t of the adaptor point T,
+// and gives away t to whoever has both.
+type PreSignature struct {
+	R *crypto.ECPoint // R + T, before its negation to an even Y
+	T *crypto.ECPoint
+	S *big.Int
+	M []byte
+}
+
+// ValidateAdaptor checks that the adaptor point T is a secp256k1 point
+func ValidateAdaptor(T *crypto.ECPoint) error {
+	if T == nil || !T.ValidateBasic() || !tss.SameCurve(T.Curve(), btcec.S256()) {
+		return errors.New("the adaptor point is missing or not a secp256k1 point")
+	}
+	return nil
+}
+
+// Verify checks the pre-signature against the public key that signed, as an x-only key:
+// s' * G ± T = ±R + c * P with c = tagged_hash("BIP0340/challenge", bytes(R) || bytes(P) || m), negated if R has an odd Y
+func (pre *PreSignature) Verify(pubkey *crypto.ECPoint) error {
+	if pre == nil || pre.R == nil || pre.T == nil || pre.S == nil || pubkey == nil || !tss.SameCurve(pubkey.Curve(), pre.R.Curve()) {
+		return errors.New("the pre-signature or the public key is missing")
+	}
+	ec := pubkey.Curve()
+	if pre.S.Sign() < 0 || pre.S.Cmp(ec.Params().N) >= 0 {
+		return errors.New("the pre-signature has s' out of range")
+	}
+	if !HasEvenY(pubkey) {
+		pubkey = Negate(pubkey)
+	}
+	R, T := pre.R, pre.T
+	if !HasEvenY(R) {
+		R, T = Negate(R), Negate(T)
+	}
+	c := ComputeChallenge(R, pubkey, pre.M)
+	left, err := crypto.ScalarBaseMult(ec, pre.S).Add(T)
+	if err != nil {
+		return err
+	}
+	right, err := pubkey.ScalarMult(c).Add(R)
+	if err != nil {
+		return err
+	}
+	if !left.Equals(right) {
+		return errors.New("the pre-signature does not verify against the public key")
+	}
+	return nil
+}
+
+// Complete returns the 64-byte BIP-340 signature of the pre-signature with the discrete logarithm t of its adaptor point
+func (pre *PreSignature) Complete(t *big.Int) (*common.SignatureData, error) {
+	if pre == nil || pre.R == nil || pre.T == nil || pre.S == nil || t == nil {
+		return nil, errors.New("the pre-signature or t is missing")
+	}
+	ec := pre.T.Curve()
+	if !crypto.ScalarBaseMult(ec, t).Equals(pre.T) {
+		return nil, errors.New("t is not the discrete logarithm of the adaptor point")
+	}
+	modN := common.ModInt(ec.Params().N)
+	var s *big.Int
+	if HasEvenY(pre.R) {
+		s = modN.Add(pre.S, t)
+	} else {
+		s = modN.Sub(pre.S, t)
+	}
+	sBytes := make([]byte, 32)
+	s.FillBytes(sBytes)
+	rBytes := XOnly(pre.R)
+	return &common.SignatureData{
+		Signature: append(append([]byte{}, rBytes...), sBytes...),
+		R:         rBytes,
+		S:         sBytes,
+		M:         pre.M,
+	}, nil
+}
+
+// Extract returns the discrete logarithm t of the adaptor point from the signature that completed the pre-signature
+func (pre *PreSignature) Extract(sig *common.SignatureData) (*big.Int, error) {
+	if pre == nil || pre.R == nil || pre.T == nil || pre.S == nil || sig == nil {
+		return nil, errors.New("the pre-signature or the signature is missing")
+	}
+	ec := pre.T.Curve()
+	if new(big.Int).SetBytes(sig.R).Cmp(pre.R.X()) != 0 {
+		return nil, errors.New("the signature is not of the nonce point of the pre-signature")
+	}
+	modN := common.ModInt(ec.Params().N)
+	t := modN.Sub(new(big.Int).SetBytes(sig.S), pre.S)
+	if !HasEvenY(pre.R) {
+		t = modN.Sub(big.NewInt(0), t)
+	}
+	if !crypto.ScalarBaseMult(ec, t).Equals(pre.T) {
+		return nil, errors.New("the signature does not complete the pre-signature")
+	}
+	return t, nil
+}
@@ -0,0 +1,198 @@
+package sign
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+
+	"github.com/felicityin/mpc-tss/common"
+	"github.com/felicityin/mpc-tss/crypto"
+	"github.com/felicityin/mpc-tss/protocols/cggmp/keygen"
+	frost "github.com/felicityin/mpc-tss/protocols/frost/sign"
+	"github.com/felicityin/mpc-tss/tss"
+)
+
+// Tags of the tagged hashes of the signing
+const (
+	// TagChallenge is the tag of the challenge of BIP-340
+	TagChallenge = "BIP0340/challenge"
+	// TagBindingFactor is the tag of the binding factors of the FROST signers
+	TagBindingFactor = "FROST/BIP340/rho"
+)
+
+// MessageLen is the byte length of the messages signed with BIP-340
+const MessageLen = 32
+
+// TaggedHash returns the tagged hash of BIP-340: SHA256(SHA256(tag) || SHA256(tag) || msgs)
+func TaggedHash(tag string, msgs ...[]byte) []byte {
+	tagHash := sha256.Sum256([]byte(tag))
+	h := sha256.New()
+	h.Write(tagHash[:])
+	h.Write(tagHash[:])
+	for _, msg := range msgs {
+		h.Write(msg)
+	}
+	return h.Sum(nil)
+}
+
+// HasEvenY tells whether the point has an even Y coordinate, as the x-only keys and nonces of BIP-340
+func HasEvenY(p *crypto.ECPoint) bool {
+	return p.Y().Bit(0) == 0
+}
+
+// Negate returns -p
+func Negate(p *crypto.ECPoint) *crypto.ECPoint {
+	y := new(big.Int).Sub(p.Curve().Params().P, p.Y())
+	return crypto.NewECPointNoCurveCheck(p.Curve(), new(big.Int).Set(p.X()), y)
+}
+
+// XOnly returns the 32-byte encoding of the X coordinate of the point
+func XOnly(p *crypto.ECPoint) []byte {
+	x := make([]byte, 32)
+	p.X().FillBytes(x)
+	return x
+}
+
+// NormalizeKey negates the key prepared for the signing if the public key has an odd Y,
+// so that the parties sign for the x-only public key of BIP-340, which has an even Y.
+func NormalizeKey(key *keygen.LocalPartySaveData) {
+	if HasEvenY(key.Pubkey) {
+		return
+	}
+	q := key.Pubkey.Curve().Params().N
+	xi := key.PrivXi
+	key.PrivXi = new(big.Int).Sub(q, xi)
+	key.PrivXi.Mod(key.PrivXi, q)
+	common.ZeroBigInts(xi)
+	for j, Xj := range key.PubXj {
+		key.PubXj[j] = Negate(Xj)
+	}
+	key.Pubkey = Negate(key.Pubkey)
+}
+
+// EncodeGroupCommitmentList encodes the commitments of the signers, in their order: identifier || D || E for each signer,
+// with the identifiers on 32 bytes and the points compressed
+func EncodeGroupCommitmentList(commitments []*frost.SigningCommitment) []byte {
+	encoded := make([]byte, 0, len(commitments)*(32+33+33))
+	for _, commitment := range commitments {
+		id := make([]byte, 32)
+		commitment.Identifier.FillBytes(id)
+		encoded = append(encoded, id...)
+		encoded = append(encoded, compressed(commitment.D)...)
+		encoded = append(encoded, compressed(commitment.E)...)
+	}
+	return encoded
+}
+
+// ComputeBindingFactors returns the binding factor rho_j of every signer, in the order of the commitments:
+// rho_j = tagged_hash("FROST/BIP340/rho", bytes(P) || m || SHA256(commitment list) || identifier_j)
+func ComputeBindingFactors(pubkey *crypto.ECPoint, commitments []*frost.SigningCommitment, msg []byte) []*big.Int {
+	q := pubkey.Curve().Params().N
+	commitmentHash := sha256.Sum256(EncodeGroupCommitmentList(commitments))
+
+	rhos := make([]*big.Int, len(commitments))
+	for j, commitment := range commitments {
+		id := make([]byte, 32)
+		commitment.Identifier.FillBytes(id)
+		rho := new(big.Int).SetBytes(TaggedHash(TagBindingFactor, XOnly(pubkey), msg, commitmentHash[:], id))
+		rhos[j] = rho.Mod(rho, q)
+	}
+	return rhos
+}
+
+// ComputeChallenge returns the challenge of BIP-340: tagged_hash("BIP0340/challenge", bytes(R) || bytes(P) || m)
+func ComputeChallenge(R, pubkey *crypto.ECPoint, msg []byte) *big.Int {
+	c := new(big.Int).SetBytes(TaggedHash(TagChallenge, XOnly(R), XOnly(pubkey), msg))
+	return c.Mod(c, pubkey.Curve().Params().N)
+}
+
+// GroupNonce is the nonce of a signing computed from the commitments of the signers
+type GroupNonce struct {
+	BindingFactors []*big.Int
+	// Commitments are the commitments Rj of the signers, negated together with R
+	Commitments []*crypto.ECPoint
+	// R is the group commitment, or R + T with an adaptor point T, negated to an even Y
+	R *crypto.ECPoint
+	// RT is R + T with an adaptor point T before its negation, nil without it
+	RT *crypto.ECPoint
+	// Negated is true if R had an odd Y, the nonces k_i of the signers are negated then
+	Negated   bool
+	Challenge *big.Int
+}
+
+// ComputeGroupNonce returns the nonce of the signing of msg for the commitments of the signers and the adaptor point T, which may be nil
+func ComputeGroupNonce(pubkey, T *crypto.ECPoint, commitments []*frost.SigningCommitment, msg []byte) (*GroupNonce, error) {
+	rhos := ComputeBindingFactors(pubkey, commitments, msg)
+	Rs, err := frost.ComputeCommitments(commitments, rhos)
+	if err != nil {
+		return nil, err
+	}
+	R, err := frost.ComputeGroupCommitment(Rs)
+	if err != nil {
+		return nil, err
+	}
+	nonce := &GroupNonce{BindingFactors: rhos, Commitments: Rs}
+	if T != nil {
+		if R, err = R.Add(T); err != nil {
+			return nil, err
+		}
+		nonce.RT = R
+	}
+	// the nonce of BIP-340 has an even Y
+	if !HasEvenY(R) {
+		nonce.Negated = true
+		R = Negate(R)
+		for j, Rj := range Rs {
+			Rs[j] = Negate(Rj)
+		}
+	}
+	nonce.R = R
+	nonce.Challenge = ComputeChallenge(R, pubkey, msg)
+	return nonce, nil
+}
+
+// Verify checks the 64-byte BIP-340 signature of the 32-byte message for the x-only public key
+func Verify(pubkey *crypto.ECPoint, msg, signature []byte) error {
+	pk, err := schnorr.ParsePubKey(XOnly(pubkey))
+	if err != nil {
+		return fmt.Errorf("parse public key err: %s", err.Error())
+	}
+	sig, err := schnorr.ParseSignature(signature)
+	if err != nil {
+		return fmt.Errorf("parse signature err: %s", err.Error())
+	}
+	if !sig.Verify(msg, pk) {
+		return errors.New("signature verification failed")
+	}
+	return nil
+}
+
+// MessageBytes returns the message to sign on 32 bytes
+func MessageBytes(m *big.Int) ([]byte, error) {
+	if m == nil || m.Sign() < 0 || m.BitLen() > 8*MessageLen {
+		return nil, fmt.Errorf("the message must be a %d-byte digest", MessageLen)
+	}
+	mBytes := make([]byte, MessageLen)
+	m.FillBytes(mBytes)
+	return mBytes, nil
+}
+
+// CheckCurve checks that the parameters of the signing are on secp256k1
+func CheckCurve(params *tss.Parameters) error {
+	if !tss.SameCurve(params.EC(), btcec.S256()) {
+		return errors.New("BIP-340 signatures are on secp256k1")
+	}
+	return nil
+}
+
+func compressed(p *crypto.ECPoint) []byte {
+	prefix := byte(0x02)
+	if !HasEvenY(p) {
+		prefix = 0x03
+	}
+	return append([]byte{prefix}, XOnly(p)...)
+}
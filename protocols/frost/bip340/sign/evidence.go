@@ -0,0 +1,95 @@
+package sign
+
+import (
+	"crypto/elliptic"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/felicityin/mpc-tss/crypto"
+	frost "github.com/felicityin/mpc-tss/protocols/frost/sign"
+	"github.com/felicityin/mpc-tss/tss"
+)
+
+// EvidenceShare is the kind of the evidence against a party whose signature share is invalid
+const EvidenceShare = "frost/bip340/sign/share"
+
+func init() {
+	tss.RegisterEvidenceCheck(EvidenceShare, checkShareEvidence)
+}
+
+// ShareInputs are the public inputs of the check of the signature share z_j of Pj: the message, the adaptor point
+// and the public data of the key prepared for the signing, whose public key has an even Y, which are fixed before the signing.
+// PubXj are the public key shares of the signers weighted by their Lagrange coefficients, and Ks their identifiers.
+// The commitment Rj of Pj and the challenge are computed again from the round 1 messages of all the signers.
+type ShareInputs struct {
+	Curve   tss.CurveName     `json:"curve"`
+	Index   int               `json:"index"`
+	M       []byte            `json:"m"`
+	Adaptor *crypto.ECPoint   `json:"adaptor,omitempty"`
+	Pubkey  *crypto.ECPoint   `json:"pubkey"`
+	Ks      []*big.Int        `json:"ks"`
+	PubXj   []*crypto.ECPoint `json:"pub_xj"`
+}
+
+// Commitments returns the commitment Rj of every signer and the challenge of the signing, with the nonce of an even Y,
+// from the signing commitments of all the signers in the order of the parties
+func (in *ShareInputs) Commitments(commitments []*frost.SigningCommitment) ([]*crypto.ECPoint, *big.Int, error) {
+	if len(commitments) != len(in.PubXj) {
+		return nil, nil, fmt.Errorf("expected the commitments of %d signers", len(in.PubXj))
+	}
+	nonce, err := ComputeGroupNonce(in.Pubkey, in.Adaptor, commitments, in.M)
+	if err != nil {
+		return nil, nil, err
+	}
+	return nonce.Commitments, nonce.Challenge, nil
+}
+
+// Validate checks that the inputs are about the culprit and that their points are on the curve
+func (in *ShareInputs) Validate(ec elliptic.Curve, culprit *tss.PartyID) error {
+	if in.Index != culprit.Index || in.Index < 0 || in.Index >= len(in.PubXj) {
+		return fmt.Errorf("the inputs are about party %d, not the culprit", in.Index)
+	}
+	if len(in.M) != MessageLen || in.Pubkey == nil || !tss.SameCurve(in.Pubkey.Curve(), ec) {
+		return errors.New("the message or the public key are missing")
+	}
+	if in.Adaptor != nil && !tss.SameCurve(in.Adaptor.Curve(), ec) {
+		return errors.New("the adaptor point is not on the curve")
+	}
+	if len(in.Ks) != len(in.PubXj) {
+		return errors.New("expected an identifier for every key share")
+	}
+	for j, Xj := range in.PubXj {
+		if Xj == nil || !tss.SameCurve(Xj.Curve(), ec) || in.Ks[j] == nil {
+			return fmt.Errorf("the key share or the identifier of party %d are missing", j)
+		}
+	}
+	return nil
+}
+
+func checkShareEvidence(ev *tss.Evidence, msgs []tss.ParsedMessage) (failure error, err error) {
+	in := new(ShareInputs)
+	if err := ev.UnmarshalInputs(in); err != nil {
+		return nil, err
+	}
+	ec, ok := tss.GetCurveByName(in.Curve)
+	if !ok {
+		return nil, fmt.Errorf("unknown curve %s", in.Curve)
+	}
+	if err := in.Validate(ec, ev.Culprit); err != nil {
+		return nil, err
+	}
+	r2msg, r1msgs, err := frost.ParseShareEvidence(in.Ks, msgs)
+	if err != nil {
+		return nil, err
+	}
+	commitments, err := frost.ParseCommitments(ec, in.Ks, r1msgs)
+	if err != nil {
+		return nil, err
+	}
+	Rs, c, err := in.Commitments(commitments)
+	if err != nil {
+		return nil, err
+	}
+	return frost.CheckShare(ec, Rs[in.Index], c, in.PubXj[in.Index], r2msg), nil
+}
@@ -0,0 +1,266 @@
+package sign
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/felicityin/mpc-tss/common"
+	"github.com/felicityin/mpc-tss/crypto"
+	"github.com/felicityin/mpc-tss/protocols/cggmp/keygen"
+	frost "github.com/felicityin/mpc-tss/protocols/frost/sign"
+	"github.com/felicityin/mpc-tss/protocols/utils"
+	"github.com/felicityin/mpc-tss/tss"
+)
+
+// Implements Party
+// Implements Stringer
+var _ tss.Party = (*LocalParty)(nil)
+var _ fmt.Stringer = (*LocalParty)(nil)
+
+type (
+	LocalParty struct {
+		*tss.BaseParty
+		params *tss.Parameters
+
+		keys keygen.LocalPartySaveData
+		temp localTempData
+		data *common.SignatureData
+
+		// outbound messaging
+		out    chan<- tss.Message
+		end    chan<- *common.SignatureData
+		preEnd chan<- *PreSignature
+	}
+
+	localMessageStore struct {
+		signRound1Messages,
+		signRound2Messages []tss.ParsedMessage
+	}
+
+	localTempData struct {
+		localMessageStore
+
+		isThreshold bool
+
+		m       []byte
+		adaptor *crypto.ECPoint
+
+		// round 1
+		d *big.Int
+		e *big.Int
+
+		// round 2
+		commitments []*frost.SigningCommitment
+		R           *crypto.ECPoint
+		zi          *big.Int
+		RT          *crypto.ECPoint // R + T with an adaptor point, before its negation to an even Y
+	}
+)
+
+// NewLocalParty returns a party of FROST over secp256k1, which outputs a 64-byte BIP-340 signature of the 32-byte digest msg
+// for the x-only public key of the key derived with path.
+func NewLocalParty(
+	msg *big.Int,
+	isThreshold bool,
+	params *tss.Parameters,
+	path string,
+	key keygen.LocalPartySaveData,
+	out chan<- tss.Message,
+	end chan<- *common.SignatureData,
+) (tss.Party, error) {
+	return newLocalParty(msg, isThreshold, params, path, key, false, nil, out, end)
+}
+
+// NewLocalPartyTaproot returns a party of FROST over secp256k1, which outputs a 64-byte BIP-340 signature of the 32-byte digest msg
+// for the key-path spending of the Taproot output whose internal key is the key derived with path.
+// The signature verifies with the x-only output key tweaked with merkleRoot, which is nil for an output without scripts (BIP-86).
+func NewLocalPartyTaproot(
+	msg *big.Int,
+	isThreshold bool,
+	params *tss.Parameters,
+	path string,
+	key keygen.LocalPartySaveData,
+	merkleRoot []byte,
+	out chan<- tss.Message,
+	end chan<- *common.SignatureData,
+) (tss.Party, error) {
+	return newLocalParty(msg, isThreshold, params, path, key, true, merkleRoot, out, end)
+}
+
+// NewLocalPartyWithAdaptor returns a party of FROST over secp256k1 that makes the pre-signature of the 32-byte digest msg
+// for the adaptor point T and the x-only public key of the key derived with path, which it outputs on end once it is checked against the key.
+// The signers commit to the nonce point R + T, and the pre-signature is completed into a BIP-340 signature
+// with the discrete logarithm of T, see PreSignature.
+func NewLocalPartyWithAdaptor(
+	msg *big.Int,
+	T *crypto.ECPoint,
+	isThreshold bool,
+	params *tss.Parameters,
+	path string,
+	key keygen.LocalPartySaveData,
+	out chan<- tss.Message,
+	end chan<- *PreSignature,
+) (tss.Party, error) {
+	return newLocalPartyWithAdaptor(msg, T, isThreshold, params, path, key, false, nil, out, end)
+}
+
+// NewLocalPartyTaprootWithAdaptor is NewLocalPartyWithAdaptor for the key-path spending of the Taproot output
+// whose internal key is the key derived with path, as NewLocalPartyTaproot.
+func NewLocalPartyTaprootWithAdaptor(
+	msg *big.Int,
+	T *crypto.ECPoint,
+	isThreshold bool,
+	params *tss.Parameters,
+	path string,
+	key keygen.LocalPartySaveData,
+	merkleRoot []byte,
+	out chan<- tss.Message,
+	end chan<- *PreSignature,
+) (tss.Party, error) {
+	return newLocalPartyWithAdaptor(msg, T, isThreshold, params, path, key, true, merkleRoot, out, end)
+}
+
+func newLocalPartyWithAdaptor(
+	msg *big.Int,
+	T *crypto.ECPoint,
+	isThreshold bool,
+	params *tss.Parameters,
+	path string,
+	key keygen.LocalPartySaveData,
+	taproot bool,
+	merkleRoot []byte,
+	out chan<- tss.Message,
+	end chan<- *PreSignature,
+) (tss.Party, error) {
+	if err := ValidateAdaptor(T); err != nil {
+		return nil, err
+	}
+	party, err := newLocalParty(msg, isThreshold, params, path, key, taproot, merkleRoot, out, nil)
+	if err != nil {
+		return nil, err
+	}
+	p := party.(*LocalParty)
+	p.temp.adaptor = T
+	p.preEnd = end
+	return p, nil
+}
+
+func newLocalParty(
+	msg *big.Int,
+	isThreshold bool,
+	params *tss.Parameters,
+	path string,
+	key keygen.LocalPartySaveData,
+	taproot bool,
+	merkleRoot []byte,
+	out chan<- tss.Message,
+	end chan<- *common.SignatureData,
+) (tss.Party, error) {
+	if err := CheckCurve(params); err != nil {
+		return nil, err
+	}
+	m, err := MessageBytes(msg)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err = keygen.BuildLocalSaveDataSubset(key, params.Parties().IDs())
+	if err != nil {
+		return nil, err
+	}
+
+	err = utils.UpdateKeyForSigning(&key, path, isThreshold, params.Threshold(), params.KeyTweaks()...)
+	if err != nil {
+		return nil, err
+	}
+	if taproot {
+		err = TweakKeyForTaproot(&key, merkleRoot)
+	} else {
+		NormalizeKey(&key)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	partyCount := len(params.Parties().IDs())
+	p := &LocalParty{
+		BaseParty: new(tss.BaseParty),
+		params:    params,
+		keys:      key,
+		temp:      localTempData{},
+		data:      &common.SignatureData{},
+		out:       out,
+		end:       end,
+	}
+	// msgs init
+	p.temp.signRound1Messages = make([]tss.ParsedMessage, partyCount)
+	p.temp.signRound2Messages = make([]tss.ParsedMessage, partyCount)
+
+	// temp data init
+	p.temp.m = m
+	p.temp.isThreshold = isThreshold
+	return p, nil
+}
+
+func (p *LocalParty) FirstRound() tss.Round {
+	return newRound1(p.temp.isThreshold, p.params, &p.keys, p.data, &p.temp, p.out, p.end, p.preEnd)
+}
+
+func (p *LocalParty) Start() *tss.Error {
+	return tss.BaseStart(p, TaskName)
+}
+
+func (p *LocalParty) Update(msg tss.ParsedMessage) (ok bool, err *tss.Error) {
+	return tss.BaseUpdate(p, msg, TaskName)
+}
+
+func (p *LocalParty) UpdateFromBytes(wireBytes []byte, from *tss.PartyID, isBroadcast bool) (bool, *tss.Error) {
+	msg, err := tss.ParseWireMessage(wireBytes, from, isBroadcast)
+	if err != nil {
+		return false, p.WrapError(err)
+	}
+	return p.Update(msg)
+}
+
+func (p *LocalParty) ValidateMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	if msg.GetFrom() == nil || !msg.GetFrom().ValidateBasic() {
+		return false, p.WrapError(fmt.Errorf("received msg with an invalid sender: %s", msg))
+	}
+	// check that the message's "from index" will fit into the array
+	if maxFromIdx := len(p.params.Parties().IDs()) - 1; maxFromIdx < msg.GetFrom().Index {
+		return false, p.WrapError(fmt.Errorf("received msg with a sender index too great (%d <= %d)",
+			maxFromIdx, msg.GetFrom().Index), msg.GetFrom())
+	}
+	return p.BaseParty.ValidateMessage(msg)
+}
+
+func (p *LocalParty) StoreMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	// ValidateBasic is cheap; double-check the message here in case the public StoreMessage was called externally
+	if ok, err := p.ValidateMessage(msg); !ok || err != nil {
+		return ok, err
+	}
+	fromPIdx := msg.GetFrom().Index
+
+	// switch/case is necessary to store any messages beyond current round
+	// this does not handle message replays. we expect the caller to apply replay and spoofing protection.
+	switch msg.Content().(type) {
+	case *frost.SignRound1Message:
+		p.temp.signRound1Messages[fromPIdx] = msg
+
+	case *frost.SignRound2Message:
+		p.temp.signRound2Messages[fromPIdx] = msg
+
+	default: // unrecognised message, just ignore!
+		common.Logger.Warningf("unrecognised message ignored: %v", msg)
+		return false, nil
+	}
+	return true, nil
+}
+
+func (p *LocalParty) PartyID() *tss.PartyID {
+	return p.params.PartyID()
+}
+
+func (p *LocalParty) String() string {
+	return fmt.Sprintf("id: %s, %s", p.PartyID(), p.BaseParty.String())
+}
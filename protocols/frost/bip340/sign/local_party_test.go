@@ -0,0 +1,242 @@
+package sign
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/ipfs/go-log"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/felicityin/mpc-tss/common"
+	"github.com/felicityin/mpc-tss/crypto"
+	"github.com/felicityin/mpc-tss/protocols/cggmp/keygen"
+	nonKeygen "github.com/felicityin/mpc-tss/protocols/cggmp/keygen/non_threshold"
+	tKeygen "github.com/felicityin/mpc-tss/protocols/cggmp/keygen/threshold"
+	"github.com/felicityin/mpc-tss/protocols/cggmp/test"
+	"github.com/felicityin/mpc-tss/protocols/utils"
+	"github.com/felicityin/mpc-tss/tss"
+)
+
+const (
+	testParticipants = 3
+	testThreshold    = 2
+)
+
+func setUp(level string) {
+	if err := log.SetLogLevel("tss-lib", level); err != nil {
+		panic(err)
+	}
+
+	// only for test
+	tss.SetCurve(tss.S256())
+}
+
+func TestE2ENonThresholdConcurrent(t *testing.T) {
+	setUp("info")
+
+	keys, signPIDs, err := nonKeygen.LoadKeygenTestFixturesRandomSet(keygen.Ecdsa, testParticipants, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	for _, path := range pathsOfBothParities(t, keys[0]) {
+		testSigning(t, keys, signPIDs, false, testParticipants, path, false, nil)
+	}
+}
+
+func TestE2EThresholdConcurrent(t *testing.T) {
+	setUp("info")
+
+	keys, signPIDs, err := tKeygen.LoadKeygenTestFixturesRandomSet(keygen.Ecdsa, testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	for _, path := range pathsOfBothParities(t, keys[0]) {
+		testSigning(t, keys, signPIDs, true, testThreshold, path, false, nil)
+	}
+}
+
+func TestE2ETaprootConcurrent(t *testing.T) {
+	setUp("info")
+
+	keys, signPIDs, err := tKeygen.LoadKeygenTestFixturesRandomSet(keygen.Ecdsa, testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	merkleRoot := TaggedHash("TapBranch", []byte("script tree"))
+	for _, path := range pathsOfBothParities(t, keys[0]) {
+		// BIP-86, then an output with scripts
+		testSigning(t, keys, signPIDs, true, testThreshold, path, true, nil)
+		testSigning(t, keys, signPIDs, true, testThreshold, path, true, merkleRoot)
+	}
+}
+
+func testSigning(
+	t *testing.T,
+	keys []keygen.LocalPartySaveData,
+	signPIDs tss.SortedPartyIDs,
+	isThreshold bool,
+	threshold int,
+	path string,
+	taproot bool,
+	merkleRoot []byte,
+) {
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	parties := make([]*LocalParty, 0, len(signPIDs))
+
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+	endCh := make(chan *common.SignatureData, len(signPIDs))
+
+	msg := new(big.Int).SetBytes(TaggedHash("test", []byte("FROST over secp256k1")))
+
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(tss.S256(), p2pCtx, signPIDs[i], len(signPIDs), threshold)
+		var party tss.Party
+		var err error
+		if taproot {
+			party, err = NewLocalPartyTaproot(msg, isThreshold, params, path, keys[i], merkleRoot, outCh, endCh)
+		} else {
+			party, err = NewLocalParty(msg, isThreshold, params, path, keys[i], outCh, endCh)
+		}
+		assert.NoError(t, err)
+		P := party.(*LocalParty)
+		parties = append(parties, P)
+
+		go func(P *LocalParty) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	for ended := 0; ended < len(parties); {
+		select {
+		case err := <-errCh:
+			assert.FailNow(t, err.Error())
+
+		case msg := <-outCh:
+			for _, P := range parties {
+				if P.PartyID().Index == msg.GetFrom().Index {
+					continue
+				}
+				go test.SharedPartyUpdater(P, msg, errCh)
+			}
+
+		case data := <-endCh:
+			ended++
+			assert.Len(t, data.Signature, 64)
+
+			// the signature verifies with the x-only public key of the derived key, or with the Taproot output key of it
+			_, derived, err := utils.DerivingPubkeyFromPath(keys[0].Pubkey, keys[0].ChainCode.Bytes(), path, tss.S256())
+			assert.NoError(t, err)
+			xOnly := XOnly(derived.PublicKey)
+			if taproot {
+				internalKey, err := btcec.ParsePubKey(compressed(derived.PublicKey))
+				assert.NoError(t, err)
+				xOnly = schnorr.SerializePubKey(txscript.ComputeTaprootOutputKey(internalKey, merkleRoot))
+			}
+			assert.Equal(t, xOnly, XOnly(parties[0].keys.Pubkey))
+			pk, err := schnorr.ParsePubKey(xOnly)
+			assert.NoError(t, err)
+			sig, err := schnorr.ParseSignature(data.Signature)
+			assert.NoError(t, err)
+			assert.True(t, sig.Verify(data.M, pk), "bip340 verify must pass")
+		}
+	}
+}
+
+// pathsOfBothParities returns a path deriving a public key with an even Y and a path deriving one with an odd Y
+func pathsOfBothParities(t *testing.T, key keygen.LocalPartySaveData) []string {
+	var even, odd string
+	for child := 0; even == "" || odd == ""; child++ {
+		path := "0/1/2/2/" + big.NewInt(int64(child)).String()
+		_, derived, err := utils.DerivingPubkeyFromPath(key.Pubkey, key.ChainCode.Bytes(), path, tss.S256())
+		assert.NoError(t, err)
+		if HasEvenY(derived.PublicKey) {
+			even = path
+		} else {
+			odd = path
+		}
+	}
+	return []string{even, odd}
+}
+
+func TestE2EAdaptorConcurrent(t *testing.T) {
+	setUp("info")
+
+	keys, signPIDs, err := tKeygen.LoadKeygenTestFixturesRandomSet(keygen.Ecdsa, testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	// the nonce point R + T is negated if it has an odd Y, which the pre-signatures of both parities cover
+	var even, odd bool
+	for attempt := 0; attempt < 32 && !(even && odd); attempt++ {
+		secret := common.GetRandomPositiveInt(rand.Reader, tss.S256().Params().N)
+		pre, pubkey := preSign(t, keys, signPIDs, crypto.ScalarBaseMult(tss.S256(), secret))
+		if HasEvenY(pre.R) {
+			even = true
+		} else {
+			odd = true
+		}
+		assert.NoError(t, pre.Verify(pubkey))
+
+		_, err := pre.Complete(new(big.Int).Add(secret, big.NewInt(1)))
+		assert.Error(t, err)
+		data, err := pre.Complete(secret)
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.NoError(t, Verify(pubkey, data.M, data.Signature), "bip340 verify must pass")
+
+		extracted, err := pre.Extract(data)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, secret.Cmp(extracted))
+	}
+	assert.True(t, even && odd)
+}
+
+// preSign makes the pre-signature of a message for the adaptor point and the Taproot output key of a derived key,
+// and returns it with the x-only public key of the signing
+func preSign(t *testing.T, keys []keygen.LocalPartySaveData, signPIDs tss.SortedPartyIDs, T *crypto.ECPoint) (*PreSignature, *crypto.ECPoint) {
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	parties := make([]*LocalParty, 0, len(signPIDs))
+
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+	endCh := make(chan *PreSignature, len(signPIDs))
+
+	msg := new(big.Int).SetBytes(TaggedHash("test", []byte("an atomic swap")))
+
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(tss.S256(), p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+		party, err := NewLocalPartyTaprootWithAdaptor(msg, T, true, params, "0/1/2/2/10", keys[i], nil, outCh, endCh)
+		assert.NoError(t, err)
+		parties = append(parties, party.(*LocalParty))
+	}
+	// start every party before routing the messages, so that no party receives all the messages of round 1 before it starts
+	for _, P := range parties {
+		if err := P.Start(); err != nil {
+			assert.FailNow(t, err.Error())
+		}
+	}
+
+	var pre *PreSignature
+	for ended := 0; ended < len(parties); {
+		select {
+		case err := <-errCh:
+			assert.FailNow(t, err.Error())
+
+		case msg := <-outCh:
+			for _, P := range parties {
+				if P.PartyID().Index == msg.GetFrom().Index {
+					continue
+				}
+				go test.SharedPartyUpdater(P, msg, errCh)
+			}
+
+		case pre = <-endCh:
+			ended++
+		}
+	}
+	return pre, parties[0].keys.Pubkey
+}
@@ -0,0 +1,117 @@
+package sign
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/felicityin/mpc-tss/common"
+	frost "github.com/felicityin/mpc-tss/protocols/frost/sign"
+	"github.com/felicityin/mpc-tss/tss"
+)
+
+func (round *round2) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+
+	round.number = 2
+	round.started = true
+	round.resetOK()
+
+	i := round.PartyID().Index
+	common.Logger.Infof("[sign] party: %d, round_2 start", i)
+
+	commitments := make([]*frost.SigningCommitment, len(round.Parties().IDs()))
+	for j, Pj := range round.Parties().IDs() {
+		r1msg := round.temp.signRound1Messages[j].Content().(*frost.SignRound1Message)
+
+		D, err := r1msg.UnmarshalD()
+		if err != nil || !tss.SameCurve(D.Curve(), round.EC()) {
+			common.Logger.Errorf("failed to unmarshal D: %v, party: %d", err, j)
+			return round.WrapError(errors.New("failed to unmarshal D"), Pj)
+		}
+		E, err := r1msg.UnmarshalE()
+		if err != nil || !tss.SameCurve(E.Curve(), round.EC()) {
+			common.Logger.Errorf("failed to unmarshal E: %v, party: %d", err, j)
+			return round.WrapError(errors.New("failed to unmarshal E"), Pj)
+		}
+		commitments[j] = &frost.SigningCommitment{
+			Identifier: new(big.Int).Mod(round.key.Ks[j], round.EC().Params().N),
+			D:          D,
+			E:          E,
+		}
+	}
+
+	round.temp.commitments = commitments
+	zi, tssErr := round.signShare(commitments, round.temp.d, round.temp.e)
+	if tssErr != nil {
+		return tssErr
+	}
+
+	// broadcast zi to other parties
+	common.Logger.Debugf("P[%d]: round_2 broadcast", i)
+	r2msg := frost.NewSignRound2Message(round.PartyID(), zi)
+	round.temp.signRound2Messages[i] = r2msg
+	round.out <- r2msg
+
+	return nil
+}
+
+// signShare computes the commitments and the challenge of the signing, and returns the signature share of the party
+// z_i = k_i + c * x_i, where k_i = d + rho_i * e is negated if the group commitment R has an odd Y.
+// With an adaptor point T, the group commitment is R + T.
+func (round *base) signShare(commitments []*frost.SigningCommitment, d, e *big.Int) (*big.Int, *tss.Error) {
+	i := round.PartyID().Index
+	q := round.EC().Params().N
+
+	nonce, err := ComputeGroupNonce(round.key.Pubkey, round.temp.adaptor, commitments, round.temp.m)
+	if err != nil {
+		return nil, round.WrapError(err)
+	}
+
+	ki := new(big.Int).Mul(e, nonce.BindingFactors[i])
+	ki.Add(ki, d)
+	ki.Mod(ki, q)
+	if nonce.Negated {
+		ki.Sub(q, ki)
+	}
+
+	zi := new(big.Int).Mul(nonce.Challenge, round.key.PrivXi)
+	zi.Add(zi, ki)
+	zi.Mod(zi, q)
+
+	// Security: the nonces and the key share are no longer needed
+	common.ZeroBigInts(d, e, ki, round.key.PrivXi)
+
+	round.temp.R = nonce.R
+	round.temp.RT = nonce.RT
+	round.temp.zi = zi
+	return zi, nil
+}
+
+func (round *round2) Update() (bool, *tss.Error) {
+	ret := true
+	for j, msg := range round.temp.signRound2Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			ret = false
+			continue
+		}
+		round.ok[j] = true
+	}
+	return ret, nil
+}
+
+func (round *round2) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*frost.SignRound2Message); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round2) NextRound() tss.Round {
+	round.started = false
+	return &finalization{round}
+}
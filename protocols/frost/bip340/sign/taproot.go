@@ -0,0 +1,57 @@
+package sign
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/felicityin/mpc-tss/crypto"
+	"github.com/felicityin/mpc-tss/protocols/cggmp/keygen"
+	"github.com/felicityin/mpc-tss/protocols/utils"
+)
+
+// TagTapTweak is the tag of the key-path tweak of BIP-341
+const TagTapTweak = "TapTweak"
+
+// TapTweak returns the tweak of BIP-341 of the internal key P: t = tagged_hash("TapTweak", bytes(P) || merkle_root).
+// An empty merkle root is an output without scripts, whose tweak is tagged_hash("TapTweak", bytes(P)) as in BIP-86.
+func TapTweak(internalKey *crypto.ECPoint, merkleRoot []byte) (*big.Int, error) {
+	if len(merkleRoot) != 0 && len(merkleRoot) != 32 {
+		return nil, fmt.Errorf("the merkle root must be 32 bytes, got %d", len(merkleRoot))
+	}
+	t := new(big.Int).SetBytes(TaggedHash(TagTapTweak, XOnly(internalKey), merkleRoot))
+	if t.Cmp(internalKey.Curve().Params().N) >= 0 {
+		return nil, errors.New("the tweak is not less than the group order")
+	}
+	return t, nil
+}
+
+// TaprootOutputKey returns the output key Q = P + t*G of the internal key P, where P is the point with an even Y
+// of the x-only internal key. The x-only Q is the witness program of the Taproot output.
+func TaprootOutputKey(internalKey *crypto.ECPoint, merkleRoot []byte) (*crypto.ECPoint, error) {
+	P := internalKey
+	if !HasEvenY(P) {
+		P = Negate(P)
+	}
+	t, err := TapTweak(P, merkleRoot)
+	if err != nil {
+		return nil, err
+	}
+	return P.Add(crypto.ScalarBaseMult(P.Curve(), t))
+}
+
+// TweakKeyForTaproot tweaks the key prepared for the signing, whose public key is the internal key,
+// so that the parties sign for the x-only output key of the Taproot output with the merkle root.
+// The internal key and the output key are both normalized to an even Y.
+func TweakKeyForTaproot(key *keygen.LocalPartySaveData, merkleRoot []byte) error {
+	NormalizeKey(key)
+	t, err := TapTweak(key.Pubkey, merkleRoot)
+	if err != nil {
+		return err
+	}
+	if err = utils.TweakKey(key, t); err != nil {
+		return err
+	}
+	NormalizeKey(key)
+	return nil
+}
@@ -0,0 +1,77 @@
+package signing
+
+import (
+	"crypto/elliptic"
+	"errors"
+	"fmt"
+	"math/big"
+
+	bip340 "github.com/felicityin/mpc-tss/protocols/frost/bip340/sign"
+	"github.com/felicityin/mpc-tss/protocols/frost/presign"
+	frost "github.com/felicityin/mpc-tss/protocols/frost/sign"
+	"github.com/felicityin/mpc-tss/tss"
+)
+
+// EvidenceShare is the kind of the evidence against a party whose signature share is invalid in the signing with a presignature
+const EvidenceShare = "frost/bip340/signing/share"
+
+func init() {
+	tss.RegisterEvidenceCheck(EvidenceShare, checkShareEvidence)
+}
+
+// ShareInputs are the public inputs of the check of the signature share z_j of Pj. DEs are the nonce commitments of the signers
+// in the presignature, which is fixed before the signing like the key: a verifier checks them against the presignature.
+// The commitment Rj of Pj and the challenge are computed again from them.
+type ShareInputs struct {
+	bip340.ShareInputs
+	DEs []*presign.DE `json:"des"`
+}
+
+// presignCommitments returns the signing commitments of the signers of the identifiers Ks in the presignature
+func presignCommitments(ec elliptic.Curve, Ks []*big.Int, DEs []*presign.DE) ([]*frost.SigningCommitment, error) {
+	if len(DEs) != len(Ks) {
+		return nil, errors.New("expected the presignature commitments of every signer")
+	}
+	commitments := make([]*frost.SigningCommitment, len(DEs))
+	for j, DE := range DEs {
+		if DE == nil || DE.D == nil || DE.E == nil || !tss.SameCurve(DE.D.Curve(), ec) || !tss.SameCurve(DE.E.Curve(), ec) {
+			return nil, fmt.Errorf("the presignature commitments of party %d are not on the curve", j)
+		}
+		commitments[j] = &frost.SigningCommitment{
+			Identifier: new(big.Int).Mod(Ks[j], ec.Params().N),
+			D:          DE.D,
+			E:          DE.E,
+		}
+	}
+	return commitments, nil
+}
+
+func checkShareEvidence(ev *tss.Evidence, msgs []tss.ParsedMessage) (failure error, err error) {
+	in := new(ShareInputs)
+	if err := ev.UnmarshalInputs(in); err != nil {
+		return nil, err
+	}
+	ec, ok := tss.GetCurveByName(in.Curve)
+	if !ok {
+		return nil, fmt.Errorf("unknown curve %s", in.Curve)
+	}
+	if err := in.Validate(ec, ev.Culprit); err != nil {
+		return nil, err
+	}
+	if len(msgs) != 1 {
+		return nil, errors.New("expected the signature share")
+	}
+	r1msg, ok := msgs[0].Content().(*frost.SignRound2Message)
+	if !ok {
+		return nil, errors.New("expected the signature share")
+	}
+	commitments, err := presignCommitments(ec, in.Ks, in.DEs)
+	if err != nil {
+		return nil, err
+	}
+	Rs, c, err := in.Commitments(commitments)
+	if err != nil {
+		return nil, err
+	}
+	return frost.CheckShare(ec, Rs[in.Index], c, in.PubXj[in.Index], r1msg), nil
+}
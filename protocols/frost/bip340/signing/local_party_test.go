@@ -0,0 +1,226 @@
+package signing
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/ipfs/go-log"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/felicityin/mpc-tss/common"
+	"github.com/felicityin/mpc-tss/crypto"
+	"github.com/felicityin/mpc-tss/protocols/cggmp/keygen"
+	nonKeygen "github.com/felicityin/mpc-tss/protocols/cggmp/keygen/non_threshold"
+	tKeygen "github.com/felicityin/mpc-tss/protocols/cggmp/keygen/threshold"
+	"github.com/felicityin/mpc-tss/protocols/cggmp/test"
+	bip340 "github.com/felicityin/mpc-tss/protocols/frost/bip340/sign"
+	"github.com/felicityin/mpc-tss/protocols/frost/presign"
+	"github.com/felicityin/mpc-tss/protocols/utils"
+	"github.com/felicityin/mpc-tss/tss"
+)
+
+const (
+	testParticipants = 3
+	testThreshold    = 2
+)
+
+func setUp(level string) {
+	if err := log.SetLogLevel("tss-lib", level); err != nil {
+		panic(err)
+	}
+
+	// only for test
+	tss.SetCurve(tss.S256())
+}
+
+func TestE2ENonThresholdConcurrent(t *testing.T) {
+	setUp("info")
+
+	keys, signPIDs, err := nonKeygen.LoadKeygenTestFixturesRandomSet(keygen.Ecdsa, testParticipants, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	testSigning(t, keys, signPIDs, false, testParticipants, "0/1/2/2/10", false, nil)
+}
+
+func TestE2EThresholdConcurrent(t *testing.T) {
+	setUp("info")
+
+	keys, signPIDs, err := tKeygen.LoadKeygenTestFixturesRandomSet(keygen.Ecdsa, testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	testSigning(t, keys, signPIDs, true, testThreshold, "0/1/2/2/10", false, nil)
+}
+
+func TestE2ETaprootConcurrent(t *testing.T) {
+	setUp("info")
+
+	keys, signPIDs, err := tKeygen.LoadKeygenTestFixturesRandomSet(keygen.Ecdsa, testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	testSigning(t, keys, signPIDs, true, testThreshold, "0/1/2/2/10", true, nil)
+	testSigning(t, keys, signPIDs, true, testThreshold, "0/1/2/2/10", true, bip340.TaggedHash("TapBranch", []byte("script tree")))
+}
+
+func testSigning(
+	t *testing.T,
+	keys []keygen.LocalPartySaveData,
+	signPIDs tss.SortedPartyIDs,
+	isThreshold bool,
+	threshold int,
+	path string,
+	taproot bool,
+	merkleRoot []byte,
+) {
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+
+	// PHASE: presigning
+	preParties := make([]tss.Party, 0, len(signPIDs))
+	preEndCh := make(chan *presign.LocalPartySaveData, len(signPIDs))
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(tss.S256(), p2pCtx, signPIDs[i], len(signPIDs), threshold)
+		preParties = append(preParties, presign.NewLocalParty(params, outCh, preEndCh))
+	}
+	pres := make([]presign.LocalPartySaveData, len(signPIDs))
+	done := make(chan struct{})
+	go func() {
+		for range preParties {
+			pre := <-preEndCh
+			index, err := pre.OriginalIndex()
+			assert.NoError(t, err)
+			pres[index] = *pre
+		}
+		close(done)
+	}()
+	runParties(t, preParties, errCh, outCh, done)
+
+	// PHASE: signing
+	parties := make([]tss.Party, 0, len(signPIDs))
+	endCh := make(chan *common.SignatureData, len(signPIDs))
+	msg := new(big.Int).SetBytes(bip340.TaggedHash("test", []byte("FROST over secp256k1")))
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(tss.S256(), p2pCtx, signPIDs[i], len(signPIDs), threshold)
+		var party tss.Party
+		var err error
+		if taproot {
+			party, err = NewLocalPartyTaproot(msg, isThreshold, params, path, keys[i], merkleRoot, pres[i], outCh, endCh)
+		} else {
+			party, err = NewLocalParty(msg, isThreshold, params, path, keys[i], pres[i], outCh, endCh)
+		}
+		assert.NoError(t, err)
+		parties = append(parties, party)
+	}
+	pk, err := schnorr.ParsePubKey(bip340.XOnly(parties[0].(*LocalParty).keys.Pubkey))
+	assert.NoError(t, err)
+	if taproot {
+		// the parties sign for the Taproot output key of the derived key
+		_, derived, err := utils.DerivingPubkeyFromPath(keys[0].Pubkey, keys[0].ChainCode.Bytes(), path, tss.S256())
+		assert.NoError(t, err)
+		outputKey, err := bip340.TaprootOutputKey(derived.PublicKey, merkleRoot)
+		assert.NoError(t, err)
+		assert.Equal(t, bip340.XOnly(outputKey), schnorr.SerializePubKey(pk))
+	}
+	done = make(chan struct{})
+	go func() {
+		for range parties {
+			data := <-endCh
+			sig, err := schnorr.ParseSignature(data.Signature)
+			assert.NoError(t, err)
+			assert.True(t, sig.Verify(data.M, pk), "bip340 verify must pass")
+		}
+		close(done)
+	}()
+	runParties(t, parties, errCh, outCh, done)
+}
+
+// runParties starts the parties and routes their broadcasts until done is closed
+func runParties(t *testing.T, parties []tss.Party, errCh chan *tss.Error, outCh chan tss.Message, done <-chan struct{}) {
+	for _, P := range parties {
+		go func(P tss.Party) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+	for {
+		select {
+		case err := <-errCh:
+			assert.FailNow(t, err.Error())
+		case msg := <-outCh:
+			for _, P := range parties {
+				if P.PartyID().Index == msg.GetFrom().Index {
+					continue
+				}
+				go test.SharedPartyUpdater(P, msg, errCh)
+			}
+		case <-done:
+			return
+		}
+	}
+}
+
+func TestE2EAdaptorConcurrent(t *testing.T) {
+	setUp("info")
+
+	keys, signPIDs, err := tKeygen.LoadKeygenTestFixturesRandomSet(keygen.Ecdsa, testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+
+	// PHASE: presigning
+	preParties := make([]tss.Party, 0, len(signPIDs))
+	preEndCh := make(chan *presign.LocalPartySaveData, len(signPIDs))
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(tss.S256(), p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+		preParties = append(preParties, presign.NewLocalParty(params, outCh, preEndCh))
+	}
+	pres := make([]presign.LocalPartySaveData, len(signPIDs))
+	done := make(chan struct{})
+	go func() {
+		for range preParties {
+			pre := <-preEndCh
+			index, err := pre.OriginalIndex()
+			assert.NoError(t, err)
+			pres[index] = *pre
+		}
+		close(done)
+	}()
+	runParties(t, preParties, errCh, outCh, done)
+
+	// PHASE: pre-signing for the adaptor point T = tG
+	secret := common.GetRandomPositiveInt(rand.Reader, tss.S256().Params().N)
+	T := crypto.ScalarBaseMult(tss.S256(), secret)
+	msg := new(big.Int).SetBytes(bip340.TaggedHash("test", []byte("an atomic swap")))
+
+	parties := make([]tss.Party, 0, len(signPIDs))
+	endCh := make(chan *bip340.PreSignature, len(signPIDs))
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(tss.S256(), p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+		party, err := NewLocalPartyTaprootWithAdaptor(msg, T, true, params, "0/1/2/2/10", keys[i], nil, pres[i], outCh, endCh)
+		assert.NoError(t, err)
+		parties = append(parties, party)
+	}
+	pubkey := parties[0].(*LocalParty).keys.Pubkey
+	done = make(chan struct{})
+	go func() {
+		for range parties {
+			pre := <-endCh
+			assert.NoError(t, pre.Verify(pubkey))
+
+			data, err := pre.Complete(secret)
+			if assert.NoError(t, err) {
+				assert.NoError(t, bip340.Verify(pubkey, data.M, data.Signature), "bip340 verify must pass")
+				extracted, err := pre.Extract(data)
+				assert.NoError(t, err)
+				assert.Equal(t, 0, secret.Cmp(extracted))
+			}
+		}
+		close(done)
+	}()
+	runParties(t, parties, errCh, outCh, done)
+}
@@ -0,0 +1,108 @@
+package signing
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/felicityin/mpc-tss/common"
+	"github.com/felicityin/mpc-tss/protocols/cggmp/keygen"
+	bip340 "github.com/felicityin/mpc-tss/protocols/frost/bip340/sign"
+	"github.com/felicityin/mpc-tss/protocols/frost/presign"
+	frost "github.com/felicityin/mpc-tss/protocols/frost/sign"
+	"github.com/felicityin/mpc-tss/tss"
+)
+
+// round 1 represents the signing round of the FROST TSS spec over secp256k1 with a presignature
+func newRound1(
+	isThreshold bool,
+	params *tss.Parameters,
+	key *keygen.LocalPartySaveData,
+	pre *presign.LocalPartySaveData,
+	data *common.SignatureData,
+	temp *localTempData,
+	out chan<- tss.Message,
+	end chan<- *common.SignatureData,
+	preEnd chan<- *bip340.PreSignature,
+) tss.Round {
+	return &round1{
+		&base{params, isThreshold, key, pre, data, temp, out, end, preEnd, make([]bool, len(params.Parties().IDs())), false, 1},
+	}
+}
+
+func (round *round1) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+
+	round.number = 1
+	round.started = true
+	round.resetOK()
+
+	i := round.PartyID().Index
+	common.Logger.Infof("[sign] party: %d, round_1 start", i)
+
+	q := round.EC().Params().N
+
+	commitments, err := presignCommitments(round.EC(), round.key.Ks, round.pre.DEs)
+	if err != nil {
+		return round.WrapError(err)
+	}
+	nonce, err := bip340.ComputeGroupNonce(round.key.Pubkey, round.temp.adaptor, commitments, round.temp.m)
+	if err != nil {
+		return round.WrapError(err)
+	}
+
+	ki := new(big.Int).Mul(round.pre.E, nonce.BindingFactors[i])
+	ki.Add(ki, round.pre.D)
+	ki.Mod(ki, q)
+	if nonce.Negated {
+		ki.Sub(q, ki)
+	}
+
+	zi := new(big.Int).Mul(nonce.Challenge, round.key.PrivXi)
+	zi.Add(zi, ki)
+	zi.Mod(zi, q)
+
+	// the presignature is single-use, wipe it together with the key share now that it has been consumed
+	common.ZeroBigInts(ki, round.key.PrivXi)
+	round.pre.Destroy()
+
+	round.temp.R = nonce.R
+	round.temp.RT = nonce.RT
+	round.temp.zi = zi
+
+	// broadcast zi to other parties
+	common.Logger.Debugf("P[%d]: round_1 broadcast", i)
+	r1msg := frost.NewSignRound2Message(round.PartyID(), zi)
+	round.temp.signRound1Messages[i] = r1msg
+	round.out <- r1msg
+
+	return nil
+}
+
+func (round *round1) Update() (bool, *tss.Error) {
+	ret := true
+	for j, msg := range round.temp.signRound1Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			ret = false
+			continue
+		}
+		round.ok[j] = true
+	}
+	return ret, nil
+}
+
+func (round *round1) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*frost.SignRound2Message); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round1) NextRound() tss.Round {
+	round.started = false
+	return &finalization{round}
+}
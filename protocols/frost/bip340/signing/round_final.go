@@ -0,0 +1,109 @@
+package signing
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/felicityin/mpc-tss/common"
+	bip340 "github.com/felicityin/mpc-tss/protocols/frost/bip340/sign"
+	frost "github.com/felicityin/mpc-tss/protocols/frost/sign"
+	"github.com/felicityin/mpc-tss/tss"
+)
+
+func (round *finalization) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 2
+	round.started = true
+	round.resetOK()
+
+	i := round.PartyID().Index
+	common.Logger.Infof("[sign] party: %d, round_final start", i)
+
+	pre, err := round.finalize(round.temp.signRound1Messages)
+	if err != nil {
+		return err
+	}
+	common.Logger.Infof("party: %d, round 2 end", i)
+	if pre != nil {
+		round.preEnd <- pre
+		return nil
+	}
+	round.end <- round.data
+
+	return nil
+}
+
+// finalize checks the signature share of every other party and sums the shares into the BIP-340 signature (R, s),
+// or into the pre-signature that it returns with an adaptor point
+func (round *base) finalize(r2msgs []tss.ParsedMessage) (*bip340.PreSignature, *tss.Error) {
+	i := round.PartyID().Index
+	q := round.EC().Params().N
+
+	commitments, err := presignCommitments(round.EC(), round.key.Ks, round.pre.DEs)
+	if err != nil {
+		return nil, round.WrapError(err)
+	}
+	Rs, c, err := round.shareInputs(i).Commitments(commitments)
+	if err != nil {
+		return nil, round.WrapError(err)
+	}
+	s := new(big.Int).Set(round.temp.zi)
+	var errs []*tss.Error
+	for j := range round.Parties().IDs() {
+		round.ok[j] = true
+		if j == i {
+			continue
+		}
+
+		msg := r2msgs[j]
+		if err := frost.CheckShare(round.EC(), Rs[j], c, round.key.PubXj[j], msg.Content().(*frost.SignRound2Message)); err != nil {
+			common.Logger.Errorf("[j: %d] %s", j, err.Error())
+			errs = append(errs, round.WrapEvidence(err, EvidenceShare, round.shareInputs(j), msg))
+			continue
+		}
+		s.Add(s, msg.Content().(*frost.SignRound2Message).UnmarshalS())
+	}
+	if err := tss.JoinErrors(errs...); err != nil {
+		return nil, err
+	}
+	s.Mod(s, q)
+
+	if round.temp.adaptor != nil {
+		pre := &bip340.PreSignature{R: round.temp.RT, T: round.temp.adaptor, S: s, M: round.temp.m}
+		if err := pre.Verify(round.key.Pubkey); err != nil {
+			return nil, round.WrapError(err)
+		}
+		return pre, nil
+	}
+
+	sBytes := make([]byte, 32)
+	s.FillBytes(sBytes)
+	rBytes := bip340.XOnly(round.temp.R)
+
+	// save the signature for final output
+	round.data.Signature = append(append([]byte{}, rBytes...), sBytes...)
+	round.data.R = rBytes
+	round.data.S = sBytes
+	round.data.M = round.temp.m
+
+	if err := bip340.Verify(round.key.Pubkey, round.temp.m, round.data.Signature); err != nil {
+		return nil, round.WrapError(err)
+	}
+	return nil, nil
+}
+
+func (round *finalization) CanAccept(msg tss.ParsedMessage) bool {
+	// not expecting any incoming messages in this round
+	return false
+}
+
+func (round *finalization) Update() (bool, *tss.Error) {
+	// not expecting any incoming messages in this round
+	return false, nil
+}
+
+func (round *finalization) NextRound() tss.Round {
+	return nil // finished!
+}
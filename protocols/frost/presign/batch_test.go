@@ -0,0 +1,99 @@
+package presign
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/felicityin/mpc-tss/common"
+	"github.com/felicityin/mpc-tss/crypto"
+	"github.com/felicityin/mpc-tss/protocols/cggmp/keygen"
+	tKeygen "github.com/felicityin/mpc-tss/protocols/cggmp/keygen/threshold"
+	"github.com/felicityin/mpc-tss/protocols/cggmp/test"
+	"github.com/felicityin/mpc-tss/tss"
+)
+
+const testBatchSize = 5
+
+func TestE2EBatchConcurrent(t *testing.T) {
+	setUp("info")
+
+	threshold := testThreshold
+
+	// PHASE: load keygen fixtures
+	_, signPIDs, err := tKeygen.LoadKeygenTestFixturesRandomSet(keygen.Eddsa, threshold, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	// PHASE: batch presigning
+
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	parties := make([]tss.Party, 0, len(signPIDs))
+
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+	endCh := make(chan []*LocalPartySaveData, len(signPIDs))
+
+	updater := test.SharedPartyUpdater
+
+	// init the parties
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(tss.Edwards(), p2pCtx, signPIDs[i], len(signPIDs), threshold)
+		P, err := NewBatchLocalParty(params, testBatchSize, outCh, endCh)
+		assert.NoError(t, err)
+		parties = append(parties, P)
+
+		go func(P tss.Party) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	msgs := 0
+	results := make([][]*LocalPartySaveData, 0, len(signPIDs))
+PRESIGN:
+	for {
+		select {
+		case err := <-errCh:
+			common.Logger.Errorf("Error: %s", err)
+			assert.FailNow(t, err.Error())
+			break PRESIGN
+
+		case msg := <-outCh:
+			msgs++
+			for _, P := range parties {
+				if P.PartyID().Index == msg.GetFrom().Index {
+					continue
+				}
+				go updater(P, msg, errCh)
+			}
+
+		case pres := <-endCh:
+			assert.Equal(t, testBatchSize, len(pres))
+			results = append(results, pres)
+			if len(results) == len(signPIDs) {
+				break PRESIGN
+			}
+		}
+	}
+	assert.Equal(t, len(signPIDs), msgs, "the instances of a batch must share round messages")
+
+	ids := make(map[string]struct{}, testBatchSize)
+	for index := 0; index < testBatchSize; index++ {
+		id, err := results[0][index].ID()
+		assert.NoError(t, err)
+		ids[id] = struct{}{}
+		for _, pres := range results {
+			idi, err := pres[index].ID()
+			assert.NoError(t, err)
+			assert.Equal(t, id, idi, "all parties must output the presignatures in the same order")
+
+			pre := pres[index]
+			j, err := pre.OriginalIndex()
+			assert.NoError(t, err)
+			assert.True(t, pre.DEs[j].D.Equals(crypto.ScalarBaseMult(tss.Edwards(), pre.D)))
+			assert.True(t, pre.DEs[j].E.Equals(crypto.ScalarBaseMult(tss.Edwards(), pre.E)))
+		}
+	}
+	assert.Equal(t, testBatchSize, len(ids), "the presignatures of a batch must be independent")
+}
@@ -1,10 +1,12 @@
 package presign
 
 import (
+	"errors"
 	"fmt"
 	"math/big"
 
 	"github.com/felicityin/mpc-tss/common"
+	"github.com/felicityin/mpc-tss/protocols/cggmp/keygen"
 	"github.com/felicityin/mpc-tss/protocols/frost/sign"
 	"github.com/felicityin/mpc-tss/tss"
 )
@@ -36,6 +38,9 @@ type (
 
 		ssid      []byte
 		ssidNonce *big.Int
+
+		// key share hedging the nonces in the RFC 9591 mode, nil otherwise
+		secret *big.Int
 	}
 )
 
@@ -54,9 +59,27 @@ func NewLocalParty(
 		end:       end,
 	}
 	p.temp.signRound1Messages = make([]tss.ParsedMessage, partyCount)
+	p.temp.ssidNonce = new(big.Int)
 	return p
 }
 
+// NewLocalPartyRFC9591 returns a party generating the nonces of the FROST(Ed25519, SHA-512) ciphersuite of RFC 9591,
+// which are hedged with the key share of the party against a bad source of randomness.
+// The presignature is then consumed by the RFC 9591 mode of the signing.
+func NewLocalPartyRFC9591(
+	params *tss.Parameters,
+	key keygen.LocalPartySaveData,
+	out chan<- tss.Message,
+	end chan<- *LocalPartySaveData,
+) (tss.Party, error) {
+	if key.PrivXi == nil {
+		return nil, errors.New("the key share is missing")
+	}
+	p := NewLocalParty(params, out, end).(*LocalParty)
+	p.temp.secret = new(big.Int).Set(key.PrivXi)
+	return p, nil
+}
+
 func (p *LocalParty) FirstRound() tss.Round {
 	return newRound1(p.params, &p.data, &p.temp, p.out, p.end)
 }
@@ -40,15 +40,26 @@ func (round *round1) Start() *tss.Error {
 	round.save.Ks = ids
 	round.save.ShareID = ids[i]
 
-	round.temp.ssidNonce = new(big.Int).SetUint64(0)
 	var err error
 	round.temp.ssid, err = round.getSSID()
 	if err != nil {
 		return round.WrapError(err)
 	}
 
-	d := common.GetRandomPositiveInt(round.Rand(), round.Params().EC().Params().N)
-	e := common.GetRandomPositiveInt(round.Rand(), round.Params().EC().Params().N)
+	var d, e *big.Int
+	if round.temp.secret != nil {
+		// hedged nonces of RFC 9591, the hiding nonce d first and then the binding nonce e
+		if d, err = sign.NonceGenerate(round.Rand(), round.temp.secret); err != nil {
+			return round.WrapError(err)
+		}
+		if e, err = sign.NonceGenerate(round.Rand(), round.temp.secret); err != nil {
+			return round.WrapError(err)
+		}
+		common.ZeroBigInts(round.temp.secret)
+	} else {
+		d = common.GetRandomPositiveInt(round.Rand(), round.Params().EC().Params().N)
+		e = common.GetRandomPositiveInt(round.Rand(), round.Params().EC().Params().N)
+	}
 
 	D := crypto.ScalarBaseMult(round.EC(), d)
 	E := crypto.ScalarBaseMult(round.EC(), e)
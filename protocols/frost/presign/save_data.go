@@ -57,6 +57,34 @@ func (save LocalPartySaveData) OriginalIndex() (int, error) {
 	return index, nil
 }
 
+// ID returns an identifier of the presignature derived from its public part (Ks and DEs),
+// which is the same for every party holding a share of it.
+// Parties use it to agree on the presignature consumed by a signing session.
+func (save LocalPartySaveData) ID() (string, error) {
+	for j, kj := range save.Ks {
+		if kj == nil {
+			return "", fmt.Errorf("presign save data Ks[%d] is missing", j)
+		}
+	}
+	if len(save.DEs) != len(save.Ks) {
+		return "", errors.New("presign save data has mismatched Ks and DEs")
+	}
+	ids := append([]*big.Int{}, save.Ks...)
+	for j, de := range save.DEs {
+		if de == nil || !de.D.ValidateBasic() || !de.E.ValidateBasic() {
+			return "", fmt.Errorf("presign save data DEs[%d] is missing or not on the curve", j)
+		}
+		ids = append(ids, de.D.X(), de.D.Y(), de.E.X(), de.E.Y())
+	}
+	return hex.EncodeToString(common.SHA512_256i_TAGGED([]byte("frost-presign"), ids...).Bytes()), nil
+}
+
+// Destroy wipes the nonces d and e from memory. Signing calls it once the presignature is consumed.
+func (save *LocalPartySaveData) Destroy() {
+	common.ZeroBigInts(save.D, save.E)
+	save.D, save.E = nil, nil
+}
+
 // BuildLocalSaveDataSubset re-creates the LocalPartySaveData to contain data for only the list of signing parties.
 func BuildLocalSaveDataSubset(sourceData LocalPartySaveData, sortedIDs tss.SortedPartyIDs) (newData LocalPartySaveData, err error) {
 	keysToIndices := make(map[string]int, len(sourceData.Ks))
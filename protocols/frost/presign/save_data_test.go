@@ -0,0 +1,29 @@
+package presign
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIDAgrees(t *testing.T) {
+	for _, isThreshold := range []bool{true, false} {
+		pres, _, err := LoadPreTestFixtures(isThreshold, testParticipants)
+		assert.NoError(t, err, "should load presign fixtures")
+
+		id, err := pres[0].ID()
+		assert.NoError(t, err)
+		for i := 1; i < testParticipants; i++ {
+			idi, err := pres[i].ID()
+			assert.NoError(t, err)
+			assert.Equal(t, id, idi, "all parties must derive the same presign id")
+		}
+
+		pre := pres[0]
+		pre.Ks = []*big.Int{pre.Ks[1], pre.Ks[0], pre.Ks[2]}
+		other, err := pre.ID()
+		assert.NoError(t, err)
+		assert.NotEqual(t, id, other)
+	}
+}
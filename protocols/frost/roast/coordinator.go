@@ -0,0 +1,352 @@
+package roast
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/felicityin/mpc-tss/common"
+	"github.com/felicityin/mpc-tss/protocols/cggmp/keygen"
+	"github.com/felicityin/mpc-tss/protocols/frost/sign"
+	"github.com/felicityin/mpc-tss/tss"
+)
+
+type (
+	// Coordinator starts the sessions of ROAST and checks the messages of the signers.
+	// It does not hold a key share and does not need to be trusted for the unforgeability of the signature.
+	Coordinator struct {
+		msg          *big.Int
+		isThreshold  bool
+		params       *tss.Parameters
+		path         string
+		key          keygen.LocalPartySaveData
+		fullBytesLen []int
+
+		mtx         sync.Mutex
+		sessionSize int
+		sessions    []*session
+		ready       []*tss.PartyID          // the responsive signers that do not owe a message, in the order they responded
+		inSessions  map[string][]*session   // the sessions of each signer, by key
+		malicious   map[string]*tss.PartyID // the excluded signers, by key
+		done        bool
+
+		out chan<- *Session
+		end chan<- *common.SignatureData
+	}
+
+	session struct {
+		*Session
+		verifier *sign.Verifier
+		r1msgs   []*sign.SignRound1Message
+		r2msgs   []*sign.SignRound2Message
+		checked  []bool // the round 2 messages which have been checked
+		closed   bool   // the session failed or produced the signature
+	}
+)
+
+// NewCoordinator returns the coordinator of the signing of msg by the signers of params, with the same arguments as the signers.
+// Only the public data of the key is used. The sessions to start are sent to out, for the signers to join them,
+// and the first signature that a session produces is sent to end.
+// A session has t+1 signers, or all the signers if isThreshold is false.
+func NewCoordinator(
+	msg *big.Int,
+	isThreshold bool,
+	params *tss.Parameters,
+	path string,
+	key keygen.LocalPartySaveData,
+	out chan<- *Session,
+	end chan<- *common.SignatureData,
+	fullBytesLen ...int,
+) *Coordinator {
+	sessionSize := len(params.Parties().IDs())
+	if isThreshold {
+		sessionSize = params.Threshold() + 1
+	}
+	return &Coordinator{
+		msg:          msg,
+		isThreshold:  isThreshold,
+		params:       params,
+		path:         path,
+		key:          key,
+		fullBytesLen: fullBytesLen,
+		sessionSize:  sessionSize,
+		inSessions:   make(map[string][]*session),
+		malicious:    make(map[string]*tss.PartyID),
+		out:          out,
+		end:          end,
+	}
+}
+
+// Start marks all the signers as responsive and starts the first sessions
+func (c *Coordinator) Start() error {
+	c.mtx.Lock()
+	if len(c.sessions) > 0 {
+		c.mtx.Unlock()
+		return errors.New("the coordinator has already started")
+	}
+	if len(c.params.Parties().IDs()) < c.sessionSize {
+		c.mtx.Unlock()
+		return ErrNotEnoughSigners
+	}
+	c.ready = append(c.ready, c.params.Parties().IDs()...)
+	started, err := c.startSessions()
+	c.mtx.Unlock()
+
+	c.send(started, nil)
+	return err
+}
+
+// Update checks a message of a signer in a session, as it was sent over the wire.
+// The cheaters are excluded from the next sessions, and new sessions are started with the signers that responded.
+func (c *Coordinator) Update(msg *Message) error {
+	wireBytes, routing, err := msg.WireBytes()
+	if err != nil {
+		return err
+	}
+	return c.UpdateFromBytes(msg.Session, wireBytes, routing.From, routing.IsBroadcast)
+}
+
+// UpdateFromBytes checks a message of a signer in a session, as it was sent over the wire
+func (c *Coordinator) UpdateFromBytes(sessionID int, wireBytes []byte, from *tss.PartyID, isBroadcast bool) error {
+	c.mtx.Lock()
+	started, data, err := c.update(sessionID, wireBytes, from, isBroadcast)
+	c.mtx.Unlock()
+
+	c.send(started, data)
+	return err
+}
+
+// send sends the started sessions and the signature, if any. It is called without the lock held, so that a reader
+// of the channels may call the coordinator back.
+func (c *Coordinator) send(started []*Session, data *common.SignatureData) {
+	for _, s := range started {
+		c.out <- s
+	}
+	if data != nil {
+		c.end <- data
+	}
+}
+
+// update checks a message of a signer in a session, and returns the sessions it started and the signature
+// if the session produced it
+func (c *Coordinator) update(
+	sessionID int, wireBytes []byte, from *tss.PartyID, isBroadcast bool,
+) ([]*Session, *common.SignatureData, error) {
+	if sessionID < 0 || len(c.sessions) <= sessionID {
+		return nil, nil, fmt.Errorf("unknown session %d", sessionID)
+	}
+	s := c.sessions[sessionID]
+	j, err := s.indexOf(from)
+	if err != nil {
+		return nil, nil, err
+	}
+	from = s.Signers[j]
+	if c.done || s.closed {
+		return nil, nil, nil
+	}
+	msg, err := tss.ParseWireMessage(wireBytes, from, isBroadcast)
+	if err != nil || !msg.ValidateBasic() || !msg.IsBroadcast() {
+		common.Logger.Errorf("[roast] session %d: invalid message from %s", s.ID, from)
+		started, err := c.exclude(s, from)
+		return started, nil, err
+	}
+
+	switch content := msg.Content().(type) {
+	case *sign.SignRound1Message:
+		if s.r1msgs[j] != nil {
+			return nil, nil, nil
+		}
+		if err := s.verifier.CheckCommitments(content); err != nil {
+			common.Logger.Errorf("[roast] session %d: invalid commitments of %s: %s", s.ID, from, err)
+			started, err := c.exclude(s, from)
+			return started, nil, err
+		}
+		s.r1msgs[j] = content
+		if s.hasAllCommitments() {
+			if err := s.verifier.SetCommitments(s.r1msgs); err != nil {
+				common.Logger.Errorf("[roast] session %d: %s", s.ID, err)
+				s.closed = true
+			} else {
+				// the signers that waited for the commitments of the others owe their shares now
+				c.removeOwing(s)
+			}
+		}
+
+	case *sign.SignRound2Message:
+		if s.r2msgs[j] != nil {
+			return nil, nil, nil
+		}
+		s.r2msgs[j] = content
+
+	default:
+		common.Logger.Warningf("[roast] session %d: unrecognised message ignored: %v", s.ID, msg)
+		return nil, nil, nil
+	}
+
+	if !s.closed && s.hasAllCommitments() {
+		// the shares can only be checked once all the commitments are known
+		var culprits []*tss.PartyID
+		for k, r2msg := range s.r2msgs {
+			if r2msg == nil || s.checked[k] {
+				continue
+			}
+			if err := s.verifier.CheckShare(k, r2msg); err != nil {
+				common.Logger.Errorf("[roast] session %d: invalid share of %s: %s", s.ID, s.Signers[k], err)
+				culprits = append(culprits, s.Signers[k])
+				continue
+			}
+			s.checked[k] = true
+		}
+		if len(culprits) > 0 {
+			started, err := c.exclude(s, culprits...)
+			return started, nil, err
+		}
+		if s.hasAllShares() {
+			data, err := s.verifier.Signature(s.r2msgs)
+			if err != nil {
+				common.Logger.Errorf("[roast] session %d: %s", s.ID, err)
+				s.closed = true
+			} else {
+				c.done = true
+				return nil, data, nil
+			}
+		}
+	}
+	c.updateReady(s)
+	started, err := c.startSessions()
+	return started, nil, err
+}
+
+// Malicious returns the signers that have been excluded for cheating
+func (c *Coordinator) Malicious() []*tss.PartyID {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	ids := make([]*tss.PartyID, 0, len(c.malicious))
+	for _, Pj := range c.params.Parties().IDs() {
+		if _, ok := c.malicious[keyOf(Pj)]; ok {
+			ids = append(ids, Pj)
+		}
+	}
+	return ids
+}
+
+// exclude marks the culprits as malicious and closes the session, whose other signers no longer owe any message in it,
+// and returns the sessions it started
+func (c *Coordinator) exclude(s *session, culprits ...*tss.PartyID) ([]*Session, error) {
+	for _, culprit := range culprits {
+		c.malicious[keyOf(culprit)] = culprit
+		for k, Pk := range c.ready {
+			if keyOf(Pk) == keyOf(culprit) {
+				c.ready = append(c.ready[:k], c.ready[k+1:]...)
+				break
+			}
+		}
+	}
+	s.closed = true
+	c.updateReady(s)
+	if len(c.params.Parties().IDs())-len(c.malicious) < c.sessionSize {
+		return nil, ErrNotEnoughSigners
+	}
+	return c.startSessions()
+}
+
+// updateReady puts the signers of the session that no longer owe any message back among the responsive signers
+func (c *Coordinator) updateReady(s *session) {
+	for _, Pj := range c.params.Parties().IDs() {
+		if _, err := s.indexOf(Pj); err != nil {
+			continue
+		}
+		if _, ok := c.malicious[keyOf(Pj)]; ok || c.isReady(Pj) || c.owesMessage(Pj) {
+			continue
+		}
+		c.ready = append(c.ready, Pj)
+	}
+}
+
+// owesMessage tells whether the signer has a message to send in one of its open sessions:
+// its round 1 message, or its round 2 message once the session has all the commitments
+func (c *Coordinator) owesMessage(Pj *tss.PartyID) bool {
+	for _, s := range c.inSessions[keyOf(Pj)] {
+		if s.closed {
+			continue
+		}
+		j, _ := s.indexOf(Pj)
+		if s.r1msgs[j] == nil || (s.hasAllCommitments() && s.r2msgs[j] == nil) {
+			return true
+		}
+	}
+	return false
+}
+
+// removeOwing removes the signers of the session that owe a message from the responsive signers
+func (c *Coordinator) removeOwing(s *session) {
+	ready := c.ready[:0]
+	for _, Pk := range c.ready {
+		if _, err := s.indexOf(Pk); err == nil && c.owesMessage(Pk) {
+			continue
+		}
+		ready = append(ready, Pk)
+	}
+	c.ready = ready
+}
+
+func (c *Coordinator) isReady(Pj *tss.PartyID) bool {
+	for _, Pk := range c.ready {
+		if keyOf(Pk) == keyOf(Pj) {
+			return true
+		}
+	}
+	return false
+}
+
+// startSessions starts sessions with the first responsive signers, as long as there are enough of them,
+// and returns them for the caller to send once it releases the lock
+func (c *Coordinator) startSessions() ([]*Session, error) {
+	var started []*Session
+	for !c.done && len(c.ready) >= c.sessionSize {
+		signers := c.ready[:c.sessionSize]
+		c.ready = append([]*tss.PartyID{}, c.ready[c.sessionSize:]...)
+
+		s := &session{
+			Session: newSession(len(c.sessions), signers),
+			r1msgs:  make([]*sign.SignRound1Message, c.sessionSize),
+			r2msgs:  make([]*sign.SignRound2Message, c.sessionSize),
+			checked: make([]bool, c.sessionSize),
+		}
+		params := tss.NewParameters(c.params.EC(), tss.NewPeerContext(s.Signers), s.Signers[0], c.sessionSize, c.params.Threshold())
+		params.SetKeyTweaks(c.params.KeyTweaks()...)
+		verifier, err := sign.NewVerifier(c.msg, c.isThreshold, params, c.path, c.key, c.fullBytesLen...)
+		if err != nil {
+			return started, err
+		}
+		s.verifier = verifier
+
+		c.sessions = append(c.sessions, s)
+		for _, Pj := range signers {
+			c.inSessions[keyOf(Pj)] = append(c.inSessions[keyOf(Pj)], s)
+		}
+		common.Logger.Infof("[roast] start session %d with %v", s.ID, s.Signers)
+		started = append(started, s.Session)
+	}
+	return started, nil
+}
+
+func (s *session) hasAllCommitments() bool {
+	for _, r1msg := range s.r1msgs {
+		if r1msg == nil {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *session) hasAllShares() bool {
+	for _, ok := range s.checked {
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,63 @@
+// Package roast runs the FROST signing of frost/sign robustly with ROAST (https://eprint.iacr.org/2022/550):
+// a coordinator starts signing sessions with any t+1 responsive signers, checks the messages of the signers as they come,
+// excludes the signers that cheated and starts new sessions as signers respond, until one of the sessions outputs a signature.
+//
+// A signer that goes silent stalls its session only: the other signers of the session are free again once they have sent
+// all the messages they can send in it, and join the next sessions. A session without faulty signers always completes,
+// so a signature is produced as long as t+1 signers are honest and responsive.
+//
+// The coordinator relays the messages of the sessions: the signers send their messages to the coordinator,
+// which must see every message of a session, and to the other signers of the session.
+package roast
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/felicityin/mpc-tss/tss"
+)
+
+type (
+	// Session is a signing session that the coordinator started with a subset of the signers.
+	// The signers of the session have their own party IDs, whose indexes are the indexes in the session.
+	Session struct {
+		ID      int
+		Signers tss.SortedPartyIDs
+	}
+
+	// Message is a message of a signer in a session
+	Message struct {
+		Session int
+		tss.Message
+	}
+)
+
+// ErrNotEnoughSigners is returned by the coordinator when fewer signers than the size of a session are left
+// after the exclusion of the cheaters, so that no session can produce a signature anymore
+var ErrNotEnoughSigners = errors.New("not enough signers left to sign")
+
+func newSession(id int, signers []*tss.PartyID) *Session {
+	ids := make(tss.UnSortedPartyIDs, len(signers))
+	for j, Pj := range signers {
+		ids[j] = tss.NewPartyID(Pj.Id, Pj.Moniker, Pj.KeyInt())
+	}
+	return &Session{
+		ID:      id,
+		Signers: tss.SortPartyIDs(ids),
+	}
+}
+
+// indexOf returns the index in the session of the signer with the key of Pi
+func (s *Session) indexOf(Pi *tss.PartyID) (int, error) {
+	for j, Pj := range s.Signers {
+		if Pj.KeyInt().Cmp(Pi.KeyInt()) == 0 {
+			return j, nil
+		}
+	}
+	return -1, fmt.Errorf("%s is not a signer of the session %d", Pi, s.ID)
+}
+
+func keyOf(Pj *tss.PartyID) string {
+	return hex.EncodeToString(Pj.Key)
+}
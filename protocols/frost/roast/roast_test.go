@@ -0,0 +1,262 @@
+package roast
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"testing"
+
+	edwards "github.com/decred/dcrd/dcrec/edwards/v2"
+	"github.com/ipfs/go-log"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/felicityin/mpc-tss/common"
+	"github.com/felicityin/mpc-tss/crypto"
+	"github.com/felicityin/mpc-tss/crypto/vss"
+	"github.com/felicityin/mpc-tss/protocols/cggmp/keygen"
+	nonKeygen "github.com/felicityin/mpc-tss/protocols/cggmp/keygen/non_threshold"
+	"github.com/felicityin/mpc-tss/protocols/frost/sign"
+	"github.com/felicityin/mpc-tss/tss"
+)
+
+const (
+	testParticipants = 5
+	testThreshold    = 2
+)
+
+var testMsg, _ = hex.DecodeString("00f163ee51bcaeff9cdff5e0e3c1a646abd19885fffbab0b3b4236e0cf95c9f5")
+
+func setUp(level string) {
+	if err := log.SetLogLevel("tss-lib", level); err != nil {
+		panic(err)
+	}
+
+	// only for test
+	tss.SetCurve(tss.Edwards())
+}
+
+type (
+	// network routes the messages of the sessions between the coordinator and the signers,
+	// with the faults of the signers
+	network struct {
+		coordinator *Coordinator
+		signers     map[string]*Signer
+		sessions    map[int]*Session
+
+		sessionCh chan *Session
+		msgCh     chan *Message
+		endCh     chan *common.SignatureData
+
+		// the signers that go silent after round 1, and the signers that send invalid shares
+		silent, cheaters map[string]bool
+	}
+)
+
+func TestE2ERobustThreshold(t *testing.T) {
+	setUp("info")
+
+	keys, pIDs := dealKeys(t, testParticipants, testThreshold)
+
+	// the first signer goes silent after round 1, and the second one sends invalid shares
+	net := newNetwork(t, keys, pIDs, true, testThreshold)
+	net.silent[keyOf(pIDs[0])] = true
+	net.cheaters[keyOf(pIDs[1])] = true
+
+	data, err := net.run(t)
+	assert.NoError(t, err)
+	verify(t, net.coordinator, data)
+
+	malicious := net.coordinator.Malicious()
+	assert.Len(t, malicious, 1)
+	assert.Equal(t, keyOf(pIDs[1]), keyOf(malicious[0]))
+}
+
+func TestE2EHonestThreshold(t *testing.T) {
+	setUp("info")
+
+	keys, pIDs := dealKeys(t, testParticipants, testThreshold)
+
+	net := newNetwork(t, keys, pIDs, true, testThreshold)
+	data, err := net.run(t)
+	assert.NoError(t, err)
+	verify(t, net.coordinator, data)
+	assert.Empty(t, net.coordinator.Malicious())
+}
+
+func TestE2EUnbufferedChannels(t *testing.T) {
+	setUp("info")
+
+	keys, pIDs := dealKeys(t, testParticipants, testThreshold)
+
+	// the reader of the channels calls the coordinator back, which must not hold its lock while it sends
+	net := newNetwork(t, keys, pIDs, true, testThreshold)
+	net.sessionCh, net.endCh = make(chan *Session), make(chan *common.SignatureData)
+	net.coordinator.out, net.coordinator.end = net.sessionCh, net.endCh
+	net.silent[keyOf(pIDs[0])] = true
+	net.cheaters[keyOf(pIDs[1])] = true
+
+	data, err := net.run(t)
+	assert.NoError(t, err)
+	verify(t, net.coordinator, data)
+	assert.Len(t, net.coordinator.Malicious(), 1)
+}
+
+func TestE2ENonThreshold(t *testing.T) {
+	setUp("info")
+
+	keys, pIDs, err := nonKeygen.LoadKeygenTestFixturesRandomSet(keygen.Eddsa, 3, 3)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	// all the signers sign in the n-party mode
+	net := newNetwork(t, keys, pIDs, false, len(pIDs)-1)
+	data, err := net.run(t)
+	assert.NoError(t, err)
+	verify(t, net.coordinator, data)
+
+	// a cheater cannot be replaced
+	net = newNetwork(t, keys, pIDs, false, len(pIDs)-1)
+	net.cheaters[keyOf(pIDs[2])] = true
+	_, err = net.run(t)
+	assert.ErrorIs(t, err, ErrNotEnoughSigners)
+}
+
+func newNetwork(t *testing.T, keys []keygen.LocalPartySaveData, pIDs tss.SortedPartyIDs, isThreshold bool, threshold int) *network {
+	p2pCtx := tss.NewPeerContext(pIDs)
+	net := &network{
+		signers:   make(map[string]*Signer),
+		sessions:  make(map[int]*Session),
+		sessionCh: make(chan *Session, 4*len(pIDs)),
+		msgCh:     make(chan *Message, 4*len(pIDs)),
+		endCh:     make(chan *common.SignatureData, 1),
+		silent:    make(map[string]bool),
+		cheaters:  make(map[string]bool),
+	}
+	// the signatures of the signers are the same as the signature of the coordinator
+	signerEndCh := make(chan *common.SignatureData, len(pIDs)*len(pIDs))
+
+	msg := new(big.Int).SetBytes(testMsg)
+	params := tss.NewParameters(tss.Edwards(), p2pCtx, pIDs[0], len(pIDs), threshold)
+	net.coordinator = NewCoordinator(msg, isThreshold, params, "", keys[0], net.sessionCh, net.endCh, len(testMsg))
+	for i, Pi := range pIDs {
+		params := tss.NewParameters(tss.Edwards(), p2pCtx, Pi, len(pIDs), threshold)
+		net.signers[keyOf(Pi)] = NewSigner(msg, isThreshold, params, "", keys[i], net.msgCh, signerEndCh, len(testMsg))
+	}
+	return net
+}
+
+// run starts the coordinator and routes the sessions and the messages until the coordinator outputs the signature
+func (net *network) run(t *testing.T) (*common.SignatureData, error) {
+	errCh := make(chan error, 1)
+	fail := func(err error) {
+		select {
+		case errCh <- err:
+		default:
+		}
+	}
+	// the sessions are sent while the coordinator starts
+	go func() {
+		if err := net.coordinator.Start(); err != nil {
+			fail(err)
+		}
+	}()
+	for {
+		select {
+		case err := <-errCh:
+			return nil, err
+
+		case session := <-net.sessionCh:
+			net.sessions[session.ID] = session
+			for _, Pj := range net.coordinator.Malicious() {
+				_, err := session.indexOf(Pj)
+				assert.Error(t, err, "a session must not include an excluded signer")
+			}
+			for _, Pj := range session.Signers {
+				go func(signer *Signer) {
+					// the errors of the signers in the sessions with a cheater are expected
+					_ = signer.JoinSession(session)
+				}(net.signers[keyOf(Pj)])
+			}
+
+		case msg := <-net.msgCh:
+			from := keyOf(msg.GetFrom())
+			if _, ok := msg.Message.(tss.ParsedMessage).Content().(*sign.SignRound2Message); ok {
+				if net.silent[from] {
+					continue
+				}
+				if net.cheaters[from] {
+					z := common.GetRandomPositiveInt(rand.Reader, tss.Edwards().Params().N)
+					msg = &Message{Session: msg.Session, Message: sign.NewSignRound2Message(msg.GetFrom(), z)}
+				}
+			}
+			go func() {
+				if err := net.coordinator.Update(msg); err != nil {
+					fail(err)
+				}
+			}()
+			for _, Pj := range net.sessions[msg.Session].Signers {
+				if Pj.Index == msg.GetFrom().Index {
+					continue
+				}
+				go func(signer *Signer) {
+					_, _ = signer.Update(msg)
+				}(net.signers[keyOf(Pj)])
+			}
+
+		case data := <-net.endCh:
+			return data, nil
+		}
+	}
+}
+
+func verify(t *testing.T, c *Coordinator, data *common.SignatureData) {
+	pk := edwards.PublicKey{
+		Curve: tss.Edwards(),
+		X:     c.key.Pubkey.X(),
+		Y:     c.key.Pubkey.Y(),
+	}
+	sig, err := edwards.ParseSignature(data.Signature)
+	assert.NoError(t, err)
+	assert.True(t, edwards.Verify(&pk, testMsg, sig.R, sig.S), "eddsa verify must pass")
+}
+
+// dealKeys returns the keys of a t-of-n key dealt with Shamir's secret sharing, as the keygen would output them
+func dealKeys(t *testing.T, n, threshold int) ([]keygen.LocalPartySaveData, tss.SortedPartyIDs) {
+	ec := tss.Edwards()
+	secret := common.GetRandomPositiveInt(rand.Reader, ec.Params().N)
+	ids := make([]*big.Int, n)
+	for i := range ids {
+		ids[i] = common.GetRandomPositiveInt(rand.Reader, ec.Params().N)
+	}
+	_, shares, err := vss.Create(ec, threshold, secret, ids, rand.Reader)
+	assert.NoError(t, err)
+
+	unsorted := make(tss.UnSortedPartyIDs, n)
+	for i, share := range shares {
+		unsorted[i] = tss.NewPartyID(fmt.Sprint(i+1), fmt.Sprintf("P[%d]", i+1), share.ID)
+	}
+	pIDs := tss.SortPartyIDs(unsorted)
+
+	key := keygen.NewLocalPartySaveData(n)
+	key.Pubkey = crypto.ScalarBaseMult(ec, secret)
+	key.ChainCode = big.NewInt(0)
+	for j, Pj := range pIDs {
+		for _, share := range shares {
+			if share.ID.Cmp(Pj.KeyInt()) == 0 {
+				key.Ks[j] = share.ID
+				key.PubXj[j] = crypto.ScalarBaseMult(ec, share.Share)
+			}
+		}
+	}
+	keys := make([]keygen.LocalPartySaveData, n)
+	for j := range pIDs {
+		keys[j] = key
+		keys[j].ShareID = key.Ks[j]
+		for _, share := range shares {
+			if share.ID.Cmp(key.Ks[j]) == 0 {
+				keys[j].PrivXi = share.Share
+			}
+		}
+	}
+	return keys, pIDs
+}
@@ -0,0 +1,135 @@
+package roast
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/felicityin/mpc-tss/common"
+	"github.com/felicityin/mpc-tss/protocols/cggmp/keygen"
+	"github.com/felicityin/mpc-tss/protocols/frost/sign"
+	"github.com/felicityin/mpc-tss/tss"
+)
+
+type (
+	// Signer is a signer of ROAST: it runs a party of frost/sign in each session that it joins
+	Signer struct {
+		msg          *big.Int
+		isThreshold  bool
+		params       *tss.Parameters
+		path         string
+		key          keygen.LocalPartySaveData
+		fullBytesLen []int
+
+		mtx     sync.Mutex
+		parties map[int]tss.Party
+		pending map[int][]*pendingMessage // the messages of the sessions that the signer has not joined yet
+
+		out chan<- *Message
+		end chan<- *common.SignatureData
+	}
+
+	pendingMessage struct {
+		wireBytes   []byte
+		from        *tss.PartyID
+		isBroadcast bool
+	}
+)
+
+// NewSigner returns the signer of msg with the party of params, which is one of the signers of the coordinator.
+// The messages of the signer in its sessions are sent to out, and the signatures of the sessions that complete to end.
+func NewSigner(
+	msg *big.Int,
+	isThreshold bool,
+	params *tss.Parameters,
+	path string,
+	key keygen.LocalPartySaveData,
+	out chan<- *Message,
+	end chan<- *common.SignatureData,
+	fullBytesLen ...int,
+) *Signer {
+	return &Signer{
+		msg:          msg,
+		isThreshold:  isThreshold,
+		params:       params,
+		path:         path,
+		key:          key,
+		fullBytesLen: fullBytesLen,
+		parties:      make(map[int]tss.Party),
+		pending:      make(map[int][]*pendingMessage),
+		out:          out,
+		end:          end,
+	}
+}
+
+// JoinSession starts the party of the signer in a session that the coordinator started
+func (s *Signer) JoinSession(session *Session) *tss.Error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if _, ok := s.parties[session.ID]; ok {
+		return tss.NewError(fmt.Errorf("already joined the session %d", session.ID), sign.TaskName, -1, s.params.PartyID())
+	}
+	i, err := session.indexOf(s.params.PartyID())
+	if err != nil {
+		return tss.NewError(err, sign.TaskName, -1, s.params.PartyID())
+	}
+
+	params := tss.NewParameters(s.params.EC(), tss.NewPeerContext(session.Signers), session.Signers[i], len(session.Signers), s.params.Threshold())
+	params.SetConcurrency(s.params.Concurrency())
+	params.SetRand(s.params.Rand())
+	params.SetKeyTweaks(s.params.KeyTweaks()...)
+
+	// a party of frost/sign sends one message in each of its two rounds
+	out := make(chan tss.Message, 2)
+	party, err := sign.NewLocalParty(s.msg, s.isThreshold, params, s.path, s.key, out, s.end, s.fullBytesLen...)
+	if err != nil {
+		return tss.NewError(err, sign.TaskName, -1, s.params.PartyID())
+	}
+	go func() {
+		for n := 0; n < 2; n++ {
+			s.out <- &Message{Session: session.ID, Message: <-out}
+		}
+	}()
+
+	s.parties[session.ID] = party
+	if err := party.Start(); err != nil {
+		return err
+	}
+	pending := s.pending[session.ID]
+	delete(s.pending, session.ID)
+	for _, msg := range pending {
+		if _, err := party.UpdateFromBytes(msg.wireBytes, msg.from, msg.isBroadcast); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Update passes a message of another signer to the party of the signer in the session of the message
+func (s *Signer) Update(msg *Message) (bool, *tss.Error) {
+	wireBytes, routing, err := msg.WireBytes()
+	if err != nil {
+		return false, tss.NewError(err, sign.TaskName, -1, s.params.PartyID())
+	}
+	return s.UpdateFromBytes(msg.Session, wireBytes, routing.From, routing.IsBroadcast)
+}
+
+// UpdateFromBytes passes a message of another signer, as it was sent over the wire, to the party of the signer in the session.
+// The messages of a session that the signer has not joined yet are kept until it joins it.
+func (s *Signer) UpdateFromBytes(sessionID int, wireBytes []byte, from *tss.PartyID, isBroadcast bool) (bool, *tss.Error) {
+	s.mtx.Lock()
+	party, ok := s.parties[sessionID]
+	if !ok {
+		s.pending[sessionID] = append(s.pending[sessionID], &pendingMessage{wireBytes, from, isBroadcast})
+		s.mtx.Unlock()
+		return true, nil
+	}
+	s.mtx.Unlock()
+	return party.UpdateFromBytes(wireBytes, from, isBroadcast)
+}
+
+// PartyID returns the party ID of the signer among all the signers
+func (s *Signer) PartyID() *tss.PartyID {
+	return s.params.PartyID()
+}
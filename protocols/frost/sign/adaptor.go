@@ -0,0 +1,146 @@
+package sign
+
+import (
+	"crypto/sha512"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/agl/ed25519/edwards25519"
+	edwards "github.com/decred/dcrd/dcrec/edwards/v2"
+
+	"github.com/felicityin/mpc-tss/common"
+	"github.com/felicityin/mpc-tss/crypto"
+	"github.com/felicityin/mpc-tss/tss"
+)
+
+// PreSignature is a Schnorr adaptor signature of Ed25519: the signers commit to the nonce point R + T,
+// so that s' = k + c * x becomes the signature (R + T, s' + t) with the discrete logarithm t of the adaptor point T,
+// and gives away t to whoever has both.
+type PreSignature struct {
+	R        *crypto.ECPoint // R + T, the nonce point of the signature
+	T        *crypto.ECPoint
+	S        *big.Int
+	M        []byte
+	HashMode common.HashMode
+	Context  []byte
+}
+
+// ValidateAdaptor checks that the adaptor point T is an ed25519 point of the prime-order subgroup other than the identity
+func ValidateAdaptor(T *crypto.ECPoint) error {
+	if T == nil || !T.ValidateBasic() || !tss.SameCurve(T.Curve(), tss.Edwards()) {
+		return errors.New("the adaptor point is missing or not an ed25519 point")
+	}
+	if T.X().Sign() == 0 && T.Y().Cmp(big.NewInt(1)) == 0 {
+		return errors.New("the adaptor point is the identity")
+	}
+	if !T.EightInvEight().Equals(T) {
+		return errors.New("the adaptor point is not in the prime-order subgroup")
+	}
+	return nil
+}
+
+// AdaptNonce returns R + T and its encoding, where encodedR is the encoding of the group commitment R
+func AdaptNonce(encodedR *[32]byte, T *crypto.ECPoint) (*crypto.ECPoint, *[32]byte, error) {
+	pk, err := edwards.ParsePubKey(encodedR[:])
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid group commitment: %s", err.Error())
+	}
+	R, err := crypto.NewECPoint(T.Curve(), pk.X, pk.Y)
+	if err != nil {
+		return nil, nil, err
+	}
+	if R, err = R.Add(T); err != nil {
+		return nil, nil, fmt.Errorf("R + T err: %s", err.Error())
+	}
+	return R, ecPointToEncodedBytes(R.X(), R.Y()), nil
+}
+
+// Verify checks the pre-signature against the public key, the group key or the child key that signed:
+// s' * G + T = R + c * X with c = SHA-512(dom2 || R || X || M)
+func (pre *PreSignature) Verify(pubkey *crypto.ECPoint) error {
+	if pre == nil || pre.R == nil || pre.T == nil || pre.S == nil || pubkey == nil || !tss.SameCurve(pubkey.Curve(), pre.R.Curve()) {
+		return errors.New("the pre-signature or the public key is missing")
+	}
+	ec := pubkey.Curve()
+	if pre.S.Sign() < 0 || pre.S.Cmp(ec.Params().N) >= 0 {
+		return errors.New("the pre-signature has s' out of range")
+	}
+	c, err := pre.challenge(pubkey)
+	if err != nil {
+		return err
+	}
+	left, err := crypto.ScalarBaseMult(ec, pre.S).Add(pre.T)
+	if err != nil {
+		return err
+	}
+	right, err := pubkey.ScalarMult(c).Add(pre.R)
+	if err != nil {
+		return err
+	}
+	if !left.Equals(right) {
+		return errors.New("the pre-signature does not verify against the public key")
+	}
+	return nil
+}
+
+// Complete returns the signature of the pre-signature with the discrete logarithm t of its adaptor point
+func (pre *PreSignature) Complete(t *big.Int) (*common.SignatureData, error) {
+	if pre == nil || pre.R == nil || pre.T == nil || pre.S == nil || t == nil {
+		return nil, errors.New("the pre-signature or t is missing")
+	}
+	ec := pre.T.Curve()
+	if !crypto.ScalarBaseMult(ec, t).Equals(pre.T) {
+		return nil, errors.New("t is not the discrete logarithm of the adaptor point")
+	}
+	s := common.ModInt(ec.Params().N).Add(pre.S, t)
+	encodedR := ecPointToEncodedBytes(pre.R.X(), pre.R.Y())
+	r := encodedBytesToBigInt(encodedR)
+	return &common.SignatureData{
+		Signature: append(encodedR[:], bigIntToEncodedBytes(s)[:]...),
+		R:         r.Bytes(),
+		S:         s.Bytes(),
+		M:         pre.M,
+		HashMode:  pre.HashMode,
+		Context:   pre.Context,
+	}, nil
+}
+
+// Extract returns the discrete logarithm t of the adaptor point from the signature that completed the pre-signature
+func (pre *PreSignature) Extract(sig *common.SignatureData) (*big.Int, error) {
+	if pre == nil || pre.R == nil || pre.T == nil || pre.S == nil || sig == nil {
+		return nil, errors.New("the pre-signature or the signature is missing")
+	}
+	ec := pre.T.Curve()
+	encodedR := ecPointToEncodedBytes(pre.R.X(), pre.R.Y())
+	if new(big.Int).SetBytes(sig.R).Cmp(encodedBytesToBigInt(encodedR)) != 0 {
+		return nil, errors.New("the signature is not of the nonce point of the pre-signature")
+	}
+	t := common.ModInt(ec.Params().N).Sub(new(big.Int).SetBytes(sig.S), pre.S)
+	if !crypto.ScalarBaseMult(ec, t).Equals(pre.T) {
+		return nil, errors.New("the signature does not complete the pre-signature")
+	}
+	return t, nil
+}
+
+// challenge returns c = SHA-512(dom2 || R || X || M) of RFC 8032, where dom2 follows from the hash mode and the context
+func (pre *PreSignature) challenge(pubkey *crypto.ECPoint) (*big.Int, error) {
+	var dom []byte
+	if pre.HashMode != common.HashMode_LEGACY {
+		var err error
+		if dom, _, err = (&common.Message{Mode: pre.HashMode, Context: pre.Context}).EdDSAInput(); err != nil {
+			return nil, err
+		}
+	}
+	h := sha512.New()
+	h.Write(dom)
+	h.Write(ecPointToEncodedBytes(pre.R.X(), pre.R.Y())[:])
+	h.Write(ecPointToEncodedBytes(pubkey.X(), pubkey.Y())[:])
+	h.Write(pre.M)
+
+	var digest [64]byte
+	h.Sum(digest[:0])
+	var reduced [32]byte
+	edwards25519.ScReduce(&reduced, &digest)
+	return encodedBytesToBigInt(&reduced), nil
+}
@@ -0,0 +1,207 @@
+package sign
+
+import (
+	"crypto/elliptic"
+	"crypto/sha512"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/agl/ed25519/edwards25519"
+
+	"github.com/felicityin/mpc-tss/crypto"
+	"github.com/felicityin/mpc-tss/tss"
+)
+
+// EvidenceShare is the kind of the evidence against a party whose signature share is invalid
+const EvidenceShare = "frost/sign/share"
+
+func init() {
+	tss.RegisterEvidenceCheck(EvidenceShare, checkShareEvidence)
+}
+
+// ShareInputs are the public inputs of the check of the signature share z_j of Pj: the message, the mode of the signing
+// and the public data of the key prepared for the signing, which are fixed before the signing.
+// PubXj are the public key shares of the signers weighted by their Lagrange coefficients, and Ks their identifiers.
+// The commitment Rj of Pj and the challenge are computed again from the round 1 messages of all the signers.
+type ShareInputs struct {
+	Curve        tss.CurveName     `json:"curve"`
+	Index        int               `json:"index"`
+	RFC9591      bool              `json:"rfc9591,omitempty"`
+	M            *big.Int          `json:"m"`
+	FullBytesLen int               `json:"full_bytes_len,omitempty"`
+	Dom          []byte            `json:"dom,omitempty"`
+	Adaptor      *crypto.ECPoint   `json:"adaptor,omitempty"`
+	Pubkey       *crypto.ECPoint   `json:"pubkey"`
+	Ks           []*big.Int        `json:"ks"`
+	PubXj        []*crypto.ECPoint `json:"pub_xj"`
+}
+
+// CheckShare checks that z_j * G = Rj + c * Xj, i.e. Rj + c * lambda_j * Y_j
+func CheckShare(ec elliptic.Curve, Rj *crypto.ECPoint, c *big.Int, Xj *crypto.ECPoint, r2msg *SignRound2Message) error {
+	zj := r2msg.UnmarshalS()
+	zjGx, zjGy := ec.ScalarBaseMult(zj.Bytes())
+
+	expected, err := Xj.ScalarMult(c).Add(Rj)
+	if err != nil {
+		return fmt.Errorf("err: Rj + c * Xj: %s", err.Error())
+	}
+	if zjGx.Cmp(expected.X()) != 0 || zjGy.Cmp(expected.Y()) != 0 {
+		return errors.New("err: Zj != Rj + c * Xj")
+	}
+	return nil
+}
+
+// Challenge returns the challenge c = SHA-512(dom2 || R || X || M) of the group commitment R, or of R + T with an adaptor point T
+func Challenge(R, T, pubkey *crypto.ECPoint, dom, msg []byte) (*big.Int, error) {
+	if T != nil {
+		var err error
+		if R, err = R.Add(T); err != nil {
+			return nil, fmt.Errorf("R + T err: %s", err.Error())
+		}
+	}
+	encodedR := ecPointToEncodedBytes(R.X(), R.Y())
+	encodedPubKey := ecPointToEncodedBytes(pubkey.X(), pubkey.Y())
+
+	h := sha512.New()
+	h.Write(dom)
+	h.Write(encodedR[:])
+	h.Write(encodedPubKey[:])
+	h.Write(msg)
+	var digest [64]byte
+	h.Sum(digest[:0])
+	var reduced [32]byte
+	edwards25519.ScReduce(&reduced, &digest)
+	return encodedBytesToBigInt(&reduced), nil
+}
+
+// Commitments returns the commitment Rj of every signer and the challenge of the signing,
+// computed from the round 1 messages of all the signers in the order of the parties, as the signers compute them
+func (in *ShareInputs) Commitments(ec elliptic.Curve, r1msgs []*SignRound1Message) ([]*crypto.ECPoint, *big.Int, error) {
+	if len(r1msgs) != len(in.PubXj) || len(in.Ks) != len(in.PubXj) {
+		return nil, nil, fmt.Errorf("expected %d round 1 messages and identifiers", len(in.PubXj))
+	}
+	commitments, err := ParseCommitments(ec, in.Ks, r1msgs)
+	if err != nil {
+		return nil, nil, err
+	}
+	msg := messageBytes(in.M, in.FullBytesLen)
+
+	var Rs, summed []*crypto.ECPoint
+	if in.RFC9591 {
+		if Rs, err = ComputeCommitments(commitments, ComputeBindingFactors(in.Pubkey, commitments, msg)); err != nil {
+			return nil, nil, err
+		}
+		summed = Rs
+	} else {
+		hashB, _, err := legacyCommitmentListHash(in.PubXj, r1msgs)
+		if err != nil {
+			return nil, nil, err
+		}
+		Rs = make([]*crypto.ECPoint, len(commitments))
+		summed = make([]*crypto.ECPoint, len(commitments))
+		for j, commitment := range commitments {
+			rhoj := legacyBindingFactor(j, in.M, hashB, ec.Params().N)
+			if Rs[j], err = commitment.E.ScalarMult(rhoj).Add(commitment.D); err != nil {
+				return nil, nil, fmt.Errorf("rho * E + D err: %s", err.Error())
+			}
+			// the signers sum the commitments with their torsion cleared
+			summed[j] = Rs[j].EightInvEight()
+		}
+	}
+	R, err := ComputeGroupCommitment(summed)
+	if err != nil {
+		return nil, nil, err
+	}
+	c, err := Challenge(R, in.Adaptor, in.Pubkey, in.Dom, msg)
+	if err != nil {
+		return nil, nil, err
+	}
+	return Rs, c, nil
+}
+
+// ParseCommitments returns the signing commitments of the round 1 messages of the signers of the identifiers Ks
+func ParseCommitments(ec elliptic.Curve, Ks []*big.Int, r1msgs []*SignRound1Message) ([]*SigningCommitment, error) {
+	commitments := make([]*SigningCommitment, len(r1msgs))
+	for j, r1msg := range r1msgs {
+		D, err := r1msg.UnmarshalD()
+		if err != nil || !tss.SameCurve(D.Curve(), ec) {
+			return nil, fmt.Errorf("failed to unmarshal D of party %d", j)
+		}
+		E, err := r1msg.UnmarshalE()
+		if err != nil || !tss.SameCurve(E.Curve(), ec) {
+			return nil, fmt.Errorf("failed to unmarshal E of party %d", j)
+		}
+		commitments[j] = &SigningCommitment{Identifier: new(big.Int).Mod(Ks[j], ec.Params().N), D: D, E: E}
+	}
+	return commitments, nil
+}
+
+// Validate checks that the inputs are about the culprit and that their points are on the curve
+func (in *ShareInputs) Validate(ec elliptic.Curve, culprit *tss.PartyID) error {
+	if in.Index != culprit.Index || in.Index < 0 || in.Index >= len(in.PubXj) {
+		return fmt.Errorf("the inputs are about party %d, not the culprit", in.Index)
+	}
+	if in.M == nil || in.Pubkey == nil || !tss.SameCurve(in.Pubkey.Curve(), ec) {
+		return errors.New("the message or the public key are missing")
+	}
+	if in.Adaptor != nil && !tss.SameCurve(in.Adaptor.Curve(), ec) {
+		return errors.New("the adaptor point is not on the curve")
+	}
+	if len(in.Ks) != len(in.PubXj) {
+		return errors.New("expected an identifier for every key share")
+	}
+	for j, Xj := range in.PubXj {
+		if Xj == nil || !tss.SameCurve(Xj.Curve(), ec) || in.Ks[j] == nil {
+			return fmt.Errorf("the key share or the identifier of party %d are missing", j)
+		}
+	}
+	return nil
+}
+
+// ParseShareEvidence returns the round 2 message of the culprit, and the round 1 messages of all the signers in the order of the parties,
+// which are sent by the parties of the identifiers Ks. The evidence holds the round 2 message first.
+func ParseShareEvidence(Ks []*big.Int, msgs []tss.ParsedMessage) (*SignRound2Message, []*SignRound1Message, error) {
+	if len(msgs) == 0 {
+		return nil, nil, errors.New("expected the round 2 message")
+	}
+	r2msg, ok := msgs[0].Content().(*SignRound2Message)
+	if !ok {
+		return nil, nil, errors.New("expected the round 2 message")
+	}
+	byIndex, err := tss.MessagesBySender(msgs[1:], len(Ks), (*SignRound1Message)(nil))
+	if err != nil {
+		return nil, nil, err
+	}
+	r1msgs := make([]*SignRound1Message, len(byIndex))
+	for j, msg := range byIndex {
+		if msg.GetFrom().KeyInt().Cmp(Ks[j]) != 0 {
+			return nil, nil, fmt.Errorf("the round 1 message of party %d is not from the signer of its identifier", j)
+		}
+		r1msgs[j] = msg.Content().(*SignRound1Message)
+	}
+	return r2msg, r1msgs, nil
+}
+
+func checkShareEvidence(ev *tss.Evidence, msgs []tss.ParsedMessage) (failure error, err error) {
+	in := new(ShareInputs)
+	if err := ev.UnmarshalInputs(in); err != nil {
+		return nil, err
+	}
+	ec, ok := tss.GetCurveByName(in.Curve)
+	if !ok {
+		return nil, fmt.Errorf("unknown curve %s", in.Curve)
+	}
+	if err := in.Validate(ec, ev.Culprit); err != nil {
+		return nil, err
+	}
+	r2msg, r1msgs, err := ParseShareEvidence(in.Ks, msgs)
+	if err != nil {
+		return nil, err
+	}
+	Rs, c, err := in.Commitments(ec, r1msgs)
+	if err != nil {
+		return nil, err
+	}
+	return CheckShare(ec, Rs[in.Index], c, in.PubXj[in.Index], r2msg), nil
+}
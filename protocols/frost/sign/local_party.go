@@ -26,8 +26,9 @@ type (
 		data *common.SignatureData
 
 		// outbound messaging
-		out chan<- tss.Message
-		end chan<- *common.SignatureData
+		out    chan<- tss.Message
+		end    chan<- *common.SignatureData
+		preEnd chan<- *PreSignature
 	}
 
 	localMessageStore struct {
@@ -39,18 +40,20 @@ type (
 		localMessageStore
 
 		isThreshold bool
+		rfc9591     bool
 
 		m            *big.Int
 		r            *big.Int
 		fullBytesLen int
+		dom          []byte // dom2 of RFC 8032, empty for Ed25519
+		adaptor      *crypto.ECPoint
 
 		// round 1
-		d *big.Int
-		e *big.Int
+		d      *big.Int
+		e      *big.Int
+		secret *big.Int // key share hedging the nonces in the RFC 9591 mode
 
 		// round 2
-		c  *big.Int
-		Rj []*crypto.ECPoint
 		si *[32]byte
 
 		ssid      []byte
@@ -67,14 +70,76 @@ func NewLocalParty(
 	out chan<- tss.Message,
 	end chan<- *common.SignatureData,
 	fullBytesLen ...int,
+) (tss.Party, error) {
+	return newLocalParty(msg, isThreshold, false, params, path, key, out, end, fullBytesLen...)
+}
+
+// NewLocalPartyWithAdaptor returns a party that makes the pre-signature of the message for the adaptor point T,
+// with the variant of RFC 8032 of its hash mode, which it outputs on end once it is checked against the key.
+// The signers commit to the nonce point R + T, and the pre-signature is completed into a signature
+// with the discrete logarithm of T, see PreSignature.
+func NewLocalPartyWithAdaptor(
+	msg *common.Message,
+	T *crypto.ECPoint,
+	isThreshold bool,
+	params *tss.Parameters,
+	path string,
+	key keygen.LocalPartySaveData,
+	out chan<- tss.Message,
+	end chan<- *PreSignature,
+) (tss.Party, error) {
+	if err := ValidateAdaptor(T); err != nil {
+		return nil, err
+	}
+	party, err := NewLocalPartyWithMessage(msg, isThreshold, params, path, key, out, nil)
+	if err != nil {
+		return nil, err
+	}
+	p := party.(*LocalParty)
+	p.temp.adaptor = T
+	p.preEnd = end
+	return p, nil
+}
+
+// NewLocalPartyRFC9591 returns a party of the FROST(Ed25519, SHA-512) ciphersuite of RFC 9591,
+// which interoperates with other implementations of the RFC.
+// The identifier of a signer is its key in Ks, and the nonces are hedged with the key share.
+func NewLocalPartyRFC9591(
+	msg *big.Int,
+	isThreshold bool,
+	params *tss.Parameters,
+	path string,
+	key keygen.LocalPartySaveData,
+	out chan<- tss.Message,
+	end chan<- *common.SignatureData,
+	fullBytesLen ...int,
+) (tss.Party, error) {
+	return newLocalParty(msg, isThreshold, true, params, path, key, out, end, fullBytesLen...)
+}
+
+func newLocalParty(
+	msg *big.Int,
+	isThreshold bool,
+	rfc9591 bool,
+	params *tss.Parameters,
+	path string,
+	key keygen.LocalPartySaveData,
+	out chan<- tss.Message,
+	end chan<- *common.SignatureData,
+	fullBytesLen ...int,
 ) (tss.Party, error) {
 	key, err := keygen.BuildLocalSaveDataSubset(key, params.Parties().IDs())
 	if err != nil {
 		return nil, err
 	}
+	var secret *big.Int
+	if rfc9591 {
+		secret = new(big.Int).Set(key.PrivXi)
+	}
 
-	err = utils.UpdateKeyForSigning(&key, path, isThreshold, params.Threshold())
+	err = utils.UpdateKeyForSigning(&key, path, isThreshold, params.Threshold(), params.KeyTweaks()...)
 	if err != nil {
+		common.ZeroBigInts(secret)
 		return nil, err
 	}
 
@@ -100,12 +165,39 @@ func NewLocalParty(
 		p.temp.fullBytesLen = 0
 	}
 	p.temp.isThreshold = isThreshold
-	p.temp.Rj = make([]*crypto.ECPoint, partyCount)
+	p.temp.rfc9591 = rfc9591
+	p.temp.secret = secret
+	return p, nil
+}
+
+// NewLocalPartyWithMessage returns a party that signs the message with the variant of RFC 8032 of its hash mode:
+// ED25519, ED25519PH or ED25519CTX. The message keeps its leading zero bytes.
+func NewLocalPartyWithMessage(
+	msg *common.Message,
+	isThreshold bool,
+	params *tss.Parameters,
+	path string,
+	key keygen.LocalPartySaveData,
+	out chan<- tss.Message,
+	end chan<- *common.SignatureData,
+) (tss.Party, error) {
+	dom, m, err := msg.EdDSAInput()
+	if err != nil {
+		return nil, err
+	}
+	party, err := NewLocalParty(new(big.Int).SetBytes(m), isThreshold, params, path, key, out, end, len(m))
+	if err != nil {
+		return nil, err
+	}
+	p := party.(*LocalParty)
+	p.temp.dom = dom
+	p.data.HashMode = msg.Mode
+	p.data.Context = msg.Context
 	return p, nil
 }
 
 func (p *LocalParty) FirstRound() tss.Round {
-	return newRound1(p.temp.isThreshold, p.params, &p.keys, p.data, &p.temp, p.out, p.end)
+	return newRound1(p.temp.isThreshold, p.params, &p.keys, p.data, &p.temp, p.out, p.end, p.preEnd)
 }
 
 func (p *LocalParty) Start() *tss.Error {
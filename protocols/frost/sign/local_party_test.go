@@ -3,7 +3,12 @@
 package sign
 
 import (
+	stdcrypto "crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha512"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"math/big"
 	"sync/atomic"
@@ -15,10 +20,13 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/felicityin/mpc-tss/common"
+	"github.com/felicityin/mpc-tss/crypto"
+	"github.com/felicityin/mpc-tss/crypto/sigfmt"
 	"github.com/felicityin/mpc-tss/protocols/cggmp/keygen"
 	nonKeygen "github.com/felicityin/mpc-tss/protocols/cggmp/keygen/non_threshold"
 	tKeygen "github.com/felicityin/mpc-tss/protocols/cggmp/keygen/threshold"
 	"github.com/felicityin/mpc-tss/protocols/cggmp/test"
+	"github.com/felicityin/mpc-tss/protocols/utils"
 	"github.com/felicityin/mpc-tss/tss"
 )
 
@@ -255,3 +263,423 @@ signing:
 		}
 	}
 }
+
+func TestCulpritsOfBadShares(t *testing.T) {
+	setUp("info")
+
+	threshold := testThreshold
+
+	keys, signPIDs, err := tKeygen.LoadKeygenTestFixturesRandomSet(keygen.Eddsa, threshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	parties := make([]*LocalParty, 0, len(signPIDs))
+
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+	endCh := make(chan *common.SignatureData, len(signPIDs))
+
+	updater := test.SharedPartyUpdater
+
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(tss.Edwards(), p2pCtx, signPIDs[i], len(signPIDs), threshold)
+		party, err := NewLocalParty(big.NewInt(42), true, params, "0/1/2/2/10", keys[i], outCh, endCh)
+		assert.NoError(t, err)
+		P := party.(*LocalParty)
+		parties = append(parties, P)
+
+		go func(P *LocalParty) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	// P0 and P1 both send a bad share to P2, which must blame both of them
+	victim := len(signPIDs) - 1
+	var tssErr *tss.Error
+	for tssErr == nil {
+		select {
+		case err := <-errCh:
+			if err.Victim().Index == victim {
+				tssErr = err
+			}
+
+		case msg := <-outCh:
+			for _, P := range parties {
+				if P.PartyID().Index == msg.GetFrom().Index {
+					continue
+				}
+				if r2msg, ok := msg.(tss.ParsedMessage).Content().(*SignRound2Message); ok && P.PartyID().Index == victim {
+					content := &SignRound2Message{Si: new(big.Int).Add(r2msg.UnmarshalS(), big.NewInt(1)).Bytes()}
+					meta := tss.MessageRouting{From: msg.GetFrom(), IsBroadcast: true}
+					go updater(P, tss.NewMessage(meta, content, tss.NewMessageWrapper(meta, content)), errCh)
+					continue
+				}
+				go updater(P, msg, errCh)
+			}
+
+		case <-endCh:
+		}
+	}
+
+	if assert.Len(t, tssErr.Culprits(), 2) {
+		assert.Equal(t, 0, tssErr.Culprits()[0].Index)
+		assert.Equal(t, 1, tssErr.Culprits()[1].Index)
+	}
+	if assert.Len(t, tssErr.Evidence(), 2) {
+		for _, ev := range tssErr.Evidence() {
+			assert.Equal(t, EvidenceShare, ev.Kind)
+			assert.NoError(t, ev.Verify())
+			assert.Len(t, ev.Messages, 1+len(signPIDs), "the evidence holds the round 1 messages of all the signers")
+		}
+		testFramedShare(t, tssErr.Evidence()[0])
+	}
+}
+
+// testFramedShare checks that the evidence against the valid share of the culprit shows no misbehaviour,
+// whatever commitment the accuser adds to the inputs, and that it is rejected without the round 1 message of a signer
+func testFramedShare(t *testing.T, ev *tss.Evidence) {
+	msgs, err := ev.ParseMessages()
+	if !assert.NoError(t, err) {
+		return
+	}
+	r2msg := msgs[0].Content().(*SignRound2Message)
+	content := &SignRound2Message{Si: new(big.Int).Sub(r2msg.UnmarshalS(), big.NewInt(1)).Bytes()}
+	meta := tss.MessageRouting{From: msgs[0].GetFrom(), IsBroadcast: true}
+	valid := tss.NewMessage(meta, content, tss.NewMessageWrapper(meta, content))
+
+	var inputs map[string]json.RawMessage
+	assert.NoError(t, ev.UnmarshalInputs(&inputs))
+	inputs["rj"], _ = crypto.ScalarBaseMult(tss.Edwards(), big.NewInt(7)).MarshalJSON()
+	framed, err := tss.NewEvidence(ev.Kind, ev.Task, ev.Round, ev.Culprit, inputs, append([]tss.ParsedMessage{valid}, msgs[1:]...)...)
+	if assert.NoError(t, err) {
+		assert.ErrorIs(t, framed.Verify(), tss.ErrNoMisbehaviour, "the commitment of the culprit is not taken from the accuser")
+	}
+
+	partial, err := tss.NewEvidence(ev.Kind, ev.Task, ev.Round, ev.Culprit, inputs, msgs[:len(msgs)-1]...)
+	if assert.NoError(t, err) {
+		err := partial.Verify()
+		assert.Error(t, err)
+		assert.NotErrorIs(t, err, tss.ErrNoMisbehaviour, "the evidence needs the round 1 messages of all the signers")
+	}
+}
+
+func TestE2ETweakedConcurrent(t *testing.T) {
+	setUp("info")
+
+	keys, signPIDs, err := tKeygen.LoadKeygenTestFixturesRandomSet(keygen.Eddsa, testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	parties := make([]*LocalParty, 0, len(signPIDs))
+
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+	endCh := make(chan *common.SignatureData, len(signPIDs))
+
+	msg, _ := hex.DecodeString("00f163ee51bcaeff9cdff5e0e3c1a646abd19885fffbab0b3b4236e0cf95c9f5")
+	path := "0/1/2/2/10"
+	tweaks := []*big.Int{big.NewInt(7), new(big.Int).Mod(common.SHA512_256i(big.NewInt(42)), tss.Edwards().Params().N)}
+
+	// the parties sign for the derived key shifted by the tweaks
+	_, derived, err := utils.DerivingPubkeyFromPath(keys[0].Pubkey, keys[0].ChainCode.Bytes(), path, tss.Edwards())
+	assert.NoError(t, err)
+	tweaked, err := utils.TweakPubkey(derived.PublicKey, tweaks...)
+	assert.NoError(t, err)
+
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(tss.Edwards(), p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+		params.SetKeyTweaks(tweaks...)
+		party, err := NewLocalParty(new(big.Int).SetBytes(msg), true, params, path, keys[i], outCh, endCh, len(msg))
+		assert.NoError(t, err)
+		P := party.(*LocalParty)
+		assert.True(t, tweaked.Equals(P.keys.Pubkey))
+		parties = append(parties, P)
+	}
+	// start every party before routing the messages, so that no party receives all the messages of round 1 before it starts
+	for _, P := range parties {
+		if err := P.Start(); err != nil {
+			assert.FailNow(t, err.Error())
+		}
+	}
+
+	for ended := 0; ended < len(parties); {
+		select {
+		case err := <-errCh:
+			assert.FailNow(t, err.Error())
+
+		case msg := <-outCh:
+			for _, P := range parties {
+				if P.PartyID().Index == msg.GetFrom().Index {
+					continue
+				}
+				go test.SharedPartyUpdater(P, msg, errCh)
+			}
+
+		case data := <-endCh:
+			ended++
+			pk := edwards.PublicKey{
+				Curve: tss.Edwards(),
+				X:     tweaked.X(),
+				Y:     tweaked.Y(),
+			}
+			sig, err := edwards.ParseSignature(data.Signature)
+			assert.NoError(t, err)
+			assert.True(t, edwards.Verify(&pk, msg, sig.R, sig.S), "eddsa verify must pass")
+		}
+	}
+}
+
+func TestE2EBIP32Ed25519Concurrent(t *testing.T) {
+	setUp("info")
+
+	keys, signPIDs, err := tKeygen.LoadKeygenTestFixturesRandomSet(keygen.Eddsa, testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	parties := make([]*LocalParty, 0, len(signPIDs))
+
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+	endCh := make(chan *common.SignatureData, len(signPIDs))
+
+	msg, _ := hex.DecodeString("00f163ee51bcaeff9cdff5e0e3c1a646abd19885fffbab0b3b4236e0cf95c9f5")
+	path := "0/1/2/2/10"
+
+	// the parties sign for the child key of BIP32-Ed25519
+	_, derived, err := utils.DerivingEd25519PubkeyFromPath(keys[0].Pubkey, keys[0].ChainCode.Bytes(), path)
+	assert.NoError(t, err)
+	_, bip32, err := utils.DerivingPubkeyFromPath(keys[0].Pubkey, keys[0].ChainCode.Bytes(), path, tss.Edwards())
+	assert.NoError(t, err)
+	assert.False(t, derived.PublicKey.Equals(bip32.PublicKey))
+
+	for i := 0; i < len(signPIDs); i++ {
+		keys[i].Derivation = keygen.DerivationBIP32Ed25519
+		assert.NoError(t, keys[i].Validate())
+
+		params := tss.NewParameters(tss.Edwards(), p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+		party, err := NewLocalParty(new(big.Int).SetBytes(msg), true, params, path, keys[i], outCh, endCh, len(msg))
+		assert.NoError(t, err)
+		P := party.(*LocalParty)
+		assert.True(t, derived.PublicKey.Equals(P.keys.Pubkey))
+		parties = append(parties, P)
+	}
+	// start every party before routing the messages, so that no party receives all the messages of round 1 before it starts
+	for _, P := range parties {
+		if err := P.Start(); err != nil {
+			assert.FailNow(t, err.Error())
+		}
+	}
+
+	for ended := 0; ended < len(parties); {
+		select {
+		case err := <-errCh:
+			assert.FailNow(t, err.Error())
+
+		case msg := <-outCh:
+			for _, P := range parties {
+				if P.PartyID().Index == msg.GetFrom().Index {
+					continue
+				}
+				go test.SharedPartyUpdater(P, msg, errCh)
+			}
+
+		case data := <-endCh:
+			ended++
+			pk := edwards.PublicKey{
+				Curve: tss.Edwards(),
+				X:     derived.PublicKey.X(),
+				Y:     derived.PublicKey.Y(),
+			}
+			sig, err := edwards.ParseSignature(data.Signature)
+			assert.NoError(t, err)
+			assert.True(t, edwards.Verify(&pk, msg, sig.R, sig.S), "eddsa verify must pass")
+		}
+	}
+}
+
+func TestE2EMessageModesConcurrent(t *testing.T) {
+	setUp("info")
+
+	// the leading zero bytes of the message are signed, with no fullBytesLen
+	msg := []byte{0x00, 0x00, 0x42}
+	tests := []struct {
+		msg  *common.Message
+		opts *ed25519.Options
+	}{
+		{common.NewEd25519Message(msg), &ed25519.Options{}},
+		{common.NewEd25519phMessage(msg, []byte("foo")), &ed25519.Options{Hash: stdcrypto.SHA512, Context: "foo"}},
+		{common.NewEd25519ctxMessage(msg, []byte("foo")), &ed25519.Options{Context: "foo"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.msg.Mode.String(), func(t *testing.T) {
+			data, pubkey := signMessage(t, tt.msg)
+			assert.Equal(t, tt.msg.Mode, data.HashMode)
+
+			sig, err := sigfmt.Ed25519(data)
+			assert.NoError(t, err)
+			pk := edwards.NewPublicKey(pubkey.X(), pubkey.Y()).Serialize()
+			signed := msg
+			if tt.opts.Hash == stdcrypto.SHA512 {
+				h := sha512.Sum512(msg)
+				signed = h[:]
+			}
+			assert.Equal(t, signed, data.M)
+			assert.NoError(t, ed25519.VerifyWithOptions(pk, signed, sig, tt.opts))
+			assert.NoError(t, sigfmt.VerifyEd25519(pubkey, data))
+		})
+	}
+
+	keys, signPIDs, err := tKeygen.LoadKeygenTestFixturesRandomSet(keygen.Eddsa, testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	params := tss.NewParameters(tss.Edwards(), tss.NewPeerContext(signPIDs), signPIDs[0], len(signPIDs), testThreshold)
+	_, err = NewLocalPartyWithMessage(common.NewSHA256Message(msg), true, params, "0/1", keys[0], nil, nil)
+	assert.Error(t, err, "SHA256 is not a mode of EdDSA")
+}
+
+// signMessage signs the message with a threshold key, and returns the signature and the public key of the signing
+func signMessage(t *testing.T, msg *common.Message) (*common.SignatureData, *crypto.ECPoint) {
+	keys, signPIDs, err := tKeygen.LoadKeygenTestFixturesRandomSet(keygen.Eddsa, testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	parties := make([]*LocalParty, 0, len(signPIDs))
+
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+	endCh := make(chan *common.SignatureData, len(signPIDs))
+
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(tss.Edwards(), p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+		party, err := NewLocalPartyWithMessage(msg, true, params, "0/1/2/2/10", keys[i], outCh, endCh)
+		assert.NoError(t, err)
+		parties = append(parties, party.(*LocalParty))
+	}
+	// start every party before routing the messages, so that no party receives all the messages of round 1 before it starts
+	for _, P := range parties {
+		if err := P.Start(); err != nil {
+			assert.FailNow(t, err.Error())
+		}
+	}
+
+	var data *common.SignatureData
+	for ended := 0; ended < len(parties); {
+		select {
+		case err := <-errCh:
+			assert.FailNow(t, err.Error())
+
+		case msg := <-outCh:
+			for _, P := range parties {
+				if P.PartyID().Index == msg.GetFrom().Index {
+					continue
+				}
+				go test.SharedPartyUpdater(P, msg, errCh)
+			}
+
+		case data = <-endCh:
+			ended++
+		}
+	}
+	return data, parties[0].keys.Pubkey
+}
+
+func TestE2EAdaptorConcurrent(t *testing.T) {
+	setUp("info")
+
+	ec := tss.Edwards()
+	msg := []byte{0x00, 0x00, 0x42}
+	for _, m := range []*common.Message{
+		common.NewEd25519Message(msg),
+		common.NewEd25519ctxMessage(msg, []byte("foo")),
+	} {
+		t.Run(m.Mode.String(), func(t *testing.T) {
+			secret := common.GetRandomPositiveInt(rand.Reader, ec.Params().N)
+			T := crypto.ScalarBaseMult(ec, secret)
+			pre, pubkey := preSignMessage(t, m, T)
+
+			assert.NoError(t, pre.Verify(pubkey))
+			assert.False(t, edwards.Verify(
+				&edwards.PublicKey{Curve: ec, X: pubkey.X(), Y: pubkey.Y()}, pre.M, encodedBytesToBigInt(ecPointToEncodedBytes(pre.R.X(), pre.R.Y())), pre.S,
+			), "the pre-signature is not a signature")
+
+			_, err := pre.Complete(new(big.Int).Add(secret, big.NewInt(1)))
+			assert.Error(t, err)
+			sig, err := pre.Complete(secret)
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Equal(t, m.Mode, sig.HashMode)
+			assert.NoError(t, sigfmt.VerifyEd25519(pubkey, sig))
+
+			extracted, err := pre.Extract(sig)
+			assert.NoError(t, err)
+			assert.Equal(t, 0, secret.Cmp(extracted))
+		})
+	}
+}
+
+func TestInvalidAdaptor(t *testing.T) {
+	setUp("info")
+
+	keys, signPIDs, err := tKeygen.LoadKeygenTestFixturesRandomSet(keygen.Eddsa, testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	params := tss.NewParameters(tss.Edwards(), tss.NewPeerContext(signPIDs), signPIDs[0], len(signPIDs), testThreshold)
+
+	identity := crypto.NewECPointNoCurveCheck(tss.Edwards(), big.NewInt(0), big.NewInt(1))
+	// (0, -1) has order 2
+	smallOrder := crypto.NewECPointNoCurveCheck(tss.Edwards(), big.NewInt(0), new(big.Int).Sub(tss.Edwards().Params().P, big.NewInt(1)))
+	torsioned, err := crypto.ScalarBaseMult(tss.Edwards(), big.NewInt(42)).Add(smallOrder)
+	assert.NoError(t, err)
+	for _, T := range []*crypto.ECPoint{nil, identity, smallOrder, torsioned, crypto.ScalarBaseMult(tss.S256(), big.NewInt(42))} {
+		_, err := NewLocalPartyWithAdaptor(common.NewEd25519Message([]byte{0x42}), T, true, params, "0/1", keys[0], nil, nil)
+		assert.Error(t, err)
+	}
+}
+
+// preSignMessage makes the pre-signature of the message for the adaptor point with a threshold key,
+// and returns it with the public key of the signing
+func preSignMessage(t *testing.T, msg *common.Message, T *crypto.ECPoint) (*PreSignature, *crypto.ECPoint) {
+	keys, signPIDs, err := tKeygen.LoadKeygenTestFixturesRandomSet(keygen.Eddsa, testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	parties := make([]*LocalParty, 0, len(signPIDs))
+
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+	endCh := make(chan *PreSignature, len(signPIDs))
+
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(tss.Edwards(), p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+		party, err := NewLocalPartyWithAdaptor(msg, T, true, params, "0/1/2/2/10", keys[i], outCh, endCh)
+		assert.NoError(t, err)
+		parties = append(parties, party.(*LocalParty))
+	}
+	// start every party before routing the messages, so that no party receives all the messages of round 1 before it starts
+	for _, P := range parties {
+		if err := P.Start(); err != nil {
+			assert.FailNow(t, err.Error())
+		}
+	}
+
+	var pre *PreSignature
+	for ended := 0; ended < len(parties); {
+		select {
+		case err := <-errCh:
+			assert.FailNow(t, err.Error())
+
+		case msg := <-outCh:
+			for _, P := range parties {
+				if P.PartyID().Index == msg.GetFrom().Index {
+					continue
+				}
+				go test.SharedPartyUpdater(P, msg, errCh)
+			}
+
+		case pre = <-endCh:
+			ended++
+		}
+	}
+	return pre, parties[0].keys.Pubkey
+}
@@ -0,0 +1,166 @@
+package sign
+
+import (
+	"crypto/sha512"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"sort"
+
+	"github.com/agl/ed25519/edwards25519"
+
+	"github.com/felicityin/mpc-tss/common"
+	"github.com/felicityin/mpc-tss/crypto"
+)
+
+// RFC9591ContextString is the context string of the FROST(Ed25519, SHA-512) ciphersuite of RFC 9591
+const RFC9591ContextString = "FROST-ED25519-SHA512-v1"
+
+// SigningCommitment is the pair of nonce commitments (D, E) that the signer with the given identifier sent in round 1.
+// In RFC 9591 D is the hiding nonce commitment and E the binding nonce commitment.
+type SigningCommitment struct {
+	Identifier *big.Int
+	D          *crypto.ECPoint
+	E          *crypto.ECPoint
+}
+
+// H1 is the hash to scalar of the binding factors
+func H1(m []byte) *big.Int {
+	return hashToScalar([]byte(RFC9591ContextString), []byte("rho"), m)
+}
+
+// H2 is the hash to scalar of the challenge, it is the challenge of Ed25519 and has no prefix
+func H2(m []byte) *big.Int {
+	return hashToScalar(m)
+}
+
+// H3 is the hash to scalar of the nonces
+func H3(m []byte) *big.Int {
+	return hashToScalar([]byte(RFC9591ContextString), []byte("nonce"), m)
+}
+
+// H4 is the hash of the message
+func H4(m []byte) []byte {
+	return hash([]byte(RFC9591ContextString), []byte("msg"), m)
+}
+
+// H5 is the hash of the encoded commitment list
+func H5(m []byte) []byte {
+	return hash([]byte(RFC9591ContextString), []byte("com"), m)
+}
+
+// NonceGenerate returns a nonce hedged against a bad source of randomness: H3(random_bytes || secret),
+// where secret is the key share of the signer.
+func NonceGenerate(rand io.Reader, secret *big.Int) (*big.Int, error) {
+	var randomBytes [32]byte
+	if _, err := io.ReadFull(rand, randomBytes[:]); err != nil {
+		return nil, fmt.Errorf("read random bytes err: %s", err.Error())
+	}
+	secretEnc := bigIntToEncodedBytes(secret)
+	nonce := H3(append(randomBytes[:], secretEnc[:]...))
+	common.ZeroBytes(randomBytes[:], secretEnc[:])
+	return nonce, nil
+}
+
+// EncodeGroupCommitmentList encodes the commitments sorted by identifier: identifier || D || E for each signer
+func EncodeGroupCommitmentList(commitments []*SigningCommitment) []byte {
+	encoded := make([]byte, 0, len(commitments)*96)
+	for _, j := range sortedByIdentifier(commitments) {
+		id := bigIntToEncodedBytes(commitments[j].Identifier)
+		D := ecPointToEncodedBytes(commitments[j].D.X(), commitments[j].D.Y())
+		E := ecPointToEncodedBytes(commitments[j].E.X(), commitments[j].E.Y())
+		encoded = append(encoded, id[:]...)
+		encoded = append(encoded, D[:]...)
+		encoded = append(encoded, E[:]...)
+	}
+	return encoded
+}
+
+// ComputeBindingFactors returns the binding factor rho_j of every signer, in the order of the commitments:
+// rho_j = H1(PK || H4(m) || H5(commitment list) || identifier_j)
+func ComputeBindingFactors(pubkey *crypto.ECPoint, commitments []*SigningCommitment, msg []byte) []*big.Int {
+	encodedPubKey := ecPointToEncodedBytes(pubkey.X(), pubkey.Y())
+
+	prefix := append([]byte{}, encodedPubKey[:]...)
+	prefix = append(prefix, H4(msg)...)
+	prefix = append(prefix, H5(EncodeGroupCommitmentList(commitments))...)
+
+	rhos := make([]*big.Int, len(commitments))
+	for j, commitment := range commitments {
+		id := bigIntToEncodedBytes(commitment.Identifier)
+		rhos[j] = H1(append(append([]byte{}, prefix...), id[:]...))
+	}
+	return rhos
+}
+
+// ComputeCommitments returns the commitment Rj = D_j + rho_j * E_j of every signer
+func ComputeCommitments(commitments []*SigningCommitment, bindingFactors []*big.Int) ([]*crypto.ECPoint, error) {
+	if len(commitments) != len(bindingFactors) {
+		return nil, errors.New("the commitments and the binding factors have different lengths")
+	}
+	Rs := make([]*crypto.ECPoint, len(commitments))
+	for j, commitment := range commitments {
+		Rj, err := commitment.E.ScalarMult(bindingFactors[j]).Add(commitment.D)
+		if err != nil {
+			return nil, fmt.Errorf("rho * E + D err: %s", err.Error())
+		}
+		Rs[j] = Rj
+	}
+	return Rs, nil
+}
+
+// ComputeGroupCommitment returns the group commitment R, the sum of the commitments Rj of the signers
+func ComputeGroupCommitment(Rs []*crypto.ECPoint) (*crypto.ECPoint, error) {
+	if len(Rs) == 0 {
+		return nil, errors.New("no commitment")
+	}
+	R := Rs[0]
+	for _, Rj := range Rs[1:] {
+		var err error
+		if R, err = R.Add(Rj); err != nil {
+			return nil, fmt.Errorf("sum of Rj err: %s", err.Error())
+		}
+	}
+	return R, nil
+}
+
+// ComputeChallenge returns the challenge c = H2(R || PK || m) together with the encoding of R
+func ComputeChallenge(R, pubkey *crypto.ECPoint, msg []byte) (*big.Int, *[32]byte) {
+	encodedR := ecPointToEncodedBytes(R.X(), R.Y())
+	encodedPubKey := ecPointToEncodedBytes(pubkey.X(), pubkey.Y())
+
+	input := append([]byte{}, encodedR[:]...)
+	input = append(input, encodedPubKey[:]...)
+	input = append(input, msg...)
+	return H2(input), encodedR
+}
+
+// sortedByIdentifier returns the indexes of the commitments in the ascending order of the identifiers
+func sortedByIdentifier(commitments []*SigningCommitment) []int {
+	order := make([]int, len(commitments))
+	for j := range order {
+		order[j] = j
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return commitments[order[a]].Identifier.Cmp(commitments[order[b]].Identifier) < 0
+	})
+	return order
+}
+
+func hash(parts ...[]byte) []byte {
+	h := sha512.New()
+	for _, part := range parts {
+		h.Write(part)
+	}
+	return h.Sum(nil)
+}
+
+// hashToScalar interprets the SHA-512 digest as a little-endian integer and reduces it modulo the group order
+func hashToScalar(parts ...[]byte) *big.Int {
+	var digest [64]byte
+	copy(digest[:], hash(parts...))
+	var reduced [32]byte
+	edwards25519.ScReduce(&reduced, &digest)
+	return encodedBytesToBigInt(&reduced)
+}
@@ -0,0 +1,208 @@
+package sign
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/felicityin/mpc-tss/common"
+	"github.com/felicityin/mpc-tss/crypto"
+	"github.com/felicityin/mpc-tss/protocols/cggmp/keygen"
+	"github.com/felicityin/mpc-tss/protocols/cggmp/test"
+	"github.com/felicityin/mpc-tss/tss"
+)
+
+// The test vectors of FROST(Ed25519, SHA-512) in RFC 9591, Appendix E.1
+const (
+	rfcGroupSecretKey = "7b1c33d3f5291d85de664833beb1ad469f7fb6025a0ec78b3a790c6e13a98304"
+	rfcGroupPublicKey = "15d21ccd7ee42959562fc8aa63224c8851fb3ec85a3faf66040d380fb9738673"
+	rfcMessage        = "74657374"
+	rfcSignature      = "36282629c383bb820a88b71cae937d41f2f2adfcc3d02e55507e2fb9e2dd3cbe" +
+		"bd9d2b0844e49ae0f3fa935161e1419aab7b47d21a37ebeae1f17d4987b3160b"
+)
+
+var (
+	rfcParticipantShares = []string{
+		"929dcc590407aae7d388761cddb0c0db6f5627aea8e217f4a033f2ec83d93509",
+		"a91e66e012e4364ac9aaa405fcafd370402d9859f7b6685c07eed76bf409e80d",
+		"d3cb090a075eb154e82fdb4b3cb507f110040905468bb9c46da8bdea643a9a02",
+	}
+
+	// the signers are the participants 1 and 3
+	rfcSigners = []rfcSigner{
+		{
+			identifier:             1,
+			hidingNonceRandomness:  "0fd2e39e111cdc266f6c0f4d0fd45c947761f1f5d3cb583dfcb9bbaf8d4c9fec",
+			bindingNonceRandomness: "69cd85f631d5f7f2721ed5e40519b1366f340a87c2f6856363dbdcda348a7501",
+			hidingNonce:            "812d6104142944d5a55924de6d49940956206909f2acaeedecda2b726e630407",
+			bindingNonce:           "b1110165fc2334149750b28dd813a39244f315cff14d4e89e6142f262ed83301",
+			hidingNonceCommitment:  "b5aa8ab305882a6fc69cbee9327e5a45e54c08af61ae77cb8207be3d2ce13de3",
+			bindingNonceCommitment: "67e98ab55aa310c3120418e5050c9cf76cf387cb20ac9e4b6fdb6f82a469f932",
+			bindingFactor:          "f2cb9d7dd9beff688da6fcc83fa89046b3479417f47f55600b106760eb3b5603",
+			sigShare:               "001719ab5a53ee1a12095cd088fd149702c0720ce5fd2f29dbecf24b7281b603",
+		},
+		{
+			identifier:             3,
+			hidingNonceRandomness:  "86d64a260059e495d0fb4fcc17ea3da7452391baa494d4b00321098ed2a0062f",
+			bindingNonceRandomness: "13e6b25afb2eba51716a9a7d44130c0dbae0004a9ef8d7b5550c8a0e07c61775",
+			hidingNonce:            "c256de65476204095ebdc01bd11dc10e57b36bc96284595b8215222374f99c0e",
+			bindingNonce:           "243d71944d929063bc51205714ae3c2218bd3451d0214dfb5aeec2a90c35180d",
+			hidingNonceCommitment:  "cfbdb165bd8aad6eb79deb8d287bcc0ab6658ae57fdcc98ed12c0669e90aec91",
+			bindingNonceCommitment: "7487bc41a6e712eea2f2af24681b58b1cf1da278ea11fe4e8b78398965f13552",
+			bindingFactor:          "b087686bf35a13f3dc78e780a34b0fe8a77fef1b9938c563f5573d71d8d7890f",
+			sigShare:               "bd86125de990acc5e1f13781d8e32c03a9bbd4c53539bbc106058bfd14326007",
+		},
+	}
+)
+
+type rfcSigner struct {
+	identifier             int64
+	hidingNonceRandomness  string
+	bindingNonceRandomness string
+	hidingNonce            string
+	bindingNonce           string
+	hidingNonceCommitment  string
+	bindingNonceCommitment string
+	bindingFactor          string
+	sigShare               string
+}
+
+func TestRFC9591Vectors(t *testing.T) {
+	ec := tss.Edwards()
+	N := ec.Params().N
+	msg, _ := hex.DecodeString(rfcMessage)
+
+	pubkey := crypto.ScalarBaseMult(ec, rfcScalar(rfcGroupSecretKey))
+	assert.Equal(t, rfcGroupPublicKey, hex.EncodeToString(ecPointToEncodedBytes(pubkey.X(), pubkey.Y())[:]))
+
+	ds := make([]*big.Int, len(rfcSigners))
+	es := make([]*big.Int, len(rfcSigners))
+	commitments := make([]*SigningCommitment, len(rfcSigners))
+	for j, signer := range rfcSigners {
+		secret := rfcScalar(rfcParticipantShares[signer.identifier-1])
+
+		var err error
+		ds[j], err = NonceGenerate(bytes.NewReader(rfcBytes(signer.hidingNonceRandomness)), secret)
+		assert.NoError(t, err)
+		es[j], err = NonceGenerate(bytes.NewReader(rfcBytes(signer.bindingNonceRandomness)), secret)
+		assert.NoError(t, err)
+		assert.Equal(t, signer.hidingNonce, rfcHex(ds[j]))
+		assert.Equal(t, signer.bindingNonce, rfcHex(es[j]))
+
+		D, E := crypto.ScalarBaseMult(ec, ds[j]), crypto.ScalarBaseMult(ec, es[j])
+		assert.Equal(t, signer.hidingNonceCommitment, hex.EncodeToString(ecPointToEncodedBytes(D.X(), D.Y())[:]))
+		assert.Equal(t, signer.bindingNonceCommitment, hex.EncodeToString(ecPointToEncodedBytes(E.X(), E.Y())[:]))
+		commitments[j] = &SigningCommitment{Identifier: big.NewInt(signer.identifier), D: D, E: E}
+	}
+
+	rhos := ComputeBindingFactors(pubkey, commitments, msg)
+	for j, signer := range rfcSigners {
+		assert.Equal(t, signer.bindingFactor, rfcHex(rhos[j]))
+	}
+
+	Rs, err := ComputeCommitments(commitments, rhos)
+	assert.NoError(t, err)
+	R, err := ComputeGroupCommitment(Rs)
+	assert.NoError(t, err)
+	c, encodedR := ComputeChallenge(R, pubkey, msg)
+
+	modN := common.ModInt(N)
+	z := big.NewInt(0)
+	for j, signer := range rfcSigners {
+		// lambda_j = id_k / (id_k - id_j), where k is the other signer
+		idj, idk := big.NewInt(signer.identifier), big.NewInt(rfcSigners[1-j].identifier)
+		lambda := modN.Mul(idk, modN.ModInverse(modN.Sub(idk, idj)))
+
+		zj := modN.Add(ds[j], modN.Mul(es[j], rhos[j]))
+		zj = modN.Add(zj, modN.Mul(c, modN.Mul(lambda, rfcScalar(rfcParticipantShares[signer.identifier-1]))))
+		assert.Equal(t, signer.sigShare, rfcHex(zj))
+		z = modN.Add(z, zj)
+	}
+	assert.Equal(t, rfcSignature, hex.EncodeToString(encodedR[:])+rfcHex(z))
+}
+
+func TestE2ERFC9591Vectors(t *testing.T) {
+	setUp("info")
+
+	ec := tss.Edwards()
+	msg, _ := hex.DecodeString(rfcMessage)
+
+	// the participants 1, 2 and 3 of a 2-of-3 key
+	allPIDs := tss.SortPartyIDs(tss.UnSortedPartyIDs{
+		tss.NewPartyID("1", "P[1]", big.NewInt(1)),
+		tss.NewPartyID("2", "P[2]", big.NewInt(2)),
+		tss.NewPartyID("3", "P[3]", big.NewInt(3)),
+	})
+	key := keygen.NewLocalPartySaveData(len(allPIDs))
+	for j, share := range rfcParticipantShares {
+		key.Ks[j] = allPIDs[j].KeyInt()
+		key.PubXj[j] = crypto.ScalarBaseMult(ec, rfcScalar(share))
+	}
+	key.Pubkey = crypto.ScalarBaseMult(ec, rfcScalar(rfcGroupSecretKey))
+	key.ChainCode = big.NewInt(0)
+
+	signPIDs := tss.SortPartyIDs(tss.UnSortedPartyIDs{allPIDs[0], allPIDs[2]})
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	parties := make([]*LocalParty, 0, len(signPIDs))
+
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+	endCh := make(chan *common.SignatureData, len(signPIDs))
+
+	for i, signer := range rfcSigners {
+		keyi := key
+		keyi.ShareID = big.NewInt(signer.identifier)
+		keyi.PrivXi = rfcScalar(rfcParticipantShares[signer.identifier-1])
+
+		params := tss.NewParameters(ec, p2pCtx, signPIDs[i], len(signPIDs), 1)
+		// the randomness of the hiding nonce, then of the binding nonce
+		params.SetRand(bytes.NewReader(append(rfcBytes(signer.hidingNonceRandomness), rfcBytes(signer.bindingNonceRandomness)...)))
+		party, err := NewLocalPartyRFC9591(new(big.Int).SetBytes(msg), true, params, "", keyi, outCh, endCh, len(msg))
+		assert.NoError(t, err)
+		parties = append(parties, party.(*LocalParty))
+	}
+	for _, P := range parties {
+		go func(P *LocalParty) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	ended := 0
+	for ended < len(parties) {
+		select {
+		case err := <-errCh:
+			assert.FailNow(t, err.Error())
+
+		case msg := <-outCh:
+			for _, P := range parties {
+				if P.PartyID().Index == msg.GetFrom().Index {
+					continue
+				}
+				go test.SharedPartyUpdater(P, msg, errCh)
+			}
+
+		case data := <-endCh:
+			assert.Equal(t, rfcSignature, hex.EncodeToString(data.Signature))
+			ended++
+		}
+	}
+}
+
+func rfcBytes(s string) []byte {
+	bz, _ := hex.DecodeString(s)
+	return bz
+}
+
+// rfcScalar decodes a little-endian scalar of the test vectors
+func rfcScalar(s string) *big.Int {
+	return encodedBytesToBigInt(copyBytes(rfcBytes(s)))
+}
+
+func rfcHex(a *big.Int) string {
+	return hex.EncodeToString(bigIntToEncodedBytes(a)[:])
+}
@@ -19,9 +19,10 @@ func newRound1(
 	temp *localTempData,
 	out chan<- tss.Message,
 	end chan<- *common.SignatureData,
+	preEnd chan<- *PreSignature,
 ) tss.Round {
 	return &round1{
-		&base{params, isThreshold, key, data, temp, out, end, make([]bool, len(params.Parties().IDs())), false, 1},
+		&base{params, isThreshold, key, data, temp, out, end, preEnd, make([]bool, len(params.Parties().IDs())), false, 1},
 	}
 }
 
@@ -45,8 +46,21 @@ func (round *round1) Start() *tss.Error {
 		return round.WrapError(err)
 	}
 
-	round.temp.d = common.GetRandomPositiveInt(round.Rand(), round.Params().EC().Params().N)
-	round.temp.e = common.GetRandomPositiveInt(round.Rand(), round.Params().EC().Params().N)
+	if round.temp.rfc9591 {
+		// hedged nonces, the hiding nonce d first and then the binding nonce e
+		round.temp.d, err = NonceGenerate(round.Rand(), round.temp.secret)
+		if err != nil {
+			return round.WrapError(err)
+		}
+		round.temp.e, err = NonceGenerate(round.Rand(), round.temp.secret)
+		if err != nil {
+			return round.WrapError(err)
+		}
+		common.ZeroBigInts(round.temp.secret)
+	} else {
+		round.temp.d = common.GetRandomPositiveInt(round.Rand(), round.Params().EC().Params().N)
+		round.temp.e = common.GetRandomPositiveInt(round.Rand(), round.Params().EC().Params().N)
+	}
 
 	D := crypto.ScalarBaseMult(round.EC(), round.temp.d)
 	E := crypto.ScalarBaseMult(round.EC(), round.temp.e)
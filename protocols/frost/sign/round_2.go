@@ -11,6 +11,7 @@ import (
 	"google.golang.org/protobuf/proto"
 
 	"github.com/felicityin/mpc-tss/common"
+	"github.com/felicityin/mpc-tss/crypto"
 	"github.com/felicityin/mpc-tss/tss"
 )
 
@@ -26,25 +27,85 @@ func (round *round2) Start() *tss.Error {
 	i := round.PartyID().Index
 	common.Logger.Infof("[sign] party: %d, round_2 start", i)
 
-	var B []byte
-
-	for j, Pj := range round.Parties().IDs() {
-		msg := round.temp.signRound1Messages[j]
-		r1msg := msg.Content().(*SignRound1Message)
-
-		bs, err := proto.Marshal(r1msg)
+	var ki *big.Int
+	var encodedR [32]byte
+	var tssErr *tss.Error
+	if round.temp.rfc9591 {
+		ki, encodedR, tssErr = round.rfc9591Commitment()
+	} else {
+		ki, encodedR, tssErr = round.legacyCommitment()
+	}
+	if tssErr != nil {
+		return tssErr
+	}
+	// with an adaptor point T, the signers commit to R + T
+	if round.temp.adaptor != nil {
+		_, adapted, err := AdaptNonce(&encodedR, round.temp.adaptor)
 		if err != nil {
-			return round.WrapError(fmt.Errorf("marshal round1 msg err: %s", err.Error()), Pj)
+			return round.WrapError(err)
 		}
+		encodedR = *adapted
+	}
+	riBytes := bigIntToEncodedBytes(ki)
 
-		B = append(B, round.key.PubXj[j].X().Bytes()...)
-		B = append(B, bs...)
+	// compute lambda
+	encodedPubKey := ecPointToEncodedBytes(round.key.Pubkey.X(), round.key.Pubkey.Y())
+
+	// h = hash512(dom2 || R || X || M)
+	h := sha512.New()
+	h.Reset()
+	h.Write(round.temp.dom)
+	h.Write(encodedR[:])
+	h.Write(encodedPubKey[:])
+	if round.temp.fullBytesLen == 0 {
+		h.Write(round.temp.m.Bytes())
+	} else {
+		var mBytes = make([]byte, round.temp.fullBytesLen)
+		round.temp.m.FillBytes(mBytes)
+		h.Write(mBytes)
 	}
 
-	rhoi := new(big.Int).SetBytes(
-		common.SHA512_256([]byte(strconv.Itoa(i)), round.temp.m.Bytes(), common.SHA512_256(B)),
-	)
-	rhoi.Mod(rhoi, round.EC().Params().N)
+	var lambda [64]byte
+	h.Sum(lambda[:0])
+	var lambdaReduced [32]byte
+	edwards25519.ScReduce(&lambdaReduced, &lambda)
+
+	// compute si
+	var localS [32]byte
+	xiBytes := bigIntToEncodedBytes(round.key.PrivXi)
+	edwards25519.ScMulAdd(&localS, &lambdaReduced, xiBytes, riBytes)
+
+	// Security: the nonces and the key share are no longer needed
+	common.ZeroBytes(xiBytes[:], riBytes[:])
+	common.ZeroBigInts(round.temp.d, round.temp.e, ki, round.key.PrivXi)
+
+	round.temp.si = &localS
+	round.temp.r = encodedBytesToBigInt(&encodedR)
+
+	// broadcast si to other parties
+	common.Logger.Debugf("P[%d]: round_2 broadcast", i)
+	r2msg := NewSignRound2Message(round.PartyID(), encodedBytesToBigInt(&localS))
+	round.temp.signRound2Messages[i] = r2msg
+	round.out <- r2msg
+
+	return nil
+}
+
+// legacyCommitment returns the nonce ki = d + rho_i * e of the party and the encoded group commitment R,
+// with the binding factors rho_j = SHA512_256(j, m, SHA512_256(B)) of the original protocol of this repo.
+func (round *round2) legacyCommitment() (*big.Int, [32]byte, *tss.Error) {
+	i := round.PartyID().Index
+
+	r1msgs := make([]*SignRound1Message, len(round.Parties().IDs()))
+	for j, msg := range round.temp.signRound1Messages {
+		r1msgs[j] = msg.Content().(*SignRound1Message)
+	}
+	hashB, culprit, err := legacyCommitmentListHash(round.key.PubXj, r1msgs)
+	if err != nil {
+		return nil, [32]byte{}, round.WrapError(err, round.Parties().IDs()[culprit])
+	}
+
+	rhoi := legacyBindingFactor(i, round.temp.m, hashB, round.EC().Params().N)
 
 	ki := new(big.Int).Mul(round.temp.e, rhoi)
 	ki.Add(ki, round.temp.d)
@@ -54,85 +115,107 @@ func (round *round2) Start() *tss.Error {
 	riBytes := bigIntToEncodedBytes(ki)
 	edwards25519.GeScalarMultBase(&R, riBytes)
 
+	// R starts from Ri = ki * G, which the party computes from its own nonce, so only the Rj of the others are added
 	for j, Pj := range round.Parties().IDs() {
 		if j == i {
 			continue
 		}
 
-		rhoj := new(big.Int).SetBytes(
-			common.SHA512_256([]byte(strconv.Itoa(j)), round.temp.m.Bytes(), common.SHA512_256(B)),
-		)
-		rhoj.Mod(rhoj, round.EC().Params().N)
+		rhoj := legacyBindingFactor(j, round.temp.m, hashB, round.EC().Params().N)
 
-		msg := round.temp.signRound1Messages[j]
-		r1msg := msg.Content().(*SignRound1Message)
+		r1msg := r1msgs[j]
 
 		D, err := r1msg.UnmarshalD()
 		if err != nil {
 			common.Logger.Errorf("failed to unmarshal D: %s, party: %d", err, j)
-			return round.WrapError(errors.New("failed to unmarshal D"), Pj)
+			return nil, [32]byte{}, round.WrapError(errors.New("failed to unmarshal D"), Pj)
 		}
 
 		E, err := r1msg.UnmarshalE()
 		if err != nil {
 			common.Logger.Errorf("failed to unmarshal E: %s, party: %d", err, j)
-			return round.WrapError(errors.New("failed to unmarshal E"), Pj)
+			return nil, [32]byte{}, round.WrapError(errors.New("failed to unmarshal E"), Pj)
 		}
 
 		Rj, err := E.ScalarMult(rhoj).Add(D)
 		if err != nil {
-			return round.WrapError(fmt.Errorf("rho * E + D err: %s", err.Error()), Pj)
+			return nil, [32]byte{}, round.WrapError(fmt.Errorf("rho * E + D err: %s", err.Error()), Pj)
 		}
-		round.temp.Rj[j] = Rj
-
 		Rj = Rj.EightInvEight()
-		if err != nil {
-			return round.WrapError(errors.Wrapf(err, "NewECPoint(Rj)"), Pj)
-		}
 
 		extendedRj := ecPointToExtendedElement(round.EC(), Rj.X(), Rj.Y(), round.Rand())
 		R = addExtendedElements(R, extendedRj)
 	}
 
-	// compute lambda
 	var encodedR [32]byte
 	R.ToBytes(&encodedR)
+	common.ZeroBytes(riBytes[:])
+	return ki, encodedR, nil
+}
 
-	encodedPubKey := ecPointToEncodedBytes(round.key.Pubkey.X(), round.key.Pubkey.Y())
+// legacyCommitmentListHash returns SHA512_256(B) of the original protocol of this repo, where B is the list of the X coordinates
+// of the key shares and the round 1 messages of the signers. It returns the index of the signer whose message cannot be encoded on error.
+func legacyCommitmentListHash(pubXj []*crypto.ECPoint, r1msgs []*SignRound1Message) ([]byte, int, error) {
+	var B []byte
+	for j, r1msg := range r1msgs {
+		bs, err := proto.Marshal(r1msg)
+		if err != nil {
+			return nil, j, fmt.Errorf("marshal round1 msg err: %s", err.Error())
+		}
 
-	// h = hash512(R || X || M)
-	h := sha512.New()
-	h.Reset()
-	h.Write(encodedR[:])
-	h.Write(encodedPubKey[:])
-	if round.temp.fullBytesLen == 0 {
-		h.Write(round.temp.m.Bytes())
-	} else {
-		var mBytes = make([]byte, round.temp.fullBytesLen)
-		round.temp.m.FillBytes(mBytes)
-		h.Write(mBytes)
+		B = append(B, pubXj[j].X().Bytes()...)
+		B = append(B, bs...)
 	}
+	return common.SHA512_256(B), -1, nil
+}
 
-	var lambda [64]byte
-	h.Sum(lambda[:0])
-	var lambdaReduced [32]byte
-	edwards25519.ScReduce(&lambdaReduced, &lambda)
+// legacyBindingFactor returns the binding factor rho_j = SHA512_256(j, m, SHA512_256(B)) of the signer j
+func legacyBindingFactor(j int, m *big.Int, hashB []byte, q *big.Int) *big.Int {
+	rhoj := new(big.Int).SetBytes(common.SHA512_256([]byte(strconv.Itoa(j)), m.Bytes(), hashB))
+	return rhoj.Mod(rhoj, q)
+}
 
-	// compute si
-	var localS [32]byte
-	edwards25519.ScMulAdd(&localS, &lambdaReduced, bigIntToEncodedBytes(round.key.PrivXi), riBytes)
+// rfc9591Commitment returns the nonce ki = d + rho_i * e of the party and the encoded group commitment R,
+// with the binding factors and the group commitment of RFC 9591.
+func (round *round2) rfc9591Commitment() (*big.Int, [32]byte, *tss.Error) {
+	i := round.PartyID().Index
 
-	round.temp.c = encodedBytesToBigInt(&lambdaReduced)
-	round.temp.si = &localS
-	round.temp.r = encodedBytesToBigInt(&encodedR)
+	commitments := make([]*SigningCommitment, len(round.Parties().IDs()))
+	for j, Pj := range round.Parties().IDs() {
+		r1msg := round.temp.signRound1Messages[j].Content().(*SignRound1Message)
 
-	// broadcast si to other parties
-	common.Logger.Debugf("P[%d]: round_2 broadcast", i)
-	r2msg := NewSignRound2Message(round.PartyID(), encodedBytesToBigInt(&localS))
-	round.temp.signRound2Messages[i] = r2msg
-	round.out <- r2msg
+		D, err := r1msg.UnmarshalD()
+		if err != nil {
+			common.Logger.Errorf("failed to unmarshal D: %s, party: %d", err, j)
+			return nil, [32]byte{}, round.WrapError(errors.New("failed to unmarshal D"), Pj)
+		}
+		E, err := r1msg.UnmarshalE()
+		if err != nil {
+			common.Logger.Errorf("failed to unmarshal E: %s, party: %d", err, j)
+			return nil, [32]byte{}, round.WrapError(errors.New("failed to unmarshal E"), Pj)
+		}
+		commitments[j] = &SigningCommitment{
+			Identifier: new(big.Int).Mod(round.key.Ks[j], round.EC().Params().N),
+			D:          D,
+			E:          E,
+		}
+	}
 
-	return nil
+	rhos := ComputeBindingFactors(round.key.Pubkey, commitments, messageBytes(round.temp.m, round.temp.fullBytesLen))
+	Rs, err := ComputeCommitments(commitments, rhos)
+	if err != nil {
+		return nil, [32]byte{}, round.WrapError(err)
+	}
+
+	R, err := ComputeGroupCommitment(Rs)
+	if err != nil {
+		return nil, [32]byte{}, round.WrapError(err)
+	}
+
+	ki := new(big.Int).Mul(round.temp.e, rhos[i])
+	ki.Add(ki, round.temp.d)
+	ki.Mod(ki, round.EC().Params().N)
+	return ki, *ecPointToEncodedBytes(R.X(), R.Y()), nil
 }
 
 func (round *round2) Update() (bool, *tss.Error) {
@@ -1,7 +1,6 @@
 package sign
 
 import (
-	"encoding/hex"
 	"errors"
 	"fmt"
 	"math/big"
@@ -11,6 +10,7 @@ import (
 
 	"github.com/felicityin/mpc-tss/common"
 	"github.com/felicityin/mpc-tss/crypto"
+	"github.com/felicityin/mpc-tss/crypto/sigfmt"
 	"github.com/felicityin/mpc-tss/tss"
 )
 
@@ -29,36 +29,45 @@ func (round *finalization) Start() *tss.Error {
 
 	sumS := round.temp.si
 
-	for j, Pj := range round.Parties().IDs() {
+	// the commitments and the challenge are computed from the round 1 messages as in the evidence, so that anyone can check it
+	r1msgs := make([]*SignRound1Message, len(round.Parties().IDs()))
+	for j, msg := range round.temp.signRound1Messages {
+		r1msgs[j] = msg.Content().(*SignRound1Message)
+	}
+	Rs, c, err := round.shareInputs(i).Commitments(round.EC(), r1msgs)
+	if err != nil {
+		return round.WrapError(err)
+	}
+
+	var errs []*tss.Error
+	for j := range round.Parties().IDs() {
 		round.ok[j] = true
 		if j == i {
 			continue
 		}
 
-		r2msg := round.temp.signRound2Messages[j].Content().(*SignRound2Message)
-		zi := r2msg.UnmarshalS()
-
-		ziGx, ziGy := round.EC().ScalarBaseMult(zi.Bytes())
-		ziG := crypto.NewECPointNoCurveCheck(round.EC(), ziGx, ziGy)
-
-		tmp := round.key.PubXj[j].ScalarMult(round.temp.c)
-		tmp, err := tmp.Add(round.temp.Rj[j])
-		if err != nil {
-			return round.WrapError(fmt.Errorf("err: Rj + c * Xj: %s", err.Error()), Pj)
-		}
-
-		if hex.EncodeToString(ziG.X().Bytes()) != hex.EncodeToString(tmp.X().Bytes()) ||
-			hex.EncodeToString(ziG.Y().Bytes()) != hex.EncodeToString(tmp.Y().Bytes()) {
-			return round.WrapError(fmt.Errorf("err: Zj != Rj + c * Xj"), Pj)
+		msg := round.temp.signRound2Messages[j]
+		if err := CheckShare(round.EC(), Rs[j], c, round.key.PubXj[j], msg.Content().(*SignRound2Message)); err != nil {
+			common.Logger.Errorf("[j: %d] %s", j, err.Error())
+			errs = append(errs, round.WrapEvidence(err, EvidenceShare, round.shareInputs(j), append([]tss.ParsedMessage{msg}, round.temp.signRound1Messages...)...))
+			continue
 		}
+		zi := msg.Content().(*SignRound2Message).UnmarshalS()
 
 		sjBytes := bigIntToEncodedBytes(zi)
 		var tmpSumS [32]byte
 		edwards25519.ScMulAdd(&tmpSumS, sumS, bigIntToEncodedBytes(big.NewInt(1)), sjBytes)
 		sumS = &tmpSumS
 	}
+	if err := tss.JoinErrors(errs...); err != nil {
+		return err
+	}
 	s := encodedBytesToBigInt(sumS)
 
+	if round.temp.adaptor != nil {
+		return round.preSignature(s)
+	}
+
 	// save the signature for final output
 	round.data.Signature = append(bigIntToEncodedBytes(round.temp.r)[:], bigIntToEncodedBytes(s)[:]...)
 	round.data.R = round.temp.r.Bytes()
@@ -77,7 +86,13 @@ func (round *finalization) Start() *tss.Error {
 		Y:     round.key.Pubkey.Y(),
 	}
 
-	ok := edwards.Verify(&pk, round.data.M, round.temp.r, s)
+	var ok bool
+	if len(round.temp.dom) == 0 {
+		ok = edwards.Verify(&pk, round.data.M, round.temp.r, s)
+	} else {
+		// edwards.Verify has no dom2, Ed25519ph and Ed25519ctx are verified with crypto/ed25519
+		ok = sigfmt.VerifyEd25519(round.key.Pubkey, round.data) == nil
+	}
 	if !ok {
 		return round.WrapError(fmt.Errorf("signature verification failed"))
 	}
@@ -87,6 +102,29 @@ func (round *finalization) Start() *tss.Error {
 	return nil
 }
 
+// preSignature outputs the pre-signature s' of the nonce point R + T, once it is checked against the key
+func (round *finalization) preSignature(s *big.Int) *tss.Error {
+	encodedR := bigIntToEncodedBytes(round.temp.r)
+	pk, err := edwards.ParsePubKey(encodedR[:])
+	if err != nil {
+		return round.WrapError(err)
+	}
+	pre := &PreSignature{
+		R:        crypto.NewECPointNoCurveCheck(round.EC(), pk.X, pk.Y),
+		T:        round.temp.adaptor,
+		S:        s,
+		M:        messageBytes(round.temp.m, round.temp.fullBytesLen),
+		HashMode: round.data.HashMode,
+		Context:  round.data.Context,
+	}
+	if err := pre.Verify(round.key.Pubkey); err != nil {
+		return round.WrapError(err)
+	}
+	common.Logger.Infof("party: %d, round 3 end", round.PartyID().Index)
+	round.preEnd <- pre
+	return nil
+}
+
 func (round *finalization) CanAccept(msg tss.ParsedMessage) bool {
 	// not expecting any incoming messages in this round
 	return false
@@ -2,7 +2,7 @@
 // versions:
 // 	protoc-gen-go v1.33.0
 // 	protoc        v3.21.4
-// source: sign.proto
+// source: protocols/frost/sign/sign.proto
 
 package sign
 
@@ -33,7 +33,7 @@ type SignRound1Message struct {
 func (x *SignRound1Message) Reset() {
 	*x = SignRound1Message{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_sign_proto_msgTypes[0]
+		mi := &file_protocols_frost_sign_sign_proto_msgTypes[0]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -46,7 +46,7 @@ func (x *SignRound1Message) String() string {
 func (*SignRound1Message) ProtoMessage() {}
 
 func (x *SignRound1Message) ProtoReflect() protoreflect.Message {
-	mi := &file_sign_proto_msgTypes[0]
+	mi := &file_protocols_frost_sign_sign_proto_msgTypes[0]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -59,7 +59,7 @@ func (x *SignRound1Message) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SignRound1Message.ProtoReflect.Descriptor instead.
 func (*SignRound1Message) Descriptor() ([]byte, []int) {
-	return file_sign_proto_rawDescGZIP(), []int{0}
+	return file_protocols_frost_sign_sign_proto_rawDescGZIP(), []int{0}
 }
 
 func (x *SignRound1Message) GetD() []byte {
@@ -88,7 +88,7 @@ type SignRound2Message struct {
 func (x *SignRound2Message) Reset() {
 	*x = SignRound2Message{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_sign_proto_msgTypes[1]
+		mi := &file_protocols_frost_sign_sign_proto_msgTypes[1]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -101,7 +101,7 @@ func (x *SignRound2Message) String() string {
 func (*SignRound2Message) ProtoMessage() {}
 
 func (x *SignRound2Message) ProtoReflect() protoreflect.Message {
-	mi := &file_sign_proto_msgTypes[1]
+	mi := &file_protocols_frost_sign_sign_proto_msgTypes[1]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -114,7 +114,7 @@ func (x *SignRound2Message) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SignRound2Message.ProtoReflect.Descriptor instead.
 func (*SignRound2Message) Descriptor() ([]byte, []int) {
-	return file_sign_proto_rawDescGZIP(), []int{1}
+	return file_protocols_frost_sign_sign_proto_rawDescGZIP(), []int{1}
 }
 
 func (x *SignRound2Message) GetSi() []byte {
@@ -124,39 +124,41 @@ func (x *SignRound2Message) GetSi() []byte {
 	return nil
 }
 
-var File_sign_proto protoreflect.FileDescriptor
+var File_protocols_frost_sign_sign_proto protoreflect.FileDescriptor
 
-var file_sign_proto_rawDesc = []byte{
-	0x0a, 0x0a, 0x73, 0x69, 0x67, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x25, 0x74, 0x73,
-	0x73, 0x6c, 0x69, 0x62, 0x2e, 0x66, 0x72, 0x6f, 0x73, 0x74, 0x2e, 0x6e, 0x6f, 0x6e, 0x5f, 0x74,
-	0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x2e, 0x73, 0x69, 0x67, 0x6e, 0x2e, 0x65, 0x64,
-	0x64, 0x73, 0x61, 0x22, 0x2f, 0x0a, 0x11, 0x53, 0x69, 0x67, 0x6e, 0x52, 0x6f, 0x75, 0x6e, 0x64,
-	0x31, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x0c, 0x0a, 0x01, 0x64, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x0c, 0x52, 0x01, 0x64, 0x12, 0x0c, 0x0a, 0x01, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x0c, 0x52, 0x01, 0x65, 0x22, 0x23, 0x0a, 0x11, 0x53, 0x69, 0x67, 0x6e, 0x52, 0x6f, 0x75, 0x6e,
-	0x64, 0x32, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x73, 0x69, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x02, 0x73, 0x69, 0x42, 0x0c, 0x5a, 0x0a, 0x66, 0x72, 0x6f,
-	0x73, 0x74, 0x2f, 0x73, 0x69, 0x67, 0x6e, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+var file_protocols_frost_sign_sign_proto_rawDesc = []byte{
+	0x0a, 0x1f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x73, 0x2f, 0x66, 0x72, 0x6f, 0x73,
+	0x74, 0x2f, 0x73, 0x69, 0x67, 0x6e, 0x2f, 0x73, 0x69, 0x67, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x12, 0x25, 0x74, 0x73, 0x73, 0x6c, 0x69, 0x62, 0x2e, 0x66, 0x72, 0x6f, 0x73, 0x74, 0x2e,
+	0x6e, 0x6f, 0x6e, 0x5f, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x2e, 0x73, 0x69,
+	0x67, 0x6e, 0x2e, 0x65, 0x64, 0x64, 0x73, 0x61, 0x22, 0x2f, 0x0a, 0x11, 0x53, 0x69, 0x67, 0x6e,
+	0x52, 0x6f, 0x75, 0x6e, 0x64, 0x31, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x0c, 0x0a,
+	0x01, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x01, 0x64, 0x12, 0x0c, 0x0a, 0x01, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x01, 0x65, 0x22, 0x23, 0x0a, 0x11, 0x53, 0x69, 0x67,
+	0x6e, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x32, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x0e,
+	0x0a, 0x02, 0x73, 0x69, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x02, 0x73, 0x69, 0x42, 0x0c,
+	0x5a, 0x0a, 0x66, 0x72, 0x6f, 0x73, 0x74, 0x2f, 0x73, 0x69, 0x67, 0x6e, 0x62, 0x06, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
-	file_sign_proto_rawDescOnce sync.Once
-	file_sign_proto_rawDescData = file_sign_proto_rawDesc
+	file_protocols_frost_sign_sign_proto_rawDescOnce sync.Once
+	file_protocols_frost_sign_sign_proto_rawDescData = file_protocols_frost_sign_sign_proto_rawDesc
 )
 
-func file_sign_proto_rawDescGZIP() []byte {
-	file_sign_proto_rawDescOnce.Do(func() {
-		file_sign_proto_rawDescData = protoimpl.X.CompressGZIP(file_sign_proto_rawDescData)
+func file_protocols_frost_sign_sign_proto_rawDescGZIP() []byte {
+	file_protocols_frost_sign_sign_proto_rawDescOnce.Do(func() {
+		file_protocols_frost_sign_sign_proto_rawDescData = protoimpl.X.CompressGZIP(file_protocols_frost_sign_sign_proto_rawDescData)
 	})
-	return file_sign_proto_rawDescData
+	return file_protocols_frost_sign_sign_proto_rawDescData
 }
 
-var file_sign_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
-var file_sign_proto_goTypes = []interface{}{
+var file_protocols_frost_sign_sign_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_protocols_frost_sign_sign_proto_goTypes = []interface{}{
 	(*SignRound1Message)(nil), // 0: tsslib.frost.non_threshold.sign.eddsa.SignRound1Message
 	(*SignRound2Message)(nil), // 1: tsslib.frost.non_threshold.sign.eddsa.SignRound2Message
 }
-var file_sign_proto_depIdxs = []int32{
+var file_protocols_frost_sign_sign_proto_depIdxs = []int32{
 	0, // [0:0] is the sub-list for method output_type
 	0, // [0:0] is the sub-list for method input_type
 	0, // [0:0] is the sub-list for extension type_name
@@ -164,13 +166,13 @@ var file_sign_proto_depIdxs = []int32{
 	0, // [0:0] is the sub-list for field type_name
 }
 
-func init() { file_sign_proto_init() }
-func file_sign_proto_init() {
-	if File_sign_proto != nil {
+func init() { file_protocols_frost_sign_sign_proto_init() }
+func file_protocols_frost_sign_sign_proto_init() {
+	if File_protocols_frost_sign_sign_proto != nil {
 		return
 	}
 	if !protoimpl.UnsafeEnabled {
-		file_sign_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+		file_protocols_frost_sign_sign_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*SignRound1Message); i {
 			case 0:
 				return &v.state
@@ -182,7 +184,7 @@ func file_sign_proto_init() {
 				return nil
 			}
 		}
-		file_sign_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+		file_protocols_frost_sign_sign_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*SignRound2Message); i {
 			case 0:
 				return &v.state
@@ -199,18 +201,18 @@ func file_sign_proto_init() {
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
-			RawDescriptor: file_sign_proto_rawDesc,
+			RawDescriptor: file_protocols_frost_sign_sign_proto_rawDesc,
 			NumEnums:      0,
 			NumMessages:   2,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
-		GoTypes:           file_sign_proto_goTypes,
-		DependencyIndexes: file_sign_proto_depIdxs,
-		MessageInfos:      file_sign_proto_msgTypes,
+		GoTypes:           file_protocols_frost_sign_sign_proto_goTypes,
+		DependencyIndexes: file_protocols_frost_sign_sign_proto_depIdxs,
+		MessageInfos:      file_protocols_frost_sign_sign_proto_msgTypes,
 	}.Build()
-	File_sign_proto = out.File
-	file_sign_proto_rawDesc = nil
-	file_sign_proto_goTypes = nil
-	file_sign_proto_depIdxs = nil
+	File_protocols_frost_sign_sign_proto = out.File
+	file_protocols_frost_sign_sign_proto_rawDesc = nil
+	file_protocols_frost_sign_sign_proto_goTypes = nil
+	file_protocols_frost_sign_sign_proto_depIdxs = nil
 }
@@ -121,3 +121,13 @@ func ecPointToExtendedElement(ec elliptic.Curve, x *big.Int, y *big.Int, rand io
 		T: T,
 	}
 }
+
+// messageBytes returns the message to sign, left-padded to fullBytesLen bytes if it is set
+func messageBytes(m *big.Int, fullBytesLen int) []byte {
+	if fullBytesLen == 0 {
+		return m.Bytes()
+	}
+	mBytes := make([]byte, fullBytesLen)
+	m.FillBytes(mBytes)
+	return mBytes
+}
@@ -0,0 +1,168 @@
+package sign
+
+import (
+	"crypto/elliptic"
+	"errors"
+	"fmt"
+	"math/big"
+
+	edwards "github.com/decred/dcrd/dcrec/edwards/v2"
+
+	"github.com/felicityin/mpc-tss/common"
+	"github.com/felicityin/mpc-tss/crypto"
+	"github.com/felicityin/mpc-tss/protocols/cggmp/keygen"
+	"github.com/felicityin/mpc-tss/protocols/utils"
+	"github.com/felicityin/mpc-tss/tss"
+)
+
+// Verifier checks the messages of the signers of a signing from outside of it, with the public data of the key only,
+// e.g. for the coordinator of ROAST: it tells which signers sent invalid nonce commitments or signature shares,
+// and aggregates the signature from the shares.
+type Verifier struct {
+	ec           elliptic.Curve
+	parties      tss.SortedPartyIDs
+	key          keygen.LocalPartySaveData
+	m            *big.Int
+	fullBytesLen int
+
+	// set by SetCommitments
+	rj       []*crypto.ECPoint
+	c        *big.Int
+	encodedR [32]byte
+}
+
+// NewVerifier returns a verifier of the signing of NewLocalParty by the parties of params, with the same arguments as the signers.
+// Only the public data of the key is used, its PrivXi and ShareID are ignored.
+func NewVerifier(
+	msg *big.Int,
+	isThreshold bool,
+	params *tss.Parameters,
+	path string,
+	key keygen.LocalPartySaveData,
+	fullBytesLen ...int,
+) (*Verifier, error) {
+	key, err := keygen.BuildLocalSaveDataSubset(key, params.Parties().IDs())
+	if err != nil {
+		return nil, err
+	}
+	// the public data of the key prepared for the signing is the same for all the signers
+	key.ShareID = key.Ks[0]
+	key.PrivXi = big.NewInt(0)
+	err = utils.UpdateKeyForSigning(&key, path, isThreshold, params.Threshold(), params.KeyTweaks()...)
+	if err != nil {
+		return nil, err
+	}
+
+	v := &Verifier{
+		ec:      params.EC(),
+		parties: params.Parties().IDs(),
+		key:     key,
+		m:       msg,
+	}
+	if len(fullBytesLen) > 0 {
+		v.fullBytesLen = fullBytesLen[0]
+	}
+	return v, nil
+}
+
+// CheckCommitments checks the nonce commitments (D, E) of the round 1 message of a signer
+func (v *Verifier) CheckCommitments(r1msg *SignRound1Message) error {
+	D, err := r1msg.UnmarshalD()
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal D: %s", err.Error())
+	}
+	E, err := r1msg.UnmarshalE()
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal E: %s", err.Error())
+	}
+	if !tss.SameCurve(D.Curve(), v.ec) || !tss.SameCurve(E.Curve(), v.ec) {
+		return errors.New("D or E is not on the curve of the signing")
+	}
+	return nil
+}
+
+// SetCommitments computes the commitment Rj of every signer, the group commitment R and the challenge
+// from the round 1 messages of all the signers, in the order of the parties. The messages must have been checked with CheckCommitments.
+func (v *Verifier) SetCommitments(r1msgs []*SignRound1Message) error {
+	if len(r1msgs) != len(v.parties) {
+		return fmt.Errorf("expected %d round 1 messages, got %d", len(v.parties), len(r1msgs))
+	}
+	hashB, _, err := legacyCommitmentListHash(v.key.PubXj, r1msgs)
+	if err != nil {
+		return err
+	}
+
+	q := v.ec.Params().N
+	Rs := make([]*crypto.ECPoint, len(r1msgs))
+	for j, r1msg := range r1msgs {
+		D, err := r1msg.UnmarshalD()
+		if err != nil {
+			return err
+		}
+		E, err := r1msg.UnmarshalE()
+		if err != nil {
+			return err
+		}
+		Rj, err := E.ScalarMult(legacyBindingFactor(j, v.m, hashB, q)).Add(D)
+		if err != nil {
+			return fmt.Errorf("rho * E + D err: %s", err.Error())
+		}
+		Rs[j] = Rj
+	}
+
+	// the signers sum the commitments of the others with their torsion cleared
+	cleared := make([]*crypto.ECPoint, len(Rs))
+	for j, Rj := range Rs {
+		cleared[j] = Rj.EightInvEight()
+	}
+	R, err := ComputeGroupCommitment(cleared)
+	if err != nil {
+		return err
+	}
+	c, encodedR := ComputeChallenge(R, v.key.Pubkey, messageBytes(v.m, v.fullBytesLen))
+
+	v.rj, v.c, v.encodedR = Rs, c, *encodedR
+	return nil
+}
+
+// CheckShare checks the signature share of the round 2 message of the signer j, once the commitments are set
+func (v *Verifier) CheckShare(j int, r2msg *SignRound2Message) error {
+	if v.c == nil {
+		return errors.New("the commitments are not set")
+	}
+	return CheckShare(v.ec, v.rj[j], v.c, v.key.PubXj[j], r2msg)
+}
+
+// Signature aggregates the signature from the checked round 2 messages of all the signers, and verifies it
+func (v *Verifier) Signature(r2msgs []*SignRound2Message) (*common.SignatureData, error) {
+	if v.c == nil {
+		return nil, errors.New("the commitments are not set")
+	}
+	modN := common.ModInt(v.ec.Params().N)
+	s := big.NewInt(0)
+	for _, r2msg := range r2msgs {
+		s = modN.Add(s, r2msg.UnmarshalS())
+	}
+	r := encodedBytesToBigInt(&v.encodedR)
+
+	data := &common.SignatureData{
+		Signature: append(bigIntToEncodedBytes(r)[:], bigIntToEncodedBytes(s)[:]...),
+		R:         r.Bytes(),
+		S:         s.Bytes(),
+		M:         messageBytes(v.m, v.fullBytesLen),
+	}
+	pk := edwards.PublicKey{
+		Curve: v.ec,
+		X:     v.key.Pubkey.X(),
+		Y:     v.key.Pubkey.Y(),
+	}
+	if !edwards.Verify(&pk, data.M, r, s) {
+		return nil, errors.New("signature verification failed")
+	}
+	return data, nil
+}
+
+// Pubkey returns the public key that the signature verifies with
+func (v *Verifier) Pubkey() *crypto.ECPoint {
+	return v.key.Pubkey
+}
@@ -0,0 +1,120 @@
+package signing
+
+import (
+	"crypto/elliptic"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/felicityin/mpc-tss/common"
+	"github.com/felicityin/mpc-tss/crypto"
+	"github.com/felicityin/mpc-tss/protocols/frost/presign"
+	"github.com/felicityin/mpc-tss/protocols/frost/sign"
+	"github.com/felicityin/mpc-tss/tss"
+)
+
+// EvidenceShare is the kind of the evidence against a party whose signature share is invalid in the signing with a presignature
+const EvidenceShare = "frost/signing/share"
+
+func init() {
+	tss.RegisterEvidenceCheck(EvidenceShare, checkShareEvidence)
+}
+
+// ShareInputs are the public inputs of the check of the signature share z_j of Pj. DEs are the nonce commitments of the signers
+// in the presignature, which is fixed before the signing like the key: a verifier checks them against the presignature.
+// The commitment Rj of Pj and the challenge are computed again from them.
+type ShareInputs struct {
+	sign.ShareInputs
+	DEs []*presign.DE `json:"des"`
+}
+
+// legacyBindingFactors returns the binding factors rho_j = SHA512_256(j, m, SHA512_256(B)) of the original protocol of this repo,
+// where B is the list of the X coordinates of the key shares and the hashes of the commitments of the signers
+func legacyBindingFactors(pubXj []*crypto.ECPoint, DEs []*presign.DE, m, q *big.Int) []*big.Int {
+	var B []byte
+	for j, DE := range DEs {
+		bs := common.SHA512_256(DE.D.X().Bytes(), DE.D.Y().Bytes(), DE.E.X().Bytes(), DE.E.Y().Bytes())
+		B = append(B, pubXj[j].X().Bytes()...)
+		B = append(B, bs...)
+	}
+	hashB := common.SHA512_256(B)
+
+	rhos := make([]*big.Int, len(DEs))
+	for j := range DEs {
+		rhos[j] = new(big.Int).SetBytes(common.SHA512_256([]byte(strconv.Itoa(j)), m.Bytes(), hashB))
+		rhos[j].Mod(rhos[j], q)
+	}
+	return rhos
+}
+
+// Commitments returns the commitment Rj of every signer and the challenge of the signing, as the signers compute them
+func (in *ShareInputs) Commitments(ec elliptic.Curve) ([]*crypto.ECPoint, *big.Int, error) {
+	commitments := make([]*sign.SigningCommitment, len(in.DEs))
+	for j, DE := range in.DEs {
+		commitments[j] = &sign.SigningCommitment{Identifier: new(big.Int).Mod(in.Ks[j], ec.Params().N), D: DE.D, E: DE.E}
+	}
+	msg := messageBytes(in.M, in.FullBytesLen)
+
+	var rhos []*big.Int
+	if in.RFC9591 {
+		rhos = sign.ComputeBindingFactors(in.Pubkey, commitments, msg)
+	} else {
+		rhos = legacyBindingFactors(in.PubXj, in.DEs, in.M, ec.Params().N)
+	}
+	Rs, err := sign.ComputeCommitments(commitments, rhos)
+	if err != nil {
+		return nil, nil, err
+	}
+	summed := Rs
+	if !in.RFC9591 {
+		// the signers sum the commitments with their torsion cleared
+		summed = make([]*crypto.ECPoint, len(Rs))
+		for j, Rj := range Rs {
+			summed[j] = Rj.EightInvEight()
+		}
+	}
+	R, err := sign.ComputeGroupCommitment(summed)
+	if err != nil {
+		return nil, nil, err
+	}
+	c, err := sign.Challenge(R, in.Adaptor, in.Pubkey, in.Dom, msg)
+	if err != nil {
+		return nil, nil, err
+	}
+	return Rs, c, nil
+}
+
+func checkShareEvidence(ev *tss.Evidence, msgs []tss.ParsedMessage) (failure error, err error) {
+	in := new(ShareInputs)
+	if err := ev.UnmarshalInputs(in); err != nil {
+		return nil, err
+	}
+	ec, ok := tss.GetCurveByName(in.Curve)
+	if !ok {
+		return nil, fmt.Errorf("unknown curve %s", in.Curve)
+	}
+	if err := in.Validate(ec, ev.Culprit); err != nil {
+		return nil, err
+	}
+	if len(in.DEs) != len(in.PubXj) {
+		return nil, errors.New("expected the commitments of every signer")
+	}
+	for j, DE := range in.DEs {
+		if DE == nil || DE.D == nil || DE.E == nil || !tss.SameCurve(DE.D.Curve(), ec) || !tss.SameCurve(DE.E.Curve(), ec) {
+			return nil, fmt.Errorf("the commitments of party %d are missing", j)
+		}
+	}
+	if len(msgs) != 1 {
+		return nil, errors.New("expected the signature share")
+	}
+	r2msg, ok := msgs[0].Content().(*sign.SignRound2Message)
+	if !ok {
+		return nil, errors.New("expected the signature share")
+	}
+	Rs, c, err := in.Commitments(ec)
+	if err != nil {
+		return nil, err
+	}
+	return sign.CheckShare(ec, Rs[in.Index], c, in.PubXj[in.Index], r2msg), nil
+}
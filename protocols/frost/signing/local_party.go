@@ -29,8 +29,9 @@ type (
 		data *common.SignatureData
 
 		// outbound messaging
-		out chan<- tss.Message
-		end chan<- *common.SignatureData
+		out    chan<- tss.Message
+		end    chan<- *common.SignatureData
+		preEnd chan<- *sign.PreSignature
 	}
 
 	localMessageStore struct {
@@ -41,13 +42,14 @@ type (
 		localMessageStore
 
 		isThreshold  bool
+		rfc9591      bool
 		m            *big.Int
 		r            *big.Int
 		fullBytesLen int
+		dom          []byte // dom2 of RFC 8032, empty for Ed25519
+		adaptor      *crypto.ECPoint
 
 		// round 1
-		c  *big.Int
-		Rj []*crypto.ECPoint
 		si *[32]byte
 
 		ssid      []byte
@@ -65,6 +67,38 @@ func NewLocalParty(
 	out chan<- tss.Message,
 	end chan<- *common.SignatureData,
 	fullBytesLen ...int,
+) (tss.Party, error) {
+	return newLocalParty(msg, isThreshold, false, params, path, key, pre, out, end, fullBytesLen...)
+}
+
+// NewLocalPartyRFC9591 returns a party of the FROST(Ed25519, SHA-512) ciphersuite of RFC 9591,
+// which interoperates with other implementations of the RFC.
+// The presignature should come from the RFC 9591 mode of the presigning, whose nonces are hedged.
+func NewLocalPartyRFC9591(
+	msg *big.Int,
+	isThreshold bool,
+	params *tss.Parameters,
+	path string,
+	key keygen.LocalPartySaveData,
+	pre presign.LocalPartySaveData,
+	out chan<- tss.Message,
+	end chan<- *common.SignatureData,
+	fullBytesLen ...int,
+) (tss.Party, error) {
+	return newLocalParty(msg, isThreshold, true, params, path, key, pre, out, end, fullBytesLen...)
+}
+
+func newLocalParty(
+	msg *big.Int,
+	isThreshold bool,
+	rfc9591 bool,
+	params *tss.Parameters,
+	path string,
+	key keygen.LocalPartySaveData,
+	pre presign.LocalPartySaveData,
+	out chan<- tss.Message,
+	end chan<- *common.SignatureData,
+	fullBytesLen ...int,
 ) (tss.Party, error) {
 	key, err := keygen.BuildLocalSaveDataSubset(key, params.Parties().IDs())
 	if err != nil {
@@ -74,7 +108,9 @@ func NewLocalParty(
 	if err != nil {
 		return nil, err
 	}
-	err = utils.UpdateKeyForSigning(&key, path, isThreshold, params.Threshold())
+	// the party works on its own copy of the presignature, so that it can wipe it once it is consumed
+	pre.D, pre.E = new(big.Int).Set(pre.D), new(big.Int).Set(pre.E)
+	err = utils.UpdateKeyForSigning(&key, path, isThreshold, params.Threshold(), params.KeyTweaks()...)
 	if err != nil {
 		return nil, err
 	}
@@ -100,12 +136,67 @@ func NewLocalParty(
 		p.temp.fullBytesLen = 0
 	}
 	p.temp.isThreshold = isThreshold
-	p.temp.Rj = make([]*crypto.ECPoint, partyCount)
+	p.temp.rfc9591 = rfc9591
+	return p, nil
+}
+
+// NewLocalPartyWithMessage returns a party that signs the message with the variant of RFC 8032 of its hash mode:
+// ED25519, ED25519PH or ED25519CTX. The message keeps its leading zero bytes.
+func NewLocalPartyWithMessage(
+	msg *common.Message,
+	isThreshold bool,
+	params *tss.Parameters,
+	path string,
+	key keygen.LocalPartySaveData,
+	pre presign.LocalPartySaveData,
+	out chan<- tss.Message,
+	end chan<- *common.SignatureData,
+) (tss.Party, error) {
+	dom, m, err := msg.EdDSAInput()
+	if err != nil {
+		return nil, err
+	}
+	party, err := NewLocalParty(new(big.Int).SetBytes(m), isThreshold, params, path, key, pre, out, end, len(m))
+	if err != nil {
+		return nil, err
+	}
+	p := party.(*LocalParty)
+	p.temp.dom = dom
+	p.data.HashMode = msg.Mode
+	p.data.Context = msg.Context
+	return p, nil
+}
+
+// NewLocalPartyWithAdaptor returns a party that makes the pre-signature of the message for the adaptor point T with the presignature,
+// with the variant of RFC 8032 of its hash mode, which it outputs on end once it is checked against the key.
+// The signers commit to the nonce point R + T, and the pre-signature is completed into a signature
+// with the discrete logarithm of T, see sign.PreSignature.
+func NewLocalPartyWithAdaptor(
+	msg *common.Message,
+	T *crypto.ECPoint,
+	isThreshold bool,
+	params *tss.Parameters,
+	path string,
+	key keygen.LocalPartySaveData,
+	pre presign.LocalPartySaveData,
+	out chan<- tss.Message,
+	end chan<- *sign.PreSignature,
+) (tss.Party, error) {
+	if err := sign.ValidateAdaptor(T); err != nil {
+		return nil, err
+	}
+	party, err := NewLocalPartyWithMessage(msg, isThreshold, params, path, key, pre, out, nil)
+	if err != nil {
+		return nil, err
+	}
+	p := party.(*LocalParty)
+	p.temp.adaptor = T
+	p.preEnd = end
 	return p, nil
 }
 
 func (p *LocalParty) FirstRound() tss.Round {
-	return newRound1(p.temp.isThreshold, p.params, &p.keys, &p.pres, p.data, &p.temp, p.out, p.end)
+	return newRound1(p.temp.isThreshold, p.params, &p.keys, &p.pres, p.data, &p.temp, p.out, p.end, p.preEnd)
 }
 
 func (p *LocalParty) Start() *tss.Error {
@@ -3,6 +3,7 @@
 package signing
 
 import (
+	"crypto/rand"
 	"encoding/hex"
 	"fmt"
 	"math/big"
@@ -15,11 +16,14 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/felicityin/mpc-tss/common"
+	"github.com/felicityin/mpc-tss/crypto"
+	"github.com/felicityin/mpc-tss/crypto/sigfmt"
 	"github.com/felicityin/mpc-tss/protocols/cggmp/keygen"
 	nonKeygen "github.com/felicityin/mpc-tss/protocols/cggmp/keygen/non_threshold"
 	tKeygen "github.com/felicityin/mpc-tss/protocols/cggmp/keygen/threshold"
 	"github.com/felicityin/mpc-tss/protocols/cggmp/test"
 	"github.com/felicityin/mpc-tss/protocols/frost/presign"
+	"github.com/felicityin/mpc-tss/protocols/frost/sign"
 	"github.com/felicityin/mpc-tss/tss"
 )
 
@@ -263,3 +267,153 @@ signing:
 		}
 	}
 }
+
+func TestE2ERFC9591ThresholdConcurrent(t *testing.T) {
+	setUp("info")
+
+	threshold := testThreshold
+
+	// PHASE: load keygen fixtures
+	keys, signPIDs, err := tKeygen.LoadKeygenTestFixturesRandomSet(keygen.Eddsa, threshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+
+	// PHASE: presigning with hedged nonces
+	preParties := make([]tss.Party, 0, len(signPIDs))
+	preEndCh := make(chan *presign.LocalPartySaveData, len(signPIDs))
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(tss.Edwards(), p2pCtx, signPIDs[i], len(signPIDs), threshold)
+		party, err := presign.NewLocalPartyRFC9591(params, keys[i], outCh, preEndCh)
+		assert.NoError(t, err)
+		preParties = append(preParties, party)
+	}
+	pres := make([]presign.LocalPartySaveData, len(signPIDs))
+	done := make(chan struct{})
+	go func() {
+		for range preParties {
+			pre := <-preEndCh
+			index, err := pre.OriginalIndex()
+			assert.NoError(t, err)
+			pres[index] = *pre
+		}
+		close(done)
+	}()
+	runParties(t, preParties, errCh, outCh, done)
+
+	// PHASE: signing
+	parties := make([]tss.Party, 0, len(signPIDs))
+	endCh := make(chan *common.SignatureData, len(signPIDs))
+	msg, _ := hex.DecodeString("00f163ee51bcaeff9cdff5e0e3c1a646abd19885fffbab0b3b4236e0cf95c9f5")
+	path := "0/1/2/2/10"
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(tss.Edwards(), p2pCtx, signPIDs[i], len(signPIDs), threshold)
+		party, err := NewLocalPartyRFC9591(new(big.Int).SetBytes(msg), true, params, path, keys[i], pres[i], outCh, endCh, len(msg))
+		assert.NoError(t, err)
+		parties = append(parties, party)
+	}
+	pubkey := parties[0].(*LocalParty).keys.Pubkey
+	pk := edwards.PublicKey{Curve: tss.Edwards(), X: pubkey.X(), Y: pubkey.Y()}
+	done = make(chan struct{})
+	go func() {
+		for range parties {
+			sig, err := edwards.ParseSignature((<-endCh).Signature)
+			assert.NoError(t, err)
+			assert.True(t, edwards.Verify(&pk, msg, sig.R, sig.S), "eddsa verify must pass")
+		}
+		close(done)
+	}()
+	runParties(t, parties, errCh, outCh, done)
+}
+
+// runParties starts the parties and routes their broadcasts until done is closed
+func runParties(t *testing.T, parties []tss.Party, errCh chan *tss.Error, outCh chan tss.Message, done <-chan struct{}) {
+	for _, P := range parties {
+		go func(P tss.Party) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+	for {
+		select {
+		case err := <-errCh:
+			assert.FailNow(t, err.Error())
+		case msg := <-outCh:
+			for _, P := range parties {
+				if P.PartyID().Index == msg.GetFrom().Index {
+					continue
+				}
+				go test.SharedPartyUpdater(P, msg, errCh)
+			}
+		case <-done:
+			return
+		}
+	}
+}
+
+func TestE2EAdaptorConcurrent(t *testing.T) {
+	setUp("info")
+
+	ec := tss.Edwards()
+	keys, signPIDs, err := tKeygen.LoadKeygenTestFixturesRandomSet(keygen.Eddsa, testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+
+	// PHASE: presigning
+	preParties := make([]tss.Party, 0, len(signPIDs))
+	preEndCh := make(chan *presign.LocalPartySaveData, len(signPIDs))
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(ec, p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+		preParties = append(preParties, presign.NewLocalParty(params, outCh, preEndCh))
+	}
+	pres := make([]presign.LocalPartySaveData, len(signPIDs))
+	done := make(chan struct{})
+	go func() {
+		for range preParties {
+			pre := <-preEndCh
+			index, err := pre.OriginalIndex()
+			assert.NoError(t, err)
+			pres[index] = *pre
+		}
+		close(done)
+	}()
+	runParties(t, preParties, errCh, outCh, done)
+
+	// PHASE: pre-signing for the adaptor point T = tG
+	secret := common.GetRandomPositiveInt(rand.Reader, ec.Params().N)
+	T := crypto.ScalarBaseMult(ec, secret)
+	msg := common.NewEd25519Message([]byte("an atomic swap"))
+
+	parties := make([]tss.Party, 0, len(signPIDs))
+	endCh := make(chan *sign.PreSignature, len(signPIDs))
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(ec, p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+		party, err := NewLocalPartyWithAdaptor(msg, T, true, params, "0/1/2/2/10", keys[i], pres[i], outCh, endCh)
+		assert.NoError(t, err)
+		parties = append(parties, party)
+	}
+	pubkey := parties[0].(*LocalParty).keys.Pubkey
+	done = make(chan struct{})
+	go func() {
+		for range parties {
+			pre := <-endCh
+			assert.NoError(t, pre.Verify(pubkey))
+
+			sig, err := pre.Complete(secret)
+			if assert.NoError(t, err) {
+				assert.NoError(t, sigfmt.VerifyEd25519(pubkey, sig))
+				extracted, err := pre.Extract(sig)
+				assert.NoError(t, err)
+				assert.Equal(t, 0, secret.Cmp(extracted))
+			}
+		}
+		close(done)
+	}()
+	runParties(t, parties, errCh, outCh, done)
+}
@@ -4,7 +4,6 @@ import (
 	"crypto/sha512"
 	"fmt"
 	"math/big"
-	"strconv"
 
 	"github.com/agl/ed25519/edwards25519"
 	"github.com/pkg/errors"
@@ -26,9 +25,10 @@ func newRound1(
 	temp *localTempData,
 	out chan<- tss.Message,
 	end chan<- *common.SignatureData,
+	preEnd chan<- *sign.PreSignature,
 ) tss.Round {
 	return &round1{
-		&base{params, isThreshold, key, pre, data, temp, out, end, make([]bool, len(params.Parties().IDs())), false, 1},
+		&base{params, isThreshold, key, pre, data, temp, out, end, preEnd, make([]bool, len(params.Parties().IDs())), false, 1},
 	}
 }
 
@@ -51,70 +51,34 @@ func (round *round1) Start() *tss.Error {
 		return round.WrapError(err)
 	}
 
-	var B []byte
-
-	for j := range round.Parties().IDs() {
-		bs := common.SHA512_256(
-			round.pre.DEs[j].D.X().Bytes(),
-			round.pre.DEs[j].D.Y().Bytes(),
-			round.pre.DEs[j].E.X().Bytes(),
-			round.pre.DEs[j].E.Y().Bytes(),
-		)
-
-		B = append(B, round.key.PubXj[j].X().Bytes()...)
-		B = append(B, bs...)
+	var ki *big.Int
+	var encodedR [32]byte
+	var tssErr *tss.Error
+	if round.temp.rfc9591 {
+		ki, encodedR, tssErr = round.rfc9591Commitment()
+	} else {
+		ki, encodedR, tssErr = round.legacyCommitment()
 	}
-
-	rhoi := new(big.Int).SetBytes(
-		common.SHA512_256([]byte(strconv.Itoa(i)), round.temp.m.Bytes(), common.SHA512_256(B)),
-	)
-	rhoi.Mod(rhoi, round.EC().Params().N)
-
-	ki := new(big.Int).Mul(round.pre.E, rhoi)
-	ki.Add(ki, round.pre.D)
-	ki.Mod(ki, round.EC().Params().N)
-
-	var R edwards25519.ExtendedGroupElement
-	riBytes := bigIntToEncodedBytes(ki)
-	edwards25519.GeScalarMultBase(&R, riBytes)
-
-	for j, Pj := range round.Parties().IDs() {
-		if j == i {
-			continue
-		}
-
-		rhoj := new(big.Int).SetBytes(
-			common.SHA512_256([]byte(strconv.Itoa(j)), round.temp.m.Bytes(), common.SHA512_256(B)),
-		)
-		rhoj.Mod(rhoj, round.EC().Params().N)
-
-		D := round.pre.DEs[j].D
-		E := round.pre.DEs[j].E
-
-		Rj, err := E.ScalarMult(rhoj).Add(D)
-		if err != nil {
-			return round.WrapError(fmt.Errorf("rho * E + D err: %s", err.Error()), Pj)
-		}
-		round.temp.Rj[j] = Rj
-
-		Rj = Rj.EightInvEight()
+	if tssErr != nil {
+		return tssErr
+	}
+	// with an adaptor point T, the signers commit to R + T
+	if round.temp.adaptor != nil {
+		_, adapted, err := sign.AdaptNonce(&encodedR, round.temp.adaptor)
 		if err != nil {
-			return round.WrapError(errors.Wrapf(err, "NewECPoint(Rj)"), Pj)
+			return round.WrapError(err)
 		}
-
-		extendedRj := ecPointToExtendedElement(round.EC(), Rj.X(), Rj.Y(), round.Rand())
-		R = addExtendedElements(R, extendedRj)
+		encodedR = *adapted
 	}
+	riBytes := bigIntToEncodedBytes(ki)
 
 	// compute lambda
-	var encodedR [32]byte
-	R.ToBytes(&encodedR)
-
 	encodedPubKey := ecPointToEncodedBytes(round.key.Pubkey.X(), round.key.Pubkey.Y())
 
-	// h = hash512(R || X || M)
+	// h = hash512(dom2 || R || X || M)
 	h := sha512.New()
 	h.Reset()
+	h.Write(round.temp.dom)
 	h.Write(encodedR[:])
 	h.Write(encodedPubKey[:])
 	if round.temp.fullBytesLen == 0 {
@@ -132,9 +96,14 @@ func (round *round1) Start() *tss.Error {
 
 	// compute si
 	var localS [32]byte
-	edwards25519.ScMulAdd(&localS, &lambdaReduced, bigIntToEncodedBytes(round.key.PrivXi), riBytes)
+	xiBytes := bigIntToEncodedBytes(round.key.PrivXi)
+	edwards25519.ScMulAdd(&localS, &lambdaReduced, xiBytes, riBytes)
+
+	// the presignature is single-use, wipe it together with the key share now that it has been consumed
+	common.ZeroBytes(xiBytes[:], riBytes[:])
+	common.ZeroBigInts(ki, round.key.PrivXi)
+	round.pre.Destroy()
 
-	round.temp.c = encodedBytesToBigInt(&lambdaReduced)
 	round.temp.si = &localS
 	round.temp.r = encodedBytesToBigInt(&encodedR)
 
@@ -147,6 +116,78 @@ func (round *round1) Start() *tss.Error {
 	return nil
 }
 
+// legacyCommitment returns the nonce ki = d + rho_i * e of the party and the encoded group commitment R,
+// with the binding factors rho_j = SHA512_256(j, m, SHA512_256(B)) of the original protocol of this repo.
+func (round *round1) legacyCommitment() (*big.Int, [32]byte, *tss.Error) {
+	i := round.PartyID().Index
+
+	rhos := legacyBindingFactors(round.key.PubXj, round.pre.DEs, round.temp.m, round.EC().Params().N)
+
+	ki := new(big.Int).Mul(round.pre.E, rhos[i])
+	ki.Add(ki, round.pre.D)
+	ki.Mod(ki, round.EC().Params().N)
+
+	var R edwards25519.ExtendedGroupElement
+	riBytes := bigIntToEncodedBytes(ki)
+	edwards25519.GeScalarMultBase(&R, riBytes)
+
+	// R starts from Ri = ki * G, which the party computes from its own nonce, so only the Rj of the others are added
+	for j, Pj := range round.Parties().IDs() {
+		if j == i {
+			continue
+		}
+
+		D := round.pre.DEs[j].D
+		E := round.pre.DEs[j].E
+
+		Rj, err := E.ScalarMult(rhos[j]).Add(D)
+		if err != nil {
+			return nil, [32]byte{}, round.WrapError(fmt.Errorf("rho * E + D err: %s", err.Error()), Pj)
+		}
+
+		Rj = Rj.EightInvEight()
+
+		extendedRj := ecPointToExtendedElement(round.EC(), Rj.X(), Rj.Y(), round.Rand())
+		R = addExtendedElements(R, extendedRj)
+	}
+
+	var encodedR [32]byte
+	R.ToBytes(&encodedR)
+	common.ZeroBytes(riBytes[:])
+	return ki, encodedR, nil
+}
+
+// rfc9591Commitment returns the nonce ki = d + rho_i * e of the party and the encoded group commitment R,
+// with the binding factors and the group commitment of RFC 9591.
+func (round *round1) rfc9591Commitment() (*big.Int, [32]byte, *tss.Error) {
+	i := round.PartyID().Index
+
+	commitments := make([]*sign.SigningCommitment, len(round.Parties().IDs()))
+	for j := range round.Parties().IDs() {
+		commitments[j] = &sign.SigningCommitment{
+			Identifier: new(big.Int).Mod(round.key.Ks[j], round.EC().Params().N),
+			D:          round.pre.DEs[j].D,
+			E:          round.pre.DEs[j].E,
+		}
+	}
+
+	rhos := sign.ComputeBindingFactors(round.key.Pubkey, commitments, messageBytes(round.temp.m, round.temp.fullBytesLen))
+	Rs, err := sign.ComputeCommitments(commitments, rhos)
+	if err != nil {
+		return nil, [32]byte{}, round.WrapError(err)
+	}
+
+	R, err := sign.ComputeGroupCommitment(Rs)
+	if err != nil {
+		return nil, [32]byte{}, round.WrapError(err)
+	}
+
+	ki := new(big.Int).Mul(round.pre.E, rhos[i])
+	ki.Add(ki, round.pre.D)
+	ki.Mod(ki, round.EC().Params().N)
+	return ki, *ecPointToEncodedBytes(R.X(), R.Y()), nil
+}
+
 func (round *round1) Update() (bool, *tss.Error) {
 	ret := true
 	for j, msg := range round.temp.signRound1Messages {
@@ -8,6 +8,7 @@ import (
 	"github.com/felicityin/mpc-tss/crypto"
 	"github.com/felicityin/mpc-tss/protocols/cggmp/keygen"
 	"github.com/felicityin/mpc-tss/protocols/frost/presign"
+	"github.com/felicityin/mpc-tss/protocols/frost/sign"
 	"github.com/felicityin/mpc-tss/tss"
 )
 
@@ -25,6 +26,7 @@ type (
 		temp        *localTempData
 		out         chan<- tss.Message
 		end         chan<- *common.SignatureData
+		preEnd      chan<- *sign.PreSignature
 		ok          []bool // `ok` tracks parties which have been verified by Update()
 		started     bool
 		number      int
@@ -82,6 +84,17 @@ func (round *base) WrapError(err error, culprits ...*tss.PartyID) *tss.Error {
 	return tss.NewError(err, TaskName, round.number, round.PartyID(), culprits...)
 }
 
+// WrapEvidence returns an error blaming the sender of msgs, with the evidence that the check `kind` failed on them
+func (round *base) WrapEvidence(err error, kind string, inputs interface{}, msgs ...tss.ParsedMessage) *tss.Error {
+	culprit := msgs[0].GetFrom()
+	ev, evErr := tss.NewEvidence(kind, TaskName, round.number, culprit, inputs, msgs...)
+	if evErr != nil {
+		common.Logger.Errorf("failed to create the evidence %s against %s: %s", kind, culprit, evErr)
+		return round.WrapError(err, culprit)
+	}
+	return tss.NewEvidenceError(err, TaskName, round.number, round.PartyID(), []*tss.Evidence{ev})
+}
+
 // ----- //
 
 // `ok` tracks parties which have been verified by Update()
@@ -91,6 +104,30 @@ func (round *base) resetOK() {
 	}
 }
 
+// shareInputs returns the inputs of the check of the signature share of Pj
+func (round *base) shareInputs(j int) *ShareInputs {
+	return &ShareInputs{
+		ShareInputs: sign.ShareInputs{
+			Curve:        round.curveName(),
+			Index:        j,
+			RFC9591:      round.temp.rfc9591,
+			M:            round.temp.m,
+			FullBytesLen: round.temp.fullBytesLen,
+			Dom:          round.temp.dom,
+			Adaptor:      round.temp.adaptor,
+			Pubkey:       round.key.Pubkey,
+			Ks:           round.key.Ks,
+			PubXj:        round.key.PubXj,
+		},
+		DEs: round.pre.DEs,
+	}
+}
+
+func (round *base) curveName() tss.CurveName {
+	name, _ := tss.GetCurveName(round.EC())
+	return name
+}
+
 // get ssid from local params
 func (round *base) getSSID() ([]byte, error) {
 	ssidList := []*big.Int{round.EC().Params().P, round.EC().Params().N, round.EC().Params().Gx, round.EC().Params().Gy} // ec curve
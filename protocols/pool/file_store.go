@@ -0,0 +1,231 @@
+package pool
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const (
+	unusedExt = ".pre"
+	usedExt   = ".used"
+	tempExt   = ".tmp"
+)
+
+// FileStore is a Store keeping one file per presignature in a directory.
+//
+// An unused presignature lives in <id>.pre. Take claims it by renaming it to <id>.used,
+// which is atomic, and syncs the directory before reading it back, then truncates the
+// file so that only an empty tombstone remains. A crash after the rename leaves the
+// presignature marked as used; its content is wiped the next time the store is opened.
+type FileStore struct {
+	mtx sync.Mutex
+	dir string
+}
+
+var _ Store = (*FileStore)(nil)
+
+// NewFileStore opens the store in dir, creating the directory if needed
+func NewFileStore(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	s := &FileStore{dir: dir}
+	if err := s.recover(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *FileStore) Put(id string, data []byte) error {
+	if err := validateID(id); err != nil {
+		return err
+	}
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if exists(s.path(id, usedExt)) {
+		return ErrUsed
+	}
+	if exists(s.path(id, unusedExt)) {
+		return ErrExists
+	}
+	tmp := s.path(id, tempExt)
+	if err := writeFileSync(tmp, data); err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	if err := os.Rename(tmp, s.path(id, unusedExt)); err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	return syncDir(s.dir)
+}
+
+func (s *FileStore) Take(id string) ([]byte, error) {
+	if err := validateID(id); err != nil {
+		return nil, err
+	}
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	used := s.path(id, usedExt)
+	if err := os.Rename(s.path(id, unusedExt), used); err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+		if exists(used) {
+			return nil, ErrUsed
+		}
+		return nil, ErrNotFound
+	}
+	// the presignature must be durably marked as used before it leaves the store
+	if err := syncDir(s.dir); err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(used)
+	if err != nil {
+		return nil, err
+	}
+	if err = wipeFile(used); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func (s *FileStore) List() ([]string, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, err
+	}
+	type item struct {
+		id      string
+		modTime int64
+	}
+	items := make([]item, 0, len(entries))
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, unusedExt) {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item{strings.TrimSuffix(name, unusedExt), info.ModTime().UnixNano()})
+	}
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].modTime != items[j].modTime {
+			return items[i].modTime < items[j].modTime
+		}
+		return items[i].id < items[j].id
+	})
+	ids := make([]string, len(items))
+	for j, it := range items {
+		ids[j] = it.id
+	}
+	return ids, nil
+}
+
+// recover removes leftovers of an interrupted Put and wipes presignatures claimed by an interrupted Take
+func (s *FileStore) recover() error {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() {
+			continue
+		}
+		switch filepath.Ext(name) {
+		case tempExt:
+			if err = os.Remove(filepath.Join(s.dir, name)); err != nil {
+				return err
+			}
+		case usedExt:
+			info, err := entry.Info()
+			if err != nil {
+				return err
+			}
+			if info.Size() == 0 {
+				continue
+			}
+			if err = wipeFile(filepath.Join(s.dir, name)); err != nil {
+				return err
+			}
+		}
+	}
+	return syncDir(s.dir)
+}
+
+func (s *FileStore) path(id, ext string) string {
+	return filepath.Join(s.dir, id+ext)
+}
+
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+func writeFileSync(path string, data []byte) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err = f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err = f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// wipeFile overwrites the content of the file with zeros and truncates it
+func wipeFile(path string) error {
+	f, err := os.OpenFile(path, os.O_WRONLY, 0)
+	if err != nil {
+		return err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+	if _, err = f.WriteAt(make([]byte, info.Size()), 0); err != nil {
+		f.Close()
+		return err
+	}
+	if err = f.Truncate(0); err != nil {
+		f.Close()
+		return err
+	}
+	if err = f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	if err = d.Sync(); err != nil {
+		d.Close()
+		return fmt.Errorf("failed to sync %s: %w", dir, err)
+	}
+	return d.Close()
+}
@@ -0,0 +1,173 @@
+package pool
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/felicityin/mpc-tss/common"
+)
+
+const defaultRetryDelay = 5 * time.Second
+
+var ErrEmpty = errors.New("presign pool is empty")
+
+// GenerateFunc runs one presign session with the other parties, which may produce a batch of presignatures,
+// and returns the IDs and the serialized presignatures of this party.
+// Every party must obtain the same IDs for the same session.
+type GenerateFunc func(ctx context.Context) (ids []string, data [][]byte, err error)
+
+// Pool keeps up to size unused presignatures in a Store, generating new ones in the background
+// with Run, and hands out each of them exactly once with Take.
+//
+// All parties must consume the same presignature in a signing session: a coordinator picks
+// an ID with Next and sends it to the other parties, and every party, the coordinator included,
+// calls Take with that ID. Since the ID is derived from the public part of the presignature,
+// a party that does not hold it, or has already used it, fails to Take it instead of signing
+// with a different presignature.
+type Pool struct {
+	store      Store
+	generate   GenerateFunc
+	size       int
+	retryDelay time.Duration
+	wake       chan struct{}
+}
+
+func NewPool(store Store, generate GenerateFunc, size int) *Pool {
+	return &Pool{
+		store:      store,
+		generate:   generate,
+		size:       size,
+		retryDelay: defaultRetryDelay,
+		wake:       make(chan struct{}, 1),
+	}
+}
+
+// SetRetryDelay sets how long Run waits after a failed presign session before starting another one
+func (p *Pool) SetRetryDelay(delay time.Duration) {
+	p.retryDelay = delay
+}
+
+// Run refills the pool until ctx is done. The presign protocol needs every party online,
+// so each party is expected to run its pool with the same size and batch size.
+// A batch may fill the pool beyond size.
+func (p *Pool) Run(ctx context.Context) error {
+	for {
+		ids, err := p.store.List()
+		if err != nil {
+			return err
+		}
+		if len(ids) >= p.size {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-p.wake:
+			}
+			continue
+		}
+
+		newIDs, data, err := p.generate(ctx)
+		if err == nil && len(newIDs) != len(data) {
+			err = fmt.Errorf("generated %d ids for %d presignatures", len(newIDs), len(data))
+		}
+		if err != nil {
+			common.Logger.Errorf("presign pool: failed to generate presignatures: %v", err)
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(p.retryDelay):
+			}
+			continue
+		}
+		for j, id := range newIDs {
+			if err = p.store.Put(id, data[j]); err != nil {
+				common.Logger.Errorf("presign pool: failed to add presignature %s: %v", id, err)
+				continue
+			}
+			common.Logger.Debugf("presign pool: added presignature %s", id)
+		}
+	}
+}
+
+// Next returns the ID of the oldest unused presignature without consuming it
+func (p *Pool) Next() (string, error) {
+	ids, err := p.store.List()
+	if err != nil {
+		return "", err
+	}
+	if len(ids) == 0 {
+		return "", ErrEmpty
+	}
+	return ids[0], nil
+}
+
+// Len returns the number of unused presignatures
+func (p *Pool) Len() (int, error) {
+	ids, err := p.store.List()
+	if err != nil {
+		return 0, err
+	}
+	return len(ids), nil
+}
+
+// Take marks the presignature as used and returns it. It never returns the same ID twice.
+func (p *Pool) Take(id string) ([]byte, error) {
+	data, err := p.store.Take(id)
+	if err != nil {
+		return nil, err
+	}
+	select {
+	case p.wake <- struct{}{}:
+	default:
+	}
+	return data, nil
+}
+
+// Presignature is implemented by the presign save data of every protocol
+type Presignature interface {
+	ID() (string, error)
+}
+
+// Generator turns a function running a presign session, e.g. with presign.NewBatchLocalParty,
+// into a GenerateFunc storing the presignatures as JSON
+func Generator(run func(ctx context.Context) ([]Presignature, error)) GenerateFunc {
+	return func(ctx context.Context) ([]string, [][]byte, error) {
+		pres, err := run(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		ids := make([]string, len(pres))
+		data := make([][]byte, len(pres))
+		for j, pre := range pres {
+			if ids[j], err = pre.ID(); err != nil {
+				return nil, nil, err
+			}
+			if data[j], err = json.Marshal(pre); err != nil {
+				return nil, nil, err
+			}
+		}
+		return ids, data, nil
+	}
+}
+
+// TakeInto takes the presignature id out of the pool and decodes it into pre, which must be a pointer,
+// e.g. *presign.LocalPartySaveData. It checks that the decoded presignature really has the agreed ID.
+func (p *Pool) TakeInto(id string, pre Presignature) error {
+	data, err := p.Take(id)
+	if err != nil {
+		return err
+	}
+	if err = json.Unmarshal(data, pre); err != nil {
+		return err
+	}
+	got, err := pre.ID()
+	if err != nil {
+		return err
+	}
+	if got != id {
+		return fmt.Errorf("presignature stored as %s has id %s", id, got)
+	}
+	return nil
+}
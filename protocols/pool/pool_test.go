@@ -0,0 +1,175 @@
+package pool
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	ecdsaPresign "github.com/felicityin/mpc-tss/protocols/cggmp/ecdsa/presign"
+)
+
+const testParticipants = 3
+
+func testStoreSingleUse(t *testing.T, store Store) {
+	assert.NoError(t, store.Put("0a", []byte("a")))
+	assert.NoError(t, store.Put("0b", []byte("b")))
+	assert.ErrorIs(t, store.Put("0a", []byte("a")), ErrExists)
+	assert.Error(t, store.Put("../0c", []byte("c")), "ids must be hex")
+
+	ids, err := store.List()
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"0a", "0b"}, ids)
+
+	data, err := store.Take("0a")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("a"), data)
+
+	_, err = store.Take("0a")
+	assert.ErrorIs(t, err, ErrUsed)
+	assert.ErrorIs(t, store.Put("0a", []byte("a")), ErrUsed, "a used id must never come back")
+	_, err = store.Take("0c")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	ids, err = store.List()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"0b"}, ids)
+}
+
+func TestMemStore(t *testing.T) {
+	testStoreSingleUse(t, NewMemStore())
+}
+
+func TestFileStore(t *testing.T) {
+	store, err := NewFileStore(t.TempDir())
+	assert.NoError(t, err)
+	testStoreSingleUse(t, store)
+}
+
+func TestFileStoreSurvivesRestart(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewFileStore(dir)
+	assert.NoError(t, err)
+	assert.NoError(t, store.Put("01", []byte("one")))
+	assert.NoError(t, store.Put("02", []byte("two")))
+	_, err = store.Take("01")
+	assert.NoError(t, err)
+
+	// simulate a crash between claiming a presignature and wiping it, and an interrupted Put
+	assert.NoError(t, os.Rename(filepath.Join(dir, "02"+unusedExt), filepath.Join(dir, "02"+usedExt)))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "03"+tempExt), []byte("three"), 0o600))
+
+	store, err = NewFileStore(dir)
+	assert.NoError(t, err)
+	ids, err := store.List()
+	assert.NoError(t, err)
+	assert.Empty(t, ids)
+	for _, id := range []string{"01", "02"} {
+		_, err = store.Take(id)
+		assert.ErrorIs(t, err, ErrUsed)
+		info, err := os.Stat(filepath.Join(dir, id+usedExt))
+		assert.NoError(t, err)
+		assert.Zero(t, info.Size(), "used presignatures must be wiped")
+	}
+	_, err = os.Stat(filepath.Join(dir, "03"+tempExt))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestFileStoreConcurrentTake(t *testing.T) {
+	store, err := NewFileStore(t.TempDir())
+	assert.NoError(t, err)
+	assert.NoError(t, store.Put("ff", []byte("x")))
+
+	var taken int32
+	wg := sync.WaitGroup{}
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := store.Take("ff"); err == nil {
+				atomic.AddInt32(&taken, 1)
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), taken)
+}
+
+func TestPoolRefills(t *testing.T) {
+	var counter int32
+	generate := func(ctx context.Context) ([]string, [][]byte, error) {
+		n := atomic.AddInt32(&counter, 1)
+		if n%3 == 0 {
+			return nil, nil, fmt.Errorf("session %d failed", n)
+		}
+		// a batch of two presignatures per session
+		return []string{hex.EncodeToString([]byte{byte(n), 0}), hex.EncodeToString([]byte{byte(n), 1})},
+			[][]byte{{byte(n), 0}, {byte(n), 1}}, nil
+	}
+	p := NewPool(NewMemStore(), generate, 4)
+	p.SetRetryDelay(time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- p.Run(ctx) }()
+
+	seen := make(map[string]struct{})
+	for len(seen) < 10 {
+		id, err := p.Next()
+		if err == ErrEmpty {
+			time.Sleep(time.Millisecond)
+			continue
+		}
+		assert.NoError(t, err)
+		_, err = p.Take(id)
+		assert.NoError(t, err)
+		_, ok := seen[id]
+		assert.False(t, ok, "presignature %s handed out twice", id)
+		seen[id] = struct{}{}
+	}
+	cancel()
+	assert.ErrorIs(t, <-done, context.Canceled)
+
+	n, err := p.Len()
+	assert.NoError(t, err)
+	assert.LessOrEqual(t, n, 5)
+}
+
+func TestTakeInto(t *testing.T) {
+	pres, _, err := ecdsaPresign.LoadPreTestFixtures(true, testParticipants)
+	assert.NoError(t, err, "should load ecdsa presign fixtures")
+
+	sessions := 0
+	generate := Generator(func(ctx context.Context) ([]Presignature, error) {
+		sessions++
+		return []Presignature{pres[0]}, nil
+	})
+	p := NewPool(NewMemStore(), generate, 1)
+	ids, data, err := generate(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(ids))
+	id := ids[0]
+	assert.NoError(t, p.store.Put(id, data[0]))
+
+	var pre ecdsaPresign.LocalPartySaveData
+	assert.NoError(t, p.TakeInto(id, &pre))
+	assert.Equal(t, 0, pre.K.Cmp(pres[0].K))
+	assert.True(t, pre.R.Equals(pres[0].R))
+
+	assert.ErrorIs(t, p.TakeInto(id, &pre), ErrUsed)
+
+	// a presignature stored under another id is rejected
+	bz, err := json.Marshal(pres[1])
+	assert.NoError(t, err)
+	assert.NoError(t, p.store.Put("ab", bz))
+	assert.Error(t, p.TakeInto("ab", &pre))
+	assert.Equal(t, 1, sessions)
+}
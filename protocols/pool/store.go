@@ -0,0 +1,103 @@
+package pool
+
+import (
+	"errors"
+	"sync"
+)
+
+var (
+	ErrNotFound = errors.New("presignature not found")
+	ErrUsed     = errors.New("presignature has already been used")
+	ErrExists   = errors.New("presignature already exists")
+)
+
+// Store persists presignatures and guarantees that each of them is handed out at most once.
+//
+// Take must mark the presignature as used before returning it, so that a crash at any point
+// can only lose a presignature and never make it available again.
+// An ID that has been taken must be rejected by Put from then on.
+type Store interface {
+	// Put saves an unused presignature under id
+	Put(id string, data []byte) error
+	// Take marks the presignature as used and returns it
+	Take(id string) ([]byte, error)
+	// List returns the IDs of the unused presignatures, oldest first
+	List() ([]string, error)
+}
+
+// MemStore is a Store kept in memory, which does not survive a restart of the process
+type MemStore struct {
+	mtx   sync.Mutex
+	order []string
+	data  map[string][]byte
+	used  map[string]struct{}
+}
+
+var _ Store = (*MemStore)(nil)
+
+func NewMemStore() *MemStore {
+	return &MemStore{
+		data: make(map[string][]byte),
+		used: make(map[string]struct{}),
+	}
+}
+
+func (s *MemStore) Put(id string, data []byte) error {
+	if err := validateID(id); err != nil {
+		return err
+	}
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if _, ok := s.used[id]; ok {
+		return ErrUsed
+	}
+	if _, ok := s.data[id]; ok {
+		return ErrExists
+	}
+	s.data[id] = append([]byte{}, data...)
+	s.order = append(s.order, id)
+	return nil
+}
+
+func (s *MemStore) Take(id string) ([]byte, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if _, ok := s.used[id]; ok {
+		return nil, ErrUsed
+	}
+	data, ok := s.data[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	s.used[id] = struct{}{}
+	delete(s.data, id)
+	for j, oid := range s.order {
+		if oid == id {
+			s.order = append(s.order[:j], s.order[j+1:]...)
+			break
+		}
+	}
+	return data, nil
+}
+
+func (s *MemStore) List() ([]string, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	return append([]string{}, s.order...), nil
+}
+
+// IDs end up in file names, so only lower case hex is accepted, as produced by the presign packages
+func validateID(id string) error {
+	if len(id) == 0 || len(id) > 128 {
+		return errors.New("invalid presignature id length")
+	}
+	for _, c := range id {
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return errors.New("presignature id must be lower case hex")
+		}
+	}
+	return nil
+}
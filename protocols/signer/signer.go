@@ -0,0 +1,298 @@
+// Package signer implements crypto.Signer with the threshold keys, so that they can be used with the standard Go APIs
+// such as crypto/x509, crypto/tls, the JWS libraries or golang.org/x/crypto/ssh.
+//
+// Sign runs a session of ecdsa/signing, or of frost/signing for the ed25519 keys, with the co-signers through a pluggable
+// Transport: the transport sends the Request to the co-signers, which run it with Cosign, and carries the messages of the session.
+// The signers consume a presignature of their Presigns in each session, e.g. of a pool.Pool.
+//
+// The keys are signing keys only: neither ECDSA nor EdDSA keys decrypt, so the Signer is no crypto.Decrypter.
+package signer
+
+import (
+	"context"
+	stdcrypto "crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	edwards "github.com/decred/dcrd/dcrec/edwards/v2"
+
+	"github.com/felicityin/mpc-tss/common"
+	"github.com/felicityin/mpc-tss/crypto"
+	"github.com/felicityin/mpc-tss/crypto/sigfmt"
+	ecdsaPresign "github.com/felicityin/mpc-tss/protocols/cggmp/ecdsa/presign"
+	ecdsaSigning "github.com/felicityin/mpc-tss/protocols/cggmp/ecdsa/signing"
+	"github.com/felicityin/mpc-tss/protocols/cggmp/keygen"
+	frostPresign "github.com/felicityin/mpc-tss/protocols/frost/presign"
+	frostSigning "github.com/felicityin/mpc-tss/protocols/frost/signing"
+	"github.com/felicityin/mpc-tss/protocols/pool"
+	"github.com/felicityin/mpc-tss/protocols/utils"
+	"github.com/felicityin/mpc-tss/tss"
+)
+
+// Implements crypto.Signer
+var _ stdcrypto.Signer = (*Signer)(nil)
+
+// Format is the encoding of the ECDSA signatures. The EdDSA signatures are always the 64 bytes R || S of RFC 8032.
+type Format int
+
+const (
+	// FormatDER is the ASN.1 DER encoding, which crypto/x509, crypto/tls and crypto/ecdsa take
+	FormatDER Format = iota
+	// FormatRaw is r || s, each of the byte length of the order of the curve, as in JWS (RFC 7518)
+	FormatRaw
+)
+
+type (
+	// Signer signs with the share of a threshold key of the local party, together with its co-signers
+	Signer struct {
+		isThreshold bool
+		params      *tss.Parameters
+		key         keygen.LocalPartySaveData
+		presigns    Presigns
+		transport   Transport
+	}
+
+	// Presigns hands out each presignature of the local party once, e.g. a pool.Pool
+	Presigns interface {
+		// Next returns the ID of a presignature for a new session, without consuming it
+		Next() (string, error)
+		// TakeInto consumes the presignature and decodes it into pre
+		TakeInto(id string, pre pool.Presignature) error
+	}
+
+	// SignerOpts are the options of Sign. Any other crypto.SignerOpts signs with the master key in FormatDER,
+	// and *ed25519.Options with its context.
+	SignerOpts struct {
+		// Hash is the hash function of the digest: 0 for Ed25519 and Ed25519ctx, crypto.SHA512 for Ed25519ph
+		Hash    stdcrypto.Hash
+		Path    string // the derivation path of the child key that signs, the master key if empty
+		Context string // the context of Ed25519ph and Ed25519ctx
+		Format  Format
+	}
+)
+
+// HashFunc implements crypto.SignerOpts
+func (opts *SignerOpts) HashFunc() stdcrypto.Hash {
+	return opts.Hash
+}
+
+// NewSigner returns the signer of the key of the party of params, whose co-signers are the other parties of params.
+// The presignatures of presigns are those of the same parties.
+func NewSigner(
+	isThreshold bool,
+	params *tss.Parameters,
+	key keygen.LocalPartySaveData,
+	presigns Presigns,
+	transport Transport,
+) (*Signer, error) {
+	if key.Pubkey == nil {
+		return nil, errors.New("save data is missing Pubkey")
+	}
+	if !tss.SameCurve(key.Pubkey.Curve(), params.EC()) {
+		return nil, errors.New("the key is not on the curve of the parameters")
+	}
+	return &Signer{
+		isThreshold: isThreshold,
+		params:      params,
+		key:         key,
+		presigns:    presigns,
+		transport:   transport,
+	}, nil
+}
+
+// Public returns the group key: a *ecdsa.PublicKey, or an ed25519.PublicKey for the ed25519 keys
+func (s *Signer) Public() stdcrypto.PublicKey {
+	return publicKey(s.key.Pubkey)
+}
+
+// ChildPublic returns the public key of the child key of the path, which signs with SignerOpts.Path
+func (s *Signer) ChildPublic(path string) (stdcrypto.PublicKey, error) {
+	_, extendedKey, err := utils.DerivingKeyFromPath(s.key, path)
+	if err != nil {
+		return nil, err
+	}
+	return publicKey(extendedKey.PublicKey), nil
+}
+
+// Sign signs the digest with the co-signers, see SignContext
+func (s *Signer) Sign(_ io.Reader, digest []byte, opts stdcrypto.SignerOpts) ([]byte, error) {
+	return s.SignContext(context.Background(), digest, opts)
+}
+
+// SignContext signs the digest with the co-signers in a new session of the transport.
+// The digest of an ECDSA signature is the hash of opts.HashFunc(), which is truncated or padded to the order of the curve
+// as crypto/ecdsa does. The digest of an EdDSA signature is the message, or its SHA-512 for Ed25519ph.
+func (s *Signer) SignContext(ctx context.Context, digest []byte, opts stdcrypto.SignerOpts) ([]byte, error) {
+	req, format, err := s.newRequest(digest, opts)
+	if err != nil {
+		return nil, err
+	}
+	if req.PresignID, err = s.presigns.Next(); err != nil {
+		return nil, err
+	}
+	session, err := s.transport.Open(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	defer session.Close()
+
+	data, err := s.run(ctx, req, session)
+	if err != nil {
+		return nil, err
+	}
+	if s.isEdDSA() {
+		return sigfmt.Ed25519(data)
+	}
+	switch format {
+	case FormatDER:
+		return sigfmt.DER(data)
+	case FormatRaw:
+		byteLen := (s.params.EC().Params().N.BitLen() + 7) / 8
+		sig := make([]byte, 0, 2*byteLen)
+		sig = append(sig, common.PadToLengthBytesInPlace(data.R, byteLen)...)
+		return append(sig, common.PadToLengthBytesInPlace(data.S, byteLen)...), nil
+	default:
+		return nil, fmt.Errorf("unknown signature format %d", format)
+	}
+}
+
+// Cosign runs the session of the request of another signer, on the side of the local party of the session.
+// The request is signed as it is: the application checks it against its policy before calling Cosign.
+func (s *Signer) Cosign(ctx context.Context, req *Request, session Session) (*common.SignatureData, error) {
+	return s.run(ctx, req, session)
+}
+
+func (s *Signer) newRequest(digest []byte, opts stdcrypto.SignerOpts) (*Request, Format, error) {
+	if opts == nil {
+		return nil, 0, errors.New("nil signer opts")
+	}
+	hash := opts.HashFunc()
+	req := &Request{}
+	format := FormatDER
+	switch o := opts.(type) {
+	case *SignerOpts:
+		req.Path, req.Context, format = o.Path, []byte(o.Context), o.Format
+	case *ed25519.Options:
+		req.Context = []byte(o.Context)
+	}
+
+	if s.isEdDSA() {
+		req.Data = digest
+		switch {
+		case hash == stdcrypto.SHA512:
+			req.Mode = common.HashMode_ED25519PH
+		case hash != 0:
+			return nil, 0, fmt.Errorf("ed25519 signs with no hash or with SHA-512, not with %s", hash)
+		case len(req.Context) > 0:
+			req.Mode = common.HashMode_ED25519CTX
+		default:
+			req.Mode = common.HashMode_ED25519
+		}
+		return req, format, nil
+	}
+
+	if !hash.Available() || len(digest) != hash.Size() {
+		return nil, 0, fmt.Errorf("ECDSA signs the digest of a hash function, got %d bytes for %s", len(digest), hash)
+	}
+	if len(req.Context) > 0 {
+		return nil, 0, errors.New("ECDSA has no context")
+	}
+	req.Mode = common.HashMode_PREHASHED
+	req.Data = ecdsaDigest(digest, s.params.EC().Params().N.BitLen())
+	return req, format, nil
+}
+
+// run runs the party of the request over the session until it outputs the signature
+func (s *Signer) run(ctx context.Context, req *Request, session Session) (*common.SignatureData, error) {
+	parties := s.params.Parties().IDs()
+	out := make(chan tss.Message, len(parties))
+	end := make(chan *common.SignatureData, 1)
+	errCh := make(chan *tss.Error, 1)
+
+	party, err := s.newParty(req, out, end)
+	if err != nil {
+		return nil, err
+	}
+	// the first round sends at most one message to each other party, which out can hold
+	if err := party.Start(); err != nil {
+		return nil, err
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+
+		case err := <-errCh:
+			return nil, err
+
+		case msg := <-out:
+			if err := session.Send(ctx, msg); err != nil {
+				return nil, err
+			}
+
+		case msg, ok := <-session.Receive():
+			if !ok {
+				return nil, errors.New("the session was closed before the signature")
+			}
+			// the parties of the messages are those of the parameters, with their indexes
+			from := parties.FindByKey(msg.From.KeyInt())
+			if from == nil {
+				common.Logger.Warningf("message of %s ignored: not a signer of the session", msg.From)
+				continue
+			}
+			go func(msg *Message) {
+				if _, err := party.UpdateFromBytes(msg.WireBytes, from, msg.IsBroadcast); err != nil {
+					select {
+					case errCh <- err:
+					default:
+					}
+				}
+			}(msg)
+
+		case data := <-end:
+			return data, nil
+		}
+	}
+}
+
+func (s *Signer) newParty(req *Request, out chan<- tss.Message, end chan<- *common.SignatureData) (tss.Party, error) {
+	if s.isEdDSA() {
+		var pre frostPresign.LocalPartySaveData
+		if err := s.presigns.TakeInto(req.PresignID, &pre); err != nil {
+			return nil, err
+		}
+		return frostSigning.NewLocalPartyWithMessage(req.message(), s.isThreshold, s.params, req.Path, s.key, pre, out, end)
+	}
+	var pre ecdsaPresign.LocalPartySaveData
+	if err := s.presigns.TakeInto(req.PresignID, &pre); err != nil {
+		return nil, err
+	}
+	return ecdsaSigning.NewLocalPartyWithMessage(req.message(), s.isThreshold, s.params, req.Path, s.key, pre, out, end)
+}
+
+func (s *Signer) isEdDSA() bool {
+	return tss.SameCurve(s.params.EC(), tss.Edwards())
+}
+
+// ecdsaDigest returns the digest of the byte length of the order of the curve with the same integer as crypto/ecdsa takes:
+// a longer digest is truncated to the bit length of the order, and a shorter one is padded with leading zeros
+func ecdsaDigest(digest []byte, orderBits int) []byte {
+	orderBytes := (orderBits + 7) / 8
+	if len(digest) < orderBytes {
+		return common.PadToLengthBytesInPlace(digest, orderBytes)
+	}
+	e := new(big.Int).SetBytes(digest[:orderBytes])
+	e.Rsh(e, uint(orderBytes*8-orderBits))
+	return e.FillBytes(make([]byte, orderBytes))
+}
+
+func publicKey(p *crypto.ECPoint) stdcrypto.PublicKey {
+	if tss.SameCurve(p.Curve(), tss.Edwards()) {
+		return ed25519.PublicKey(edwards.NewPublicKey(p.X(), p.Y()).Serialize())
+	}
+	return &ecdsa.PublicKey{Curve: p.Curve(), X: p.X(), Y: p.Y()}
+}
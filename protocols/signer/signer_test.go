@@ -0,0 +1,179 @@
+package signer
+
+import (
+	"context"
+	stdcrypto "crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	ecdsaPresign "github.com/felicityin/mpc-tss/protocols/cggmp/ecdsa/presign"
+	"github.com/felicityin/mpc-tss/protocols/cggmp/keygen"
+	tKeygen "github.com/felicityin/mpc-tss/protocols/cggmp/keygen/threshold"
+	frostPresign "github.com/felicityin/mpc-tss/protocols/frost/presign"
+	"github.com/felicityin/mpc-tss/protocols/pool"
+	"github.com/felicityin/mpc-tss/tss"
+)
+
+const (
+	testParticipants = 3
+	testThreshold    = 2
+)
+
+type (
+	// memNetwork connects the signers in memory: opening a session of a signer makes the others cosign it
+	memNetwork struct {
+		t       *testing.T
+		signers []*Signer
+		cosigns sync.WaitGroup
+	}
+
+	memTransport struct {
+		net  *memNetwork
+		self int
+	}
+
+	memSession struct {
+		self  int
+		peers []*memSession
+		in    chan *Message
+	}
+)
+
+func (tr *memTransport) Open(ctx context.Context, req *Request) (Session, error) {
+	sessions := make([]*memSession, len(tr.net.signers))
+	for j := range sessions {
+		sessions[j] = &memSession{self: j, peers: sessions, in: make(chan *Message, 2*len(sessions))}
+	}
+	for j, signer := range tr.net.signers {
+		if j == tr.self {
+			continue
+		}
+		tr.net.cosigns.Add(1)
+		go func(signer *Signer, session *memSession) {
+			defer tr.net.cosigns.Done()
+			_, err := signer.Cosign(ctx, req, session)
+			assert.NoError(tr.net.t, err)
+		}(signer, sessions[j])
+	}
+	return sessions[tr.self], nil
+}
+
+func (s *memSession) Send(ctx context.Context, msg tss.Message) error {
+	wireBytes, routing, err := msg.WireBytes()
+	if err != nil {
+		return err
+	}
+	for _, peer := range s.peers {
+		if peer.self == s.self {
+			continue
+		}
+		if routing.To != nil && routing.To[0].Index != peer.self {
+			continue
+		}
+		peer.in <- &Message{WireBytes: wireBytes, From: routing.From, IsBroadcast: routing.IsBroadcast}
+	}
+	return nil
+}
+
+func (s *memSession) Receive() <-chan *Message {
+	return s.in
+}
+
+func (s *memSession) Close() error {
+	return nil
+}
+
+// newSigners returns the signers of the threshold fixtures of the kind, with the presignature of each party in its pool
+func newSigners(t *testing.T, kind int, pres []pool.Presignature) []*Signer {
+	keys, signPIDs, err := tKeygen.LoadKeygenTestFixturesRandomSet(kind, testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	net := &memNetwork{t: t}
+	t.Cleanup(net.cosigns.Wait)
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	for i := range signPIDs {
+		id, err := pres[i].ID()
+		assert.NoError(t, err)
+		data, err := json.Marshal(pres[i])
+		assert.NoError(t, err)
+		store := pool.NewMemStore()
+		assert.NoError(t, store.Put(id, data))
+
+		params := tss.NewParameters(tKeygen.TestCurve(kind), p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+		signer, err := NewSigner(true, params, keys[i], pool.NewPool(store, nil, 1), &memTransport{net: net, self: i})
+		assert.NoError(t, err)
+		net.signers = append(net.signers, signer)
+	}
+	return net.signers
+}
+
+func TestX509Certificate(t *testing.T) {
+	pres, _, err := ecdsaPresign.LoadPreTestFixturesOfKind(keygen.EcdsaP256, true, testThreshold+1)
+	assert.NoError(t, err, "should load presign fixtures")
+	presignatures := make([]pool.Presignature, len(pres))
+	for i := range pres {
+		presignatures[i] = pres[i]
+	}
+	signer := newSigners(t, keygen.EcdsaP256, presignatures)[0]
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "threshold CA"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, signer.Public(), signer)
+	assert.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	assert.NoError(t, err)
+	assert.NoError(t, cert.CheckSignatureFrom(cert))
+
+	_, err = signer.Sign(rand.Reader, der, &SignerOpts{Hash: stdcrypto.SHA256})
+	assert.Error(t, err, "the digest is not a SHA-256")
+	_, err = signer.Sign(rand.Reader, der[:32], &SignerOpts{Hash: stdcrypto.SHA256})
+	assert.ErrorIs(t, err, pool.ErrEmpty, "the presignature was consumed")
+}
+
+func TestEd25519ctxChildKey(t *testing.T) {
+	pres, _, err := frostPresign.LoadPreTestFixtures(true, testThreshold+1)
+	assert.NoError(t, err, "should load presign fixtures")
+	presignatures := make([]pool.Presignature, len(pres))
+	for i := range pres {
+		presignatures[i] = pres[i]
+	}
+	signer := newSigners(t, keygen.Eddsa, presignatures)[1]
+
+	msg := []byte{0x00, 0x01, 0x02}
+	path := "0/1/2/2/10"
+	_, err = signer.Sign(rand.Reader, msg, &SignerOpts{Hash: stdcrypto.SHA256, Path: path})
+	assert.Error(t, err, "ed25519 does not sign SHA-256 digests")
+
+	sig, err := signer.Sign(rand.Reader, msg, &SignerOpts{Path: path, Context: "foo"})
+	assert.NoError(t, err)
+	pub, err := signer.ChildPublic(path)
+	assert.NoError(t, err)
+	assert.NoError(t, ed25519.VerifyWithOptions(pub.(ed25519.PublicKey), msg, sig, &ed25519.Options{Context: "foo"}))
+	assert.False(t, ed25519.Verify(signer.Public().(ed25519.PublicKey), msg, sig), "signed by the child key")
+}
+
+func TestECDSADigest(t *testing.T) {
+	h := sha256.Sum256([]byte("hello"))
+	assert.Equal(t, h[:], ecdsaDigest(h[:], 256))
+	assert.Equal(t, append(make([]byte, 12), h[:20]...), ecdsaDigest(h[:20], 256), "SHA-1 is padded")
+	long := append(h[:], h[:16]...)
+	assert.Equal(t, h[:], ecdsaDigest(long, 256), "SHA-384 is truncated")
+	assert.Equal(t, new(big.Int).Rsh(new(big.Int).SetBytes(h[:]), 3).Bytes(), new(big.Int).SetBytes(ecdsaDigest(h[:], 253)).Bytes())
+}
@@ -0,0 +1,54 @@
+package signer
+
+import (
+	"context"
+
+	"github.com/felicityin/mpc-tss/common"
+	"github.com/felicityin/mpc-tss/tss"
+)
+
+type (
+	// Transport opens the signing sessions of the local party with its co-signers
+	Transport interface {
+		// Open sends the request to the co-signers, which run it with Cosign on their side of the session,
+		// and returns the session of the local party
+		Open(ctx context.Context, req *Request) (Session, error)
+	}
+
+	// Session carries the messages of a signing session between the local party and its co-signers
+	Session interface {
+		// Send sends a message of the local party to the co-signers of msg.GetTo(), or to all of them if it is a broadcast
+		Send(ctx context.Context, msg tss.Message) error
+
+		// Receive returns the channel of the messages of the co-signers to the local party, which is closed with the session
+		Receive() <-chan *Message
+
+		Close() error
+	}
+
+	// Message is a message of a co-signer as it came over the wire, see tss.Party.UpdateFromBytes
+	Message struct {
+		WireBytes   []byte
+		From        *tss.PartyID
+		IsBroadcast bool
+	}
+
+	// Request is what the co-signers need to know to join a signing session: the message, the key and the presignature
+	Request struct {
+		// Mode is PREHASHED for ECDSA, and ED25519, ED25519PH or ED25519CTX for EdDSA
+		Mode    common.HashMode
+		Data    []byte // the digest for ECDSA and Ed25519ph, the message for Ed25519 and Ed25519ctx
+		Context []byte // the context of Ed25519ph and Ed25519ctx
+
+		Path      string // the derivation path of the child key that signs, the master key if empty
+		PresignID string // the ID of the presignature that every signer consumes
+	}
+)
+
+// message returns the message of the request that the signing parties take
+func (req *Request) message() *common.Message {
+	if req.Mode == common.HashMode_ED25519PH {
+		return common.NewEd25519phDigestMessage(req.Data, req.Context)
+	}
+	return &common.Message{Mode: req.Mode, Data: req.Data, Context: req.Context}
+}
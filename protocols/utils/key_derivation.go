@@ -4,6 +4,8 @@ package utils
 
 import (
 	"crypto/elliptic"
+	"errors"
+	"fmt"
 	"math/big"
 
 	"github.com/btcsuite/btcd/chaincfg"
@@ -30,21 +32,149 @@ func UpdatePrivkey(key *keygen.LocalPartySaveData, keyDerivationDelta *big.Int,
 	key.PrivXi = mod.Add(keyDerivationDelta, key.PrivXi)
 }
 
+// TweakKey shifts the key prepared for the signing by the scalar tweak: the public key and PubXj[0] are shifted by tweak*G
+// and the party 0 adds the tweak to its share, so that the parties sign for the public key + tweak*G.
+func TweakKey(key *keygen.LocalPartySaveData, tweak *big.Int) error {
+	i, err := key.OriginalIndex()
+	if err != nil {
+		return fmt.Errorf("get party index err: %s", err.Error())
+	}
+
+	ec := key.Pubkey.Curve()
+	if err = CheckTweak(tweak, ec); err != nil {
+		return err
+	}
+	if tweak.Sign() == 0 {
+		return nil
+	}
+	tweakedPk, err := TweakPubkey(key.Pubkey, tweak)
+	if err != nil {
+		return err
+	}
+	err = UpdatePubkeyAndAdjustPubXj(key, tweak, tweakedPk, ec)
+	if err != nil {
+		return fmt.Errorf("there should not be an error setting the tweaked keys: %s", err.Error())
+	}
+
+	if i == 0 {
+		xi := key.PrivXi
+		UpdatePrivkey(key, tweak, ec)
+		common.ZeroBigInts(xi)
+	}
+	return nil
+}
+
+// TweakPubkey returns the public key pubkey + (t_1 + ... + t_n)*G that the parties sign for with the tweaks
+func TweakPubkey(pubkey *crypto.ECPoint, tweaks ...*big.Int) (*crypto.ECPoint, error) {
+	ec := pubkey.Curve()
+	for _, tweak := range tweaks {
+		if err := CheckTweak(tweak, ec); err != nil {
+			return nil, err
+		}
+		if tweak.Sign() == 0 {
+			continue
+		}
+		var err error
+		if pubkey, err = pubkey.Add(crypto.ScalarBaseMult(ec, tweak)); err != nil {
+			return nil, fmt.Errorf("tweak the public key err: %s", err.Error())
+		}
+	}
+	return pubkey, nil
+}
+
+// CheckTweak checks that the tweak is a scalar of the curve
+func CheckTweak(tweak *big.Int, ec elliptic.Curve) error {
+	if tweak == nil || tweak.Sign() < 0 || tweak.Cmp(ec.Params().N) >= 0 {
+		return errors.New("the tweak must be a scalar in [0, N)")
+	}
+	return nil
+}
+
+// DerivingPubkeyFromPath derives the child key of the path from the master public key with BIP32.
+// The extended keys have the version bytes of the bitcoin mainnet, see DerivingPubkeyFromPathWithVersion.
 func DerivingPubkeyFromPath(
 	masterPub *crypto.ECPoint, chainCode []byte, path string, ec elliptic.Curve,
+) (keyDerivationDelta *big.Int, extendedParentPk *ckd.ExtendedKey, err error) {
+	return DerivingPubkeyFromPathWithVersion(masterPub, chainCode, path, ec, chaincfg.MainNetParams.HDPublicKeyID)
+}
+
+// DerivingPubkeyFromPathWithVersion derives the child key of the path from the master public key with BIP32.
+// The extended keys have the version bytes of a network, e.g. chaincfg.TestNet3Params.HDPublicKeyID.
+func DerivingPubkeyFromPathWithVersion(
+	masterPub *crypto.ECPoint, chainCode []byte, path string, ec elliptic.Curve, version [4]byte,
+) (keyDerivationDelta *big.Int, extendedParentPk *ckd.ExtendedKey, err error) {
+	walletPath, err := ckd.ConvertPath(path)
+	if err != nil {
+		return
+	}
+	return ckd.DeriveChildKeyFromHierarchy(walletPath, masterExtendedKey(masterPub, chainCode, version), ec.Params().N, ec)
+}
+
+// DerivingEd25519PubkeyFromPath derives the child key of the path from the ed25519 master public key with BIP32-Ed25519
+func DerivingEd25519PubkeyFromPath(
+	masterPub *crypto.ECPoint, chainCode []byte, path string,
 ) (keyDerivationDelta *big.Int, extendedParentPk *ckd.ExtendedKey, err error) {
 	walletPath, err := ckd.ConvertPath(path)
 	if err != nil {
 		return
 	}
-	net := &chaincfg.MainNetParams
-	extendedParentPk = &ckd.ExtendedKey{
+	return ckd.DeriveEd25519ChildKeyFromHierarchy(walletPath, masterExtendedKey(masterPub, paddedChainCode(chainCode), [4]byte{}))
+}
+
+// DerivingKeyFromPath derives the child key of the path from the public key of the key, with the derivation of the key
+func DerivingKeyFromPath(key keygen.LocalPartySaveData, path string) (*big.Int, *ckd.ExtendedKey, error) {
+	switch key.Derivation {
+	case keygen.DerivationBIP32:
+		return DerivingPubkeyFromPath(key.Pubkey, key.ChainCode.Bytes(), path, key.Pubkey.Curve())
+	case keygen.DerivationBIP32V2:
+		return DerivingPubkeyFromPath(key.Pubkey, paddedChainCode(key.ChainCode.Bytes()), path, key.Pubkey.Curve())
+	case keygen.DerivationBIP32Ed25519:
+		return DerivingEd25519PubkeyFromPath(key.Pubkey, key.ChainCode.Bytes(), path)
+	default:
+		return nil, nil, fmt.Errorf("unknown key derivation %q", key.Derivation)
+	}
+}
+
+// DerivingKeysFromPaths derives the child keys of many paths from the public key of the key in a single call,
+// with the derivation of the key. The keys of the common prefixes of the paths are derived once,
+// and the extended keys have the version bytes of a network, e.g. chaincfg.MainNetParams.HDPublicKeyID.
+func DerivingKeysFromPaths(key keygen.LocalPartySaveData, paths []string, version [4]byte) ([]*big.Int, []*ckd.ExtendedKey, error) {
+	walletPaths := make([][]uint32, len(paths))
+	for i, path := range paths {
+		var err error
+		if walletPaths[i], err = ckd.ConvertPath(path); err != nil {
+			return nil, nil, err
+		}
+	}
+	switch key.Derivation {
+	case keygen.DerivationBIP32:
+		ec := key.Pubkey.Curve()
+		return ckd.DeriveChildKeysFromHierarchies(walletPaths, masterExtendedKey(key.Pubkey, key.ChainCode.Bytes(), version), ec.Params().N, ec)
+	case keygen.DerivationBIP32V2:
+		ec := key.Pubkey.Curve()
+		masterKey := masterExtendedKey(key.Pubkey, paddedChainCode(key.ChainCode.Bytes()), version)
+		return ckd.DeriveChildKeysFromHierarchies(walletPaths, masterKey, ec.Params().N, ec)
+	case keygen.DerivationBIP32Ed25519:
+		masterKey := masterExtendedKey(key.Pubkey, paddedChainCode(key.ChainCode.Bytes()), version)
+		return ckd.DeriveEd25519ChildKeysFromHierarchies(walletPaths, masterKey)
+	default:
+		return nil, nil, fmt.Errorf("unknown key derivation %q", key.Derivation)
+	}
+}
+
+// paddedChainCode returns the chain code of BIP32-Ed25519 and keygen.DerivationBIP32V2, which is always the 32 bytes
+// of the HMAC key with its leading zeros
+func paddedChainCode(chainCode []byte) []byte {
+	return common.PadToLengthBytesInPlace(chainCode, keygen.ChainCodeLen)
+}
+
+func masterExtendedKey(masterPub *crypto.ECPoint, chainCode []byte, version [4]byte) *ckd.ExtendedKey {
+	return &ckd.ExtendedKey{
 		PublicKey:  masterPub,
 		Depth:      0,
 		ChildIndex: 0,
 		ChainCode:  chainCode[:],
 		ParentFP:   []byte{0x00, 0x00, 0x00, 0x00},
-		Version:    net.HDPrivateKeyID[:],
+		Version:    version[:],
 	}
-	return ckd.DeriveChildKeyFromHierarchy(walletPath, extendedParentPk, ec.Params().N, ec)
 }
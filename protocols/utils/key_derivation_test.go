@@ -0,0 +1,80 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/felicityin/mpc-tss/crypto"
+	"github.com/felicityin/mpc-tss/crypto/ckd"
+	"github.com/felicityin/mpc-tss/protocols/cggmp/keygen"
+	"github.com/felicityin/mpc-tss/tss"
+)
+
+// The child keys of a chain code with a leading zero byte are the ones that the keys have always been derived with:
+// the chain code is the HMAC key without its leading zeros.
+func TestDerivingPubkeyFromPathLeadingZeroChainCode(t *testing.T) {
+	ec := tss.S256()
+	key := keygen.NewLocalPartySaveData(1)
+	key.Pubkey = crypto.ScalarBaseMult(ec, big.NewInt(7))
+	key.ChainCode = new(big.Int).SetBytes(append([]byte{0x00}, bytes.Repeat([]byte{0x01}, 31)...))
+
+	wantDelta, _ := new(big.Int).SetString("9958968dc4a6b4f6caa58e1b9ca917cc2a79638e1991364b66cba7366798baa7", 16)
+	wantX, _ := hex.DecodeString("4b72a28e60747c8c4068404fd50d58b50584cd7c391cfe6e4f8dea4cccf604cd")
+	wantY, _ := hex.DecodeString("679055c785f9b27c70f43c9939e18dc86d50f756b5b4dca1c24399978e60f70a")
+	want, err := crypto.NewECPoint(ec, new(big.Int).SetBytes(wantX), new(big.Int).SetBytes(wantY))
+	assert.NoError(t, err)
+
+	delta, child, err := DerivingPubkeyFromPath(key.Pubkey, key.ChainCode.Bytes(), "0/1/2", ec)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, wantDelta.Cmp(delta))
+	assert.True(t, want.Equals(child.PublicKey))
+
+	delta, child, err = DerivingKeyFromPath(key, "m/0/1/2")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, wantDelta.Cmp(delta))
+	assert.True(t, want.Equals(child.PublicKey))
+
+	deltas, children, err := DerivingKeysFromPaths(key, []string{"0/1/2"}, chaincfg.TestNet3Params.HDPublicKeyID)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, wantDelta.Cmp(deltas[0]))
+	assert.True(t, want.Equals(children[0].PublicKey))
+}
+
+// The chain code of BIP32-Ed25519 is the HMAC key of 32 bytes, whose leading zeros are part of it
+func TestDerivingEd25519PubkeyFromPathLeadingZeroChainCode(t *testing.T) {
+	ec := tss.Edwards()
+	key := keygen.NewLocalPartySaveData(1)
+	key.Pubkey = crypto.ScalarBaseMult(ec, big.NewInt(7))
+	key.ChainCode = new(big.Int).SetBytes(append([]byte{0x00}, bytes.Repeat([]byte{0x01}, 31)...))
+	key.Derivation = keygen.DerivationBIP32Ed25519
+
+	walletPath, err := ckd.ConvertPath("0/1/2")
+	assert.NoError(t, err)
+	wantDelta, want, err := ckd.DeriveEd25519ChildKeyFromHierarchy(walletPath, &ckd.ExtendedKey{
+		PublicKey: key.Pubkey,
+		ChainCode: append([]byte{0x00}, bytes.Repeat([]byte{0x01}, 31)...),
+		ParentFP:  []byte{0x00, 0x00, 0x00, 0x00},
+	})
+	assert.NoError(t, err)
+
+	delta, child, err := DerivingEd25519PubkeyFromPath(key.Pubkey, key.ChainCode.Bytes(), "0/1/2")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, wantDelta.Cmp(delta))
+	assert.True(t, want.PublicKey.Equals(child.PublicKey))
+	assert.Equal(t, want.ChainCode, child.ChainCode)
+
+	delta, child, err = DerivingKeyFromPath(key, "m/0/1/2")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, wantDelta.Cmp(delta))
+	assert.True(t, want.PublicKey.Equals(child.PublicKey))
+
+	deltas, children, err := DerivingKeysFromPaths(key, []string{"0/1/2"}, [4]byte{})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, wantDelta.Cmp(deltas[0]))
+	assert.True(t, want.PublicKey.Equals(children[0].PublicKey))
+}
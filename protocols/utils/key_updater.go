@@ -2,11 +2,15 @@ package utils
 
 import (
 	"fmt"
+	"math/big"
 
+	"github.com/felicityin/mpc-tss/common"
 	"github.com/felicityin/mpc-tss/protocols/cggmp/keygen"
 )
 
-func UpdateKeyForSigning(key *keygen.LocalPartySaveData, path string, isThreshold bool, threshold int) error {
+// UpdateKeyForSigning prepares the key share for the signing: it applies the Lagrange coefficient of the party if isThreshold,
+// then shifts the key to the child key of path with the derivation of the key, then adds the tweaks to the key in order.
+func UpdateKeyForSigning(key *keygen.LocalPartySaveData, path string, isThreshold bool, threshold int, tweaks ...*big.Int) error {
 	i, err := key.OriginalIndex()
 	if err != nil {
 		return fmt.Errorf("get party index err: %s", err.Error())
@@ -14,22 +18,39 @@ func UpdateKeyForSigning(key *keygen.LocalPartySaveData, path string, isThreshol
 
 	ec := key.Pubkey.Curve()
 
+	// the party works on its own copy of the key share, so that it can wipe it when it is done
+	key.PrivXi = new(big.Int).Set(key.PrivXi)
+
 	if isThreshold {
 		if threshold+1 > len(key.Ks) {
 			return fmt.Errorf("t+1=%d is not satisfied by the key count of %d", threshold+1, len(key.Ks))
 		}
 
-		key.PrivXi, key.PubXj, key.Pubkey, err = PrepareForSigning(ec, i, len(key.Ks), key.PrivXi, key.Ks, key.PubXj)
+		xi := key.PrivXi
+		key.PrivXi, key.PubXj, key.Pubkey, err = PrepareForSigning(ec, i, len(key.Ks), xi, key.Ks, key.PubXj)
+		common.ZeroBigInts(xi)
 		if err != nil {
 			return err
 		}
 	}
 
-	if path == "" || path == "m" {
-		return nil
+	if path != "" && path != "m" {
+		if err = updateKeyForPath(key, i, path); err != nil {
+			return err
+		}
+	}
+
+	for _, tweak := range tweaks {
+		if err = TweakKey(key, tweak); err != nil {
+			return err
+		}
 	}
+	return nil
+}
 
-	keyDerivationDelta, extendedChildPk, err := DerivingPubkeyFromPath(key.Pubkey, key.ChainCode.Bytes(), path, ec)
+func updateKeyForPath(key *keygen.LocalPartySaveData, i int, path string) error {
+	ec := key.Pubkey.Curve()
+	keyDerivationDelta, extendedChildPk, err := DerivingKeyFromPath(*key, path)
 	if err != nil {
 		return fmt.Errorf("there should not be an error deriving the child public key: %s", err.Error())
 	}
@@ -40,7 +61,9 @@ func UpdateKeyForSigning(key *keygen.LocalPartySaveData, path string, isThreshol
 	}
 
 	if i == 0 {
+		xi := key.PrivXi
 		UpdatePrivkey(key, keyDerivationDelta, ec)
+		common.ZeroBigInts(xi)
 	}
 	return nil
 }
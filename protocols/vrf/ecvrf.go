@@ -0,0 +1,339 @@
+package vrf
+
+import (
+	"crypto/elliptic"
+	"crypto/sha256"
+	"crypto/sha512"
+	"errors"
+	"fmt"
+	"hash"
+	"math/big"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	edwards "github.com/decred/dcrd/dcrec/edwards/v2"
+
+	"github.com/felicityin/mpc-tss/common"
+	"github.com/felicityin/mpc-tss/crypto"
+	"github.com/felicityin/mpc-tss/tss"
+)
+
+// The suite strings of the ciphersuites of RFC 9381 section 5.5
+const (
+	SuiteP256SHA256TAI         byte = 0x01
+	SuiteEdwards25519SHA512TAI byte = 0x03
+)
+
+// SuiteSecp256k1SHA256TAI is the suite string of SECP256K1-SHA256-TAI, which is P256-SHA256-TAI on secp256k1.
+// It is not in RFC 9381, so the verifiers of the RFC do not know it, and the VRF of a secp256k1 key is only evaluated
+// and verified with it explicitly, see NewLocalPartyWithSuite and VerifyWithSuite.
+const SuiteSecp256k1SHA256TAI byte = 0xfe
+
+const (
+	challengeLen                 = 16 // cLen
+	scalarLen                    = 32 // qLen
+	maxEncodeToCurveAttempts     = 256
+	domainSeparatorEncodeToCurve = 0x01
+	domainSeparatorChallenge     = 0x02
+	domainSeparatorProofToHash   = 0x03
+	domainSeparatorBack          = 0x00
+)
+
+// suite is an ECVRF ciphersuite with the try-and-increment encoding to the curve of RFC 9381 section 5.4.1.1
+type suite struct {
+	id       byte
+	ec       elliptic.Curve
+	hash     func() hash.Hash
+	cofactor *big.Int
+}
+
+// suiteOf returns the ciphersuite of RFC 9381 of the curve: EDWARDS25519-SHA512-TAI on ed25519 and P256-SHA256-TAI on P-256
+func suiteOf(ec elliptic.Curve) (*suite, error) {
+	switch {
+	case tss.SameCurve(ec, tss.Edwards()):
+		return suiteByID(SuiteEdwards25519SHA512TAI, ec)
+	case tss.SameCurve(ec, tss.P256()):
+		return suiteByID(SuiteP256SHA256TAI, ec)
+	case tss.SameCurve(ec, tss.S256()):
+		return nil, errors.New("secp256k1 has no ECVRF ciphersuite in RFC 9381, the non-standard SuiteSecp256k1SHA256TAI must be chosen explicitly")
+	}
+	return nil, errors.New("the curve has no ECVRF ciphersuite")
+}
+
+// suiteByID returns the ciphersuite of the suite string, which must be on the curve
+func suiteByID(id byte, ec elliptic.Curve) (*suite, error) {
+	var s *suite
+	switch id {
+	case SuiteEdwards25519SHA512TAI:
+		s = &suite{id, tss.Edwards(), sha512.New, big.NewInt(8)}
+	case SuiteP256SHA256TAI:
+		s = &suite{id, tss.P256(), sha256.New, big.NewInt(1)}
+	case SuiteSecp256k1SHA256TAI:
+		s = &suite{id, tss.S256(), sha256.New, big.NewInt(1)}
+	default:
+		return nil, fmt.Errorf("unknown ECVRF ciphersuite 0x%02x", id)
+	}
+	if !tss.SameCurve(ec, s.ec) {
+		return nil, fmt.Errorf("the ECVRF ciphersuite 0x%02x is not on the curve", id)
+	}
+	s.ec = ec
+	return s, nil
+}
+
+func (s *suite) isEdwards() bool {
+	return s.id == SuiteEdwards25519SHA512TAI
+}
+
+// ProofLen returns the byte length of the proofs pi of the ciphersuite of RFC 9381 of the curve, or 0 if it has none
+func ProofLen(ec elliptic.Curve) int {
+	s, err := suiteOf(ec)
+	if err != nil {
+		return 0
+	}
+	return s.proofLen()
+}
+
+// Verify checks the proof pi of the input alpha against the public key, the group key or one of its child keys,
+// as ECVRF_verify of RFC 9381 section 5.3 with the validation of the public key, and returns its output beta.
+// The ciphersuite is the one of RFC 9381 of the curve of the key.
+func Verify(pubkey *crypto.ECPoint, alpha, pi []byte) ([]byte, error) {
+	if pubkey == nil || !pubkey.ValidateBasic() {
+		return nil, errors.New("the public key is missing or not on its curve")
+	}
+	s, err := suiteOf(pubkey.Curve())
+	if err != nil {
+		return nil, err
+	}
+	return s.verify(pubkey, alpha, pi)
+}
+
+// VerifyWithSuite checks the proof pi as Verify does, with the ciphersuite of the suite string, which must be on the curve
+// of the key. It is how the proofs of the non-standard SuiteSecp256k1SHA256TAI are verified.
+func VerifyWithSuite(suiteID byte, pubkey *crypto.ECPoint, alpha, pi []byte) ([]byte, error) {
+	if pubkey == nil || !pubkey.ValidateBasic() {
+		return nil, errors.New("the public key is missing or not on its curve")
+	}
+	s, err := suiteByID(suiteID, pubkey.Curve())
+	if err != nil {
+		return nil, err
+	}
+	return s.verify(pubkey, alpha, pi)
+}
+
+func (s *suite) verify(pubkey *crypto.ECPoint, alpha, pi []byte) ([]byte, error) {
+	if err := s.validateKey(pubkey); err != nil {
+		return nil, err
+	}
+	Gamma, c, sc, err := s.decodeProof(pi)
+	if err != nil {
+		return nil, err
+	}
+	H, err := s.encodeToCurve(pubkey, alpha)
+	if err != nil {
+		return nil, err
+	}
+	// U = s * B - c * Y and V = s * H - c * Gamma
+	minusC := common.ModInt(s.ec.Params().N).Sub(big.NewInt(0), c)
+	U, err := crypto.ScalarBaseMult(s.ec, sc).Add(pubkey.ScalarMult(minusC))
+	if err != nil {
+		return nil, fmt.Errorf("s * B - c * Y err: %s", err.Error())
+	}
+	V, err := H.ScalarMult(sc).Add(Gamma.ScalarMult(minusC))
+	if err != nil {
+		return nil, fmt.Errorf("s * H - c * Gamma err: %s", err.Error())
+	}
+	if s.challenge(pubkey, H, Gamma, U, V).Cmp(c) != 0 {
+		return nil, errors.New("the proof does not verify against the public key")
+	}
+	return s.proofToHash(Gamma), nil
+}
+
+// ProofToHash returns the output beta of the proof pi, as ECVRF_proof_to_hash of RFC 9381 section 5.2,
+// with the ciphersuite of RFC 9381 of the curve. It does not verify the proof, which only Verify does.
+func ProofToHash(ec elliptic.Curve, pi []byte) ([]byte, error) {
+	s, err := suiteOf(ec)
+	if err != nil {
+		return nil, err
+	}
+	return s.proofToHashOf(pi)
+}
+
+// ProofToHashWithSuite returns the output beta of the proof pi as ProofToHash does, with the ciphersuite of the suite string
+func ProofToHashWithSuite(suiteID byte, ec elliptic.Curve, pi []byte) ([]byte, error) {
+	s, err := suiteByID(suiteID, ec)
+	if err != nil {
+		return nil, err
+	}
+	return s.proofToHashOf(pi)
+}
+
+func (s *suite) proofToHashOf(pi []byte) ([]byte, error) {
+	Gamma, _, _, err := s.decodeProof(pi)
+	if err != nil {
+		return nil, err
+	}
+	return s.proofToHash(Gamma), nil
+}
+
+// encodeToCurve returns H = ECVRF_encode_to_curve_try_and_increment(Y, alpha) of RFC 9381 section 5.4.1.1
+func (s *suite) encodeToCurve(pubkey *crypto.ECPoint, alpha []byte) (*crypto.ECPoint, error) {
+	Y := s.pointToString(pubkey)
+	for ctr := 0; ctr < maxEncodeToCurveAttempts; ctr++ {
+		h := s.hash()
+		h.Write([]byte{s.id, domainSeparatorEncodeToCurve})
+		h.Write(Y)
+		h.Write(alpha)
+		h.Write([]byte{byte(ctr), domainSeparatorBack})
+		digest := h.Sum(nil)
+
+		// interpret_hash_value_as_a_point
+		var bz []byte
+		if s.isEdwards() {
+			bz = digest[:s.pointLen()]
+		} else {
+			bz = append([]byte{0x02}, digest[:s.pointLen()-1]...)
+		}
+		H, err := s.stringToPoint(bz)
+		if err != nil {
+			continue
+		}
+		return H.ScalarMult(s.cofactor), nil
+	}
+	return nil, errors.New("no point of the input was found")
+}
+
+// challenge returns c = ECVRF_challenge_generation(P1, P2, P3, P4, P5) of RFC 9381 section 5.4.3
+func (s *suite) challenge(points ...*crypto.ECPoint) *big.Int {
+	h := s.hash()
+	h.Write([]byte{s.id, domainSeparatorChallenge})
+	for _, P := range points {
+		h.Write(s.pointToString(P))
+	}
+	h.Write([]byte{domainSeparatorBack})
+	return s.stringToInt(h.Sum(nil)[:challengeLen])
+}
+
+// proofToHash returns beta = Hash(suite_string || 0x03 || point_to_string(cofactor * Gamma) || 0x00)
+func (s *suite) proofToHash(Gamma *crypto.ECPoint) []byte {
+	h := s.hash()
+	h.Write([]byte{s.id, domainSeparatorProofToHash})
+	h.Write(s.pointToString(Gamma.ScalarMult(s.cofactor)))
+	h.Write([]byte{domainSeparatorBack})
+	return h.Sum(nil)
+}
+
+// encodeProof returns pi = point_to_string(Gamma) || int_to_string(c, cLen) || int_to_string(s, qLen)
+func (s *suite) encodeProof(Gamma *crypto.ECPoint, c, sc *big.Int) []byte {
+	pi := s.pointToString(Gamma)
+	pi = append(pi, s.intToString(c, challengeLen)...)
+	return append(pi, s.intToString(sc, scalarLen)...)
+}
+
+// decodeProof returns Gamma, c and s of the proof pi, as ECVRF_decode_proof of RFC 9381 section 5.4.4
+func (s *suite) decodeProof(pi []byte) (*crypto.ECPoint, *big.Int, *big.Int, error) {
+	ptLen := s.pointLen()
+	if len(pi) != ptLen+challengeLen+scalarLen {
+		return nil, nil, nil, fmt.Errorf("the proof has %d bytes, expected %d", len(pi), ptLen+challengeLen+scalarLen)
+	}
+	Gamma, err := s.stringToPoint(pi[:ptLen])
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("the Gamma of the proof: %s", err.Error())
+	}
+	c := s.stringToInt(pi[ptLen : ptLen+challengeLen])
+	sc := s.stringToInt(pi[ptLen+challengeLen:])
+	// a zero c or s, which an honest prover outputs with a negligible probability, would give the points at infinity
+	if sc.Cmp(s.ec.Params().N) >= 0 || sc.Sign() == 0 || c.Sign() == 0 {
+		return nil, nil, nil, errors.New("the c or the s of the proof is out of range")
+	}
+	return Gamma, c, sc, nil
+}
+
+// validateKey rejects the public keys of small order, as ECVRF_validate_key of RFC 9381 section 5.4.5
+func (s *suite) validateKey(pubkey *crypto.ECPoint) error {
+	if !s.isEdwards() {
+		return nil
+	}
+	Y := pubkey.ScalarMult(s.cofactor)
+	if Y == nil || (Y.X().Sign() == 0 && Y.Y().Cmp(big.NewInt(1)) == 0) {
+		return errors.New("the public key is of small order")
+	}
+	return nil
+}
+
+func (s *suite) proofLen() int {
+	return s.pointLen() + challengeLen + scalarLen
+}
+
+func (s *suite) pointLen() int {
+	if s.isEdwards() {
+		return 32
+	}
+	return 1 + (s.ec.Params().BitSize+7)/8
+}
+
+// pointToString is the encoding of RFC 8032 on ed25519, and the compressed point of SEC 1 on the other curves
+func (s *suite) pointToString(P *crypto.ECPoint) []byte {
+	if s.isEdwards() {
+		return edwards.NewPublicKey(P.X(), P.Y()).Serialize()
+	}
+	return elliptic.MarshalCompressed(s.ec, P.X(), P.Y())
+}
+
+func (s *suite) stringToPoint(bz []byte) (*crypto.ECPoint, error) {
+	switch {
+	case s.isEdwards():
+		pk, err := edwards.ParsePubKey(bz)
+		if err != nil {
+			return nil, err
+		}
+		// the decoding of RFC 8032 rejects the non-canonical encodings, which the parser reduces
+		P, err := crypto.NewECPoint(s.ec, pk.X, pk.Y)
+		if err != nil {
+			return nil, err
+		}
+		if string(s.pointToString(P)) != string(bz) {
+			return nil, errors.New("non-canonical point encoding")
+		}
+		return P, nil
+
+	case tss.SameCurve(s.ec, tss.S256()):
+		// elliptic.UnmarshalCompressed is for the curves with a = -3
+		if len(bz) != s.pointLen() {
+			return nil, errors.New("invalid compressed point")
+		}
+		pk, err := btcec.ParsePubKey(bz)
+		if err != nil {
+			return nil, err
+		}
+		return crypto.NewECPoint(s.ec, pk.X(), pk.Y())
+
+	default:
+		x, y := elliptic.UnmarshalCompressed(s.ec, bz)
+		if x == nil {
+			return nil, errors.New("invalid compressed point")
+		}
+		return crypto.NewECPoint(s.ec, x, y)
+	}
+}
+
+// intToString is little-endian on ed25519, and big-endian on the other curves
+func (s *suite) intToString(n *big.Int, length int) []byte {
+	bz := n.FillBytes(make([]byte, length))
+	if s.isEdwards() {
+		reverse(bz)
+	}
+	return bz
+}
+
+func (s *suite) stringToInt(bz []byte) *big.Int {
+	be := append([]byte{}, bz...)
+	if s.isEdwards() {
+		reverse(be)
+	}
+	return new(big.Int).SetBytes(be)
+}
+
+func reverse(b []byte) {
+	for i, j := 0, len(b)-1; i < j; i, j = i+1, j-1 {
+		b[i], b[j] = b[j], b[i]
+	}
+}
@@ -0,0 +1,131 @@
+package vrf
+
+import (
+	"crypto/elliptic"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/felicityin/mpc-tss/crypto"
+	"github.com/felicityin/mpc-tss/tss"
+)
+
+func TestRFC9381Vectors(t *testing.T) {
+	vectors := []struct {
+		name  string
+		ec    elliptic.Curve
+		pk    string
+		alpha []byte
+		pi    string
+		beta  string
+	}{
+		{
+			// example 16 of RFC 9381 appendix B.3
+			name:  "EDWARDS25519-SHA512-TAI",
+			ec:    tss.Edwards(),
+			pk:    "d75a980182b10ab7d54bfed3c964073a0ee172f3daa62325af021a68f707511a",
+			alpha: []byte{},
+			pi:    "8657106690b5526245a92b003bb079ccd1a92130477671f6fc01ad16f26f723f26f8a57ccaed74ee1b190bed1f479d9727d2d0f9b005a6e456a35d4fb0daab1268a1b0db10836d9826a528ca76567805",
+			beta:  "90cf1df3b703cce59e2a35b925d411164068269d7b2d29f3301c03dd757876ff66b71dda49d2de59d03450451af026798e8f81cd2e333de5cdf4f3e140fdd8ae",
+		},
+		{
+			// example 10 of RFC 9381 appendix B.1
+			name:  "P256-SHA256-TAI",
+			ec:    tss.P256(),
+			pk:    "0360fed4ba255a9d31c961eb74c6356d68c049b8923b61fa6ce669622e60f29fb6",
+			alpha: []byte("sample"),
+			pi:    "035b5c726e8c0e2c488a107c600578ee75cb702343c153cb1eb8dec77f4b5071b4a53f0a46f018bc2c56e58d383f2305e0975972c26feea0eb122fe7893c15af376b33edf7de17c6ea056d4d82de6bc02f",
+			beta:  "a3ad7b0ef73d8fc6655053ea22f9bede8c743f08bbed3d38821f0e16474b505e",
+		},
+	}
+	for _, v := range vectors {
+		s, err := suiteOf(v.ec)
+		assert.NoError(t, err)
+		pk, err := s.stringToPoint(mustHex(v.pk))
+		if !assert.NoError(t, err, v.name) {
+			continue
+		}
+		pi := mustHex(v.pi)
+		beta, err := Verify(pk, v.alpha, pi)
+		if assert.NoError(t, err, v.name) {
+			assert.Equal(t, v.beta, hex.EncodeToString(beta), v.name)
+		}
+		beta, err = ProofToHash(v.ec, pi)
+		assert.NoError(t, err, v.name)
+		assert.Equal(t, v.beta, hex.EncodeToString(beta), v.name)
+
+		_, err = Verify(pk, []byte("other"), pi)
+		assert.Error(t, err, v.name)
+		pi[len(pi)-1] ^= 1
+		_, err = Verify(pk, v.alpha, pi)
+		assert.Error(t, err, v.name)
+		_, err = Verify(pk, v.alpha, pi[1:])
+		assert.Error(t, err, v.name)
+	}
+}
+
+// SECP256K1-SHA256-TAI is not in RFC 9381, so its vectors are fixed here: the key is SHA-256("secp256k1 ecvrf test key"),
+// and the nonce of the proof of alpha is SHA-256("secp256k1 ecvrf test nonce " || alpha)
+func TestSecp256k1Vectors(t *testing.T) {
+	vectors := []struct {
+		alpha []byte
+		pi    string
+		beta  string
+	}{
+		{
+			alpha: []byte{},
+			pi:    "030d0c4c52c55cf68ff93c40fbf49bf299ef83d4d6a58763f7cb05623e9aa5e3cbf4cf1006a75f07d0e76f44d9378920273e687ef45ec5f0e312e5afeb30a68928b68d4fc39884472af0af898f43513d0f",
+			beta:  "fe8660b6ed7f06fc162abac3c4c67578b67cc163c380fd38d824f036f7870ba9",
+		},
+		{
+			alpha: []byte("sample"),
+			pi:    "03f658bfa72d1700480ccca9a788fdedc651854f67fac50c62420df1cc84c867d7eb69167213d8104a645465de0a42a2d37c60aa018bd59d23d541639150aa94e7a1a4657bcec80e7e760967f505787daf",
+			beta:  "2ea680968e5c7d20e5b7d3823afd698493d4292acef84fa6549ac84980cdb934",
+		},
+	}
+	s, err := suiteByID(SuiteSecp256k1SHA256TAI, tss.S256())
+	assert.NoError(t, err)
+	pk, err := s.stringToPoint(mustHex("03853f78d97bc1adc2e25016911b75f2730f169469b19880f2696309854b51d21f"))
+	assert.NoError(t, err)
+	for _, v := range vectors {
+		pi := mustHex(v.pi)
+		beta, err := VerifyWithSuite(SuiteSecp256k1SHA256TAI, pk, v.alpha, pi)
+		if assert.NoError(t, err) {
+			assert.Equal(t, v.beta, hex.EncodeToString(beta))
+		}
+		beta, err = ProofToHashWithSuite(SuiteSecp256k1SHA256TAI, tss.S256(), pi)
+		assert.NoError(t, err)
+		assert.Equal(t, v.beta, hex.EncodeToString(beta))
+
+		// secp256k1 has no ciphersuite in RFC 9381, so the suite is only chosen explicitly
+		_, err = Verify(pk, v.alpha, pi)
+		assert.Error(t, err)
+		_, err = ProofToHash(tss.S256(), pi)
+		assert.Error(t, err)
+		assert.Equal(t, 0, ProofLen(tss.S256()))
+		_, err = VerifyWithSuite(SuiteP256SHA256TAI, pk, v.alpha, pi)
+		assert.Error(t, err, "the suite must be on the curve of the key")
+
+		pi[len(pi)-1] ^= 1
+		_, err = VerifyWithSuite(SuiteSecp256k1SHA256TAI, pk, v.alpha, pi)
+		assert.Error(t, err)
+	}
+}
+
+func TestSmallOrderKey(t *testing.T) {
+	// (0, -1) is the point of order 2 of ed25519
+	P, err := crypto.NewECPoint(tss.Edwards(), big.NewInt(0), new(big.Int).Sub(tss.Edwards().Params().P, big.NewInt(1)))
+	assert.NoError(t, err)
+	_, err = Verify(P, nil, make([]byte, ProofLen(tss.Edwards())))
+	assert.Error(t, err)
+}
+
+func mustHex(s string) []byte {
+	bz, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return bz
+}
@@ -0,0 +1,276 @@
+package vrf
+
+import (
+	"crypto/elliptic"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/felicityin/mpc-tss/common"
+	"github.com/felicityin/mpc-tss/crypto"
+	"github.com/felicityin/mpc-tss/crypto/dleq"
+	"github.com/felicityin/mpc-tss/tss"
+)
+
+const (
+	// EvidenceShare is the kind of the evidence against a party whose share of Gamma has an invalid DLEQ proof,
+	// or whose commitments are invalid
+	EvidenceShare = "vrf/share"
+	// EvidenceResponse is the kind of the evidence against a party whose share of s is invalid
+	EvidenceResponse = "vrf/response"
+)
+
+func init() {
+	tss.RegisterEvidenceCheck(EvidenceShare, checkShareEvidence)
+	tss.RegisterEvidenceCheck(EvidenceResponse, checkResponseEvidence)
+}
+
+// Inputs are the public inputs of the checks of the shares of Pj: the ciphersuite, the input alpha and the public data
+// of the key prepared for the evaluation, which are fixed before the evaluation. PubXj are the public key shares of the signers
+// weighted by their Lagrange coefficients, and Ks the keys of the signers. The point H of alpha and the ssid are computed again from them,
+// and the commitments of Pj and the challenge from the round 1 messages of all the signers.
+type Inputs struct {
+	Curve  tss.CurveName     `json:"curve"`
+	Suite  byte              `json:"suite"`
+	Index  int               `json:"index"`
+	Alpha  []byte            `json:"alpha"`
+	Pubkey *crypto.ECPoint   `json:"pubkey"`
+	Ks     []*big.Int        `json:"ks"`
+	PubXj  []*crypto.ECPoint `json:"pub_xj"`
+}
+
+// session returns the ciphersuite, the point H of alpha and the ssid of the evaluation
+func (in *Inputs) session(ec elliptic.Curve) (*suite, *crypto.ECPoint, []byte, error) {
+	s, err := suiteByID(in.Suite, ec)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if err := s.validateKey(in.Pubkey); err != nil {
+		return nil, nil, nil, err
+	}
+	H, err := s.encodeToCurve(in.Pubkey, in.Alpha)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	ssid, err := computeSSID(ec, in.Ks, in.PubXj, H, 1, big.NewInt(0))
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return s, H, ssid, nil
+}
+
+// validate checks that the inputs are about the culprit and that their points are on the curve
+func (in *Inputs) validate(ec elliptic.Curve, culprit *tss.PartyID) error {
+	if in.Index != culprit.Index || in.Index < 0 || in.Index >= len(in.PubXj) {
+		return fmt.Errorf("the inputs are about party %d, not the culprit", in.Index)
+	}
+	if in.Pubkey == nil || !tss.SameCurve(in.Pubkey.Curve(), ec) {
+		return errors.New("the public key is missing")
+	}
+	if len(in.Ks) != len(in.PubXj) {
+		return errors.New("expected a key for every key share")
+	}
+	for j, Xj := range in.PubXj {
+		if Xj == nil || !tss.SameCurve(Xj.Curve(), ec) || in.Ks[j] == nil {
+			return fmt.Errorf("the key share or the key of party %d are missing", j)
+		}
+	}
+	return nil
+}
+
+// CheckShare checks the DLEQ proof that Gamma_j = x_j * H for Xj = x_j * G, that Gamma_j is not of small order on ed25519,
+// and that the commitments of Pj are points of the curve
+func CheckShare(ec elliptic.Curve, ssid []byte, H, Xj *crypto.ECPoint, r1msg *VRFRound1Message) error {
+	Gammaj, err := r1msg.UnmarshalGamma()
+	if err != nil {
+		return fmt.Errorf("err: Gammaj: %s", err.Error())
+	}
+	proof, err := r1msg.UnmarshalProof()
+	if err != nil {
+		return fmt.Errorf("err: DLEQ proof: %s", err.Error())
+	}
+	D, E, DH, EH, err := r1msg.UnmarshalCommitments()
+	if err != nil {
+		return fmt.Errorf("err: commitments: %s", err.Error())
+	}
+	for _, P := range []*crypto.ECPoint{D, E, DH, EH} {
+		if !tss.SameCurve(P.Curve(), ec) {
+			return errors.New("err: the commitments are not on the curve")
+		}
+	}
+	if err := dleq.VerifyShare(ec, ssid, Xj, H, Gammaj, proof); err != nil {
+		return fmt.Errorf("err: Gammaj: %s", err.Error())
+	}
+	return nil
+}
+
+// nonceCommitments are the values of round 2 computed from the round 1 messages of all the signers
+type nonceCommitments struct {
+	rhos   []*big.Int
+	Gammaj []*crypto.ECPoint
+	Uj     []*crypto.ECPoint // D_j + rho_j * E_j
+	Vj     []*crypto.ECPoint // D'_j + rho_j * E'_j
+	Gamma  *crypto.ECPoint
+	c      *big.Int
+}
+
+// computeNonceCommitments binds the commitments of the signers to their shares of Gamma with the binding factors
+// rho_j = SHA512_256(ssid, j, Gamma_k, D_k, E_k, D'_k, E'_k for every k), which gives the U and V of the proof and its challenge c
+func computeNonceCommitments(s *suite, ssid []byte, pubkey, H *crypto.ECPoint, r1msgs []*VRFRound1Message) (*nonceCommitments, error) {
+	n := &nonceCommitments{
+		rhos:   make([]*big.Int, len(r1msgs)),
+		Gammaj: make([]*crypto.ECPoint, len(r1msgs)),
+		Uj:     make([]*crypto.ECPoint, len(r1msgs)),
+		Vj:     make([]*crypto.ECPoint, len(r1msgs)),
+	}
+	commitments := make([][]*crypto.ECPoint, len(r1msgs))
+	var list []*big.Int
+	for j, r1msg := range r1msgs {
+		Gammaj, err := r1msg.UnmarshalGamma()
+		if err != nil {
+			return nil, fmt.Errorf("Gamma of party %d: %s", j, err.Error())
+		}
+		D, E, DH, EH, err := r1msg.UnmarshalCommitments()
+		if err != nil {
+			return nil, fmt.Errorf("commitments of party %d: %s", j, err.Error())
+		}
+		commitments[j] = []*crypto.ECPoint{D, E, DH, EH}
+		n.Gammaj[j] = Gammaj
+
+		flattened, err := crypto.FlattenECPoints([]*crypto.ECPoint{Gammaj, D, E, DH, EH})
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, flattened...)
+	}
+	q := s.ec.Params().N
+	ssidInt := new(big.Int).SetBytes(ssid)
+
+	var U, V *crypto.ECPoint
+	for j := range r1msgs {
+		n.rhos[j] = new(big.Int).Mod(common.SHA512_256i(append([]*big.Int{ssidInt, big.NewInt(int64(j))}, list...)...), q)
+		D, E, DH, EH := commitments[j][0], commitments[j][1], commitments[j][2], commitments[j][3]
+		Uj, err := E.ScalarMult(n.rhos[j]).Add(D)
+		if err != nil {
+			return nil, fmt.Errorf("D + rho * E err of party %d: %s", j, err.Error())
+		}
+		Vj, err := EH.ScalarMult(n.rhos[j]).Add(DH)
+		if err != nil {
+			return nil, fmt.Errorf("D' + rho * E' err of party %d: %s", j, err.Error())
+		}
+		n.Uj[j], n.Vj[j] = Uj, Vj
+
+		if j == 0 {
+			n.Gamma, U, V = n.Gammaj[j], Uj, Vj
+			continue
+		}
+		if n.Gamma, err = n.Gamma.Add(n.Gammaj[j]); err != nil {
+			return nil, fmt.Errorf("sum of the shares of Gamma: %s", err.Error())
+		}
+		if U, err = U.Add(Uj); err != nil {
+			return nil, fmt.Errorf("sum of the commitments U: %s", err.Error())
+		}
+		if V, err = V.Add(Vj); err != nil {
+			return nil, fmt.Errorf("sum of the commitments V: %s", err.Error())
+		}
+	}
+	n.c = s.challenge(pubkey, H, n.Gamma, U, V)
+	return n, nil
+}
+
+// CheckResponse checks that s_j * G = Uj + c * Xj and s_j * H = Vj + c * Gammaj
+func CheckResponse(H, Uj, Vj *crypto.ECPoint, c *big.Int, Xj, Gammaj *crypto.ECPoint, r2msg *VRFRound2Message) error {
+	sj := r2msg.UnmarshalS()
+	if sj.Sign() == 0 || sj.Cmp(Xj.Curve().Params().N) >= 0 {
+		return errors.New("err: sj is out of range")
+	}
+	expected, err := Xj.ScalarMult(c).Add(Uj)
+	if err != nil {
+		return fmt.Errorf("err: Uj + c * Xj: %s", err.Error())
+	}
+	if !crypto.ScalarBaseMult(Xj.Curve(), sj).Equals(expected) {
+		return errors.New("err: sj * G != Uj + c * Xj")
+	}
+	expected, err = Gammaj.ScalarMult(c).Add(Vj)
+	if err != nil {
+		return fmt.Errorf("err: Vj + c * Gammaj: %s", err.Error())
+	}
+	if !H.ScalarMult(sj).Equals(expected) {
+		return errors.New("err: sj * H != Vj + c * Gammaj")
+	}
+	return nil
+}
+
+func parseInputs(ev *tss.Evidence) (*Inputs, elliptic.Curve, error) {
+	in := new(Inputs)
+	if err := ev.UnmarshalInputs(in); err != nil {
+		return nil, nil, err
+	}
+	ec, ok := tss.GetCurveByName(in.Curve)
+	if !ok {
+		return nil, nil, fmt.Errorf("unknown curve %s", in.Curve)
+	}
+	if err := in.validate(ec, ev.Culprit); err != nil {
+		return nil, nil, err
+	}
+	return in, ec, nil
+}
+
+func checkShareEvidence(ev *tss.Evidence, msgs []tss.ParsedMessage) (failure error, err error) {
+	in, ec, err := parseInputs(ev)
+	if err != nil {
+		return nil, err
+	}
+	_, H, ssid, err := in.session(ec)
+	if err != nil {
+		return nil, err
+	}
+	if len(msgs) != 1 {
+		return nil, errors.New("expected the round 1 message")
+	}
+	r1msg, ok := msgs[0].Content().(*VRFRound1Message)
+	if !ok {
+		return nil, errors.New("expected the round 1 message")
+	}
+	return CheckShare(ec, ssid, H, in.PubXj[in.Index], r1msg), nil
+}
+
+// checkResponseEvidence checks the round 2 message of the culprit, which the evidence holds first,
+// against the round 1 messages of all the signers
+func checkResponseEvidence(ev *tss.Evidence, msgs []tss.ParsedMessage) (failure error, err error) {
+	in, ec, err := parseInputs(ev)
+	if err != nil {
+		return nil, err
+	}
+	s, H, ssid, err := in.session(ec)
+	if err != nil {
+		return nil, err
+	}
+	if len(msgs) == 0 {
+		return nil, errors.New("expected the round 2 message")
+	}
+	r2msg, ok := msgs[0].Content().(*VRFRound2Message)
+	if !ok {
+		return nil, errors.New("expected the round 2 message")
+	}
+	byIndex, err := tss.MessagesBySender(msgs[1:], len(in.Ks), (*VRFRound1Message)(nil))
+	if err != nil {
+		return nil, err
+	}
+	r1msgs := make([]*VRFRound1Message, len(byIndex))
+	for j, msg := range byIndex {
+		if msg.GetFrom().KeyInt().Cmp(in.Ks[j]) != 0 {
+			return nil, fmt.Errorf("the round 1 message of party %d is not from the signer of its key", j)
+		}
+		r1msgs[j] = msg.Content().(*VRFRound1Message)
+		if err := CheckShare(ec, ssid, H, in.PubXj[j], r1msgs[j]); err != nil {
+			return nil, fmt.Errorf("the round 1 message of party %d is invalid: %s", j, err.Error())
+		}
+	}
+	n, err := computeNonceCommitments(s, ssid, in.Pubkey, H, r1msgs)
+	if err != nil {
+		return nil, err
+	}
+	j := in.Index
+	return CheckResponse(H, n.Uj[j], n.Vj[j], n.c, in.PubXj[j], n.Gammaj[j], r2msg), nil
+}
@@ -0,0 +1,224 @@
+// Package vrf implements the threshold ECVRF of RFC 9381: the signers of a key evaluate the VRF of the key on an input alpha,
+// without reconstructing the key, into the output beta and the proof pi that any verifier of RFC 9381 checks against the public key.
+//
+// The ciphersuites are EDWARDS25519-SHA512-TAI on ed25519 and P256-SHA256-TAI on P-256. The secp256k1 keys have no ciphersuite
+// in RFC 9381, and are evaluated with the non-standard SECP256K1-SHA256-TAI only when it is chosen with NewLocalPartyWithSuite.
+// In round 1, each signer broadcasts its share Gamma_i = lambda_i * x_i * H of Gamma = x * H, where H is the point of alpha,
+// with a DLEQ proof against its public key share, and the commitments D_i, E_i to its nonces on both B and H.
+// In round 2, the commitments are bound to the shares with rho_j as in FROST, which gives the U and V of the proof and its challenge c,
+// and each signer broadcasts its share s_i = d_i + rho_i * e_i + c * lambda_i * x_i of s.
+// The invalid shares of Gamma and s are blamed with evidence.
+//
+// The proof is randomized by the nonces, unlike the deterministic proofs of RFC 9381, but its output beta depends only on the key and alpha.
+// The key may be a child key of a derivation path, as in the signing.
+package vrf
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/felicityin/mpc-tss/common"
+	"github.com/felicityin/mpc-tss/crypto"
+	"github.com/felicityin/mpc-tss/protocols/cggmp/keygen"
+	"github.com/felicityin/mpc-tss/protocols/utils"
+	"github.com/felicityin/mpc-tss/tss"
+)
+
+// Implements Party
+// Implements Stringer
+var _ tss.Party = (*LocalParty)(nil)
+var _ fmt.Stringer = (*LocalParty)(nil)
+
+type (
+	LocalParty struct {
+		*tss.BaseParty
+		params *tss.Parameters
+
+		keys keygen.LocalPartySaveData
+		temp localTempData
+
+		// outbound messaging
+		out chan<- tss.Message
+		end chan<- *Output
+	}
+
+	// Output is the VRF output of the key on the input, with its proof
+	Output struct {
+		Proof []byte // pi, which Verify checks against the public key
+		Hash  []byte // beta
+	}
+
+	localMessageStore struct {
+		vrfRound1Messages,
+		vrfRound2Messages []tss.ParsedMessage
+	}
+
+	localTempData struct {
+		localMessageStore
+
+		isThreshold bool
+		alpha       []byte
+		suite       *suite
+		H           *crypto.ECPoint
+
+		// round 1
+		d, e *big.Int
+
+		// round 2
+		Gammaj []*crypto.ECPoint
+		Uj     []*crypto.ECPoint // D_j + rho_j * E_j
+		Vj     []*crypto.ECPoint // D'_j + rho_j * E'_j
+		Gamma  *crypto.ECPoint
+		c      *big.Int
+
+		ssid      []byte
+		ssidNonce *big.Int
+	}
+)
+
+// NewLocalParty returns a party that outputs the VRF output of alpha on end, with the key of the path, or the master key if path is empty
+func NewLocalParty(
+	alpha []byte,
+	isThreshold bool,
+	params *tss.Parameters,
+	path string,
+	key keygen.LocalPartySaveData,
+	out chan<- tss.Message,
+	end chan<- *Output,
+) (tss.Party, error) {
+	s, err := suiteOf(params.EC())
+	if err != nil {
+		return nil, err
+	}
+	return newLocalParty(s, alpha, isThreshold, params, path, key, out, end)
+}
+
+// NewLocalPartyWithSuite returns a party as NewLocalParty does, with the ciphersuite of the suite string,
+// which must be on the curve of the parameters. It is how the VRF of a secp256k1 key is evaluated,
+// with the non-standard SuiteSecp256k1SHA256TAI.
+func NewLocalPartyWithSuite(
+	suiteID byte,
+	alpha []byte,
+	isThreshold bool,
+	params *tss.Parameters,
+	path string,
+	key keygen.LocalPartySaveData,
+	out chan<- tss.Message,
+	end chan<- *Output,
+) (tss.Party, error) {
+	s, err := suiteByID(suiteID, params.EC())
+	if err != nil {
+		return nil, err
+	}
+	return newLocalParty(s, alpha, isThreshold, params, path, key, out, end)
+}
+
+func newLocalParty(
+	s *suite,
+	alpha []byte,
+	isThreshold bool,
+	params *tss.Parameters,
+	path string,
+	key keygen.LocalPartySaveData,
+	out chan<- tss.Message,
+	end chan<- *Output,
+) (tss.Party, error) {
+	key, err := keygen.BuildLocalSaveDataSubset(key, params.Parties().IDs())
+	if err != nil {
+		return nil, err
+	}
+	err = utils.UpdateKeyForSigning(&key, path, isThreshold, params.Threshold(), params.KeyTweaks()...)
+	if err != nil {
+		return nil, err
+	}
+	if err = s.validateKey(key.Pubkey); err != nil {
+		return nil, err
+	}
+	H, err := s.encodeToCurve(key.Pubkey, alpha)
+	if err != nil {
+		return nil, err
+	}
+	partyCount := len(params.Parties().IDs())
+	p := &LocalParty{
+		BaseParty: new(tss.BaseParty),
+		params:    params,
+		keys:      key,
+		temp:      localTempData{},
+		out:       out,
+		end:       end,
+	}
+	// msgs init
+	p.temp.vrfRound1Messages = make([]tss.ParsedMessage, partyCount)
+	p.temp.vrfRound2Messages = make([]tss.ParsedMessage, partyCount)
+
+	// temp data init
+	p.temp.isThreshold = isThreshold
+	p.temp.alpha = alpha
+	p.temp.suite = s
+	p.temp.H = H
+	return p, nil
+}
+
+func (p *LocalParty) FirstRound() tss.Round {
+	return newRound1(p.temp.isThreshold, p.params, &p.keys, &p.temp, p.out, p.end)
+}
+
+func (p *LocalParty) Start() *tss.Error {
+	return tss.BaseStart(p, TaskName)
+}
+
+func (p *LocalParty) Update(msg tss.ParsedMessage) (ok bool, err *tss.Error) {
+	return tss.BaseUpdate(p, msg, TaskName)
+}
+
+func (p *LocalParty) UpdateFromBytes(wireBytes []byte, from *tss.PartyID, isBroadcast bool) (bool, *tss.Error) {
+	msg, err := tss.ParseWireMessage(wireBytes, from, isBroadcast)
+	if err != nil {
+		return false, p.WrapError(err)
+	}
+	return p.Update(msg)
+}
+
+func (p *LocalParty) ValidateMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	if msg.GetFrom() == nil || !msg.GetFrom().ValidateBasic() {
+		return false, p.WrapError(fmt.Errorf("received msg with an invalid sender: %s", msg))
+	}
+	// check that the message's "from index" will fit into the array
+	if maxFromIdx := len(p.params.Parties().IDs()) - 1; maxFromIdx < msg.GetFrom().Index {
+		return false, p.WrapError(fmt.Errorf("received msg with a sender index too great (%d <= %d)",
+			maxFromIdx, msg.GetFrom().Index), msg.GetFrom())
+	}
+	return p.BaseParty.ValidateMessage(msg)
+}
+
+func (p *LocalParty) StoreMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	// ValidateBasic is cheap; double-check the message here in case the public StoreMessage was called externally
+	if ok, err := p.ValidateMessage(msg); !ok || err != nil {
+		return ok, err
+	}
+	fromPIdx := msg.GetFrom().Index
+
+	// switch/case is necessary to store any messages beyond current round
+	// this does not handle message replays. we expect the caller to apply replay and spoofing protection.
+	switch msg.Content().(type) {
+
+	case *VRFRound1Message:
+		p.temp.vrfRound1Messages[fromPIdx] = msg
+
+	case *VRFRound2Message:
+		p.temp.vrfRound2Messages[fromPIdx] = msg
+
+	default: // unrecognised message, just ignore!
+		common.Logger.Warningf("unrecognised message ignored: %v", msg)
+		return false, nil
+	}
+	return true, nil
+}
+
+func (p *LocalParty) PartyID() *tss.PartyID {
+	return p.params.PartyID()
+}
+
+func (p *LocalParty) String() string {
+	return fmt.Sprintf("id: %s, %s", p.PartyID(), p.BaseParty.String())
+}
@@ -0,0 +1,220 @@
+package vrf
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/ipfs/go-log"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/felicityin/mpc-tss/crypto"
+	"github.com/felicityin/mpc-tss/protocols/cggmp/keygen"
+	tKeygen "github.com/felicityin/mpc-tss/protocols/cggmp/keygen/threshold"
+	"github.com/felicityin/mpc-tss/protocols/cggmp/test"
+	"github.com/felicityin/mpc-tss/protocols/utils"
+	"github.com/felicityin/mpc-tss/tss"
+)
+
+const (
+	testParticipants = 3
+	testThreshold    = 2
+	testPath         = "0/1/2/2/10"
+)
+
+func setUp(level string) {
+	if err := log.SetLogLevel("tss-lib", level); err != nil {
+		panic(err)
+	}
+}
+
+// runVRF runs the VRF of the threshold fixtures of the kind on alpha, and returns the outputs of the parties.
+// tamper, if not nil, changes the messages to the last party.
+func runVRF(
+	t *testing.T,
+	kind int,
+	alpha []byte,
+	tamper func(msg tss.ParsedMessage) tss.ParsedMessage,
+) (childPubkey *crypto.ECPoint, outputs []*Output, tssErr *tss.Error) {
+	keys, signPIDs, err := tKeygen.LoadKeygenTestFixturesRandomSet(kind, testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	_, extendedKey, err := utils.DerivingKeyFromPath(keys[0], testPath)
+	assert.NoError(t, err)
+	childPubkey = extendedKey.PublicKey
+
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	parties := make([]*LocalParty, 0, len(signPIDs))
+
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+	endCh := make(chan *Output, len(signPIDs))
+
+	updater := test.SharedPartyUpdater
+
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(tKeygen.TestCurve(kind), p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+		party, err := NewLocalPartyWithSuite(testSuite(kind), alpha, true, params, testPath, keys[i], outCh, endCh)
+		assert.NoError(t, err)
+		parties = append(parties, party.(*LocalParty))
+	}
+	// start every party before routing the messages, so that no party receives all the messages of round 1 before it starts
+	for _, P := range parties {
+		if err := P.Start(); err != nil {
+			assert.FailNow(t, err.Error())
+		}
+	}
+
+	victim := len(signPIDs) - 1
+	for len(outputs) < len(signPIDs) {
+		select {
+		case err := <-errCh:
+			return childPubkey, outputs, err
+
+		case msg := <-outCh:
+			for _, Pj := range parties {
+				if Pj.PartyID().Index == msg.GetFrom().Index {
+					continue
+				}
+				if tamper != nil && Pj.PartyID().Index == victim {
+					go updater(Pj, tamper(msg.(tss.ParsedMessage)), errCh)
+					continue
+				}
+				go updater(Pj, msg, errCh)
+			}
+
+		case out := <-endCh:
+			outputs = append(outputs, out)
+		}
+	}
+	return childPubkey, outputs, nil
+}
+
+// testSuite returns the ciphersuite of the keys of the kind: the one of RFC 9381 of the curve,
+// or the non-standard SECP256K1-SHA256-TAI for the secp256k1 keys
+func testSuite(kind int) byte {
+	switch kind {
+	case keygen.Eddsa:
+		return SuiteEdwards25519SHA512TAI
+	case keygen.EcdsaP256:
+		return SuiteP256SHA256TAI
+	default:
+		return SuiteSecp256k1SHA256TAI
+	}
+}
+
+func TestE2EThresholdConcurrent(t *testing.T) {
+	setUp("info")
+
+	alpha := []byte("leader election, epoch 42")
+	for _, kind := range []int{keygen.Ecdsa, keygen.Eddsa, keygen.EcdsaP256} {
+		childPubkey, outputs, err := runVRF(t, kind, alpha, nil)
+		if !assert.Nil(t, err) {
+			continue
+		}
+		for _, out := range outputs {
+			assert.Equal(t, outputs[0].Proof, out.Proof, "the parties output the same proof")
+			beta, err := VerifyWithSuite(testSuite(kind), childPubkey, alpha, out.Proof)
+			assert.NoError(t, err, "the proof verifies against the child key")
+			assert.Equal(t, beta, out.Hash)
+		}
+		_, err2 := VerifyWithSuite(testSuite(kind), childPubkey, []byte("another input"), outputs[0].Proof)
+		assert.Error(t, err2)
+		if kind == keygen.Ecdsa {
+			_, err2 = Verify(childPubkey, alpha, outputs[0].Proof)
+			assert.Error(t, err2, "secp256k1 has no ciphersuite in RFC 9381")
+		} else {
+			assert.Len(t, outputs[0].Proof, ProofLen(childPubkey.Curve()))
+			beta, err := Verify(childPubkey, alpha, outputs[0].Proof)
+			assert.NoError(t, err, "the proof verifies with the ciphersuite of RFC 9381 of the curve")
+			assert.Equal(t, outputs[0].Hash, beta)
+		}
+
+		// the proof is randomized, but not the output
+		_, again, err := runVRF(t, kind, alpha, nil)
+		if assert.Nil(t, err) {
+			assert.False(t, bytes.Equal(outputs[0].Proof, again[0].Proof))
+			assert.Equal(t, outputs[0].Hash, again[0].Hash, "the output depends only on the key and the input")
+		}
+	}
+}
+
+func TestSecp256k1SuiteIsExplicit(t *testing.T) {
+	keys, signPIDs, err := tKeygen.LoadKeygenTestFixturesRandomSet(keygen.Ecdsa, testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	params := tss.NewParameters(tss.S256(), tss.NewPeerContext(signPIDs), signPIDs[0], len(signPIDs), testThreshold)
+
+	_, err = NewLocalParty([]byte("alpha"), true, params, testPath, keys[0], nil, nil)
+	assert.Error(t, err, "secp256k1 has no ciphersuite in RFC 9381")
+	_, err = NewLocalPartyWithSuite(SuiteP256SHA256TAI, []byte("alpha"), true, params, testPath, keys[0], nil, nil)
+	assert.Error(t, err, "the suite must be on the curve of the key")
+	_, err = NewLocalPartyWithSuite(SuiteSecp256k1SHA256TAI, []byte("alpha"), true, params, testPath, keys[0], nil, nil)
+	assert.NoError(t, err)
+}
+
+func TestCulpritsOfBadShares(t *testing.T) {
+	setUp("info")
+
+	// P0 and P1 both send a wrong share of Gamma to P2, which must blame both of them
+	_, _, tssErr := runVRF(t, keygen.Eddsa, []byte("alpha"), func(msg tss.ParsedMessage) tss.ParsedMessage {
+		r1msg, ok := msg.Content().(*VRFRound1Message)
+		if !ok {
+			return msg
+		}
+		Gamma, _ := r1msg.UnmarshalGamma()
+		Gamma, _ = Gamma.Add(crypto.ScalarBaseMult(Gamma.Curve(), big.NewInt(1)))
+		gamma, _ := Gamma.MarshalJSON()
+		content := &VRFRound1Message{
+			Gamma: gamma, ProofA: r1msg.ProofA, ProofB: r1msg.ProofB, ProofZ: r1msg.ProofZ,
+			D: r1msg.D, E: r1msg.E, Dh: r1msg.Dh, Eh: r1msg.Eh,
+		}
+		meta := tss.MessageRouting{From: msg.GetFrom(), IsBroadcast: true}
+		return tss.NewMessage(meta, content, tss.NewMessageWrapper(meta, content))
+	})
+	assertCulprits(t, tssErr, EvidenceShare)
+}
+
+func TestCulpritsOfBadResponses(t *testing.T) {
+	setUp("info")
+
+	// P0 and P1 both send a wrong share of s to P2, which must blame both of them
+	_, _, tssErr := runVRF(t, keygen.Ecdsa, []byte("alpha"), func(msg tss.ParsedMessage) tss.ParsedMessage {
+		r2msg, ok := msg.Content().(*VRFRound2Message)
+		if !ok {
+			return msg
+		}
+		s := new(big.Int).Add(r2msg.UnmarshalS(), big.NewInt(1))
+		return NewVRFRound2Message(msg.GetFrom(), s)
+	})
+	assertCulprits(t, tssErr, EvidenceResponse)
+
+	// the commitments and the challenge are computed from the round 1 messages of all the signers, not taken from the accuser
+	for _, ev := range tssErr.Evidence() {
+		msgs, err := ev.ParseMessages()
+		if !assert.NoError(t, err) || !assert.Len(t, msgs, 1+testThreshold+1) {
+			continue
+		}
+		partial, err := tss.NewEvidence(ev.Kind, ev.Task, ev.Round, ev.Culprit, ev.Inputs, msgs[:len(msgs)-1]...)
+		if assert.NoError(t, err) {
+			err := partial.Verify()
+			assert.Error(t, err)
+			assert.NotErrorIs(t, err, tss.ErrNoMisbehaviour)
+		}
+	}
+}
+
+func assertCulprits(t *testing.T, tssErr *tss.Error, kind string) {
+	if !assert.NotNil(t, tssErr) {
+		return
+	}
+	assert.Equal(t, testThreshold, tssErr.Victim().Index)
+	if assert.Len(t, tssErr.Culprits(), 2) {
+		assert.Equal(t, 0, tssErr.Culprits()[0].Index)
+		assert.Equal(t, 1, tssErr.Culprits()[1].Index)
+	}
+	if assert.Len(t, tssErr.Evidence(), 2) {
+		for _, ev := range tssErr.Evidence() {
+			assert.Equal(t, kind, ev.Kind)
+			assert.NoError(t, ev.Verify())
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package vrf
+
+import (
+	"math/big"
+
+	"github.com/felicityin/mpc-tss/common"
+	"github.com/felicityin/mpc-tss/crypto"
+	"github.com/felicityin/mpc-tss/crypto/dleq"
+	"github.com/felicityin/mpc-tss/tss"
+)
+
+// These messages were generated from Protocol Buffers definitions into vrf.pb.go
+// The following messages are registered on the Protocol Buffers "wire"
+
+var (
+	// Ensure that VRF messages implement ValidateBasic
+	_ = []tss.MessageContent{
+		(*VRFRound1Message)(nil),
+		(*VRFRound2Message)(nil),
+	}
+)
+
+// ----- //
+
+func NewVRFRound1Message(
+	from *tss.PartyID,
+	Gamma *crypto.ECPoint,
+	proof *dleq.Proof,
+	D, E, DH, EH *crypto.ECPoint,
+) (tss.ParsedMessage, error) {
+	var bzs [7][]byte
+	for k, P := range []*crypto.ECPoint{Gamma, proof.A, proof.B, D, E, DH, EH} {
+		bz, err := P.MarshalJSON()
+		if err != nil {
+			return nil, err
+		}
+		bzs[k] = bz
+	}
+
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &VRFRound1Message{
+		Gamma:  bzs[0],
+		ProofA: bzs[1],
+		ProofB: bzs[2],
+		ProofZ: proof.Z.Bytes(),
+		D:      bzs[3],
+		E:      bzs[4],
+		Dh:     bzs[5],
+		Eh:     bzs[6],
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg), nil
+}
+
+func (m *VRFRound1Message) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetGamma()) &&
+		common.NonEmptyBytes(m.GetProofA()) &&
+		common.NonEmptyBytes(m.GetProofB()) &&
+		common.NonEmptyBytes(m.GetProofZ()) &&
+		common.NonEmptyBytes(m.GetD()) &&
+		common.NonEmptyBytes(m.GetE()) &&
+		common.NonEmptyBytes(m.GetDh()) &&
+		common.NonEmptyBytes(m.GetEh())
+}
+
+func (m *VRFRound1Message) UnmarshalGamma() (*crypto.ECPoint, error) {
+	return crypto.UnmarshalJSONPoint(m.GetGamma())
+}
+
+func (m *VRFRound1Message) UnmarshalProof() (*dleq.Proof, error) {
+	A, err := crypto.UnmarshalJSONPoint(m.GetProofA())
+	if err != nil {
+		return nil, err
+	}
+	B, err := crypto.UnmarshalJSONPoint(m.GetProofB())
+	if err != nil {
+		return nil, err
+	}
+	return &dleq.Proof{A: A, B: B, Z: new(big.Int).SetBytes(m.GetProofZ())}, nil
+}
+
+// UnmarshalCommitments returns the commitments D, E, D' and E' to the nonces of the sender
+func (m *VRFRound1Message) UnmarshalCommitments() (D, E, DH, EH *crypto.ECPoint, err error) {
+	points := make([]*crypto.ECPoint, 4)
+	for k, bz := range [][]byte{m.GetD(), m.GetE(), m.GetDh(), m.GetEh()} {
+		if points[k], err = crypto.UnmarshalJSONPoint(bz); err != nil {
+			return nil, nil, nil, nil, err
+		}
+	}
+	return points[0], points[1], points[2], points[3], nil
+}
+
+// ----- //
+
+func NewVRFRound2Message(
+	from *tss.PartyID,
+	s *big.Int,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &VRFRound2Message{
+		S: s.Bytes(),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *VRFRound2Message) ValidateBasic() bool {
+	return m != nil && common.NonEmptyBytes(m.GetS())
+}
+
+func (m *VRFRound2Message) UnmarshalS() *big.Int {
+	return new(big.Int).SetBytes(m.GetS())
+}
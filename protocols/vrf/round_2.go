@@ -0,0 +1,92 @@
+package vrf
+
+import (
+	"errors"
+
+	"github.com/felicityin/mpc-tss/common"
+	"github.com/felicityin/mpc-tss/tss"
+)
+
+func (round *round2) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+
+	round.number = 2
+	round.started = true
+	round.resetOK()
+
+	i := round.PartyID().Index
+	common.Logger.Infof("[vrf] party: %d, round_2 start", i)
+
+	var errs []*tss.Error
+	for j := range round.Parties().IDs() {
+		if j == i {
+			continue
+		}
+		msg := round.temp.vrfRound1Messages[j]
+		if err := CheckShare(round.EC(), round.temp.ssid, round.temp.H, round.key.PubXj[j], msg.Content().(*VRFRound1Message)); err != nil {
+			common.Logger.Errorf("[j: %d] %s", j, err.Error())
+			errs = append(errs, round.WrapEvidence(err, EvidenceShare, round.inputs(j), msg))
+		}
+	}
+	if err := tss.JoinErrors(errs...); err != nil {
+		return err
+	}
+
+	r1msgs := make([]*VRFRound1Message, len(round.Parties().IDs()))
+	for j, msg := range round.temp.vrfRound1Messages {
+		r1msgs[j] = msg.Content().(*VRFRound1Message)
+	}
+	n, err := computeNonceCommitments(round.temp.suite, round.temp.ssid, round.key.Pubkey, round.temp.H, r1msgs)
+	if err != nil {
+		return round.WrapError(err)
+	}
+	modN := common.ModInt(round.EC().Params().N)
+	rhoi := n.rhos[i]
+	round.temp.Gammaj, round.temp.Uj, round.temp.Vj = n.Gammaj, n.Uj, n.Vj
+	round.temp.Gamma = n.Gamma
+	round.temp.c = n.c
+
+	// s_i = d_i + rho_i * e_i + c * x_i
+	si := modN.Add(round.temp.d, modN.Mul(rhoi, round.temp.e))
+	si = modN.Add(si, modN.Mul(round.temp.c, round.key.PrivXi))
+
+	// Security: the nonces and the key share are no longer needed
+	common.ZeroBigInts(round.temp.d, round.temp.e, round.key.PrivXi)
+
+	// broadcast
+	common.Logger.Debugf("P[%d]: round_2 broadcast", i)
+	r2msg := NewVRFRound2Message(round.PartyID(), si)
+	round.temp.vrfRound2Messages[i] = r2msg
+	round.out <- r2msg
+
+	return nil
+}
+
+func (round *round2) Update() (bool, *tss.Error) {
+	ret := true
+	for j, msg := range round.temp.vrfRound2Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			ret = false
+			continue
+		}
+		round.ok[j] = true
+	}
+	return ret, nil
+}
+
+func (round *round2) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*VRFRound2Message); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round2) NextRound() tss.Round {
+	round.started = false
+	return &finalization{round}
+}
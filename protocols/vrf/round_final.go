@@ -0,0 +1,70 @@
+package vrf
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/felicityin/mpc-tss/common"
+	"github.com/felicityin/mpc-tss/tss"
+)
+
+func (round *finalization) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 3
+	round.started = true
+	round.resetOK()
+
+	Pi := round.PartyID()
+	i := Pi.Index
+
+	common.Logger.Infof("[vrf] party: %d, round_final start", i)
+
+	var errs []*tss.Error
+	modN := common.ModInt(round.EC().Params().N)
+	s := round.temp.vrfRound2Messages[i].Content().(*VRFRound2Message).UnmarshalS()
+	for j := range round.Parties().IDs() {
+		round.ok[j] = true
+		if j == i {
+			continue
+		}
+
+		msg := round.temp.vrfRound2Messages[j]
+		r2msg := msg.Content().(*VRFRound2Message)
+		if err := CheckResponse(round.temp.H, round.temp.Uj[j], round.temp.Vj[j], round.temp.c, round.key.PubXj[j], round.temp.Gammaj[j], r2msg); err != nil {
+			common.Logger.Errorf("[j: %d] %s", j, err.Error())
+			errs = append(errs, round.WrapEvidence(err, EvidenceResponse, round.inputs(j), append([]tss.ParsedMessage{msg}, round.temp.vrfRound1Messages...)...))
+			continue
+		}
+		s = modN.Add(s, r2msg.UnmarshalS())
+	}
+	if err := tss.JoinErrors(errs...); err != nil {
+		return err
+	}
+
+	pi := round.temp.suite.encodeProof(round.temp.Gamma, round.temp.c, s)
+	beta, err := round.temp.suite.verify(round.key.Pubkey, round.temp.alpha, pi)
+	if err != nil {
+		return round.WrapError(fmt.Errorf("the proof does not verify: %s", err.Error()))
+	}
+
+	common.Logger.Infof("party: %d, round 3 end", i)
+	round.end <- &Output{Proof: pi, Hash: beta}
+
+	return nil
+}
+
+func (round *finalization) CanAccept(msg tss.ParsedMessage) bool {
+	// not expecting any incoming messages in this round
+	return false
+}
+
+func (round *finalization) Update() (bool, *tss.Error) {
+	// not expecting any incoming messages in this round
+	return false, nil
+}
+
+func (round *finalization) NextRound() tss.Round {
+	return nil // finished!
+}
@@ -0,0 +1,275 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.33.0
+// 	protoc        v3.21.4
+// source: protocols/vrf/vrf.proto
+
+package vrf
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Represents a BROADCAST message sent to all parties during Round 1 of the threshold ECVRF:
+// the share Gamma_i = x_i * H of the party, with the DLEQ proof (A, B, z) that it has the discrete logarithm of its key share,
+// and the commitments D_i = d_i * B, E_i = e_i * B, D'_i = d_i * H and E'_i = e_i * H to its nonces.
+type VRFRound1Message struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Gamma  []byte `protobuf:"bytes,1,opt,name=gamma,proto3" json:"gamma,omitempty"`
+	ProofA []byte `protobuf:"bytes,2,opt,name=proof_a,json=proofA,proto3" json:"proof_a,omitempty"`
+	ProofB []byte `protobuf:"bytes,3,opt,name=proof_b,json=proofB,proto3" json:"proof_b,omitempty"`
+	ProofZ []byte `protobuf:"bytes,4,opt,name=proof_z,json=proofZ,proto3" json:"proof_z,omitempty"`
+	D      []byte `protobuf:"bytes,5,opt,name=d,proto3" json:"d,omitempty"`
+	E      []byte `protobuf:"bytes,6,opt,name=e,proto3" json:"e,omitempty"`
+	Dh     []byte `protobuf:"bytes,7,opt,name=dh,proto3" json:"dh,omitempty"`
+	Eh     []byte `protobuf:"bytes,8,opt,name=eh,proto3" json:"eh,omitempty"`
+}
+
+func (x *VRFRound1Message) Reset() {
+	*x = VRFRound1Message{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocols_vrf_vrf_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VRFRound1Message) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VRFRound1Message) ProtoMessage() {}
+
+func (x *VRFRound1Message) ProtoReflect() protoreflect.Message {
+	mi := &file_protocols_vrf_vrf_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VRFRound1Message.ProtoReflect.Descriptor instead.
+func (*VRFRound1Message) Descriptor() ([]byte, []int) {
+	return file_protocols_vrf_vrf_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *VRFRound1Message) GetGamma() []byte {
+	if x != nil {
+		return x.Gamma
+	}
+	return nil
+}
+
+func (x *VRFRound1Message) GetProofA() []byte {
+	if x != nil {
+		return x.ProofA
+	}
+	return nil
+}
+
+func (x *VRFRound1Message) GetProofB() []byte {
+	if x != nil {
+		return x.ProofB
+	}
+	return nil
+}
+
+func (x *VRFRound1Message) GetProofZ() []byte {
+	if x != nil {
+		return x.ProofZ
+	}
+	return nil
+}
+
+func (x *VRFRound1Message) GetD() []byte {
+	if x != nil {
+		return x.D
+	}
+	return nil
+}
+
+func (x *VRFRound1Message) GetE() []byte {
+	if x != nil {
+		return x.E
+	}
+	return nil
+}
+
+func (x *VRFRound1Message) GetDh() []byte {
+	if x != nil {
+		return x.Dh
+	}
+	return nil
+}
+
+func (x *VRFRound1Message) GetEh() []byte {
+	if x != nil {
+		return x.Eh
+	}
+	return nil
+}
+
+// Represents a BROADCAST message sent to all parties during Round 2 of the threshold ECVRF:
+// the share s_i = d_i + rho_i * e_i + c * x_i of the s of the proof.
+type VRFRound2Message struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	S []byte `protobuf:"bytes,1,opt,name=s,proto3" json:"s,omitempty"`
+}
+
+func (x *VRFRound2Message) Reset() {
+	*x = VRFRound2Message{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocols_vrf_vrf_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VRFRound2Message) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VRFRound2Message) ProtoMessage() {}
+
+func (x *VRFRound2Message) ProtoReflect() protoreflect.Message {
+	mi := &file_protocols_vrf_vrf_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VRFRound2Message.ProtoReflect.Descriptor instead.
+func (*VRFRound2Message) Descriptor() ([]byte, []int) {
+	return file_protocols_vrf_vrf_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *VRFRound2Message) GetS() []byte {
+	if x != nil {
+		return x.S
+	}
+	return nil
+}
+
+var File_protocols_vrf_vrf_proto protoreflect.FileDescriptor
+
+var file_protocols_vrf_vrf_proto_rawDesc = []byte{
+	0x0a, 0x17, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x73, 0x2f, 0x76, 0x72, 0x66, 0x2f,
+	0x76, 0x72, 0x66, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0a, 0x74, 0x73, 0x73, 0x6c, 0x69,
+	0x62, 0x2e, 0x76, 0x72, 0x66, 0x22, 0xaf, 0x01, 0x0a, 0x10, 0x56, 0x52, 0x46, 0x52, 0x6f, 0x75,
+	0x6e, 0x64, 0x31, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x67, 0x61,
+	0x6d, 0x6d, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x67, 0x61, 0x6d, 0x6d, 0x61,
+	0x12, 0x17, 0x0a, 0x07, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5f, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x06, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x41, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x72, 0x6f,
+	0x6f, 0x66, 0x5f, 0x62, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x70, 0x72, 0x6f, 0x6f,
+	0x66, 0x42, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5f, 0x7a, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x06, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5a, 0x12, 0x0c, 0x0a, 0x01, 0x64,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x01, 0x64, 0x12, 0x0c, 0x0a, 0x01, 0x65, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x01, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x64, 0x68, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x02, 0x64, 0x68, 0x12, 0x0e, 0x0a, 0x02, 0x65, 0x68, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x02, 0x65, 0x68, 0x22, 0x20, 0x0a, 0x10, 0x56, 0x52, 0x46, 0x52, 0x6f,
+	0x75, 0x6e, 0x64, 0x32, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x0c, 0x0a, 0x01, 0x73,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x01, 0x73, 0x42, 0x0f, 0x5a, 0x0d, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x73, 0x2f, 0x76, 0x72, 0x66, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
+}
+
+var (
+	file_protocols_vrf_vrf_proto_rawDescOnce sync.Once
+	file_protocols_vrf_vrf_proto_rawDescData = file_protocols_vrf_vrf_proto_rawDesc
+)
+
+func file_protocols_vrf_vrf_proto_rawDescGZIP() []byte {
+	file_protocols_vrf_vrf_proto_rawDescOnce.Do(func() {
+		file_protocols_vrf_vrf_proto_rawDescData = protoimpl.X.CompressGZIP(file_protocols_vrf_vrf_proto_rawDescData)
+	})
+	return file_protocols_vrf_vrf_proto_rawDescData
+}
+
+var file_protocols_vrf_vrf_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_protocols_vrf_vrf_proto_goTypes = []interface{}{
+	(*VRFRound1Message)(nil), // 0: tsslib.vrf.VRFRound1Message
+	(*VRFRound2Message)(nil), // 1: tsslib.vrf.VRFRound2Message
+}
+var file_protocols_vrf_vrf_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_protocols_vrf_vrf_proto_init() }
+func file_protocols_vrf_vrf_proto_init() {
+	if File_protocols_vrf_vrf_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_protocols_vrf_vrf_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VRFRound1Message); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocols_vrf_vrf_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VRFRound2Message); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_protocols_vrf_vrf_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_protocols_vrf_vrf_proto_goTypes,
+		DependencyIndexes: file_protocols_vrf_vrf_proto_depIdxs,
+		MessageInfos:      file_protocols_vrf_vrf_proto_msgTypes,
+	}.Build()
+	File_protocols_vrf_vrf_proto = out.File
+	file_protocols_vrf_vrf_proto_rawDesc = nil
+	file_protocols_vrf_vrf_proto_goTypes = nil
+	file_protocols_vrf_vrf_proto_depIdxs = nil
+}
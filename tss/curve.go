@@ -20,11 +20,19 @@ type CurveName string
 const (
 	Secp256k1 CurveName = "secp256k1"
 	Ed25519   CurveName = "ed25519"
+	Nist256p1 CurveName = "nist256p1"
 )
 
 var (
 	ec       elliptic.Curve
 	registry map[CurveName]elliptic.Curve
+
+	// other names of the registered curves, which GetCurveByName accepts and GetCurveName never returns
+	aliases = map[CurveName]CurveName{
+		"P-256":      Nist256p1,
+		"secp256r1":  Nist256p1,
+		"prime256v1": Nist256p1,
+	}
 )
 
 // Init default curve (secp256k1)
@@ -34,6 +42,7 @@ func init() {
 	registry = make(map[CurveName]elliptic.Curve)
 	registry[Secp256k1] = s256k1.S256()
 	registry[Ed25519] = edwards.Edwards()
+	registry[Nist256p1] = elliptic.P256()
 }
 
 func RegisterCurve(name CurveName, curve elliptic.Curve) {
@@ -45,6 +54,9 @@ func GetCurveByName(name CurveName) (elliptic.Curve, bool) {
 	if val, exist := registry[name]; exist {
 		return val, true
 	}
+	if val, exist := registry[aliases[name]]; exist {
+		return val, true
+	}
 
 	return nil, false
 }
@@ -81,6 +93,17 @@ func S256() elliptic.Curve {
 	return s256k1.S256()
 }
 
+// NIST P-256 (secp256r1)
+func P256() elliptic.Curve {
+	return elliptic.P256()
+}
+
 func Edwards() elliptic.Curve {
 	return edwards.Edwards()
 }
+
+// SameCurve returns whether the two curves have the same domain parameters
+func SameCurve(a, b elliptic.Curve) bool {
+	pa, pb := a.Params(), b.Params()
+	return pa == pb || (pa.P.Cmp(pb.P) == 0 && pa.N.Cmp(pb.N) == 0 && pa.Gx.Cmp(pb.Gx) == 0 && pa.Gy.Cmp(pb.Gy) == 0)
+}
@@ -0,0 +1,22 @@
+package tss
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetCurveByAlias(t *testing.T) {
+	for _, name := range []CurveName{Nist256p1, "P-256", "secp256r1", "prime256v1"} {
+		curve, ok := GetCurveByName(name)
+		assert.True(t, ok, name)
+		assert.True(t, SameCurve(curve, P256()), name)
+	}
+	// the aliases resolve to the curve, whose name stays the registered one
+	name, ok := GetCurveName(P256())
+	assert.True(t, ok)
+	assert.Equal(t, Nist256p1, name)
+
+	_, ok = GetCurveByName("P-384")
+	assert.False(t, ok)
+}
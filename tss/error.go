@@ -17,12 +17,54 @@ type Error struct {
 	round    int
 	victim   *PartyID
 	culprits []*PartyID
+	evidence []*Evidence
 }
 
 func NewError(err error, task string, round int, victim *PartyID, culprits ...*PartyID) *Error {
 	return &Error{cause: err, task: task, round: round, victim: victim, culprits: culprits}
 }
 
+// NewEvidenceError creates an error blaming the culprits and the culprits of the evidence
+func NewEvidenceError(err error, task string, round int, victim *PartyID, evidence []*Evidence, culprits ...*PartyID) *Error {
+	blamed := make(map[int]struct{}, len(culprits)+len(evidence))
+	all := make([]*PartyID, 0, len(culprits)+len(evidence))
+	for _, culprit := range culprits {
+		if _, ok := blamed[culprit.Index]; !ok {
+			blamed[culprit.Index] = struct{}{}
+			all = append(all, culprit)
+		}
+	}
+	for _, ev := range evidence {
+		if ev.Victim == nil {
+			ev.Victim = victim
+		}
+		if _, ok := blamed[ev.Culprit.Index]; !ok {
+			blamed[ev.Culprit.Index] = struct{}{}
+			all = append(all, ev.Culprit)
+		}
+	}
+	return &Error{cause: err, task: task, round: round, victim: victim, culprits: all, evidence: evidence}
+}
+
+// JoinErrors returns the first of errs, blaming the culprits of all of them with all their evidence, or nil if there are none.
+// A round checks the messages of every party before it fails, so that all the cheaters are blamed at once and can be
+// excluded from the next attempt.
+func JoinErrors(errs ...*Error) *Error {
+	if len(errs) == 0 {
+		return nil
+	}
+	var (
+		culprits []*PartyID
+		evidence []*Evidence
+	)
+	for _, err := range errs {
+		culprits = append(culprits, err.culprits...)
+		evidence = append(evidence, err.evidence...)
+	}
+	first := errs[0]
+	return NewEvidenceError(first.cause, first.task, first.round, first.victim, evidence, culprits...)
+}
+
 func (err *Error) Unwrap() error { return err.cause }
 
 func (err *Error) Cause() error { return err.cause }
@@ -35,6 +77,9 @@ func (err *Error) Victim() *PartyID { return err.victim }
 
 func (err *Error) Culprits() []*PartyID { return err.culprits }
 
+// Evidence returns the proofs of the misbehaviour of the culprits, if any
+func (err *Error) Evidence() []*Evidence { return err.evidence }
+
 func (err *Error) Error() string {
 	if err == nil || err.cause == nil {
 		return "Error is nil"
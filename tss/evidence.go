@@ -0,0 +1,196 @@
+package tss
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+type (
+	// Evidence proves that a party misbehaved: it holds the messages of the culprit that a check failed on,
+	// the messages of the other parties that the check depends on, and the public inputs of the check,
+	// so that anyone can run the check again with Verify.
+	//
+	// The messages are kept as they were received. The tss messages are not signed, so the evidence is only
+	// binding if the transport authenticates the messages, e.g. if the application keeps the signature of the
+	// sender over the wire bytes of each message alongside the evidence.
+	// The inputs are public values fixed before the session, such as the ssid, the message to sign or the public keys
+	// of the parties, which a verifier should check against what it knows of the session. A value that the parties
+	// compute from the messages of the session is never an input: the check computes it again from the messages,
+	// so that the accuser cannot choose it.
+	// The victim is the party that received the messages, and the recipient of the p2p messages among them.
+	Evidence struct {
+		Kind     string             `json:"kind"`
+		Task     string             `json:"task"`
+		Round    int                `json:"round"`
+		Culprit  *PartyID           `json:"culprit"`
+		Victim   *PartyID           `json:"victim,omitempty"`
+		Messages []*EvidenceMessage `json:"messages"`
+		Inputs   json.RawMessage    `json:"inputs,omitempty"`
+	}
+
+	// EvidenceMessage is a message of the session, as it was sent over the wire.
+	// From is the sender of a message that is not from the culprit.
+	EvidenceMessage struct {
+		WireBytes   []byte   `json:"wire_bytes"`
+		IsBroadcast bool     `json:"is_broadcast"`
+		From        *PartyID `json:"from,omitempty"`
+	}
+
+	// EvidenceCheck runs the check of an evidence again on its messages and inputs.
+	// It returns the failure of the check, or err if the evidence itself is invalid.
+	EvidenceCheck func(ev *Evidence, msgs []ParsedMessage) (failure error, err error)
+)
+
+// ErrNoMisbehaviour is returned by Verify when the check of an evidence passes
+var ErrNoMisbehaviour = errors.New("the evidence does not show any misbehaviour")
+
+var (
+	evidenceChecks    = make(map[string]EvidenceCheck)
+	evidenceChecksMtx sync.RWMutex
+)
+
+// RegisterEvidenceCheck registers the check of the evidence of the given kind.
+// The protocols register their checks when they are imported.
+func RegisterEvidenceCheck(kind string, check EvidenceCheck) {
+	evidenceChecksMtx.Lock()
+	defer evidenceChecksMtx.Unlock()
+
+	if _, ok := evidenceChecks[kind]; ok {
+		panic(fmt.Sprintf("the evidence check of kind %s is already registered", kind))
+	}
+	evidenceChecks[kind] = check
+}
+
+// NewEvidence creates the evidence that the check `kind` failed on msgs. The messages of the culprit come first,
+// followed by the messages of the other parties that the check depends on. inputs must be serializable to JSON.
+func NewEvidence(kind, task string, round int, culprit *PartyID, inputs interface{}, msgs ...ParsedMessage) (*Evidence, error) {
+	ev := &Evidence{
+		Kind:     kind,
+		Task:     task,
+		Round:    round,
+		Culprit:  culprit,
+		Messages: make([]*EvidenceMessage, len(msgs)),
+	}
+	for n, msg := range msgs {
+		if msg == nil {
+			return nil, errors.New("NewEvidence: nil message")
+		}
+		bz, _, err := msg.WireBytes()
+		if err != nil {
+			return nil, err
+		}
+		ev.Messages[n] = &EvidenceMessage{WireBytes: bz, IsBroadcast: msg.IsBroadcast()}
+		if from := msg.GetFrom(); from != nil && from.Index != culprit.Index {
+			ev.Messages[n].From = from
+		}
+	}
+	if inputs != nil {
+		bz, err := json.Marshal(inputs)
+		if err != nil {
+			return nil, err
+		}
+		ev.Inputs = bz
+	}
+	return ev, nil
+}
+
+// ParseMessages parses the messages of the evidence, as sent by the culprit or by their sender, with the victim as the recipient of the p2p messages
+func (ev *Evidence) ParseMessages() ([]ParsedMessage, error) {
+	if !ev.Culprit.ValidateBasic() {
+		return nil, errors.New("the evidence has an invalid culprit")
+	}
+	if ev.Victim != nil && !ev.Victim.ValidateBasic() {
+		return nil, errors.New("the evidence has an invalid victim")
+	}
+	msgs := make([]ParsedMessage, len(ev.Messages))
+	for n, m := range ev.Messages {
+		if m == nil {
+			return nil, fmt.Errorf("the evidence message %d is nil", n)
+		}
+		from := ev.Culprit
+		if m.From != nil {
+			if !m.From.ValidateBasic() {
+				return nil, fmt.Errorf("the evidence message %d has an invalid sender", n)
+			}
+			from = m.From
+		}
+		msg, err := ParseWireMessage(m.WireBytes, from, m.IsBroadcast)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse the evidence message %d: %s", n, err.Error())
+		}
+		if !msg.ValidateBasic() {
+			// the message could not have been accepted by the victim
+			return nil, fmt.Errorf("the evidence message %d is not valid", n)
+		}
+		if impl, ok := msg.(*MessageImpl); ok && !m.IsBroadcast && ev.Victim != nil {
+			impl.To = []*PartyID{ev.Victim}
+		}
+		msgs[n] = msg
+	}
+	return msgs, nil
+}
+
+// UnmarshalInputs decodes the public inputs of the evidence into v
+func (ev *Evidence) UnmarshalInputs(v interface{}) error {
+	if len(ev.Inputs) == 0 {
+		return errors.New("the evidence has no inputs")
+	}
+	return json.Unmarshal(ev.Inputs, v)
+}
+
+// Verify runs the check of the evidence again. It returns nil if the check fails, which proves that the culprit misbehaved.
+// The package of the protocol that produced the evidence must be imported, so that its checks are registered.
+func (ev *Evidence) Verify() error {
+	evidenceChecksMtx.RLock()
+	check, ok := evidenceChecks[ev.Kind]
+	evidenceChecksMtx.RUnlock()
+	if !ok {
+		return fmt.Errorf("no check is registered for the evidence of kind %s", ev.Kind)
+	}
+
+	msgs, err := ev.ParseMessages()
+	if err != nil {
+		return err
+	}
+	failure, err := check(ev, msgs)
+	if err != nil {
+		return fmt.Errorf("invalid evidence of kind %s: %s", ev.Kind, err.Error())
+	}
+	if failure == nil {
+		return ErrNoMisbehaviour
+	}
+	return nil
+}
+
+// MessagesBySender returns the messages of the content type of sample, one for each of the count parties in the order
+// of their index, e.g. the round 1 broadcasts of all the parties that a check depends on.
+// The other messages are ignored, and it fails unless every party has exactly one message of the type.
+func MessagesBySender(msgs []ParsedMessage, count int, sample MessageContent) ([]ParsedMessage, error) {
+	byIndex := make([]ParsedMessage, count)
+	for _, msg := range msgs {
+		if reflect.TypeOf(msg.Content()) != reflect.TypeOf(sample) {
+			continue
+		}
+		j := msg.GetFrom().Index
+		if j < 0 || j >= count {
+			return nil, fmt.Errorf("the message of %T of party %d is not from a party of the session", sample, j)
+		}
+		if byIndex[j] != nil {
+			return nil, fmt.Errorf("party %d has two messages of %T", j, sample)
+		}
+		byIndex[j] = msg
+	}
+	for j, msg := range byIndex {
+		if msg == nil {
+			return nil, fmt.Errorf("the message of %T of party %d is missing", sample, j)
+		}
+	}
+	return byIndex, nil
+}
+
+func (ev *Evidence) String() string {
+	return fmt.Sprintf("evidence %s against %v (task %s, round %d)", ev.Kind, ev.Culprit, ev.Task, ev.Round)
+}
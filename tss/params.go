@@ -10,6 +10,7 @@ import (
 	"crypto/elliptic"
 	"crypto/rand"
 	"io"
+	"math/big"
 	"runtime"
 	"time"
 )
@@ -25,6 +26,8 @@ type (
 		safePrimeGenTimeout time.Duration
 		// random sources
 		partialKeyRand, rand io.Reader
+		// scalars added to the key by the signing
+		keyTweaks []*big.Int
 	}
 
 	ReSharingParameters struct {
@@ -107,6 +110,17 @@ func (params *Parameters) SetRand(rand io.Reader) {
 	params.rand = rand
 }
 
+func (params *Parameters) KeyTweaks() []*big.Int {
+	return params.keyTweaks
+}
+
+// SetKeyTweaks sets the scalars that the signing parties add to the key in order, after the derivation of the path,
+// so that they sign for the public key + (t_1 + ... + t_n)*G.
+// Every party of the signing must set the same tweaks.
+func (params *Parameters) SetKeyTweaks(tweaks ...*big.Int) {
+	params.keyTweaks = tweaks
+}
+
 // ----- //
 
 // Exported, used in `tss` client
@@ -139,6 +139,32 @@ func BaseStart(p Party, task string, prepare ...func(Round) *Error) *Error {
 	return p.round().Start()
 }
 
+// BaseProceed runs the update of the current round on the messages that were stored before the round started,
+// and advances the party while its rounds can proceed. A party calls it after BaseStart when all the messages of its
+// first round may arrive before Start, as no later message would run the update of that round again.
+func BaseProceed(p Party, task string) *Error {
+	p.lock()
+	defer p.unlock()
+	for p.round() != nil {
+		if _, err := p.round().Update(); err != nil {
+			return err
+		}
+		if !p.round().CanProceed() {
+			return nil
+		}
+		if p.advance(); p.round() == nil {
+			// finished! the round implementation will have sent the data through the `end` channel.
+			common.Logger.Infof("party %s: %s finished!", p.PartyID(), task)
+			return nil
+		}
+		if err := p.round().Start(); err != nil {
+			return err
+		}
+		common.Logger.Infof("party %s: %s round %d started", p.round().Params().PartyID(), task, p.round().RoundNumber())
+	}
+	return nil
+}
+
 // an implementation of Update that is shared across the different types of parties (keygen, signing, dynamic groups)
 func BaseUpdate(p Party, msg ParsedMessage, task string) (ok bool, err *Error) {
 	// fast-fail on an invalid message; do not lock the mutex yet